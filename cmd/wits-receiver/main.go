@@ -0,0 +1,62 @@
+// Command wits-receiver listens for WITS Level 0 feeds from rig-site systems and writes them into the
+// actual drilling data of the configured wellbores.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	postgres "github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/connection"
+	repositories "github.com/munaiplan/munaiplan-backend/internal/infrastructure/repositories/postgres"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/wits"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultConfigPath = "internal/infrastructure/configs/wits.yml"
+
+func main() {
+	configPath := flag.String("config", defaultConfigPath, "receiver configuration file")
+	flag.Parse()
+
+	cfg, err := wits.LoadConfig(*configPath)
+	if err != nil {
+		logrus.Fatalf("failed to load wits config: %v", err)
+	}
+
+	db := postgres.NewDatabase()
+	if db == nil {
+		logrus.Fatal("failed to initialize database connection")
+	}
+
+	commonRepo := repositories.NewCommonRepository(db.Conn)
+	for _, listener := range cfg.Listeners {
+		if err := commonRepo.CheckIfWellboreExists(context.Background(), listener.WellboreID); err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// The writer stops after the listeners so the readings of closing connections are written too
+	receiver := wits.NewReceiver(cfg, repositories.NewDrillingDataRepository(db.Conn))
+	writerCtx, stopWriter := context.WithCancel(context.Background())
+	writerDone := make(chan struct{})
+	go func() {
+		receiver.RunWriter(writerCtx)
+		close(writerDone)
+	}()
+
+	if err := receiver.Run(ctx); err != nil {
+		logrus.Errorf("wits receiver stopped: %v", err)
+	}
+	stopWriter()
+	<-writerDone
+
+	if sqlDB, err := db.Conn.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
@@ -0,0 +1,133 @@
+// Command wits-simulator streams a synthetic WITS Level 0 feed to a receiver for local testing. It repeats a
+// cycle of tripping in, drilling ahead with rotation and tripping out, one frame per interval.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/pkg/wits"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	tripSpeed     = 0.5   // m/s
+	drillingSpeed = 0.01  // m/s
+	blockWeight   = 300.0 // kN
+	stringWeight  = 0.25  // kN/m, buoyed
+	dragFactor    = 0.12  // share of the string weight lost or added by drag while tripping
+	reconnectWait = 2 * time.Second
+)
+
+func main() {
+	address := flag.String("address", "localhost:7001", "receiver address")
+	interval := flag.Duration("interval", time.Second, "time between frames")
+	startDepth := flag.Float64("start-depth", 1500, "bit depth of the first trip in, m")
+	tripLength := flag.Float64("trip-length", 300, "length of a trip, m")
+	flag.Parse()
+
+	sim := &simulator{bitDepth: *startDepth - *tripLength, top: *startDepth - *tripLength, holeDepth: *startDepth, tripLength: *tripLength}
+	for {
+		conn, err := net.Dial("tcp", *address)
+		if err != nil {
+			logrus.Errorf("connecting to %s: %v", *address, err)
+			time.Sleep(reconnectWait)
+			continue
+		}
+		logrus.Infof("streaming to %s", *address)
+
+		if err := sim.stream(conn, *interval); err != nil {
+			logrus.Errorf("streaming to %s: %v", *address, err)
+		}
+		conn.Close()
+		time.Sleep(reconnectWait)
+	}
+}
+
+// Phases of the simulated cycle
+const (
+	phaseTripIn = iota
+	phaseDrilling
+	phaseTripOut
+)
+
+type simulator struct {
+	phase      int
+	bitDepth   float64
+	top        float64
+	holeDepth  float64
+	tripLength float64
+}
+
+func (s *simulator) stream(conn net.Conn, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		s.advance(interval.Seconds())
+		if err := wits.Encode(conn, s.frame(now.UTC())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// advance moves the bit and switches to the next phase at the ends of the trip and after drilling 10 m.
+func (s *simulator) advance(seconds float64) {
+	switch s.phase {
+	case phaseTripIn:
+		s.bitDepth += tripSpeed * seconds
+		if s.bitDepth >= s.holeDepth {
+			s.bitDepth, s.phase = s.holeDepth, phaseDrilling
+		}
+	case phaseDrilling:
+		s.bitDepth += drillingSpeed * seconds
+		s.holeDepth = s.bitDepth
+		if s.bitDepth >= s.top+s.tripLength+10 {
+			s.phase = phaseTripOut
+		}
+	case phaseTripOut:
+		s.bitDepth -= tripSpeed * seconds
+		if s.bitDepth <= s.top {
+			s.top = s.holeDepth - s.tripLength
+			s.bitDepth, s.phase = s.top, phaseTripIn
+		}
+	}
+}
+
+// frame builds a record 1 frame with the items of the receiver's default channel mapping.
+func (s *simulator) frame(now time.Time) wits.Frame {
+	weight := blockWeight + stringWeight*s.bitDepth
+	var hookLoad, weightOnBit, torque, rpm float64
+	switch s.phase {
+	case phaseTripIn:
+		hookLoad = weight * (1 - dragFactor)
+	case phaseDrilling:
+		weightOnBit = 80
+		hookLoad = weight - weightOnBit
+		rpm = 120
+		torque = 8 + 0.004*s.bitDepth
+	case phaseTripOut:
+		hookLoad = weight * (1 + dragFactor)
+	}
+
+	noise := func(value, amplitude float64) string {
+		return fmt.Sprintf("%.2f", math.Max(0, value+amplitude*(rand.Float64()*2-1)))
+	}
+	return wits.Frame{
+		"0105": now.Format("060102"),
+		"0106": now.Format("150405"),
+		"0108": fmt.Sprintf("%.2f", s.bitDepth),
+		"0110": fmt.Sprintf("%.2f", s.holeDepth),
+		"0114": noise(hookLoad, 5),
+		"0116": noise(weightOnBit, 2),
+		"0118": noise(torque, 0.3),
+		"0120": noise(rpm, 2),
+		"0122": noise(12+0.005*s.bitDepth, 0.2),
+		"0130": noise(2200, 30),
+	}
+}
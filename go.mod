@@ -7,6 +7,7 @@ toolchain go1.21.1
 require (
 	github.com/gin-gonic/gin v1.9.1
 	github.com/swaggo/swag v1.16.3
+	golang.org/x/image v0.18.0
 	gorm.io/gorm v1.25.7
 )
 
@@ -14,7 +14,9 @@ import (
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
 	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/configs"
 	postgres "github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/connection"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/email"
 	infrastructure "github.com/munaiplan/munaiplan-backend/internal/infrastructure/http"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/notify"
 	"github.com/munaiplan/munaiplan-backend/internal/presentation/middleware"
 	"github.com/sirupsen/logrus"
 	//"github.com/xuri/excelize/v2"
@@ -61,10 +63,26 @@ func Run(configPath string) {
 	repos := repository.NewRepositories(db.Conn)
 
 	// Initializing services
-	services := service.NewServices(repos, jwt, helpers.GetEnv("PREDICTION_SERVICE_URL", "http://localhost:8001"))
+	services := service.NewServices(
+		repos,
+		jwt,
+		helpers.GetEnv("PREDICTION_SERVICE_URL", "http://localhost:8001"),
+		predictionServiceTimeout(),
+		email.NewFileSender(helpers.GetEnv("EMAIL_OUTBOX_DIR", "data/outbox")),
+		helpers.GetEnv("APP_URL", "http://localhost:3000"),
+		newNotifier(),
+	)
+
+	// Calculation jobs run in the background until shutdown
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobsDone := make(chan struct{})
+	go func() {
+		services.Jobs.Run(jobsCtx)
+		close(jobsDone)
+	}()
 
 	// Initializing middleware
-	authMiddleware := middleware.NewAuthMiddleware(jwt)
+	authMiddleware := middleware.NewAuthMiddleware(jwt, services.Users, services.Roles, services.ApiKeys)
 
 	// Initializing router and handlers
 	router := infrastructure.NewRouter(services, authMiddleware)
@@ -95,6 +113,9 @@ func Run(configPath string) {
 		logrus.Errorf("failed to stop server: %v", err)
 	}
 
+	stopJobs()
+	<-jobsDone
+
 	sqlDB, err := db.Conn.DB()
 	if err != nil {
 		logrus.Error(err.Error())
@@ -104,3 +125,26 @@ func Run(configPath string) {
 		logrus.Error(err.Error())
 	}
 }
+
+// predictionServiceTimeout returns the timeout of a prediction service request from PREDICTION_SERVICE_TIMEOUT,
+// e.g. "2m". Zero, the client default, when it is not set or invalid.
+func predictionServiceTimeout() time.Duration {
+	value := helpers.GetEnv("PREDICTION_SERVICE_TIMEOUT", "")
+	if value == "" {
+		return 0
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		logrus.Errorf("invalid PREDICTION_SERVICE_TIMEOUT %q: %v", value, err)
+		return 0
+	}
+	return timeout
+}
+
+// newNotifier posts notifications to NOTIFY_WEBHOOK_URL when it is set and only logs them otherwise.
+func newNotifier() notify.Notifier {
+	if url := helpers.GetEnv("NOTIFY_WEBHOOK_URL", ""); url != "" {
+		return notify.NewWebhookNotifier(url)
+	}
+	return notify.NewLogNotifier()
+}
@@ -0,0 +1,416 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/geodesy"
+	"github.com/munaiplan/munaiplan-backend/pkg/wellpath"
+)
+
+const (
+	defaultAntiCollisionStep          = 30.0
+	defaultSurfaceErrorRadius         = 0.5
+	defaultErrorRadiusPerMD           = 0.005
+	defaultWarningSeparationFactor    = 1.5
+	defaultMinimumSeparationFactor    = 1.0
+	defaultMinimumCenterToCenter      = 0.0
+	AntiCollisionErrorModelSimple     = "simple"
+	AntiCollisionErrorModelISCWSA     = "iscwsa"
+	AntiCollisionStatusOK             = "ok"
+	AntiCollisionStatusWarning        = "warning"
+	AntiCollisionStatusViolation      = "violation"
+	AntiCollisionStatusSkipped        = "skipped"
+	antiCollisionSeparationFactorNone = -1
+)
+
+type antiCollisionService struct {
+	commonRepo repository.CommonRepository
+	repo       repository.TrajectoriesRepository
+	toolsRepo  repository.SurveyToolsRepository
+}
+
+func NewAntiCollisionService(repo repository.TrajectoriesRepository, toolsRepo repository.SurveyToolsRepository, commonRepo repository.CommonRepository) *antiCollisionService {
+	return &antiCollisionService{
+		repo:       repo,
+		toolsRepo:  toolsRepo,
+		commonRepo: commonRepo,
+	}
+}
+
+// antiCollisionSettings holds the error model and thresholds used for one scan
+type antiCollisionSettings struct {
+	step                    float64
+	errorModel              string
+	sigma                   float64
+	defaultSurveyToolID     string
+	surfaceErrorRadius      float64
+	errorRadiusPerMD        float64
+	warningSeparationFactor float64
+	minimumSeparationFactor float64
+	minimumCenterToCenter   float64
+}
+
+// antiCollisionPath is a trajectory prepared for the scan. Covariances are set only for the ISCWSA error model.
+type antiCollisionPath struct {
+	stations    []wellpath.Station
+	covariances []wellpath.Covariance
+}
+
+// ScanTrajectory compares the trajectory against all trajectories of the other wells on the same site or field.
+// All trajectories are placed in the projected system of the site of the reference well from the surface
+// locations of their wells, a well without a georeferenced surface location fails the scan.
+func (s *antiCollisionService) ScanTrajectory(ctx context.Context, input *requests.AntiCollisionRequest) (*responses.AntiCollisionResponse, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.TrajectoryID); err != nil {
+		return nil, err
+	}
+
+	scope := input.Body.Scope
+	if scope == "" {
+		scope = entities.AntiCollisionScopeSite
+	}
+	if scope != entities.AntiCollisionScopeSite && scope != entities.AntiCollisionScopeField {
+		return nil, fmt.Errorf("%w %s", domainErrors.ErrUnknownAntiCollisionScope, scope)
+	}
+
+	settings, err := s.toSettings(&input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	reference, err := s.repo.GetTrajectoryByID(ctx, input.TrajectoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets, err := s.repo.GetOffsetTrajectories(ctx, input.TrajectoryID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	referenceWell, err := s.commonRepo.GetWellByTrajectoryID(ctx, input.TrajectoryID)
+	if err != nil {
+		return nil, err
+	}
+	frame, err := s.siteFrame(ctx, referenceWell)
+	if err != nil {
+		return nil, err
+	}
+	referenceOrigin, err := wellOrigin(referenceWell, frame)
+	if err != nil {
+		return nil, err
+	}
+	// Offsets of one well share its surface location
+	origins := make(map[string]*geodesy.GridPoint)
+	for _, offset := range offsets {
+		if _, ok := origins[offset.WellID]; ok {
+			continue
+		}
+		well, err := s.commonRepo.GetWellByTrajectoryID(ctx, offset.Trajectory.ID)
+		if err != nil {
+			return nil, err
+		}
+		if origins[offset.WellID], err = wellOrigin(well, frame); err != nil {
+			return nil, err
+		}
+	}
+
+	referencePath, err := s.toPath(ctx, reference, referenceOrigin, settings.step, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.AntiCollisionResponse{
+		TrajectoryID:            input.TrajectoryID,
+		Scope:                   scope,
+		WarningSeparationFactor: settings.warningSeparationFactor,
+		MinimumSeparationFactor: settings.minimumSeparationFactor,
+		MinimumCenterToCenter:   settings.minimumCenterToCenter,
+		Status:                  AntiCollisionStatusOK,
+		Offsets:                 make([]responses.AntiCollisionOffsetResponse, 0, len(offsets)),
+	}
+
+	for _, offset := range offsets {
+		offsetPath, err := s.toPath(ctx, offset.Trajectory, origins[offset.WellID], 0, settings)
+		if err != nil {
+			return nil, err
+		}
+		result := s.scanOffset(referencePath, offset, offsetPath, settings)
+		response.Status = worseAntiCollisionStatus(response.Status, result.Status)
+		response.Offsets = append(response.Offsets, *result)
+	}
+
+	return response, nil
+}
+
+// scanOffset finds the closest approach of the offset trajectory for every reference station.
+// An offset is skipped when either trajectory has no stations, it is not reported as clear.
+func (s *antiCollisionService) scanOffset(referencePath *antiCollisionPath, offset *entities.OffsetTrajectory, offsetPath *antiCollisionPath, settings *antiCollisionSettings) *responses.AntiCollisionOffsetResponse {
+	result := &responses.AntiCollisionOffsetResponse{
+		WellID:              offset.WellID,
+		WellName:            offset.WellName,
+		WellboreID:          offset.WellboreID,
+		WellboreName:        offset.WellboreName,
+		DesignID:            offset.DesignID,
+		TrajectoryID:        offset.Trajectory.ID,
+		TrajectoryName:      offset.Trajectory.Name,
+		MinCenterToCenter:   math.Inf(1),
+		MinSeparationFactor: antiCollisionSeparationFactorNone,
+		Status:              AntiCollisionStatusOK,
+	}
+
+	if len(referencePath.stations) == 0 || len(offsetPath.stations) == 0 {
+		result.MinCenterToCenter = 0
+		result.Status = AntiCollisionStatusSkipped
+		result.SkipReason = "the offset trajectory has no stations"
+		if len(referencePath.stations) == 0 {
+			result.SkipReason = "the reference trajectory has no stations"
+		}
+		return result
+	}
+
+	for k, reference := range referencePath.stations {
+		closest, distance := wellpath.ClosestPoint(offsetPath.stations, reference)
+
+		station := responses.AntiCollisionStationResponse{
+			ReferenceMD:    reference.MD,
+			ReferenceTVD:   reference.TVD,
+			OffsetMD:       closest.MD,
+			OffsetTVD:      closest.TVD,
+			CenterToCenter: distance,
+		}
+
+		if settings.errorModel == AntiCollisionErrorModelISCWSA {
+			// Uncertainties are projected on the center to center line, the combined radius
+			// comes from the sum of both covariances
+			referenceCov := referencePath.covariances[k]
+			offsetCov := wellpath.InterpolateCovariance(offsetPath.stations, offsetPath.covariances, closest.MD)
+			station.ReferenceRadius = settings.sigma * referenceCov.SigmaAlong(reference, closest)
+			station.OffsetRadius = settings.sigma * offsetCov.SigmaAlong(reference, closest)
+			station.SeparationFactor = separationFactor(distance, settings.sigma*referenceCov.Sum(offsetCov).SigmaAlong(reference, closest))
+		} else {
+			station.ReferenceRadius = settings.errorRadius(reference.MD)
+			station.OffsetRadius = settings.errorRadius(closest.MD)
+			station.SeparationFactor = separationFactor(distance, station.ReferenceRadius+station.OffsetRadius)
+		}
+		station.Status = settings.status(station.CenterToCenter, station.SeparationFactor)
+
+		result.Stations = append(result.Stations, station)
+		result.Status = worseAntiCollisionStatus(result.Status, station.Status)
+
+		if station.CenterToCenter < result.MinCenterToCenter {
+			result.MinCenterToCenter = station.CenterToCenter
+			closestApproach := station
+			result.ClosestApproach = &closestApproach
+		}
+		if result.MinSeparationFactor == antiCollisionSeparationFactorNone || station.SeparationFactor < result.MinSeparationFactor {
+			result.MinSeparationFactor = station.SeparationFactor
+			minSeparation := station
+			result.MinSeparationFactorAt = &minSeparation
+		}
+	}
+
+	return result
+}
+
+// toPath converts the trajectory to stations in the site frame around the surface location origin, resampled
+// every step, and, for the ISCWSA error model, propagates the error models of its survey program.
+func (s *antiCollisionService) toPath(ctx context.Context, trajectory *entities.Trajectory, origin *geodesy.GridPoint, step float64, settings *antiCollisionSettings) (*antiCollisionPath, error) {
+	path := &antiCollisionPath{
+		stations: wellpath.Resample(siteStations(trajectory, origin), step),
+	}
+	if settings.errorModel != AntiCollisionErrorModelISCWSA || len(path.stations) == 0 {
+		return path, nil
+	}
+
+	runs, _, err := buildToolRuns(ctx, s.toolsRepo, trajectory, settings.defaultSurveyToolID)
+	if err != nil {
+		return nil, err
+	}
+	if path.covariances, err = wellpath.PositionCovariance(path.stations, runs); err != nil {
+		return nil, fmt.Errorf("trajectory %s: %w", trajectory.Name, err)
+	}
+
+	return path, nil
+}
+
+func (s *antiCollisionService) toSettings(body *requests.AntiCollisionRequestBody) (*antiCollisionSettings, error) {
+	settings := &antiCollisionSettings{
+		step:                    defaultAntiCollisionStep,
+		errorModel:              AntiCollisionErrorModelSimple,
+		sigma:                   defaultUncertaintySigma,
+		defaultSurveyToolID:     body.DefaultSurveyToolID,
+		surfaceErrorRadius:      defaultSurfaceErrorRadius,
+		errorRadiusPerMD:        defaultErrorRadiusPerMD,
+		warningSeparationFactor: defaultWarningSeparationFactor,
+		minimumSeparationFactor: defaultMinimumSeparationFactor,
+		minimumCenterToCenter:   defaultMinimumCenterToCenter,
+	}
+
+	if body.Step > 0 {
+		settings.step = body.Step
+	}
+	if body.ErrorModel != "" {
+		settings.errorModel = body.ErrorModel
+	}
+	if settings.errorModel != AntiCollisionErrorModelSimple && settings.errorModel != AntiCollisionErrorModelISCWSA {
+		return nil, fmt.Errorf("%w %s", domainErrors.ErrUnknownErrorModel, settings.errorModel)
+	}
+	if body.Sigma > 0 {
+		settings.sigma = body.Sigma
+	}
+	if body.SurfaceErrorRadius != nil {
+		settings.surfaceErrorRadius = *body.SurfaceErrorRadius
+	}
+	if body.ErrorRadiusPerMD != nil {
+		settings.errorRadiusPerMD = *body.ErrorRadiusPerMD
+	}
+	if body.WarningSeparationFactor != nil {
+		settings.warningSeparationFactor = *body.WarningSeparationFactor
+	}
+	if body.MinimumSeparationFactor != nil {
+		settings.minimumSeparationFactor = *body.MinimumSeparationFactor
+	}
+	if body.MinimumCenterToCenter != nil {
+		settings.minimumCenterToCenter = *body.MinimumCenterToCenter
+	}
+
+	return settings, nil
+}
+
+// errorRadius is the radius of the uncertainty sphere at md for the simple linear error model.
+func (s *antiCollisionSettings) errorRadius(md float64) float64 {
+	return s.surfaceErrorRadius + s.errorRadiusPerMD*md
+}
+
+func (s *antiCollisionSettings) status(centerToCenter, separationFactor float64) string {
+	if centerToCenter < s.minimumCenterToCenter || separationFactor < s.minimumSeparationFactor {
+		return AntiCollisionStatusViolation
+	}
+	if separationFactor < s.warningSeparationFactor {
+		return AntiCollisionStatusWarning
+	}
+	return AntiCollisionStatusOK
+}
+
+// separationFactor is the ratio between the center to center distance and the sum of the uncertainty radii.
+func separationFactor(centerToCenter, radiiSum float64) float64 {
+	if radiiSum <= 0 {
+		return math.MaxFloat64
+	}
+	return centerToCenter / radiiSum
+}
+
+func worseAntiCollisionStatus(a, b string) string {
+	// A skipped offset keeps the scan from being reported as clear, any warning outranks it
+	rank := map[string]int{
+		AntiCollisionStatusOK:        0,
+		AntiCollisionStatusSkipped:   1,
+		AntiCollisionStatusWarning:   2,
+		AntiCollisionStatusViolation: 3,
+	}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// trajectoryToStations converts trajectory units to survey stations sorted by MD.
+// Global coordinates are used when the trajectory has them so wells on one site share a reference.
+func trajectoryToStations(trajectory *entities.Trajectory) []wellpath.Station {
+	useGlobal := false
+	for _, unit := range trajectory.Units {
+		if unit.GlobalNCoord != 0 || unit.GlobalECoord != 0 {
+			useGlobal = true
+			break
+		}
+	}
+
+	stations := make([]wellpath.Station, 0, len(trajectory.Units))
+	for _, unit := range trajectory.Units {
+		station := wellpath.Station{
+			MD:    unit.MD,
+			Incl:  unit.Incl,
+			Azim:  unit.Azim,
+			North: unit.LocalNCoord,
+			East:  unit.LocalECoord,
+			TVD:   unit.TVD,
+		}
+		if useGlobal {
+			station.North = unit.GlobalNCoord
+			station.East = unit.GlobalECoord
+		}
+		stations = append(stations, station)
+	}
+
+	wellpath.SortByMD(stations)
+	return stations
+}
+
+// siteFrame returns the projected system of the site of the well, or of the well when the site has none.
+func (s *antiCollisionService) siteFrame(ctx context.Context, well *entities.Well) (*geodesy.CRS, error) {
+	site, err := s.commonRepo.GetSiteByWellID(ctx, well.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	code := site.EPSGCode
+	if code == 0 {
+		code = well.EPSGCode
+	}
+	if code == 0 {
+		return nil, fmt.Errorf("%w: site %s and well %s have no coordinate reference system", domainErrors.ErrWellNotGeoreferenced, site.Name, well.Name)
+	}
+	crs, err := geodesy.Lookup(code)
+	if err != nil || !crs.IsProjected() {
+		return nil, fmt.Errorf("%w: EPSG:%d of site %s is not a supported projected system", domainErrors.ErrWellNotGeoreferenced, code, site.Name)
+	}
+	return crs, nil
+}
+
+// wellOrigin returns the surface location of the well in the frame with the grid convergence and scale factor there.
+func wellOrigin(well *entities.Well, frame *geodesy.CRS) (*geodesy.GridPoint, error) {
+	if well.EPSGCode == 0 || (well.Easting == 0 && well.Northing == 0) {
+		return nil, fmt.Errorf("%w: well %s", domainErrors.ErrWellNotGeoreferenced, well.Name)
+	}
+	crs, err := geodesy.Lookup(well.EPSGCode)
+	if err != nil || !crs.IsProjected() {
+		return nil, fmt.Errorf("%w: EPSG:%d of well %s is not a supported projected system", domainErrors.ErrWellNotGeoreferenced, well.EPSGCode, well.Name)
+	}
+
+	easting, northing, err := geodesy.Transform(crs, frame, well.Easting, well.Northing)
+	if err != nil {
+		return nil, fmt.Errorf("%w: well %s: %v", domainErrors.ErrWellNotGeoreferenced, well.Name, err)
+	}
+	lat, lon, err := frame.ToGeographic(easting, northing)
+	if err != nil {
+		return nil, err
+	}
+	return frame.ToGrid(lat, lon)
+}
+
+// siteStations converts trajectory units to survey stations sorted by MD whose north and east are grid
+// coordinates of the local offsets around the surface location origin.
+func siteStations(trajectory *entities.Trajectory, origin *geodesy.GridPoint) []wellpath.Station {
+	stations := make([]wellpath.Station, 0, len(trajectory.Units))
+	for _, unit := range trajectory.Units {
+		north, east := geodesy.LocalToGrid(origin, unit.LocalNCoord, unit.LocalECoord)
+		stations = append(stations, wellpath.Station{
+			MD:    unit.MD,
+			Incl:  unit.Incl,
+			Azim:  unit.Azim,
+			North: north,
+			East:  east,
+			TVD:   unit.TVD,
+		})
+	}
+
+	wellpath.SortByMD(stations)
+	return stations
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// apiKeyPrefixLength is the number of leading characters of a key kept to recognize it in the list of keys
+	apiKeyPrefixLength = 11
+)
+
+type apiKeysService struct {
+	repo       repository.ApiKeysRepository
+	commonRepo repository.CommonRepository
+}
+
+func NewApiKeysService(repo repository.ApiKeysRepository, commonRepo repository.CommonRepository) *apiKeysService {
+	return &apiKeysService{
+		repo:       repo,
+		commonRepo: commonRepo,
+	}
+}
+
+// CreateApiKey creates an API key of the user. The key is only returned here, the database keeps its hash.
+func (s *apiKeysService) CreateApiKey(ctx context.Context, input *requests.CreateApiKeyRequest) (*responses.ApiKeyCreatedResponse, error) {
+	if input.Body.Scope != entities.ApiKeyScopeRead && input.Body.Scope != entities.ApiKeyScopeReadWrite {
+		return nil, domainErrors.ErrInvalidApiKeyScope
+	}
+	if input.Body.CompanyID != nil {
+		if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCompany, *input.Body.CompanyID); err != nil {
+			return nil, err
+		}
+	}
+
+	token, _, err := helpers.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+	plainKey := entities.ApiKeyPrefix + token
+	key := &entities.ApiKey{
+		UserID:         input.UserID,
+		OrganizationID: input.OrganizationID,
+		Name:           input.Body.Name,
+		Prefix:         plainKey[:apiKeyPrefixLength],
+		KeyHash:        helpers.HashToken(plainKey),
+		Scope:          input.Body.Scope,
+		CompanyID:      input.Body.CompanyID,
+		ExpiresAt:      input.Body.ExpiresAt,
+	}
+	if err := s.repo.CreateApiKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &responses.ApiKeyCreatedResponse{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scope:     key.Scope,
+		CompanyID: key.CompanyID,
+		ExpiresAt: key.ExpiresAt,
+		CreatedAt: key.CreatedAt,
+		Key:       plainKey,
+	}, nil
+}
+
+func (s *apiKeysService) GetApiKeys(ctx context.Context, input *requests.GetApiKeysRequest) ([]*entities.ApiKey, error) {
+	return s.repo.GetApiKeys(ctx, input.UserID)
+}
+
+func (s *apiKeysService) DeleteApiKey(ctx context.Context, input *requests.DeleteApiKeyRequest) error {
+	return s.repo.DeleteApiKey(ctx, input.UserID, input.ID)
+}
+
+// Authenticate returns the API key matching the plain key and records its use.
+func (s *apiKeysService) Authenticate(ctx context.Context, plainKey string) (*entities.ApiKey, error) {
+	key, err := s.repo.GetApiKeyByHash(ctx, helpers.HashToken(plainKey))
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrResourceNotFound) {
+			return nil, domainErrors.ErrInvalidApiKey
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if key.Expired(now) {
+		return nil, domainErrors.ErrInvalidApiKey
+	}
+	if err := s.repo.TouchApiKey(ctx, key.ID, now); err != nil {
+		// The request can go on without the last used time
+		logrus.Errorf("Error recording the use of API key %s: %s", key.ID, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+)
+
+const (
+	defaultAuditLogsLimit = 100
+)
+
+type auditLogsService struct {
+	repo repository.AuditLogsRepository
+}
+
+func NewAuditLogsService(repo repository.AuditLogsRepository) *auditLogsService {
+	return &auditLogsService{repo: repo}
+}
+
+// GetAuditLogs retrieves the audit log entries of the organization, newest first.
+func (s *auditLogsService) GetAuditLogs(ctx context.Context, input *requests.GetAuditLogsRequest) ([]*entities.AuditLogEntry, error) {
+	query := input.Query
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, domainErrors.ErrInvalidTimeRange
+	}
+
+	filter := &entities.AuditLogFilter{
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+		UserID:     query.UserID,
+		From:       query.From,
+		To:         query.To,
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditLogsLimit
+	}
+	return s.repo.GetAuditLogs(ctx, input.OrganizationID, filter)
+}
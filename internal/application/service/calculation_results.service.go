@@ -0,0 +1,225 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/sirupsen/logrus"
+)
+
+// calculationInputs are the engineering values of the inputs of a torque and drag calculation of a case, their
+// hash identifies a stored result. IDs, timestamps, names and descriptions are left out and every list has a
+// defined order, so relabelling a component or the order of the rows in the database doesn't change the hash.
+type calculationInputs struct {
+	TrajectoryUnits []*entities.TrajectoryUnit `json:"trajectory_units"`
+	Strings         []json.RawMessage          `json:"strings"`
+	Holes           []json.RawMessage          `json:"holes"`
+	Fluids          []json.RawMessage          `json:"fluids"`
+	Rigs            []json.RawMessage          `json:"rigs"`
+	Params          json.RawMessage            `json:"params,omitempty"`
+}
+
+// GetCalculationResults retrieves the stored calculation results of the case without their values, newest first.
+func (s *torqueAndDragService) GetCalculationResults(ctx context.Context, input *requests.GetCalculationResultsRequest) ([]*entities.CalculationResult, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return nil, err
+	}
+
+	return s.resultsRepo.GetCalculationResults(ctx, input.CaseID)
+}
+
+// calculateStored serves the result of the calculation from storage while the inputs of the case and params
+// are unchanged and the result is not stale. Otherwise calculate fills in res, which is then stored. res is
+// a pointer the stored result is decoded into.
+func (s *torqueAndDragService) calculateStored(ctx context.Context, organizationID string, caseID string, calculationType string, params interface{}, res interface{}, calculate func() error) error {
+	if err := s.commonRepo.CheckOwnership(ctx, organizationID, entities.ScopeCase, caseID); err != nil {
+		return err
+	}
+
+	var encodedParams json.RawMessage
+	if params != nil {
+		var err error
+		if encodedParams, err = json.Marshal(params); err != nil {
+			return err
+		}
+	}
+	inputHash, err := s.calculationInputHash(ctx, caseID, encodedParams)
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.resultsRepo.GetLatestCalculationResult(ctx, caseID, entities.CalculationEngineMLModel, calculationType, inputHash)
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		return json.Unmarshal(stored.Result, res)
+	}
+
+	if err := calculate(); err != nil {
+		return err
+	}
+	result := &entities.CalculationResult{
+		CaseID:    caseID,
+		Engine:    entities.CalculationEngineMLModel,
+		Type:      calculationType,
+		InputHash: inputHash,
+		Params:    encodedParams,
+	}
+	if result.Result, err = json.Marshal(res); err == nil {
+		err = s.resultsRepo.CreateCalculationResult(ctx, result)
+	}
+	if err != nil {
+		// The result is still returned, the next request calculates it again
+		logrus.Errorf("failed to store %s result of case %s: %v", calculationType, caseID, err)
+	}
+	return nil
+}
+
+// calculationInputHash returns the SHA-256 hash of the trajectory, strings, holes, fluids and rigs of the case
+// and the params of the calculation, see calculationInputs.
+func (s *torqueAndDragService) calculationInputHash(ctx context.Context, caseID string, params json.RawMessage) (string, error) {
+	trajectory, err := s.commonRepo.GetTrajectoryByCaseID(ctx, caseID)
+	if err != nil {
+		return "", err
+	}
+	caseEntity, err := s.casesRepo.GetCaseWithComponents(ctx, caseID)
+	if err != nil {
+		return "", err
+	}
+
+	inputs, err := newCalculationInputs(trajectory.Units, caseEntity)
+	if err != nil {
+		return "", err
+	}
+	inputs.Params = params
+	encoded, err := json.Marshal(inputs)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// newCalculationInputs copies the engineering values of the trajectory units and the components of the case.
+// Stations are ordered by MD, string sections and casings by depth and then by their encoding, the components
+// by their encoding.
+func newCalculationInputs(units []*entities.TrajectoryUnit, caseEntity *entities.Case) (*calculationInputs, error) {
+	res := &calculationInputs{TrajectoryUnits: make([]*entities.TrajectoryUnit, len(units))}
+	for i, unit := range units {
+		copied := *unit
+		copied.ID, copied.CreatedAt = "", time.Time{}
+		res.TrajectoryUnits[i] = &copied
+	}
+	sort.Slice(res.TrajectoryUnits, func(i, j int) bool {
+		a, b := res.TrajectoryUnits[i], res.TrajectoryUnits[j]
+		return a.MD < b.MD || a.MD == b.MD && encodedLess(a, b)
+	})
+
+	var components []interface{}
+	for _, str := range caseEntity.Strings {
+		copied := *str
+		copied.ID, copied.Name, copied.CreatedAt = "", "", time.Time{}
+		copied.Sections = make([]*entities.Section, len(str.Sections))
+		for i, section := range str.Sections {
+			copiedSection := *section
+			copiedSection.ID, copiedSection.Description, copiedSection.Manufacturer, copiedSection.CreatedAt = "", nil, nil, time.Time{}
+			copied.Sections[i] = &copiedSection
+		}
+		sort.Slice(copied.Sections, func(i, j int) bool {
+			a, b := copied.Sections[i], copied.Sections[j]
+			return a.BodyMD < b.BodyMD || a.BodyMD == b.BodyMD && encodedLess(a, b)
+		})
+		components = append(components, &copied)
+	}
+	strs, err := encodeSorted(components)
+	if err != nil {
+		return nil, err
+	}
+
+	components = nil
+	for _, hole := range caseEntity.Holes {
+		copied := *hole
+		copied.ID, copied.CreatedAt, copied.DescriptionOpenHole = "", time.Time{}, nil
+		copied.Caisings = make([]*entities.Caising, len(hole.Caisings))
+		for i, caising := range hole.Caisings {
+			copiedCaising := *caising
+			copiedCaising.ID = ""
+			copiedCaising.DescriptionCaising, copiedCaising.ManufacturerCaising, copiedCaising.ModelCaising = nil, nil, nil
+			copied.Caisings[i] = &copiedCaising
+		}
+		sort.Slice(copied.Caisings, func(i, j int) bool {
+			a, b := copied.Caisings[i], copied.Caisings[j]
+			return a.MDTop < b.MDTop || a.MDTop == b.MDTop && encodedLess(a, b)
+		})
+		components = append(components, &copied)
+	}
+	holes, err := encodeSorted(components)
+	if err != nil {
+		return nil, err
+	}
+
+	components = nil
+	for _, fluid := range caseEntity.Fluids {
+		copied := *fluid
+		copied.ID, copied.Name, copied.Description, copied.CreatedAt = "", "", "", time.Time{}
+		copied.FluidBaseType, copied.BaseFluid = fluidTypeWithoutID(fluid.FluidBaseType), fluidTypeWithoutID(fluid.BaseFluid)
+		components = append(components, &copied)
+	}
+	fluids, err := encodeSorted(components)
+	if err != nil {
+		return nil, err
+	}
+
+	components = nil
+	for _, rig := range caseEntity.Rigs {
+		copied := *rig
+		copied.ID, copied.CreatedAt, copied.UpdatedAt = "", time.Time{}, time.Time{}
+		components = append(components, &copied)
+	}
+	rigs, err := encodeSorted(components)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Strings, res.Holes, res.Fluids, res.Rigs = strs, holes, fluids, rigs
+	return res, nil
+}
+
+// fluidTypeWithoutID returns a copy of the fluid type without its ID.
+func fluidTypeWithoutID(fluidType *entities.FluidType) *entities.FluidType {
+	if fluidType == nil {
+		return nil
+	}
+	return &entities.FluidType{Name: fluidType.Name}
+}
+
+// encodeSorted encodes the components and sorts the encodings, which orders components that have no depth.
+func encodeSorted(components []interface{}) ([]json.RawMessage, error) {
+	res := make([]json.RawMessage, len(components))
+	for i, component := range components {
+		encoded, err := json.Marshal(component)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = encoded
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i], res[j]) < 0
+	})
+	return res, nil
+}
+
+// encodedLess orders values at the same depth by their encoding.
+func encodedLess(a, b interface{}) bool {
+	encodedA, _ := json.Marshal(a)
+	encodedB, _ := json.Marshal(b)
+	return bytes.Compare(encodedA, encodedB) < 0
+}
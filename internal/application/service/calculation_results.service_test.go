@@ -0,0 +1,111 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+func TestCalculationInputs(t *testing.T) {
+	stored := encodedCalculationInputs(t, testTrajectoryUnits(), testCase())
+
+	reordered := testCase()
+	units := testTrajectoryUnits()
+	units[0], units[1] = units[1], units[0]
+	reordered.Holes[0].Caisings[0], reordered.Holes[0].Caisings[1] = reordered.Holes[0].Caisings[1], reordered.Holes[0].Caisings[0]
+	reordered.Strings[0].Sections[0], reordered.Strings[0].Sections[1] = reordered.Strings[0].Sections[1], reordered.Strings[0].Sections[0]
+	reordered.Fluids[0], reordered.Fluids[1] = reordered.Fluids[1], reordered.Fluids[0]
+	if got := encodedCalculationInputs(t, units, reordered); !bytes.Equal(got, stored) {
+		t.Errorf("the order of the rows changed the inputs:\n%s\n%s", got, stored)
+	}
+
+	relabelled := testCase()
+	units = testTrajectoryUnits()
+	units[0].ID, units[0].CreatedAt = "another", time.Now()
+	relabelled.Strings[0].ID, relabelled.Strings[0].Name = "another", "Another string"
+	relabelled.Fluids[0].Name, relabelled.Fluids[0].FluidBaseType.ID = "Another fluid", "another"
+	relabelled.Rigs[0].UpdatedAt = time.Now()
+	if got := encodedCalculationInputs(t, units, relabelled); !bytes.Equal(got, stored) {
+		t.Errorf("the labels changed the inputs:\n%s\n%s", got, stored)
+	}
+
+	changes := map[string]func(units []*entities.TrajectoryUnit, caseEntity *entities.Case){
+		"station inclination": func(units []*entities.TrajectoryUnit, _ *entities.Case) { units[1].Incl = 5 },
+		"section weight":      func(_ []*entities.TrajectoryUnit, c *entities.Case) { *c.Strings[0].Sections[0].Weight = 30 },
+		"casing depth":        func(_ []*entities.TrajectoryUnit, c *entities.Case) { c.Holes[0].Caisings[1].MDBase = 1200 },
+		"fluid density":       func(_ []*entities.TrajectoryUnit, c *entities.Case) { c.Fluids[1].Density = 1.3 },
+		"rig rating":          func(_ []*entities.TrajectoryUnit, c *entities.Case) { c.Rigs[0].SurfacePressureLoss = 2 },
+	}
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			units, caseEntity := testTrajectoryUnits(), testCase()
+			change(units, caseEntity)
+			if bytes.Equal(encodedCalculationInputs(t, units, caseEntity), stored) {
+				t.Error("the inputs did not change")
+			}
+		})
+	}
+}
+
+func TestCalculationInputsKeepTheCase(t *testing.T) {
+	units, caseEntity := testTrajectoryUnits(), testCase()
+	units[0], units[1] = units[1], units[0]
+	encodedCalculationInputs(t, units, caseEntity)
+	if units[0].ID != "2" || units[0].CreatedAt.IsZero() || caseEntity.Strings[0].Name != "String" || caseEntity.Fluids[0].FluidBaseType.ID != "water" {
+		t.Error("newCalculationInputs() changed the trajectory units or the case")
+	}
+}
+
+func encodedCalculationInputs(t *testing.T, units []*entities.TrajectoryUnit, caseEntity *entities.Case) []byte {
+	t.Helper()
+	inputs, err := newCalculationInputs(units, caseEntity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+func testTrajectoryUnits() []*entities.TrajectoryUnit {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []*entities.TrajectoryUnit{
+		{ID: "1", MD: 0, CreatedAt: createdAt},
+		{ID: "2", MD: 1000, Incl: 10, Azim: 45, TVD: 994.9, CreatedAt: createdAt},
+	}
+}
+
+func testCase() *entities.Case {
+	weight, heavyWeight := 29.0, 73.5
+	return &entities.Case{
+		ID: "case",
+		Strings: []*entities.String{{
+			ID:    "string",
+			Name:  "String",
+			Depth: 1000,
+			Sections: []*entities.Section{
+				{ID: "pipe", Type: "Drill Pipe", BodyMD: 800, BodyLength: 800, Weight: &weight},
+				{ID: "collar", Type: "Drill Collar", BodyMD: 1000, BodyLength: 200, Weight: &heavyWeight},
+			},
+		}},
+		Holes: []*entities.Hole{{
+			ID:             "hole",
+			OpenHoleMDTop:  1100,
+			OpenHoleMDBase: 1500,
+			Caisings: []*entities.Caising{
+				{ID: "conductor", MDTop: 0, MDBase: 100},
+				{ID: "surface", MDTop: 0, MDBase: 1100},
+			},
+		}},
+		Fluids: []*entities.Fluid{
+			{ID: "water", Name: "Water", Density: 1, FluidBaseType: &entities.FluidType{ID: "water", Name: "Water Based"}},
+			{ID: "mud", Name: "Mud", Density: 1.2, FluidBaseType: &entities.FluidType{ID: "water", Name: "Water Based"}},
+		},
+		Rigs: []*entities.Rig{{ID: "rig", SurfacePressureLoss: 1}},
+	}
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/jsondiff"
+)
+
+const (
+	// defaultComparisonDepthStep is the step of the common depth grid in meters
+	defaultComparisonDepthStep = 30.0
+)
+
+// comparisonIgnoredKeys are not compared between cases: every case has its own ids and creation times.
+var comparisonIgnoredKeys = []string{"id", "created_at"}
+
+type caseComparisonService struct {
+	casesRepo     repository.CasesRepository
+	commonRepo    repository.CommonRepository
+	roles         Roles
+	torqueAndDrag TorqueAndDrag
+}
+
+func NewCaseComparisonService(casesRepo repository.CasesRepository, commonRepo repository.CommonRepository, roles Roles, torqueAndDrag TorqueAndDrag) *caseComparisonService {
+	return &caseComparisonService{
+		casesRepo:     casesRepo,
+		commonRepo:    commonRepo,
+		roles:         roles,
+		torqueAndDrag: torqueAndDrag,
+	}
+}
+
+// caseResults holds the raw torque and drag results of a case on the measured depths of its trajectory.
+type caseResults struct {
+	tension     *responses.EffectiveTensionFromMLModelResponse
+	weightOnBit *responses.WeightOnBitFromMLModelResponse
+	torque      *responses.MomentFromMLModelResponse
+	err         error
+}
+
+// CompareCases compares the inputs and the torque and drag results of the cases with the first case.
+// The cases are calculated concurrently, a case that fails to calculate is reported with its error.
+func (s *caseComparisonService) CompareCases(ctx context.Context, input *requests.CompareCasesRequest) (*responses.CaseComparisonResponse, error) {
+	caseIDs := input.Body.CaseIDs
+	for _, caseID := range caseIDs {
+		err := s.roles.Authorize(ctx, &requests.AuthorizeRequest{
+			UserID:          input.UserID,
+			OrganizationID:  input.OrganizationID,
+			Scope:           entities.ScopeCase,
+			ID:              caseID,
+			Permission:      entities.PermissionRead,
+			ApiKeyScope:     input.ApiKeyScope,
+			ApiKeyCompanyID: input.ApiKeyCompanyID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cases := make([]*entities.Case, len(caseIDs))
+	res := &responses.CaseComparisonResponse{
+		BaselineCaseID: caseIDs[0],
+		Cases:          make([]*responses.CaseComparisonCaseResponse, len(caseIDs)),
+	}
+	for i, caseID := range caseIDs {
+		caseEntity, err := s.casesRepo.GetCaseWithComponents(ctx, caseID)
+		if err != nil {
+			return nil, err
+		}
+		trajectory, err := s.commonRepo.GetTrajectoryByCaseID(ctx, caseID)
+		if err != nil {
+			return nil, err
+		}
+
+		cases[i] = caseEntity
+		res.Cases[i] = &responses.CaseComparisonCaseResponse{
+			CaseID:         caseID,
+			CaseName:       caseEntity.CaseName,
+			TrajectoryID:   trajectory.ID,
+			TrajectoryName: trajectory.Name,
+		}
+		if res.Cases[i].InputChanges, err = jsondiff.Diff(cases[0], caseEntity, comparisonIgnoredKeys...); err != nil {
+			return nil, err
+		}
+	}
+
+	results := s.calculateCases(ctx, input.OrganizationID, cases)
+
+	step := input.Body.DepthStep
+	if step == 0 {
+		step = defaultComparisonDepthStep
+	}
+	res.Depth = comparisonDepthGrid(results, step)
+
+	for i, result := range results {
+		comparison := res.Cases[i]
+		if result.err != nil {
+			comparison.Error = result.err.Error()
+			continue
+		}
+
+		depth := result.tension.Depth
+		comparison.Curves = responses.CaseComparisonCurvesResponse{
+			HookLoadPullUp:     resample(depth, result.tension.PullUp, res.Depth),
+			HookLoadRunIn:      resample(depth, result.tension.RunIn, res.Depth),
+			HookLoadRotary:     resample(depth, result.tension.RotaryDrilling, res.Depth),
+			TorqueRotary:       resample(result.torque.Depth, result.torque.RotaryDrilling, res.Depth),
+			WeightOnBitRotary:  resample(result.weightOnBit.Depth, result.weightOnBit.RotaryDrilling, res.Depth),
+			WeightOnBitSliding: resample(result.weightOnBit.Depth, result.weightOnBit.DrillingGZD, res.Depth),
+			MudWeight:          fluidDensityCurve(cases[i], depth, res.Depth),
+		}
+		comparison.Summary = responses.CaseComparisonSummaryResponse{
+			MaxHookLoad:    maxValue(result.tension.PullUp),
+			MaxTorque:      maxValue(result.torque.RotaryDrilling),
+			MaxWeightOnBit: maxValue(result.weightOnBit.RotaryDrilling),
+			MaxMudWeight:   maxValue(curveValues(comparison.Curves.MudWeight)),
+		}
+	}
+
+	baseline := res.Cases[0].Summary
+	for _, comparison := range res.Cases {
+		summary := &comparison.Summary
+		summary.DeltaMaxHookLoad = delta(summary.MaxHookLoad, baseline.MaxHookLoad)
+		summary.DeltaMaxTorque = delta(summary.MaxTorque, baseline.MaxTorque)
+		summary.DeltaMaxWeightOnBit = delta(summary.MaxWeightOnBit, baseline.MaxWeightOnBit)
+		summary.DeltaMaxMudWeight = delta(summary.MaxMudWeight, baseline.MaxMudWeight)
+	}
+
+	return res, nil
+}
+
+// calculateCases runs the effective tension, weight on bit and torque calculations of every case.
+func (s *caseComparisonService) calculateCases(ctx context.Context, organizationID string, cases []*entities.Case) []*caseResults {
+	results := make([]*caseResults, len(cases))
+	var wg sync.WaitGroup
+	for i, caseEntity := range cases {
+		results[i] = &caseResults{}
+		if !torqueAndDragReady(caseEntity) {
+			results[i].err = domainErrors.ErrCaseNotReadyForTorqueAndDrag
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *caseResults, caseID string) {
+			defer wg.Done()
+			if result.tension, result.err = s.torqueAndDrag.CalculateEffectiveTensionFromMLModel(ctx, organizationID, caseID); result.err != nil {
+				return
+			}
+			if result.weightOnBit, result.err = s.torqueAndDrag.CalculateWeightOnBitFromMlModel(ctx, organizationID, caseID); result.err != nil {
+				return
+			}
+			result.torque, result.err = s.torqueAndDrag.CalculateSurfaceTorqueFromMlModel(ctx, organizationID, caseID)
+		}(results[i], caseEntity.ID)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// comparisonDepthGrid returns depths from the surface to the deepest result of all cases with the step,
+// the deepest depth is always included.
+func comparisonDepthGrid(results []*caseResults, step float64) []float64 {
+	var maxDepth float64
+	for _, result := range results {
+		if result.err != nil {
+			continue
+		}
+		for _, depth := range result.tension.Depth {
+			maxDepth = math.Max(maxDepth, depth)
+		}
+	}
+
+	grid := []float64{}
+	for depth := 0.0; depth < maxDepth; depth += step {
+		grid = append(grid, depth)
+	}
+	return append(grid, maxDepth)
+}
+
+// resample interpolates values given at depths linearly on the grid. Grid depths outside the range
+// of the depths have no value.
+func resample(depths []float64, values []float64, grid []float64) []*float64 {
+	n := len(depths)
+	if len(values) < n {
+		n = len(values)
+	}
+	points := make([][2]float64, n)
+	for i := 0; i < n; i++ {
+		points[i] = [2]float64{depths[i], values[i]}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i][0] < points[j][0] })
+
+	res := make([]*float64, len(grid))
+	if n == 0 {
+		return res
+	}
+	for i, depth := range grid {
+		j := sort.Search(n, func(k int) bool { return points[k][0] >= depth })
+		switch {
+		case j == n:
+			continue
+		case points[j][0] == depth:
+			value := points[j][1]
+			res[i] = &value
+		case j > 0:
+			lower, upper := points[j-1], points[j]
+			value := lower[1] + (upper[1]-lower[1])*(depth-lower[0])/(upper[0]-lower[0])
+			res[i] = &value
+		}
+	}
+	return res
+}
+
+// fluidDensityCurve returns the static density of the first fluid of the case down to the deepest result of
+// the case, the mud weight curve of the comparison.
+func fluidDensityCurve(caseEntity *entities.Case, depths []float64, grid []float64) []*float64 {
+	res := make([]*float64, len(grid))
+	if len(caseEntity.Fluids) == 0 || len(depths) == 0 {
+		return res
+	}
+
+	density := caseEntity.Fluids[0].Density
+	maxDepth := depths[0]
+	for _, depth := range depths {
+		maxDepth = math.Max(maxDepth, depth)
+	}
+	for i, depth := range grid {
+		if depth <= maxDepth {
+			res[i] = &density
+		}
+	}
+	return res
+}
+
+func curveValues(curve []*float64) []float64 {
+	var res []float64
+	for _, value := range curve {
+		if value != nil {
+			res = append(res, *value)
+		}
+	}
+	return res
+}
+
+func maxValue(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	res := values[0]
+	for _, value := range values[1:] {
+		res = math.Max(res, value)
+	}
+	return &res
+}
+
+func delta(value *float64, baseline *float64) *float64 {
+	if value == nil || baseline == nil {
+		return nil
+	}
+	res := *value - *baseline
+	return &res
+}
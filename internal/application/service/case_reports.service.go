@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	"github.com/munaiplan/munaiplan-backend/pkg/chart"
+	"github.com/munaiplan/munaiplan-backend/pkg/pdf"
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+)
+
+const (
+	// reportChartHeight is the height of a row of charts in points
+	reportChartHeight = 300.0
+	// reportChartGap is the space between charts side by side in points
+	reportChartGap = 16.0
+)
+
+type caseReportsService struct {
+	casesRepo     repository.CasesRepository
+	commonRepo    repository.CommonRepository
+	torqueAndDrag TorqueAndDrag
+}
+
+func NewCaseReportsService(casesRepo repository.CasesRepository, commonRepo repository.CommonRepository, torqueAndDrag TorqueAndDrag) *caseReportsService {
+	return &caseReportsService{
+		casesRepo:     casesRepo,
+		commonRepo:    commonRepo,
+		torqueAndDrag: torqueAndDrag,
+	}
+}
+
+// caseReport is the data of a report, in the units of the report.
+type caseReport struct {
+	units      *units.System
+	names      *entities.HierarchyNames
+	caseEntity *entities.Case
+	trajectory *entities.Trajectory
+	tension    *responses.EffectiveTensionFromMLModelResponse
+	torque     *responses.MomentFromMLModelResponse
+	// torqueAndDragErr tells why the case has no torque and drag results
+	torqueAndDragErr error
+	generated        time.Time
+}
+
+// GetCaseReport builds the engineering report of the case: the hierarchy of the case, the trajectory with plan
+// and section views, the holes, strings, fluids and rig, the mud weight window and the torque and drag results.
+// The values are in the unit system of the request. A case the torque and drag model can't calculate gets a
+// note instead of the charts. Hydraulics results are not part of the report yet: there is no hydraulics model
+// and fluids have no rheology, so the report has a Hydraulics section saying they are not available.
+func (s *caseReportsService) GetCaseReport(ctx context.Context, input *requests.GetCaseReportRequest) (*pdf.Document, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return nil, err
+	}
+
+	report := &caseReport{units: input.Units, generated: time.Now().UTC()}
+	var err error
+	if report.names, err = s.commonRepo.GetHierarchyNames(ctx, entities.ScopeCase, input.CaseID); err != nil {
+		return nil, err
+	}
+	if report.caseEntity, err = s.casesRepo.GetCaseWithComponents(ctx, input.CaseID); err != nil {
+		return nil, err
+	}
+	if report.trajectory, err = s.commonRepo.GetTrajectoryByCaseID(ctx, input.CaseID); err != nil {
+		return nil, err
+	}
+	report.tension, report.torqueAndDragErr = s.torqueAndDrag.CalculateEffectiveTensionFromMLModel(ctx, input.OrganizationID, input.CaseID)
+	if report.torqueAndDragErr == nil {
+		report.torque, report.torqueAndDragErr = s.torqueAndDrag.CalculateSurfaceTorqueFromMlModel(ctx, input.OrganizationID, input.CaseID)
+	}
+
+	report.caseEntity = input.Units.FromCanonical(report.caseEntity).(*entities.Case)
+	report.trajectory = input.Units.FromCanonical(report.trajectory).(*entities.Trajectory)
+	report.tension = input.Units.FromCanonical(report.tension).(*responses.EffectiveTensionFromMLModelResponse)
+	report.torque = input.Units.FromCanonical(report.torque).(*responses.MomentFromMLModelResponse)
+
+	return report.build(), nil
+}
+
+func (r *caseReport) build() *pdf.Document {
+	names := r.names
+	doc := pdf.New(fmt.Sprintf("%s - %s - %s", names.WellName, names.WellboreName, names.CaseName))
+	doc.Created = r.generated
+	flow := pdf.NewFlow(doc, fmt.Sprintf("%s / %s / %s / %s / %s / %s",
+		names.CompanyName, names.FieldName, names.SiteName, names.WellName, names.WellboreName, names.CaseName))
+
+	flow.Title("Well engineering report")
+	r.writeOverview(flow)
+	r.writeTrajectory(doc, flow)
+	r.writeHoles(flow)
+	r.writeStrings(flow)
+	r.writeFluidsAndRig(flow)
+	r.writeMudWeightWindow(doc, flow)
+	r.writeTorqueAndDrag(doc, flow)
+	r.writeHydraulics(flow)
+
+	flow.Close(fmt.Sprintf("Generated %s, %s units", r.generated.Format("2006-01-02 15:04 MST"), r.units.Name))
+	return doc
+}
+
+func (r *caseReport) writeOverview(flow *pdf.Flow) {
+	names, caseEntity := r.names, r.caseEntity
+	design := names.DesignName
+	if names.DesignStage != "" {
+		design = fmt.Sprintf("%s (%s)", design, names.DesignStage)
+	}
+	flow.Heading("Well")
+	flow.Fields([][2]string{
+		{"Company", names.CompanyName},
+		{"Field", names.FieldName},
+		{"Site", names.SiteName},
+		{"Well", names.WellName},
+		{"Wellbore", names.WellboreName},
+		{"Design", design},
+		{"Trajectory", names.TrajectoryName},
+		{"Case", names.CaseName},
+		{"Drill depth", r.quantity(caseEntity.DrillDepth, units.Length, 1)},
+		{"Pipe size", r.quantity(caseEntity.PipeSize, units.Diameter, 2)},
+	})
+	if caseEntity.CaseDescription != "" {
+		flow.Paragraph(caseEntity.CaseDescription)
+	}
+}
+
+func (r *caseReport) writeTrajectory(doc *pdf.Document, flow *pdf.Flow) {
+	flow.Heading("Trajectory")
+	stations := r.trajectory.Units
+	if len(stations) == 0 {
+		flow.Paragraph("The trajectory has no survey stations.")
+		return
+	}
+
+	var maxIncl, maxDogleg float64
+	for _, station := range stations {
+		maxIncl = math.Max(maxIncl, station.Incl)
+		maxDogleg = math.Max(maxDogleg, station.Dogleg)
+	}
+	last := stations[len(stations)-1]
+	flow.Fields([][2]string{
+		{"Stations", strconv.Itoa(len(stations))},
+		{"Total depth MD", r.quantity(last.MD, units.Length, 1)},
+		{"Total depth TVD", r.quantity(last.TVD, units.Length, 1)},
+		{"Displacement", r.quantity(math.Hypot(last.LocalNCoord, last.LocalECoord), units.Length, 1)},
+		{"Max inclination", formatNumber(maxIncl, 2) + " °"},
+		{"Max dogleg severity", r.quantity(maxDogleg, units.DoglegSeverity, 2)},
+	})
+
+	drawCharts(doc, flow, planViewChart(r.trajectory, r.units), verticalSectionChart(r.trajectory, r.units))
+
+	length := r.units.Symbol(units.Length)
+	rows := make([][]string, 0, len(stations))
+	for _, station := range stations {
+		rows = append(rows, []string{
+			formatNumber(station.MD, 2), formatNumber(station.Incl, 2), formatNumber(station.Azim, 2), formatNumber(station.TVD, 2),
+			formatNumber(station.LocalNCoord, 2), formatNumber(station.LocalECoord, 2), formatNumber(station.VerticalSection, 2), formatNumber(station.Dogleg, 2),
+		})
+	}
+	flow.Subheading("Survey stations")
+	flow.Table([]pdf.Column{
+		{Title: "MD, " + length, Width: 1, Numeric: true},
+		{Title: "Incl, °", Width: 1, Numeric: true},
+		{Title: "Azim, °", Width: 1, Numeric: true},
+		{Title: "TVD, " + length, Width: 1, Numeric: true},
+		{Title: "N, " + length, Width: 1, Numeric: true},
+		{Title: "E, " + length, Width: 1, Numeric: true},
+		{Title: "VS, " + length, Width: 1, Numeric: true},
+		{Title: "DLS, " + r.units.Symbol(units.DoglegSeverity), Width: 1.2, Numeric: true},
+	}, rows)
+}
+
+func (r *caseReport) writeHoles(flow *pdf.Flow) {
+	flow.Heading("Hole")
+	if len(r.caseEntity.Holes) == 0 {
+		flow.Paragraph("The case has no hole.")
+		return
+	}
+	length, diameter := r.units.Symbol(units.Length), r.units.Symbol(units.Diameter)
+	for _, hole := range r.caseEntity.Holes {
+		if len(hole.Caisings) > 0 {
+			flow.Subheading("Casings")
+			rows := make([][]string, 0, len(hole.Caisings))
+			for _, casing := range hole.Caisings {
+				rows = append(rows, []string{
+					stringValue(casing.DescriptionCaising), formatNumber(casing.MDTop, 1), formatNumber(casing.MDBase, 1),
+					formatNumber(casing.OD, 2), formatNumber(casing.DriftID, 2), formatNumber(casing.Weight, 2), casing.Grade,
+					formatOptional(casing.ShoeMD, 1),
+				})
+			}
+			flow.Table([]pdf.Column{
+				{Title: "Description", Width: 2.2},
+				{Title: "MD top, " + length, Width: 1, Numeric: true},
+				{Title: "MD base, " + length, Width: 1, Numeric: true},
+				{Title: "OD, " + diameter, Width: 1, Numeric: true},
+				{Title: "Drift ID, " + diameter, Width: 1, Numeric: true},
+				{Title: "Weight, " + r.units.Symbol(units.LinearWeight), Width: 1, Numeric: true},
+				{Title: "Grade", Width: 0.8},
+				{Title: "Shoe MD, " + length, Width: 1, Numeric: true},
+			}, rows)
+		}
+
+		flow.Subheading("Open hole")
+		flow.Fields([][2]string{
+			{"MD top", r.quantity(hole.OpenHoleMDTop, units.Length, 1)},
+			{"MD base", r.quantity(hole.OpenHoleMDBase, units.Length, 1)},
+			{"Effective diameter", r.quantity(hole.EffectiveDiameter, units.Diameter, 2)},
+			{"Description", stringValue(hole.DescriptionOpenHole)},
+		})
+		flow.Subheading("Friction factors")
+		flow.Table([]pdf.Column{
+			{Title: "", Width: 1},
+			{Title: "Trip in", Width: 1, Numeric: true},
+			{Title: "Trip out", Width: 1, Numeric: true},
+			{Title: "Rotating on bottom", Width: 1.3, Numeric: true},
+			{Title: "Slide drilling", Width: 1.1, Numeric: true},
+			{Title: "Back reaming", Width: 1.1, Numeric: true},
+			{Title: "Rotating off bottom", Width: 1.3, Numeric: true},
+		}, [][]string{
+			{"Casing", formatNumber(hole.TrippingInCasing, 2), formatNumber(hole.TrippingOutCasing, 2), formatNumber(hole.RotatingOnBottomCasing, 2),
+				formatNumber(hole.SlideDrillingCasing, 2), formatNumber(hole.BackReamingCasing, 2), formatNumber(hole.RotatingOffBottomCasing, 2)},
+			{"Open hole", formatNumber(hole.TrippingInOpenHole, 2), formatNumber(hole.TrippingOutOpenHole, 2), formatNumber(hole.RotatingOnBottomOpenHole, 2),
+				formatNumber(hole.SlideDrillingOpenHole, 2), formatNumber(hole.BackReamingOpenHole, 2), formatNumber(hole.RotatingOffBottomOpenHole, 2)},
+		})
+	}
+}
+
+func (r *caseReport) writeStrings(flow *pdf.Flow) {
+	flow.Heading("Drill string")
+	if len(r.caseEntity.Strings) == 0 {
+		flow.Paragraph("The case has no drill string.")
+		return
+	}
+	length, diameter := r.units.Symbol(units.Length), r.units.Symbol(units.Diameter)
+	for _, drillString := range r.caseEntity.Strings {
+		flow.Subheading(fmt.Sprintf("%s, depth %s", drillString.Name, r.quantity(drillString.Depth, units.Length, 1)))
+		rows := make([][]string, 0, len(drillString.Sections))
+		for _, section := range drillString.Sections {
+			rows = append(rows, []string{
+				section.Type, stringValue(section.Description), formatNumber(section.BodyLength, 2), formatNumber(section.BodyMD, 2),
+				formatNumber(section.BodyOD, 2), formatNumber(section.BodyID, 2), formatOptional(section.Weight, 2), stringValue(section.Grade),
+			})
+		}
+		flow.Table([]pdf.Column{
+			{Title: "Type", Width: 1.3},
+			{Title: "Description", Width: 2},
+			{Title: "Length, " + length, Width: 1, Numeric: true},
+			{Title: "MD, " + length, Width: 1, Numeric: true},
+			{Title: "OD, " + diameter, Width: 0.9, Numeric: true},
+			{Title: "ID, " + diameter, Width: 0.9, Numeric: true},
+			{Title: "Weight, " + r.units.Symbol(units.LinearWeight), Width: 1, Numeric: true},
+			{Title: "Grade", Width: 0.7},
+		}, rows)
+	}
+}
+
+func (r *caseReport) writeFluidsAndRig(flow *pdf.Flow) {
+	flow.Heading("Fluids")
+	if len(r.caseEntity.Fluids) == 0 {
+		flow.Paragraph("The case has no fluid.")
+	} else {
+		rows := make([][]string, 0, len(r.caseEntity.Fluids))
+		for _, fluid := range r.caseEntity.Fluids {
+			rows = append(rows, []string{fluid.Name, fluidTypeName(fluid.FluidBaseType), fluidTypeName(fluid.BaseFluid), formatNumber(fluid.Density, 3), fluid.Description})
+		}
+		flow.Table([]pdf.Column{
+			{Title: "Name", Width: 1.5},
+			{Title: "Type", Width: 1.2},
+			{Title: "Base fluid", Width: 1.2},
+			{Title: "Density, " + r.units.Symbol(units.Density), Width: 1, Numeric: true},
+			{Title: "Description", Width: 2.5},
+		}, rows)
+	}
+
+	flow.Heading("Rig")
+	if len(r.caseEntity.Rigs) == 0 {
+		flow.Paragraph("The case has no rig.")
+		return
+	}
+	for _, rig := range r.caseEntity.Rigs {
+		flow.Fields([][2]string{
+			{"Block rating", r.optionalQuantity(rig.BlockRating, units.Force, 1)},
+			{"Torque rating", r.optionalQuantity(rig.TorqueRating, units.Torque, 1)},
+			{"Rated working pressure", r.quantity(rig.RatedWorkingPressure, units.Pressure, 2)},
+			{"BOP pressure rating", r.quantity(rig.BopPressureRating, units.Pressure, 2)},
+			{"Surface pressure loss", r.quantity(rig.SurfacePressureLoss, units.Pressure, 2)},
+		})
+	}
+}
+
+// writeMudWeightWindow charts the pore pressure and fracture gradients against the density of the fluids.
+func (r *caseReport) writeMudWeightWindow(doc *pdf.Document, flow *pdf.Flow) {
+	flow.Heading("Mud weight window")
+	mudWeightWindow := mudWeightWindowChart(r.caseEntity, r.units)
+	if len(mudWeightWindow.Series) == 0 {
+		flow.Paragraph("The case has no pore pressure or fracture gradient with an equivalent mud weight.")
+		return
+	}
+	// The heading of the section names the chart
+	mudWeightWindow.Title = ""
+	drawCharts(doc, flow, mudWeightWindow)
+}
+
+func (r *caseReport) writeTorqueAndDrag(doc *pdf.Document, flow *pdf.Flow) {
+	flow.Heading("Torque and drag")
+	if r.torqueAndDragErr != nil {
+		flow.Paragraph("Torque and drag results are not available: " + r.torqueAndDragErr.Error())
+		return
+	}
+	drawCharts(doc, flow, effectiveTensionChart(r.tension, r.units), surfaceTorqueChart(r.torque, r.units))
+}
+
+// writeHydraulics notes that hydraulics results are missing, so a reader doesn't take the report as complete.
+func (r *caseReport) writeHydraulics(flow *pdf.Flow) {
+	flow.Heading("Hydraulics")
+	flow.Paragraph("Hydraulics results are not available: pressure losses and ECD are not calculated, " +
+		"the fluids of the case have no rheology.")
+}
+
+// drawCharts draws the charts side by side in a row of the flow.
+func drawCharts(doc *pdf.Document, flow *pdf.Flow, charts ...*chart.Chart) {
+	flow.Block(reportChartHeight, func(page *pdf.Page, x, y, width float64) {
+		canvas := chart.NewPDFCanvas(doc, page)
+		chartWidth := (width - reportChartGap*float64(len(charts)-1)) / float64(len(charts))
+		for i, c := range charts {
+			c.Draw(canvas, x+float64(i)*(chartWidth+reportChartGap), y, chartWidth, reportChartHeight-reportChartGap)
+		}
+	})
+}
+
+// quantity formats the value with the unit symbol of the quantity.
+func (r *caseReport) quantity(value float64, q units.Quantity, decimals int) string {
+	return formatNumber(value, decimals) + " " + r.units.Symbol(q)
+}
+
+func (r *caseReport) optionalQuantity(value *float64, q units.Quantity, decimals int) string {
+	if value == nil {
+		return ""
+	}
+	return r.quantity(*value, q, decimals)
+}
+
+func formatNumber(value float64, decimals int) string {
+	return strconv.FormatFloat(value, 'f', decimals, 64)
+}
+
+func formatOptional(value *float64, decimals int) string {
+	if value == nil {
+		return ""
+	}
+	return formatNumber(*value, decimals)
+}
+
+func fluidTypeName(fluidType *entities.FluidType) string {
+	if fluidType == nil {
+		return ""
+	}
+	return fluidType.Name
+}
@@ -0,0 +1,630 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+	"github.com/munaiplan/munaiplan-backend/pkg/workbook"
+	"github.com/xuri/excelize/v2"
+)
+
+// Sheets of a case workbook
+const (
+	caseSheet              = "Case"
+	trajectorySheet        = "Trajectory"
+	stringSheet            = "String"
+	sectionsSheet          = "Sections"
+	holeSheet              = "Hole"
+	casingsSheet           = "Casings"
+	fluidSheet             = "Fluid"
+	porePressureSheet      = "Pore pressure"
+	fractureGradientSheet  = "Fracture gradient"
+	rigSheet               = "Rig"
+	effectiveTensionSheet  = "Effective tension"
+	weightOnBitSheet       = "Weight on bit"
+	surfaceTorqueSheet     = "Surface torque"
+	minWeightOnBitSheet    = "Min weight on bit"
+	notCalculatedSheetNote = "Not calculated: "
+)
+
+// workbookCase is the Case sheet, Units names the unit system of the values of the workbook.
+type workbookCase struct {
+	ID              string  `json:"id"`
+	CaseName        string  `json:"case_name"`
+	CaseDescription string  `json:"case_description"`
+	DrillDepth      float64 `json:"drill_depth" unit:"length"`
+	PipeSize        float64 `json:"pipe_size" unit:"diameter"`
+	Units           string  `json:"units"`
+}
+
+// workbookFluid is the Fluid sheet with the fluid types by name.
+type workbookFluid struct {
+	*entities.Fluid
+	FluidBaseType string `json:"fluid_base_type"`
+	BaseFluid     string `json:"base_fluid"`
+}
+
+type caseWorkbooksService struct {
+	casesRepo             repository.CasesRepository
+	trajectoriesRepo      repository.TrajectoriesRepository
+	stringsRepo           repository.StringsRepository
+	holesRepo             repository.HolesRepository
+	fluidsRepo            repository.FluidsRepository
+	porePressuresRepo     repository.PorePressuresRepository
+	fractureGradientsRepo repository.FractureGradientsRepository
+	rigsRepo              repository.RigsRepository
+	commonRepo            repository.CommonRepository
+	transactionsRepo      repository.TransactionsRepository
+	torqueAndDrag         TorqueAndDrag
+}
+
+func NewCaseWorkbooksService(casesRepo repository.CasesRepository, trajectoriesRepo repository.TrajectoriesRepository, stringsRepo repository.StringsRepository, holesRepo repository.HolesRepository, fluidsRepo repository.FluidsRepository, porePressuresRepo repository.PorePressuresRepository, fractureGradientsRepo repository.FractureGradientsRepository, rigsRepo repository.RigsRepository, commonRepo repository.CommonRepository, transactionsRepo repository.TransactionsRepository, torqueAndDrag TorqueAndDrag) *caseWorkbooksService {
+	return &caseWorkbooksService{
+		casesRepo:             casesRepo,
+		trajectoriesRepo:      trajectoriesRepo,
+		stringsRepo:           stringsRepo,
+		holesRepo:             holesRepo,
+		fluidsRepo:            fluidsRepo,
+		porePressuresRepo:     porePressuresRepo,
+		fractureGradientsRepo: fractureGradientsRepo,
+		rigsRepo:              rigsRepo,
+		commonRepo:            commonRepo,
+		transactionsRepo:      transactionsRepo,
+		torqueAndDrag:         torqueAndDrag,
+	}
+}
+
+// ExportCaseWorkbook writes the case to a workbook with a sheet per component and per torque and drag result.
+// The values are in the unit system of the request, the headers name the units. Results the model can't
+// calculate get a sheet with the reason.
+func (s *caseWorkbooksService) ExportCaseWorkbook(ctx context.Context, input *requests.ExportCaseWorkbookRequest) (*excelize.File, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return nil, err
+	}
+
+	caseEntity, err := s.casesRepo.GetCaseWithComponents(ctx, input.CaseID)
+	if err != nil {
+		return nil, err
+	}
+	trajectory, err := s.commonRepo.GetTrajectoryByCaseID(ctx, input.CaseID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(trajectory.Units, func(i, j int) bool { return trajectory.Units[i].MD < trajectory.Units[j].MD })
+
+	type result struct {
+		sheet string
+		value interface{}
+		err   error
+	}
+	var results []*result
+	add := func(sheet string, value interface{}, err error) {
+		results = append(results, &result{sheet: sheet, value: value, err: err})
+	}
+	tension, err := s.torqueAndDrag.CalculateEffectiveTensionFromMLModel(ctx, input.OrganizationID, input.CaseID)
+	add(effectiveTensionSheet, tension, err)
+	weightOnBit, err := s.torqueAndDrag.CalculateWeightOnBitFromMlModel(ctx, input.OrganizationID, input.CaseID)
+	add(weightOnBitSheet, weightOnBit, err)
+	torque, err := s.torqueAndDrag.CalculateSurfaceTorqueFromMlModel(ctx, input.OrganizationID, input.CaseID)
+	add(surfaceTorqueSheet, torque, err)
+	minWeight, err := s.torqueAndDrag.CalculateMinWeightFromMLModel(ctx, input.OrganizationID, input.CaseID)
+	add(minWeightOnBitSheet, minWeight, err)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	system := input.Units
+	caseEntity = system.FromCanonical(caseEntity).(*entities.Case)
+	trajectory = system.FromCanonical(trajectory).(*entities.Trajectory)
+	for _, r := range results {
+		if r.err == nil {
+			r.value = system.FromCanonical(r.value)
+		}
+	}
+
+	f := excelize.NewFile()
+	write := func() error {
+		if err := workbook.WriteFields(f, caseSheet, &workbookCase{
+			ID:              caseEntity.ID,
+			CaseName:        caseEntity.CaseName,
+			CaseDescription: caseEntity.CaseDescription,
+			DrillDepth:      caseEntity.DrillDepth,
+			PipeSize:        caseEntity.PipeSize,
+			Units:           system.Name,
+		}, system); err != nil {
+			return err
+		}
+		if err := workbook.WriteTable(f, trajectorySheet, trajectory.Units, system); err != nil {
+			return err
+		}
+
+		stringEntity, sections := &entities.String{}, []*entities.Section{}
+		if len(caseEntity.Strings) > 0 {
+			stringEntity, sections = caseEntity.Strings[0], caseEntity.Strings[0].Sections
+		}
+		if err := workbook.WriteFields(f, stringSheet, stringEntity, system); err != nil {
+			return err
+		}
+		if err := workbook.WriteTable(f, sectionsSheet, sections, system); err != nil {
+			return err
+		}
+
+		hole, casings := &entities.Hole{}, []*entities.Caising{}
+		if len(caseEntity.Holes) > 0 {
+			hole, casings = caseEntity.Holes[0], caseEntity.Holes[0].Caisings
+		}
+		if err := workbook.WriteFields(f, holeSheet, hole, system); err != nil {
+			return err
+		}
+		if err := workbook.WriteTable(f, casingsSheet, casings, system); err != nil {
+			return err
+		}
+
+		fluid := &workbookFluid{Fluid: &entities.Fluid{}}
+		if len(caseEntity.Fluids) > 0 {
+			fluid.Fluid = caseEntity.Fluids[0]
+			fluid.FluidBaseType = fluidTypeName(fluid.Fluid.FluidBaseType)
+			fluid.BaseFluid = fluidTypeName(fluid.Fluid.BaseFluid)
+		}
+		if err := workbook.WriteFields(f, fluidSheet, fluid, system); err != nil {
+			return err
+		}
+
+		if err := workbook.WriteTable(f, porePressureSheet, caseEntity.PorePressures, system); err != nil {
+			return err
+		}
+		if err := workbook.WriteTable(f, fractureGradientSheet, caseEntity.FractureGradients, system); err != nil {
+			return err
+		}
+
+		rig := &entities.Rig{}
+		if len(caseEntity.Rigs) > 0 {
+			rig = caseEntity.Rigs[0]
+		}
+		if err := workbook.WriteFields(f, rigSheet, rig, system); err != nil {
+			return err
+		}
+
+		for _, r := range results {
+			if r.err != nil {
+				if err := workbook.WriteNote(f, r.sheet, notCalculatedSheetNote+r.err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := workbook.WriteColumns(f, r.sheet, r.value, system); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := write(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	f.SetActiveSheet(0)
+	return f, nil
+}
+
+// caseWorkbookStep is a change of the case from a sheet of a workbook.
+type caseWorkbookStep struct {
+	sheets []string
+	apply  func(ctx context.Context) error
+}
+
+// ImportCaseWorkbook updates the case from the sheets of an exported and edited workbook. Sheets the workbook
+// doesn't have and the calculation results are ignored. The Trajectory, Sections, Casings, Pore pressure and
+// Fracture gradient sheets replace the rows of the case: rows without an id are added and rows left out are
+// deleted. The String, Hole, Fluid and Rig sheets update the component of the case or create it when the case
+// has none. The values are in the unit system named on the Case sheet, or in the one of the request. Every sheet
+// is read and checked before the case is changed, and the sheets are saved in one transaction, so a failed
+// import leaves the case as it was.
+func (s *caseWorkbooksService) ImportCaseWorkbook(ctx context.Context, input *requests.ImportCaseWorkbookRequest) (*responses.CaseWorkbookImportResponse, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.CaseID); err != nil {
+		return nil, err
+	}
+
+	f, err := excelize.OpenReader(input.File)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidWorkbookFile, err)
+	}
+	defer f.Close()
+
+	current, err := s.casesRepo.GetCaseWithComponents(ctx, input.CaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	system := input.Units
+	var caseRow *workbookCase
+	if workbook.HasSheet(f, caseSheet) {
+		caseRow = &workbookCase{}
+		if err := workbook.ReadFields(f, caseSheet, caseRow); err != nil {
+			return nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidWorkbookFile, err)
+		}
+		if caseRow.Units != "" {
+			if system, err = units.Lookup(caseRow.Units); err != nil {
+				return nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidWorkbookFile, err)
+			}
+		}
+		system.ToCanonical(caseRow)
+	}
+
+	var steps []*caseWorkbookStep
+	if caseRow != nil {
+		if caseRow.ID != "" && caseRow.ID != input.CaseID {
+			return nil, fmt.Errorf("%w: the Case sheet is of case %s", domainErrors.ErrUnknownWorkbookRow, caseRow.ID)
+		}
+		caseEntity := &entities.Case{
+			ID:              input.CaseID,
+			CaseName:        caseRow.CaseName,
+			CaseDescription: caseRow.CaseDescription,
+			DrillDepth:      caseRow.DrillDepth,
+			PipeSize:        caseRow.PipeSize,
+		}
+		steps = append(steps, &caseWorkbookStep{sheets: []string{caseSheet}, apply: func(ctx context.Context) error {
+			_, err := s.casesRepo.UpdateCase(ctx, caseEntity)
+			return err
+		}})
+	}
+
+	for _, plan := range []func(context.Context, *excelize.File, *units.System, *entities.Case) ([]*caseWorkbookStep, error){
+		s.planTrajectory, s.planString, s.planHole, s.planFluid, s.planProfiles, s.planRig,
+	} {
+		planned, err := plan(ctx, f, system, current)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, planned...)
+	}
+
+	res := &responses.CaseWorkbookImportResponse{ImportedSheets: []string{}}
+	err = s.transactionsRepo.Transaction(ctx, func(ctx context.Context) error {
+		for _, step := range steps {
+			if err := step.apply(ctx); err != nil {
+				return err
+			}
+			res.ImportedSheets = append(res.ImportedSheets, step.sheets...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Case, err = s.casesRepo.GetCaseWithComponents(ctx, input.CaseID); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// readSheet reads a table or fields sheet of the workbook into value in canonical units, false when the
+// workbook has no such sheet.
+func readSheet(f *excelize.File, sheet string, system *units.System, value interface{}, table bool) (bool, error) {
+	if !workbook.HasSheet(f, sheet) {
+		return false, nil
+	}
+	read := workbook.ReadFields
+	if table {
+		read = workbook.ReadTable
+	}
+	if err := read(f, sheet, value); err != nil {
+		return false, fmt.Errorf("%w: %v", domainErrors.ErrInvalidWorkbookFile, err)
+	}
+	system.ToCanonical(value)
+	return true, nil
+}
+
+// checkRowIDs checks that the rows with an id are rows of the component.
+func checkRowIDs(sheet string, rowIDs []string, existing map[string]bool) error {
+	for i, id := range rowIDs {
+		if id != "" && !existing[id] {
+			return fmt.Errorf("%w: row %d of the %s sheet", domainErrors.ErrUnknownWorkbookRow, i+2, sheet)
+		}
+	}
+	return nil
+}
+
+// checkFieldsID checks that the id of a fields sheet is the one of the component, if the sheet has one.
+func checkFieldsID(sheet, id, existing string) error {
+	if id != "" && id != existing {
+		return fmt.Errorf("%w: the %s sheet", domainErrors.ErrUnknownWorkbookRow, sheet)
+	}
+	return nil
+}
+
+func (s *caseWorkbooksService) planTrajectory(ctx context.Context, f *excelize.File, system *units.System, current *entities.Case) ([]*caseWorkbookStep, error) {
+	var stations []*entities.TrajectoryUnit
+	if ok, err := readSheet(f, trajectorySheet, system, &stations, true); err != nil || !ok {
+		return nil, err
+	}
+	if len(stations) < 2 {
+		return nil, fmt.Errorf("%w: the %s sheet needs two stations", domainErrors.ErrInvalidWorkbookFile, trajectorySheet)
+	}
+
+	trajectory, err := s.commonRepo.GetTrajectoryByCaseID(ctx, current.ID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(trajectory.Units))
+	for _, unit := range trajectory.Units {
+		existing[unit.ID] = true
+	}
+	ids := make([]string, len(stations))
+	for i, station := range stations {
+		ids[i] = station.ID
+	}
+	if err := checkRowIDs(trajectorySheet, ids, existing); err != nil {
+		return nil, err
+	}
+
+	// The headers are kept and the survey program is left out, so the update doesn't replace it
+	update := &entities.Trajectory{
+		ID:          trajectory.ID,
+		Name:        trajectory.Name,
+		Description: trajectory.Description,
+		Headers:     trajectory.Headers,
+		Units:       stations,
+	}
+	return []*caseWorkbookStep{{sheets: []string{trajectorySheet}, apply: func(ctx context.Context) error {
+		_, err := s.trajectoriesRepo.UpdateTrajectory(ctx, update)
+		return err
+	}}}, nil
+}
+
+func (s *caseWorkbooksService) planString(ctx context.Context, f *excelize.File, system *units.System, current *entities.Case) ([]*caseWorkbookStep, error) {
+	fields := &entities.String{}
+	hasFields, err := readSheet(f, stringSheet, system, fields, false)
+	if err != nil {
+		return nil, err
+	}
+	var sections []*entities.Section
+	hasSections, err := readSheet(f, sectionsSheet, system, &sections, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing *entities.String
+	if len(current.Strings) > 0 {
+		existing = current.Strings[0]
+	}
+	if !hasFields && !hasSections || existing == nil && isZero(fields) && len(sections) == 0 {
+		return nil, nil
+	}
+
+	stringEntity := &entities.String{}
+	sectionIDs := make(map[string]bool)
+	if existing != nil {
+		if err := checkFieldsID(stringSheet, fields.ID, existing.ID); err != nil {
+			return nil, err
+		}
+		stringEntity = &entities.String{ID: existing.ID, Name: existing.Name, Depth: existing.Depth, Sections: existing.Sections}
+		for _, section := range existing.Sections {
+			sectionIDs[section.ID] = true
+		}
+	} else if fields.ID != "" {
+		return nil, fmt.Errorf("%w: the %s sheet", domainErrors.ErrUnknownWorkbookRow, stringSheet)
+	}
+
+	var sheets []string
+	if hasFields && (existing != nil || !isZero(fields)) {
+		stringEntity.Name, stringEntity.Depth = fields.Name, fields.Depth
+		sheets = append(sheets, stringSheet)
+	}
+	if hasSections {
+		ids := make([]string, len(sections))
+		for i, section := range sections {
+			ids[i] = section.ID
+		}
+		if err := checkRowIDs(sectionsSheet, ids, sectionIDs); err != nil {
+			return nil, err
+		}
+		stringEntity.Sections = sections
+		sheets = append(sheets, sectionsSheet)
+	}
+
+	return []*caseWorkbookStep{{sheets: sheets, apply: func(ctx context.Context) error {
+		if existing == nil {
+			return s.stringsRepo.CreateString(ctx, current.ID, stringEntity)
+		}
+		_, err := s.stringsRepo.UpdateString(ctx, stringEntity)
+		return err
+	}}}, nil
+}
+
+func (s *caseWorkbooksService) planHole(ctx context.Context, f *excelize.File, system *units.System, current *entities.Case) ([]*caseWorkbookStep, error) {
+	fields := &entities.Hole{}
+	hasFields, err := readSheet(f, holeSheet, system, fields, false)
+	if err != nil {
+		return nil, err
+	}
+	var casings []*entities.Caising
+	hasCasings, err := readSheet(f, casingsSheet, system, &casings, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing *entities.Hole
+	if len(current.Holes) > 0 {
+		existing = current.Holes[0]
+	}
+	if !hasFields && !hasCasings || existing == nil && isZero(fields) && len(casings) == 0 {
+		return nil, nil
+	}
+
+	hole := &entities.Hole{}
+	casingIDs := make(map[string]bool)
+	if existing != nil {
+		if err := checkFieldsID(holeSheet, fields.ID, existing.ID); err != nil {
+			return nil, err
+		}
+		hole = &entities.Hole{ID: existing.ID, Caisings: existing.Caisings}
+		for _, casing := range existing.Caisings {
+			casingIDs[casing.ID] = true
+		}
+	} else if fields.ID != "" {
+		return nil, fmt.Errorf("%w: the %s sheet", domainErrors.ErrUnknownWorkbookRow, holeSheet)
+	}
+
+	var sheets []string
+	if hasFields && (existing != nil || !isZero(fields)) {
+		fields.ID, fields.Caisings = hole.ID, hole.Caisings
+		hole = fields
+		sheets = append(sheets, holeSheet)
+	}
+	if hasCasings {
+		ids := make([]string, len(casings))
+		for i, casing := range casings {
+			ids[i] = casing.ID
+		}
+		if err := checkRowIDs(casingsSheet, ids, casingIDs); err != nil {
+			return nil, err
+		}
+		hole.Caisings = casings
+		sheets = append(sheets, casingsSheet)
+	}
+
+	return []*caseWorkbookStep{{sheets: sheets, apply: func(ctx context.Context) error {
+		if existing == nil {
+			return s.holesRepo.CreateHole(ctx, current.ID, hole)
+		}
+		_, err := s.holesRepo.UpdateHole(ctx, hole)
+		return err
+	}}}, nil
+}
+
+func (s *caseWorkbooksService) planFluid(ctx context.Context, f *excelize.File, system *units.System, current *entities.Case) ([]*caseWorkbookStep, error) {
+	row := &workbookFluid{Fluid: &entities.Fluid{}}
+	if ok, err := readSheet(f, fluidSheet, system, row, false); err != nil || !ok {
+		return nil, err
+	}
+
+	var existing *entities.Fluid
+	if len(current.Fluids) > 0 {
+		existing = current.Fluids[0]
+	}
+	if existing == nil && isZero(row.Fluid) && row.FluidBaseType == "" && row.BaseFluid == "" {
+		return nil, nil
+	}
+	fluid := row.Fluid
+	if existing != nil {
+		if err := checkFieldsID(fluidSheet, fluid.ID, existing.ID); err != nil {
+			return nil, err
+		}
+		fluid.ID = existing.ID
+		fluid.FluidBaseType, fluid.BaseFluid = existing.FluidBaseType, existing.BaseFluid
+	} else if fluid.ID != "" {
+		return nil, fmt.Errorf("%w: the %s sheet", domainErrors.ErrUnknownWorkbookRow, fluidSheet)
+	}
+
+	types, err := s.fluidsRepo.GetFluidTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolve := func(name string, current *entities.FluidType, column string) (*entities.FluidType, error) {
+		if name == "" {
+			if current == nil {
+				return nil, fmt.Errorf("%w: the %s sheet needs the %s", domainErrors.ErrInvalidWorkbookFile, fluidSheet, column)
+			}
+			return current, nil
+		}
+		for _, fluidType := range types {
+			if strings.EqualFold(fluidType.Name, name) {
+				return fluidType, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: unknown %s %q on the %s sheet", domainErrors.ErrInvalidWorkbookFile, column, name, fluidSheet)
+	}
+	if fluid.FluidBaseType, err = resolve(row.FluidBaseType, fluid.FluidBaseType, "fluid_base_type"); err != nil {
+		return nil, err
+	}
+	if fluid.BaseFluid, err = resolve(row.BaseFluid, fluid.BaseFluid, "base_fluid"); err != nil {
+		return nil, err
+	}
+
+	return []*caseWorkbookStep{{sheets: []string{fluidSheet}, apply: func(ctx context.Context) error {
+		if existing == nil {
+			return s.fluidsRepo.CreateFluid(ctx, current.ID, fluid)
+		}
+		_, err := s.fluidsRepo.UpdateFluid(ctx, fluid)
+		return err
+	}}}, nil
+}
+
+// planProfiles replaces the pore pressure and fracture gradient profiles, the rows get new ids.
+func (s *caseWorkbooksService) planProfiles(ctx context.Context, f *excelize.File, system *units.System, current *entities.Case) ([]*caseWorkbookStep, error) {
+	var steps []*caseWorkbookStep
+
+	var porePressures []*entities.PorePressure
+	if ok, err := readSheet(f, porePressureSheet, system, &porePressures, true); err != nil {
+		return nil, err
+	} else if ok {
+		for _, porePressure := range porePressures {
+			porePressure.ID = ""
+		}
+		steps = append(steps, &caseWorkbookStep{sheets: []string{porePressureSheet}, apply: func(ctx context.Context) error {
+			return s.porePressuresRepo.ReplacePorePressures(ctx, current.ID, porePressures)
+		}})
+	}
+
+	var fractureGradients []*entities.FractureGradient
+	if ok, err := readSheet(f, fractureGradientSheet, system, &fractureGradients, true); err != nil {
+		return nil, err
+	} else if ok {
+		for _, fractureGradient := range fractureGradients {
+			fractureGradient.ID = ""
+		}
+		steps = append(steps, &caseWorkbookStep{sheets: []string{fractureGradientSheet}, apply: func(ctx context.Context) error {
+			return s.fractureGradientsRepo.ReplaceFractureGradients(ctx, current.ID, fractureGradients)
+		}})
+	}
+	return steps, nil
+}
+
+func (s *caseWorkbooksService) planRig(ctx context.Context, f *excelize.File, system *units.System, current *entities.Case) ([]*caseWorkbookStep, error) {
+	rig := &entities.Rig{}
+	if ok, err := readSheet(f, rigSheet, system, rig, false); err != nil || !ok {
+		return nil, err
+	}
+
+	var existing *entities.Rig
+	if len(current.Rigs) > 0 {
+		existing = current.Rigs[0]
+	}
+	if existing == nil && isZero(rig) {
+		return nil, nil
+	}
+	if existing != nil {
+		if err := checkFieldsID(rigSheet, rig.ID, existing.ID); err != nil {
+			return nil, err
+		}
+		rig.ID = existing.ID
+	} else if rig.ID != "" {
+		return nil, fmt.Errorf("%w: the %s sheet", domainErrors.ErrUnknownWorkbookRow, rigSheet)
+	}
+
+	return []*caseWorkbookStep{{sheets: []string{rigSheet}, apply: func(ctx context.Context) error {
+		if existing == nil {
+			return s.rigsRepo.CreateRig(ctx, current.ID, rig)
+		}
+		_, err := s.rigsRepo.UpdateRig(ctx, rig)
+		return err
+	}}}, nil
+}
+
+// isZero reports whether a fields sheet was left empty.
+func isZero(value interface{}) bool {
+	return reflect.ValueOf(value).Elem().IsZero()
+}
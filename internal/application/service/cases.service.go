@@ -21,7 +21,7 @@ func NewCasesService(repo repository.CasesRepository, commonRepo repository.Comm
 }
 
 func (s *casesService) GetCases(ctx context.Context, input *requests.GetCasesRequest) ([]*entities.Case, error) {
-	if err := s.commonRepo.CheckIfTrajectoryExists(ctx, input.TrajectoryID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.TrajectoryID); err != nil {
 		return nil, err
 	}
 
@@ -29,6 +29,10 @@ func (s *casesService) GetCases(ctx context.Context, input *requests.GetCasesReq
 }
 
 func (s *casesService) GetCaseByID(ctx context.Context, input *requests.GetCaseByIDRequest) (*entities.Case, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.ID); err != nil {
+		return nil, err
+	}
+
 	_, err := s.commonRepo.CheckCaseCompleteness(ctx, input.ID)
 	if err != nil {
 		return nil, err
@@ -37,7 +41,10 @@ func (s *casesService) GetCaseByID(ctx context.Context, input *requests.GetCaseB
 }
 
 func (s *casesService) CreateCase(ctx context.Context, input *requests.CreateCaseRequest) error {
-	if err := s.commonRepo.CheckIfTrajectoryExists(ctx, input.TrajectoryID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.TrajectoryID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeTrajectory, input.TrajectoryID); err != nil {
 		return err
 	}
 
@@ -52,6 +59,13 @@ func (s *casesService) CreateCase(ctx context.Context, input *requests.CreateCas
 }
 
 func (s *casesService) UpdateCase(ctx context.Context, input *requests.UpdateCaseRequest) (*entities.Case, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.ID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.ID); err != nil {
+		return nil, err
+	}
+
 	caseEntity := &entities.Case{
 		ID:              input.ID,
 		CaseName:        input.Body.CaseName,
@@ -64,5 +78,12 @@ func (s *casesService) UpdateCase(ctx context.Context, input *requests.UpdateCas
 }
 
 func (s *casesService) DeleteCase(ctx context.Context, input *requests.DeleteCaseRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.ID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteCase(ctx, input.ID)
-}
\ No newline at end of file
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/chart"
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+)
+
+// Charts of a case
+const (
+	ChartEffectiveTension = "effective-tension"
+	ChartWeightOnBit      = "weight-on-bit"
+	ChartSurfaceTorque    = "surface-torque"
+	ChartMinWeightOnBit   = "min-weight-on-bit"
+	ChartMudWeightWindow  = "mud-weight-window"
+)
+
+// Charts of a trajectory
+const (
+	ChartPlanView        = "plan-view"
+	ChartVerticalSection = "vertical-section"
+)
+
+// Fixed colors of the mud weight window
+var (
+	porePressureColor = chart.Color{R: 31, G: 119, B: 180}
+	fractureColor     = chart.Color{R: 214, G: 39, B: 40}
+	mudWeightColor    = chart.Color{R: 44, G: 160, B: 44}
+)
+
+type chartsService struct {
+	casesRepo        repository.CasesRepository
+	trajectoriesRepo repository.TrajectoriesRepository
+	commonRepo       repository.CommonRepository
+	torqueAndDrag    TorqueAndDrag
+}
+
+func NewChartsService(casesRepo repository.CasesRepository, trajectoriesRepo repository.TrajectoriesRepository, commonRepo repository.CommonRepository, torqueAndDrag TorqueAndDrag) *chartsService {
+	return &chartsService{
+		casesRepo:        casesRepo,
+		trajectoriesRepo: trajectoriesRepo,
+		commonRepo:       commonRepo,
+		torqueAndDrag:    torqueAndDrag,
+	}
+}
+
+// GetCaseChart builds a torque and drag chart or the mud weight window of the case in the unit system of the request.
+func (s *chartsService) GetCaseChart(ctx context.Context, input *requests.GetCaseChartRequest) (*chart.Chart, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return nil, err
+	}
+
+	switch input.Chart {
+	case ChartEffectiveTension:
+		tension, err := s.torqueAndDrag.CalculateEffectiveTensionFromMLModel(ctx, input.OrganizationID, input.CaseID)
+		if err != nil {
+			return nil, err
+		}
+		tension = input.Units.FromCanonical(tension).(*responses.EffectiveTensionFromMLModelResponse)
+		return effectiveTensionChart(tension, input.Units), nil
+	case ChartWeightOnBit:
+		weight, err := s.torqueAndDrag.CalculateWeightOnBitFromMlModel(ctx, input.OrganizationID, input.CaseID)
+		if err != nil {
+			return nil, err
+		}
+		weight = input.Units.FromCanonical(weight).(*responses.WeightOnBitFromMLModelResponse)
+		return weightOnBitChart(weight, input.Units), nil
+	case ChartSurfaceTorque:
+		torque, err := s.torqueAndDrag.CalculateSurfaceTorqueFromMlModel(ctx, input.OrganizationID, input.CaseID)
+		if err != nil {
+			return nil, err
+		}
+		torque = input.Units.FromCanonical(torque).(*responses.MomentFromMLModelResponse)
+		return surfaceTorqueChart(torque, input.Units), nil
+	case ChartMinWeightOnBit:
+		minWeight, err := s.torqueAndDrag.CalculateMinWeightFromMLModel(ctx, input.OrganizationID, input.CaseID)
+		if err != nil {
+			return nil, err
+		}
+		minWeight = input.Units.FromCanonical(minWeight).(*responses.MinWeightFromMLModelResponse)
+		return minWeightOnBitChart(minWeight, input.Units), nil
+	case ChartMudWeightWindow:
+		caseEntity, err := s.casesRepo.GetCaseWithComponents(ctx, input.CaseID)
+		if err != nil {
+			return nil, err
+		}
+		caseEntity = input.Units.FromCanonical(caseEntity).(*entities.Case)
+		return mudWeightWindowChart(caseEntity, input.Units), nil
+	}
+	return nil, domainErrors.ErrUnknownChart
+}
+
+// GetTrajectoryChart builds the plan view or the vertical section of the trajectory in the unit system of the request.
+func (s *chartsService) GetTrajectoryChart(ctx context.Context, input *requests.GetTrajectoryChartRequest) (*chart.Chart, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.TrajectoryID); err != nil {
+		return nil, err
+	}
+	if input.Chart != ChartPlanView && input.Chart != ChartVerticalSection {
+		return nil, domainErrors.ErrUnknownChart
+	}
+
+	trajectory, err := s.trajectoriesRepo.GetTrajectoryByID(ctx, input.TrajectoryID)
+	if err != nil {
+		return nil, err
+	}
+	trajectory = input.Units.FromCanonical(trajectory).(*entities.Trajectory)
+	if input.Chart == ChartPlanView {
+		return planViewChart(trajectory, input.Units), nil
+	}
+	return verticalSectionChart(trajectory, input.Units), nil
+}
+
+// planViewChart draws the north and east coordinates of the survey stations at the same scale.
+func planViewChart(trajectory *entities.Trajectory, system *units.System) *chart.Chart {
+	north, east := make([]float64, len(trajectory.Units)), make([]float64, len(trajectory.Units))
+	for i, station := range trajectory.Units {
+		north[i], east[i] = station.LocalNCoord, station.LocalECoord
+	}
+	length := system.Symbol(units.Length)
+	return &chart.Chart{
+		Title:      "Plan view",
+		XLabel:     "East, " + length,
+		YLabel:     "North, " + length,
+		Series:     []*chart.Series{{X: east, Y: north}},
+		EqualScale: true,
+	}
+}
+
+func verticalSectionChart(trajectory *entities.Trajectory, system *units.System) *chart.Chart {
+	section, tvd := make([]float64, len(trajectory.Units)), make([]float64, len(trajectory.Units))
+	for i, station := range trajectory.Units {
+		section[i], tvd[i] = station.VerticalSection, station.TVD
+	}
+	length := system.Symbol(units.Length)
+	return &chart.Chart{
+		Title:    "Vertical section",
+		XLabel:   "Vertical section, " + length,
+		YLabel:   "TVD, " + length,
+		Series:   []*chart.Series{{X: section, Y: tvd}},
+		ReverseY: true,
+	}
+}
+
+// mudWeightWindowChart draws the pore pressure and fracture gradients against the density of the fluids.
+// The chart has no series when the case has no gradient with an equivalent mud weight.
+func mudWeightWindowChart(caseEntity *entities.Case, system *units.System) *chart.Chart {
+	c := &chart.Chart{
+		Title:    "Mud weight window",
+		XLabel:   "Equivalent mud weight, " + system.Symbol(units.Density),
+		YLabel:   "TVD, " + system.Symbol(units.Length),
+		ReverseY: true,
+	}
+
+	porePressure := &chart.Series{Name: "Pore pressure", Color: &porePressureColor}
+	for _, row := range caseEntity.PorePressures {
+		porePressure.X = append(porePressure.X, row.EMW)
+		porePressure.Y = append(porePressure.Y, row.TVD)
+	}
+	fractureGradients := make([]*entities.FractureGradient, 0, len(caseEntity.FractureGradients))
+	for _, row := range caseEntity.FractureGradients {
+		if row.EMW != nil {
+			fractureGradients = append(fractureGradients, row)
+		}
+	}
+	sort.Slice(fractureGradients, func(i, j int) bool { return fractureGradients[i].WellTVD < fractureGradients[j].WellTVD })
+	fracture := &chart.Series{Name: "Fracture gradient", Color: &fractureColor}
+	for _, row := range fractureGradients {
+		fracture.X = append(fracture.X, *row.EMW)
+		fracture.Y = append(fracture.Y, row.WellTVD)
+	}
+	if len(porePressure.X) == 0 && len(fracture.X) == 0 {
+		return c
+	}
+
+	maxDepth := 0.0
+	for _, depths := range [][]float64{porePressure.Y, fracture.Y} {
+		for _, depth := range depths {
+			maxDepth = math.Max(maxDepth, depth)
+		}
+	}
+	c.Series = []*chart.Series{porePressure, fracture}
+	for _, fluid := range caseEntity.Fluids {
+		c.Series = append(c.Series, &chart.Series{
+			Name:   "Mud weight " + fluid.Name,
+			X:      []float64{fluid.Density, fluid.Density},
+			Y:      []float64{0, maxDepth},
+			Color:  &mudWeightColor,
+			Dashed: true,
+		})
+	}
+	return c
+}
+
+func effectiveTensionChart(tension *responses.EffectiveTensionFromMLModelResponse, system *units.System) *chart.Chart {
+	return &chart.Chart{
+		Title:  "Effective tension",
+		XLabel: "Tension, " + system.Symbol(units.Force),
+		YLabel: "MD, " + system.Symbol(units.Length),
+		Series: []*chart.Series{
+			{Name: "Run in", X: tension.RunIn, Y: tension.Depth},
+			{Name: "Pull up", X: tension.PullUp, Y: tension.Depth},
+			{Name: "Rotary drilling", X: tension.RotaryDrilling, Y: tension.Depth},
+			{Name: "Slide drilling", X: tension.DrillingGZD, Y: tension.Depth},
+			limitSeries("Tension limit", tension.TensionLimit, tension.Depth),
+			limitSeries("Sinusoidal buckling", tension.SinusoidalBucklingAllOperations, tension.Depth),
+			limitSeries("Helical buckling", tension.HelicalBucklingWithoutRotation, tension.Depth),
+		},
+		ReverseY: true,
+	}
+}
+
+func weightOnBitChart(weight *responses.WeightOnBitFromMLModelResponse, system *units.System) *chart.Chart {
+	return &chart.Chart{
+		Title:  "Weight on bit",
+		XLabel: "Weight on bit, " + system.Symbol(units.Force),
+		YLabel: "MD, " + system.Symbol(units.Length),
+		Series: []*chart.Series{
+			{Name: "Run in", X: weight.RunIn, Y: weight.Depth},
+			{Name: "Pull up", X: weight.PullUp, Y: weight.Depth},
+			{Name: "Rotary drilling", X: weight.RotaryDrilling, Y: weight.Depth},
+			{Name: "Slide drilling", X: weight.DrillingGZD, Y: weight.Depth},
+			limitSeries("Helical buckling (run in)", weight.MinWeightForHelicalBucklingRun, weight.Depth),
+			limitSeries("Yield limit (pull up)", weight.MaxWeightBeforeYieldLimitPullUp, weight.Depth),
+		},
+		ReverseY: true,
+	}
+}
+
+func surfaceTorqueChart(torque *responses.MomentFromMLModelResponse, system *units.System) *chart.Chart {
+	return &chart.Chart{
+		Title:  "Surface torque",
+		XLabel: "Torque, " + system.Symbol(units.Torque),
+		YLabel: "MD, " + system.Symbol(units.Length),
+		Series: []*chart.Series{
+			{Name: "Run in", X: torque.RunIn, Y: torque.Depth},
+			{Name: "Pull up", X: torque.PullUp, Y: torque.Depth},
+			{Name: "Rotary drilling", X: torque.RotaryDrilling, Y: torque.Depth},
+			limitSeries("Make-up torque", torque.MakeUpTorque, torque.Depth),
+		},
+		ReverseY: true,
+	}
+}
+
+func minWeightOnBitChart(minWeight *responses.MinWeightFromMLModelResponse, system *units.System) *chart.Chart {
+	return &chart.Chart{
+		Title:  "Minimum weight on bit",
+		XLabel: "Weight on bit, " + system.Symbol(units.Force),
+		YLabel: "MD, " + system.Symbol(units.Length),
+		Series: []*chart.Series{
+			{Name: "Sinusoidal buckling (rotary)", X: minWeight.MinWeightOnBitForSinusoidalBucklingRotaryDrilling, Y: minWeight.Depth},
+			{Name: "Helical buckling (rotary)", X: minWeight.MinWeightOnBitForHelicalBucklingRotaryDrilling, Y: minWeight.Depth},
+			{Name: "Sinusoidal buckling (slide)", X: minWeight.MinWeightOnBitForSinusoidalBucklingGZDDrilling, Y: minWeight.Depth},
+			{Name: "Helical buckling (slide)", X: minWeight.MinWeightOnBitForHelicalBucklingGZDDrilling, Y: minWeight.Depth},
+		},
+		ReverseY: true,
+	}
+}
+
+// limitSeries is a dashed series of a limit the loads are compared against.
+func limitSeries(name string, x []float64, depth []float64) *chart.Series {
+	return &chart.Series{Name: name, X: x, Y: depth, Dashed: true}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/pkg/geodesy"
+)
+
+type coordinatesService struct{}
+
+func NewCoordinatesService() *coordinatesService {
+	return &coordinatesService{}
+}
+
+func (s *coordinatesService) GetSupportedCRS(ctx context.Context) []*responses.CRSResponse {
+	supported := geodesy.Supported()
+	res := make([]*responses.CRSResponse, len(supported))
+	for i, crs := range supported {
+		res[i] = &responses.CRSResponse{
+			EPSGCode:  crs.Code,
+			Name:      crs.Name,
+			Datum:     crs.Datum.Name,
+			Projected: crs.IsProjected(),
+		}
+	}
+	return res
+}
+
+// TransformCoordinates converts points between coordinate reference systems including the datum shift.
+func (s *coordinatesService) TransformCoordinates(ctx context.Context, input *requests.TransformCoordinatesRequest) (*responses.TransformCoordinatesResponse, error) {
+	source, err := geodesy.Lookup(input.Body.SourceEPSGCode)
+	if err != nil {
+		return nil, err
+	}
+	target, err := geodesy.Lookup(input.Body.TargetEPSGCode)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &responses.TransformCoordinatesResponse{
+		SourceEPSGCode: source.Code,
+		TargetEPSGCode: target.Code,
+		Points:         make([]responses.CoordinatePointResponse, len(input.Body.Points)),
+	}
+
+	for i, point := range input.Body.Points {
+		x, y, err := geodesy.Transform(source, target, point.X, point.Y)
+		if err != nil {
+			return nil, err
+		}
+		response.Points[i] = responses.CoordinatePointResponse{X: x, Y: y}
+
+		if target.IsProjected() {
+			lat, lon, err := target.ToGeographic(x, y)
+			if err != nil {
+				return nil, err
+			}
+			grid, err := target.ToGrid(lat, lon)
+			if err != nil {
+				return nil, err
+			}
+			response.Points[i].GridConvergence = grid.GridConvergence
+			response.Points[i].ScaleFactor = grid.ScaleFactor
+		}
+	}
+
+	return response, nil
+}
+
+// surfaceLocation completes a surface location in the projected system epsgCode. Grid coordinates
+// take precedence, otherwise they are projected from latitude and longitude. It returns nil when no
+// location or CRS is set.
+func surfaceLocation(epsgCode int, latitude, longitude, easting, northing float64) (*geodesy.GridPoint, float64, float64, error) {
+	if epsgCode == 0 {
+		return nil, latitude, longitude, nil
+	}
+
+	crs, err := geodesy.Lookup(epsgCode)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if !crs.IsProjected() {
+		return nil, 0, 0, fmt.Errorf("EPSG:%d is not a projected coordinate reference system", epsgCode)
+	}
+
+	if easting != 0 || northing != 0 {
+		if latitude, longitude, err = crs.ToGeographic(easting, northing); err != nil {
+			return nil, 0, 0, err
+		}
+	} else if latitude == 0 && longitude == 0 {
+		return nil, latitude, longitude, nil
+	}
+
+	grid, err := crs.ToGrid(latitude, longitude)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return grid, latitude, longitude, nil
+}
+
+// applyGlobalCoordinates derives grid coordinates of the trajectory units from their local offsets
+// when the well has a georeferenced surface location. Local offsets are referenced to true north.
+func applyGlobalCoordinates(well *entities.Well, units []*entities.TrajectoryUnit) {
+	if well == nil || well.EPSGCode == 0 || (well.Easting == 0 && well.Northing == 0) {
+		return
+	}
+
+	origin := &geodesy.GridPoint{
+		Easting:         well.Easting,
+		Northing:        well.Northing,
+		GridConvergence: well.GridConvergence,
+		ScaleFactor:     well.ScaleFactor,
+	}
+	for _, unit := range units {
+		unit.GlobalNCoord, unit.GlobalECoord = geodesy.LocalToGrid(origin, unit.LocalNCoord, unit.LocalECoord)
+	}
+}
@@ -3,26 +3,36 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/notify"
+	"github.com/munaiplan/munaiplan-backend/pkg/jsondiff"
+	"github.com/sirupsen/logrus"
 )
 
 type designsService struct {
 	commonRepo repository.CommonRepository
 	repo       repository.DesignsRepository
+	roles      Roles
+	notifier   notify.Notifier
 }
 
-func NewDesignsService(repo repository.DesignsRepository, commonRepo repository.CommonRepository) *designsService {
+func NewDesignsService(repo repository.DesignsRepository, commonRepo repository.CommonRepository, roles Roles, notifier notify.Notifier) *designsService {
 	return &designsService{
 		repo:       repo,
 		commonRepo: commonRepo,
+		roles:      roles,
+		notifier:   notifier,
 	}
 }
 
 func (s *designsService) GetDesigns(ctx context.Context, input *requests.GetDesignsRequest) ([]*entities.Design, error) {
-	if err := s.commonRepo.CheckIfWellboreExists(ctx, input.WellboreID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.WellboreID); err != nil {
 		return nil, err
 	}
 
@@ -32,18 +42,23 @@ func (s *designsService) GetDesigns(ctx context.Context, input *requests.GetDesi
 }
 
 func (s *designsService) GetDesignByID(ctx context.Context, input *requests.GetDesignByIDRequest) (*entities.Design, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetDesignByID(ctx, input.ID)
 }
 
 func (s *designsService) CreateDesign(ctx context.Context, input *requests.CreateDesignRequest) error {
-	if err := s.commonRepo.CheckIfWellboreExists(ctx, input.WellboreID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.WellboreID); err != nil {
 		return err
 	}
 
 	design := &entities.Design{
 		PlanName:   input.Body.PlanName,
-		Stage:      input.Body.Stage,
-		Version:    input.Body.Version,
+		Stage:      entities.DesignStagePrototype,
+		Version:    entities.DesignVersion(1),
+		Revision:   1,
 		ActualDate: input.Body.ActualDate,
 	}
 
@@ -51,11 +66,16 @@ func (s *designsService) CreateDesign(ctx context.Context, input *requests.Creat
 }
 
 func (s *designsService) UpdateDesign(ctx context.Context, input *requests.UpdateDesignRequest) (*entities.Design, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.ID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeDesign, input.ID); err != nil {
+		return nil, err
+	}
+
 	design := &entities.Design{
 		ID:         input.ID,
 		PlanName:   input.Body.PlanName,
-		Stage:      input.Body.Stage,
-		Version:    input.Body.Version,
 		ActualDate: input.Body.ActualDate,
 	}
 
@@ -63,5 +83,149 @@ func (s *designsService) UpdateDesign(ctx context.Context, input *requests.Updat
 }
 
 func (s *designsService) DeleteDesign(ctx context.Context, input *requests.DeleteDesignRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.ID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeDesign, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteDesign(ctx, input.ID)
 }
+
+// FreezeDesign snapshots the design tree as an immutable revision and bumps the revision of the design.
+func (s *designsService) FreezeDesign(ctx context.Context, input *requests.FreezeDesignRequest) (*entities.DesignRevision, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.ID); err != nil {
+		return nil, err
+	}
+
+	revision := &entities.DesignRevision{
+		DesignID: input.ID,
+		Comment:  input.Body.Comment,
+	}
+	if input.UserID != "" {
+		revision.CreatedBy = &input.UserID
+	}
+	if err := s.repo.FreezeDesign(ctx, revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+func (s *designsService) GetDesignRevisions(ctx context.Context, input *requests.GetDesignRevisionsRequest) ([]*entities.DesignRevision, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.ID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDesignRevisions(ctx, input.ID)
+}
+
+func (s *designsService) GetDesignRevision(ctx context.Context, input *requests.GetDesignRevisionRequest) (*entities.DesignRevision, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.ID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDesignRevision(ctx, input.ID, input.Revision)
+}
+
+// DiffDesignRevisions compares the snapshots of two revisions field by field. Trajectories, cases and their
+// components are matched by id, the revision number itself is not reported as a change.
+func (s *designsService) DiffDesignRevisions(ctx context.Context, input *requests.DiffDesignRevisionsRequest) (*responses.DesignRevisionsDiffResponse, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.ID); err != nil {
+		return nil, err
+	}
+
+	from, err := s.repo.GetDesignRevision(ctx, input.ID, input.Query.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.repo.GetDesignRevision(ctx, input.ID, input.Query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := jsondiff.Diff(from.Snapshot, to.Snapshot, "revision")
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses.DesignRevisionsDiffResponse{
+		DesignID: input.ID,
+		From:     from.Revision,
+		To:       to.Revision,
+		Changes:  changes,
+	}, nil
+}
+
+// TransitionDesignStage moves the design to another stage. Each transition needs the permission listed for it
+// in the domain, approving freezes the design as a revision so that the approved program is kept as it was.
+// Notifications are sent after the transition is saved, a failed notification does not undo it.
+func (s *designsService) TransitionDesignStage(ctx context.Context, input *requests.TransitionDesignStageRequest) (*entities.DesignStageTransition, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.ID); err != nil {
+		return nil, err
+	}
+	if !entities.IsDesignStage(input.Body.Stage) {
+		return nil, domainErrors.ErrInvalidDesignStage
+	}
+
+	design, err := s.repo.GetDesignByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	permission, ok := entities.DesignStageTransitionPermission(design.Stage, input.Body.Stage)
+	if !ok {
+		return nil, domainErrors.ErrInvalidStageTransition
+	}
+	err = s.roles.Authorize(ctx, &requests.AuthorizeRequest{
+		UserID:          input.UserID,
+		OrganizationID:  input.OrganizationID,
+		Scope:           entities.ScopeDesign,
+		ID:              input.ID,
+		Permission:      permission,
+		ApiKeyScope:     input.ApiKeyScope,
+		ApiKeyCompanyID: input.ApiKeyCompanyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transition := &entities.DesignStageTransition{
+		DesignID:  input.ID,
+		FromStage: design.Stage,
+		ToStage:   input.Body.Stage,
+		Comment:   input.Body.Comment,
+		UserID:    &input.UserID,
+	}
+	if err := s.repo.TransitionDesignStage(ctx, transition, input.Body.Stage == entities.DesignStageApproved); err != nil {
+		return nil, err
+	}
+
+	notification := notify.Notification{
+		Event:          "design.stage_changed",
+		OrganizationID: input.OrganizationID,
+		Subject:        fmt.Sprintf("Design %s is %s", design.PlanName, transition.ToStage),
+		Message:        fmt.Sprintf("Design %s moved from %s to %s. %s", design.PlanName, transition.FromStage, transition.ToStage, transition.Comment),
+		Data: map[string]interface{}{
+			"design_id":  transition.DesignID,
+			"from_stage": transition.FromStage,
+			"to_stage":   transition.ToStage,
+			"comment":    transition.Comment,
+			"user_id":    input.UserID,
+			"revision":   transition.Revision,
+		},
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		logrus.Errorf("failed to notify about the stage of design %s: %v", input.ID, err)
+	}
+
+	return transition, nil
+}
+
+func (s *designsService) GetDesignStageTransitions(ctx context.Context, input *requests.GetDesignStageTransitionsRequest) ([]*entities.DesignStageTransition, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.ID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDesignStageTransitions(ctx, input.ID)
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/drillingcsv"
+)
+
+const (
+	defaultDrillingReadingsLimit = 1000
+	// maxCalibrationReadings limits the readings a calibration loads, narrow the time range for longer records
+	maxCalibrationReadings = 500000
+	// defaultCalibrationIntervalLength is the length of the bit depth intervals in meters
+	defaultCalibrationIntervalLength = 150.0
+	// minCalibrationReadings is the number of readings an operation needs in an interval to be fitted
+	minCalibrationReadings = 5
+	// rotatingRPM is the surface speed above which the string counts as rotating
+	rotatingRPM = 5.0
+	// minBitMovement is the bit depth change between readings in meters below which the string counts as standing
+	minBitMovement = 0.05
+)
+
+// defaultCalibrationFrictionFactors are fitted when the request has no range.
+var defaultCalibrationFrictionFactors = requests.FrictionFactorRange{From: 0.05, To: 0.6, Step: 0.05}
+
+// Operations the readings are classified into for the calibration
+const (
+	operationTripIn = iota
+	operationTripOut
+	operationRotating
+)
+
+type drillingDataService struct {
+	repo          repository.DrillingDataRepository
+	commonRepo    repository.CommonRepository
+	torqueAndDrag TorqueAndDrag
+}
+
+func NewDrillingDataService(repo repository.DrillingDataRepository, commonRepo repository.CommonRepository, torqueAndDrag TorqueAndDrag) *drillingDataService {
+	return &drillingDataService{
+		repo:          repo,
+		commonRepo:    commonRepo,
+		torqueAndDrag: torqueAndDrag,
+	}
+}
+
+// ImportDrillingData reads the CSV file, converts its values to canonical units and saves the readings.
+// Rows that cannot be read are skipped and reported.
+func (s *drillingDataService) ImportDrillingData(ctx context.Context, input *requests.ImportDrillingDataRequest) (*responses.DrillingDataImportResponse, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.WellboreID); err != nil {
+		return nil, err
+	}
+
+	records, report, err := drillingcsv.Read(input.File)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidDrillingDataFile, err)
+	}
+
+	readings := make([]*entities.DrillingReading, 0, len(records))
+	for _, record := range records {
+		readings = append(readings, &entities.DrillingReading{
+			WellboreID:        input.WellboreID,
+			Time:              record.Time,
+			BitDepth:          record.BitDepth,
+			HookLoad:          record.HookLoad,
+			SurfaceTorque:     record.SurfaceTorque,
+			WeightOnBit:       record.WeightOnBit,
+			RPM:               record.RPM,
+			FlowRate:          record.FlowRate,
+			StandpipePressure: record.StandpipePressure,
+		})
+	}
+	input.Units.ToCanonical(&readings)
+
+	if len(readings) > 0 {
+		if err := s.repo.SaveDrillingReadings(ctx, readings); err != nil {
+			return nil, err
+		}
+	}
+
+	return &responses.DrillingDataImportResponse{
+		Imported: len(readings),
+		Skipped:  report.Skipped,
+		Errors:   report.Errors,
+	}, nil
+}
+
+// GetDrillingReadings retrieves the readings of the wellbore, oldest first.
+func (s *drillingDataService) GetDrillingReadings(ctx context.Context, input *requests.GetDrillingReadingsRequest) ([]*entities.DrillingReading, error) {
+	query := input.Query
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, domainErrors.ErrInvalidTimeRange
+	}
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.WellboreID); err != nil {
+		return nil, err
+	}
+
+	filter := &entities.DrillingReadingFilter{
+		From:   query.From,
+		To:     query.To,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultDrillingReadingsLimit
+	}
+	return s.repo.GetDrillingReadings(ctx, input.WellboreID, filter)
+}
+
+// DeleteDrillingReadings deletes the readings of the wellbore in the time range.
+func (s *drillingDataService) DeleteDrillingReadings(ctx context.Context, input *requests.DeleteDrillingReadingsRequest) (*responses.DeleteDrillingReadingsResponse, error) {
+	query := input.Query
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, domainErrors.ErrInvalidTimeRange
+	}
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.WellboreID); err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.repo.DeleteDrillingReadings(ctx, input.WellboreID, &entities.DrillingReadingFilter{From: query.From, To: query.To})
+	if err != nil {
+		return nil, err
+	}
+	return &responses.DeleteDrillingReadingsResponse{Deleted: deleted}, nil
+}
+
+// CalibrateFrictionFactors back-calculates apparent friction factors from the actual readings. The readings are
+// classified into trip in, trip out and rotating by the bit movement and the surface speed and grouped into bit
+// depth intervals. For every interval and operation the friction factor whose torque and drag result, with the
+// same factor along the whole string, has the smallest root mean square error against the readings is returned.
+// Trip in and trip out are fitted on the hook load, rotating on the surface torque against the rotary drilling model.
+func (s *drillingDataService) CalibrateFrictionFactors(ctx context.Context, input *requests.CalibrateFrictionFactorsRequest) (*responses.FrictionCalibrationResponse, error) {
+	body := input.Body
+	if body.From != nil && body.To != nil && !body.From.Before(*body.To) {
+		return nil, domainErrors.ErrInvalidTimeRange
+	}
+	factorsRange := defaultCalibrationFrictionFactors
+	if body.FrictionFactors != nil {
+		factorsRange = *body.FrictionFactors
+	}
+	if frictionFactorCount(factorsRange) > maxFrictionSensitivityRuns {
+		return nil, domainErrors.ErrTooManySensitivityRuns
+	}
+	factors := frictionFactors(factorsRange)
+	intervalLength := body.IntervalLength
+	if intervalLength == 0 {
+		intervalLength = defaultCalibrationIntervalLength
+	}
+
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.WellboreID); err != nil {
+		return nil, err
+	}
+	wellboreID, err := s.commonRepo.GetWellboreIDByCaseID(ctx, body.CaseID)
+	if err != nil {
+		return nil, err
+	}
+	if wellboreID != input.WellboreID {
+		return nil, domainErrors.ErrCaseNotInWellbore
+	}
+
+	readings, err := s.repo.GetDrillingReadings(ctx, input.WellboreID, &entities.DrillingReadingFilter{
+		From:  body.From,
+		To:    body.To,
+		Limit: maxCalibrationReadings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	intervals := groupCalibrationReadings(readings, intervalLength)
+	if len(intervals) == 0 {
+		return nil, domainErrors.ErrNotEnoughDrillingData
+	}
+
+	curves, err := s.torqueAndDrag.CalculateFrictionCurves(ctx, input.OrganizationID, body.CaseID, factors)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &responses.FrictionCalibrationResponse{
+		WellboreID:     input.WellboreID,
+		CaseID:         body.CaseID,
+		IntervalLength: intervalLength,
+	}
+	for _, interval := range intervals {
+		result := &responses.FrictionCalibrationIntervalResponse{
+			Top:    interval.top,
+			Bottom: interval.top + intervalLength,
+		}
+		if fit := fitFrictionFactor(curves, &interval.points[operationTripIn], func(curve *responses.FrictionSensitivityCurveResponse) []float64 { return curve.HookLoadTripIn }); fit != nil {
+			result.TripIn = &responses.HookLoadFrictionFitResponse{FrictionFactor: fit.factor, RMSError: fit.rmsError, Readings: fit.readings}
+		}
+		if fit := fitFrictionFactor(curves, &interval.points[operationTripOut], func(curve *responses.FrictionSensitivityCurveResponse) []float64 { return curve.HookLoadTripOut }); fit != nil {
+			result.TripOut = &responses.HookLoadFrictionFitResponse{FrictionFactor: fit.factor, RMSError: fit.rmsError, Readings: fit.readings}
+		}
+		if fit := fitFrictionFactor(curves, &interval.points[operationRotating], func(curve *responses.FrictionSensitivityCurveResponse) []float64 { return curve.TorqueRotating }); fit != nil {
+			result.Rotating = &responses.TorqueFrictionFitResponse{FrictionFactor: fit.factor, RMSError: fit.rmsError, Readings: fit.readings}
+		}
+		if result.TripIn != nil || result.TripOut != nil || result.Rotating != nil {
+			res.Intervals = append(res.Intervals, result)
+		}
+	}
+	if len(res.Intervals) == 0 {
+		return nil, domainErrors.ErrNotEnoughDrillingData
+	}
+
+	return res, nil
+}
+
+// calibrationPoints are the bit depths and the measured values of one operation.
+type calibrationPoints struct {
+	depths []float64
+	values []float64
+}
+
+type calibrationInterval struct {
+	top    float64
+	points [3]calibrationPoints
+}
+
+type frictionFit struct {
+	factor   float64
+	rmsError float64
+	readings int
+}
+
+// groupCalibrationReadings classifies the readings, which must be ordered by time, by the bit movement since
+// the previous reading and groups them into bit depth intervals, shallowest first.
+func groupCalibrationReadings(readings []*entities.DrillingReading, intervalLength float64) []*calibrationInterval {
+	byIndex := make(map[int]*calibrationInterval)
+	for i := 1; i < len(readings); i++ {
+		reading := readings[i]
+		movement := reading.BitDepth - readings[i-1].BitDepth
+
+		var operation int
+		var value *float64
+		switch {
+		case reading.RPM != nil && *reading.RPM > rotatingRPM:
+			operation, value = operationRotating, reading.SurfaceTorque
+		case movement > minBitMovement:
+			operation, value = operationTripIn, reading.HookLoad
+		case movement < -minBitMovement:
+			operation, value = operationTripOut, reading.HookLoad
+		default:
+			continue
+		}
+		if value == nil {
+			continue
+		}
+
+		index := int(math.Floor(reading.BitDepth / intervalLength))
+		interval, ok := byIndex[index]
+		if !ok {
+			interval = &calibrationInterval{top: float64(index) * intervalLength}
+			byIndex[index] = interval
+		}
+		points := &interval.points[operation]
+		points.depths = append(points.depths, reading.BitDepth)
+		points.values = append(points.values, *value)
+	}
+
+	res := make([]*calibrationInterval, 0, len(byIndex))
+	for _, interval := range byIndex {
+		res = append(res, interval)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].top < res[j].top })
+	return res
+}
+
+// fitFrictionFactor returns the curve whose values, interpolated at the depths of the points, have the smallest
+// root mean square error. Points deeper than the model are left out, nil means not enough points.
+func fitFrictionFactor(curves *responses.FrictionSensitivityResponse, points *calibrationPoints, values func(*responses.FrictionSensitivityCurveResponse) []float64) *frictionFit {
+	if len(points.depths) < minCalibrationReadings {
+		return nil
+	}
+
+	var res *frictionFit
+	for _, curve := range curves.Curves {
+		modelled := resample(curves.Depth, values(curve), points.depths)
+
+		var sum float64
+		var count int
+		for i, value := range modelled {
+			if value != nil {
+				sum += (*value - points.values[i]) * (*value - points.values[i])
+				count++
+			}
+		}
+		if count < minCalibrationReadings {
+			continue
+		}
+
+		rmsError := math.Sqrt(sum / float64(count))
+		if res == nil || rmsError < res.rmsError {
+			res = &frictionFit{factor: curve.OpenHoleFrictionFactor, rmsError: rmsError, readings: count}
+		}
+	}
+	return res
+}
@@ -21,7 +21,7 @@ func NewFieldsService(repo repository.FieldsRepository, commonRepo repository.Co
 }
 
 func (s *fieldsService) GetFields(ctx context.Context, input *requests.GetFieldsRequest) ([]*entities.Field, error) {
-	if err := s.commonRepo.CheckIfCompanyExists(ctx, input.CompanyID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCompany, input.CompanyID); err != nil {
 		return nil, err
 	}
 
@@ -29,11 +29,18 @@ func (s *fieldsService) GetFields(ctx context.Context, input *requests.GetFields
 }
 
 func (s *fieldsService) GetFieldByID(ctx context.Context, input *requests.GetFieldByIDRequest) (*entities.Field, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeField, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetFieldByID(ctx, input.ID)
 }
 
 func (s *fieldsService) CreateField(ctx context.Context, input *requests.CreateFieldRequest) error {
-	if err := s.commonRepo.CheckIfCompanyExists(ctx, input.CompanyID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCompany, input.CompanyID); err != nil {
+		return err
+	}
+	if err := validateUnitSystem(input.Body.ActiveFieldUnit); err != nil {
 		return err
 	}
 
@@ -48,6 +55,14 @@ func (s *fieldsService) CreateField(ctx context.Context, input *requests.CreateF
 }
 
 func (s *fieldsService) UpdateField(ctx context.Context, input *requests.UpdateFieldRequest) (*entities.Field, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeField, input.ID); err != nil {
+		return nil, err
+	}
+
+	if err := validateUnitSystem(input.Body.ActiveFieldUnit); err != nil {
+		return nil, err
+	}
+
 	field := &entities.Field{
 		ID:              input.ID,
 		Name:            input.Body.Name,
@@ -60,5 +75,9 @@ func (s *fieldsService) UpdateField(ctx context.Context, input *requests.UpdateF
 }
 
 func (s *fieldsService) DeleteField(ctx context.Context, input *requests.DeleteFieldRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeField, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteField(ctx, input.ID)
-}
\ No newline at end of file
+}
@@ -24,7 +24,7 @@ func NewFluidsService(repo repository.FluidsRepository, commonRepo repository.Co
 
 // GetFluids retrieves all fluids associated with a specific case.
 func (s *fluidsService) GetFluids(ctx context.Context, input *requests.GetFluidsRequest) ([]*entities.Fluid, error) {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
 		return nil, err
 	}
 
@@ -38,12 +38,19 @@ func (s *fluidsService) GetFluidTypes(ctx context.Context) ([]*entities.FluidTyp
 
 // GetFluidByID retrieves a fluid by its ID.
 func (s *fluidsService) GetFluidByID(ctx context.Context, input *requests.GetFluidByIDRequest) (*entities.Fluid, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeFluid, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetFluidByID(ctx, input.ID)
 }
 
 // CreateFluid creates a new fluid within a specific case.
 func (s *fluidsService) CreateFluid(ctx context.Context, input *requests.CreateFluidRequest) error {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.CaseID); err != nil {
 		return err
 	}
 
@@ -59,6 +66,13 @@ func (s *fluidsService) CreateFluid(ctx context.Context, input *requests.CreateF
 
 // UpdateFluid updates an existing fluid.
 func (s *fluidsService) UpdateFluid(ctx context.Context, input *requests.UpdateFluidRequest) (*entities.Fluid, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeFluid, input.ID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeFluid, input.ID); err != nil {
+		return nil, err
+	}
+
 	fluid := s.UpdateFluidRequestToEntity(&input.Body)
 	fluid.ID = input.ID
 	return s.repo.UpdateFluid(ctx, fluid)
@@ -66,6 +80,13 @@ func (s *fluidsService) UpdateFluid(ctx context.Context, input *requests.UpdateF
 
 // DeleteFluid deletes a fluid by its ID.
 func (s *fluidsService) DeleteFluid(ctx context.Context, input *requests.DeleteFluidRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeFluid, input.ID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeFluid, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteFluid(ctx, input.ID)
 }
 
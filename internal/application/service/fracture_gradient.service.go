@@ -22,7 +22,7 @@ func NewFractureGradientsService(repo repository.FractureGradientsRepository, co
 }
 
 func (s *fractureGradientsService) GetFractureGradients(ctx context.Context, input *requests.GetFractureGradientsRequest) ([]*entities.FractureGradient, error) {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
 		return nil, err
 	}
 
@@ -32,11 +32,18 @@ func (s *fractureGradientsService) GetFractureGradients(ctx context.Context, inp
 }
 
 func (s *fractureGradientsService) GetFractureGradientByID(ctx context.Context, input *requests.GetFractureGradientByIDRequest) (*entities.FractureGradient, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeFractureGradient, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetFractureGradientByID(ctx, input.ID)
 }
 
 func (s *fractureGradientsService) CreateFractureGradient(ctx context.Context, input *requests.CreateFractureGradientRequest) error {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.CaseID); err != nil {
 		return err
 	}
 
@@ -45,23 +52,41 @@ func (s *fractureGradientsService) CreateFractureGradient(ctx context.Context, i
 		TemperatureAtWellTVD: input.Body.TemperatureAtWellTVD,
 		TemperatureGradient:  input.Body.TemperatureGradient,
 		WellTVD:              input.Body.WellTVD,
+		Pressure:             input.Body.Pressure,
+		EMW:                  input.Body.EMW,
 	}
 
 	return s.repo.CreateFractureGradient(ctx, input.CaseID, fractureGradient)
 }
 
 func (s *fractureGradientsService) UpdateFractureGradient(ctx context.Context, input *requests.UpdateFractureGradientRequest) (*entities.FractureGradient, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeFractureGradient, input.ID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeFractureGradient, input.ID); err != nil {
+		return nil, err
+	}
+
 	fractureGradient := &entities.FractureGradient{
 		ID:                   input.ID,
 		TemperatureAtSurface: input.Body.TemperatureAtSurface,
 		TemperatureAtWellTVD: input.Body.TemperatureAtWellTVD,
 		TemperatureGradient:  input.Body.TemperatureGradient,
 		WellTVD:              input.Body.WellTVD,
+		Pressure:             input.Body.Pressure,
+		EMW:                  input.Body.EMW,
 	}
 
 	return s.repo.UpdateFractureGradient(ctx, fractureGradient)
 }
 
 func (s *fractureGradientsService) DeleteFractureGradient(ctx context.Context, input *requests.DeleteFractureGradientRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeFractureGradient, input.ID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeFractureGradient, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteFractureGradient(ctx, input.ID)
 }
@@ -23,7 +23,7 @@ func NewHolesService(repo repository.HolesRepository, commonRepo repository.Comm
 }
 
 func (s *holesService) GetHoles(ctx context.Context, input *requests.GetHolesRequest) ([]*entities.Hole, error) {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
 		return nil, err
 	}
 
@@ -31,11 +31,18 @@ func (s *holesService) GetHoles(ctx context.Context, input *requests.GetHolesReq
 }
 
 func (s *holesService) GetHoleByID(ctx context.Context, input *requests.GetHoleByIDRequest) (*entities.Hole, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeHole, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetHoleByID(ctx, input.ID)
 }
 
 func (s *holesService) CreateHole(ctx context.Context, input *requests.CreateHoleRequest) error {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.CaseID); err != nil {
 		return err
 	}
 
@@ -52,12 +59,26 @@ func (s *holesService) CreateHole(ctx context.Context, input *requests.CreateHol
 }
 
 func (s *holesService) UpdateHole(ctx context.Context, input *requests.UpdateHoleRequest) (*entities.Hole, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeHole, input.ID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeHole, input.ID); err != nil {
+		return nil, err
+	}
+
 	hole := s.UpdateHoleRequestToEntity(&input.Body)
 	hole.ID = input.ID
 	return s.repo.UpdateHole(ctx, hole)
 }
 
 func (s *holesService) DeleteHole(ctx context.Context, input *requests.DeleteHoleRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeHole, input.ID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeHole, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteHole(ctx, input.ID)
 }
 
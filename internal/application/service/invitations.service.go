@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/email"
+)
+
+const (
+	invitationLifetime = 7 * 24 * time.Hour
+)
+
+type invitationsService struct {
+	repo        repository.InvitationsRepository
+	commonRepo  repository.CommonRepository
+	emailSender email.Sender
+	appUrl      string
+}
+
+func NewInvitationsService(repo repository.InvitationsRepository, commonRepo repository.CommonRepository, emailSender email.Sender, appUrl string) *invitationsService {
+	return &invitationsService{
+		repo:        repo,
+		commonRepo:  commonRepo,
+		emailSender: emailSender,
+		appUrl:      appUrl,
+	}
+}
+
+// CreateInvitation invites a person to the organization with a role and emails them a one-time sign up link.
+func (s *invitationsService) CreateInvitation(ctx context.Context, input *requests.CreateInvitationRequest) (*entities.Invitation, error) {
+	if _, ok := rolePermissions[input.Body.Role]; !ok {
+		return nil, domainErrors.ErrInvalidRole
+	}
+	emailAddress := helpers.NormalizeEmail(input.Body.Email)
+	if err := s.commonRepo.CheckIfUserExistsByEmail(ctx, emailAddress); err == nil {
+		return nil, domainErrors.ErrUserAlreadyExists
+	}
+
+	token, tokenHash, err := helpers.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+	invitation := &entities.Invitation{
+		OrganizationID: input.OrganizationID,
+		Email:          emailAddress,
+		Role:           input.Body.Role,
+		InvitedBy:      input.UserID,
+		TokenHash:      tokenHash,
+		ExpiresAt:      time.Now().Add(invitationLifetime),
+	}
+	if err := s.repo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	err = s.emailSender.Send(ctx, email.Message{
+		To:      invitation.Email,
+		Subject: "You are invited to MunaiPlan",
+		Body: fmt.Sprintf(
+			"You have been invited to join MunaiPlan as %s.\n\nSign up here: %s/sign-up?token=%s\n\nThe invitation expires on %s.",
+			invitation.Role, s.appUrl, token, invitation.ExpiresAt.UTC().Format(time.RFC1123),
+		),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (s *invitationsService) GetInvitations(ctx context.Context, input *requests.GetInvitationsRequest) ([]*entities.Invitation, error) {
+	return s.repo.GetInvitations(ctx, input.OrganizationID)
+}
+
+func (s *invitationsService) DeleteInvitation(ctx context.Context, input *requests.DeleteInvitationRequest) error {
+	return s.repo.DeleteInvitation(ctx, input.OrganizationID, input.ID)
+}
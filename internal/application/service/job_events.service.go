@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/sirupsen/logrus"
+)
+
+// Events of a job stream. A stream ends with the succeeded, failed or cancelled event.
+const (
+	JobEventStatus    = "status"
+	JobEventProgress  = "progress"
+	JobEventPartial   = "partial"
+	JobEventResult    = "result"
+	JobEventSucceeded = entities.JobStatusSucceeded
+	JobEventFailed    = entities.JobStatusFailed
+	JobEventCancelled = entities.JobStatusCancelled
+
+	// jobEventFinished tells the streams of a job to load its final state, it is not sent to clients
+	jobEventFinished = "finished"
+)
+
+const (
+	// jobResultBatchDepths is the number of depths of a result event
+	jobResultBatchDepths = 500
+	// jobEventsBuffer is the number of events a slow stream may fall behind before it misses progress events
+	jobEventsBuffer = 64
+)
+
+// jobEvents passes the events of the jobs running on this server to their streams.
+type jobEvents struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan *responses.JobEvent]struct{}
+}
+
+func newJobEvents() *jobEvents {
+	return &jobEvents{subscribers: make(map[string]map[chan *responses.JobEvent]struct{})}
+}
+
+func (e *jobEvents) subscribe(jobID string) chan *responses.JobEvent {
+	ch := make(chan *responses.JobEvent, jobEventsBuffer)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.subscribers[jobID] == nil {
+		e.subscribers[jobID] = make(map[chan *responses.JobEvent]struct{})
+	}
+	e.subscribers[jobID][ch] = struct{}{}
+	return ch
+}
+
+func (e *jobEvents) unsubscribe(jobID string, ch chan *responses.JobEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.subscribers[jobID], ch)
+	if len(e.subscribers[jobID]) == 0 {
+		delete(e.subscribers, jobID)
+	}
+}
+
+// publish sends the event without waiting. A stream with a full buffer misses it, the final state of a job
+// is also found by polling.
+func (e *jobEvents) publish(jobID string, name string, data interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for ch := range e.subscribers[jobID] {
+		select {
+		case ch <- &responses.JobEvent{Name: name, Data: data}:
+		default:
+		}
+	}
+}
+
+type progressCtxKey struct{}
+
+// progressFunc receives the number of finished steps of a calculation and the partial result of the last one.
+type progressFunc func(done int, total int, partial interface{})
+
+// withProgress returns a copy of ctx that reports the progress of calculations to report.
+func withProgress(ctx context.Context, report progressFunc) context.Context {
+	return context.WithValue(ctx, progressCtxKey{}, report)
+}
+
+// reportProgress reports the progress of a calculation, if ctx has a progress function. It may be called
+// from several goroutines.
+func reportProgress(ctx context.Context, done int, total int, partial interface{}) {
+	if report, ok := ctx.Value(progressCtxKey{}).(progressFunc); ok {
+		report(done, total, partial)
+	}
+}
+
+// SubscribeJob streams the events of the job in the unit system of the request: its status, the progress of
+// friction sensitivity sweeps with every finished curve as a partial result, the result in batches of depths
+// once the job has succeeded and the final event. The channel is closed after the final event or when ctx is
+// done. Jobs running on other servers only send status events, found by polling.
+func (s *jobsService) SubscribeJob(ctx context.Context, input *requests.SubscribeJobRequest) (<-chan *responses.JobEvent, error) {
+	// Subscribing first keeps events between reading the job and subscribing from getting lost
+	events := s.events.subscribe(input.ID)
+	job, err := s.getJob(ctx, &input.JobRequester, input.ID)
+	if err != nil {
+		s.events.unsubscribe(input.ID, events)
+		return nil, err
+	}
+
+	out := make(chan *responses.JobEvent)
+	go s.stream(ctx, input, job, events, out)
+	return out, nil
+}
+
+func (s *jobsService) stream(ctx context.Context, input *requests.SubscribeJobRequest, job *entities.Job, events chan *responses.JobEvent, out chan<- *responses.JobEvent) {
+	defer close(out)
+	defer s.events.unsubscribe(job.ID, events)
+
+	send := func(name string, data interface{}) bool {
+		select {
+		case out <- &responses.JobEvent{Name: name, Data: input.Units.FromCanonical(data)}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	if !send(JobEventStatus, job) {
+		return
+	}
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for !job.Finished() {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			if event.Name != jobEventFinished {
+				// The events of the broker are shared by the streams of the job, the conversion copies them
+				if !send(event.Name, event.Data) {
+					return
+				}
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		latest, err := s.repo.GetJobByID(ctx, job.ID)
+		if err != nil {
+			if ctx.Err() == nil {
+				logrus.Errorf("failed to load job %s: %v", job.ID, err)
+			}
+			return
+		}
+		changed := latest.Status != job.Status || latest.Attempts != job.Attempts
+		job = latest
+		if changed && !job.Finished() && !send(JobEventStatus, job) {
+			return
+		}
+	}
+
+	if job.Status == entities.JobStatusSucceeded {
+		res, err := jobResponse(job)
+		if err != nil {
+			logrus.Errorf("failed to decode result of job %s: %v", job.ID, err)
+			return
+		}
+		for _, batch := range jobResultBatches(res.Result) {
+			if !send(JobEventResult, batch) {
+				return
+			}
+		}
+	}
+	send(job.Status, job)
+}
+
+// jobResultBatches splits the result into batches of jobResultBatchDepths depths. The result has a Depth
+// slice, every float slice of the same length, also of nested structs, is split with it.
+func jobResultBatches(result interface{}) []*responses.JobResultBatchResponse {
+	value := reflect.ValueOf(result)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	var depth []float64
+	if field := value.Elem().FieldByName("Depth"); field.IsValid() {
+		depth, _ = field.Interface().([]float64)
+	}
+	if len(depth) == 0 {
+		return []*responses.JobResultBatchResponse{{Batch: 1, Batches: 1, Result: result}}
+	}
+
+	batches := (len(depth) + jobResultBatchDepths - 1) / jobResultBatchDepths
+	res := make([]*responses.JobResultBatchResponse, 0, batches)
+	for i := 0; i < batches; i++ {
+		from, to := i*jobResultBatchDepths, min((i+1)*jobResultBatchDepths, len(depth))
+		res = append(res, &responses.JobResultBatchResponse{
+			Batch:     i + 1,
+			Batches:   batches,
+			FromDepth: depth[from],
+			ToDepth:   depth[to-1],
+			Result:    sliceDepths(value, len(depth), from, to).Interface(),
+		})
+	}
+	return res
+}
+
+// sliceDepths returns a copy of the value whose float slices of n values hold the values from from to to.
+// Other float slices are copied, so the units of every batch are converted separately.
+func sliceDepths(value reflect.Value, n int, from int, to int) reflect.Value {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		res := reflect.New(value.Elem().Type())
+		res.Elem().Set(sliceDepths(value.Elem(), n, from, to))
+		return res
+	case reflect.Struct:
+		res := reflect.New(value.Type()).Elem()
+		res.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if res.Field(i).CanSet() {
+				res.Field(i).Set(sliceDepths(value.Field(i), n, from, to))
+			}
+		}
+		return res
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Float64 {
+			if value.Len() != n {
+				return reflect.AppendSlice(reflect.MakeSlice(value.Type(), 0, value.Len()), value)
+			}
+			return value.Slice3(from, to, to)
+		}
+		res := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			res.Index(i).Set(sliceDepths(value.Index(i), n, from, to))
+		}
+		return res
+	}
+	return value
+}
@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// jobWorkers is the number of jobs calculated at the same time
+	jobWorkers = 4
+	// maxJobAttempts is the number of times a job runs before it fails
+	maxJobAttempts = 3
+	// jobRetryDelay is the wait before the first retry, it doubles with every attempt
+	jobRetryDelay = 10 * time.Second
+	// jobTimeout limits one attempt of a job
+	jobTimeout = 10 * time.Minute
+	// jobPollInterval is how often the queue is checked for retries and jobs queued by other servers
+	jobPollInterval = 5 * time.Second
+	// jobLeaseDuration is how long a running job stays with its server without a heartbeat
+	jobLeaseDuration = time.Minute
+	// jobHeartbeatInterval is how often the server renews the leases of its jobs and requeues expired ones
+	jobHeartbeatInterval = 20 * time.Second
+)
+
+type jobsService struct {
+	repo          repository.JobsRepository
+	roles         Roles
+	torqueAndDrag TorqueAndDrag
+	events        *jobEvents
+	// workerID tells the jobs of this server from the jobs of other servers
+	workerID string
+
+	// slots holds a token per running job
+	slots chan struct{}
+	// wake makes the dispatcher check the queue before the next poll
+	wake    chan struct{}
+	workers sync.WaitGroup
+
+	mu sync.Mutex
+	// cancels cancels the jobs running on this server by job ID
+	cancels map[string]context.CancelFunc
+}
+
+func NewJobsService(repo repository.JobsRepository, roles Roles, torqueAndDrag TorqueAndDrag) *jobsService {
+	return &jobsService{
+		repo:          repo,
+		roles:         roles,
+		torqueAndDrag: torqueAndDrag,
+		events:        newJobEvents(),
+		workerID:      newWorkerID(),
+		slots:         make(chan struct{}, jobWorkers),
+		wake:          make(chan struct{}, 1),
+		cancels:       make(map[string]context.CancelFunc),
+	}
+}
+
+// CreateJob queues a calculation of the case, the user needs the read permission on the case.
+func (s *jobsService) CreateJob(ctx context.Context, input *requests.CreateJobRequest) (*responses.JobResponse, error) {
+	if err := s.authorize(ctx, &input.JobRequester, input.Body.CaseID); err != nil {
+		return nil, err
+	}
+
+	job := &entities.Job{
+		OrganizationID: input.OrganizationID,
+		CaseID:         input.Body.CaseID,
+		Type:           input.Body.Type,
+		Status:         entities.JobStatusQueued,
+		RunAfter:       time.Now().UTC(),
+	}
+	if input.Body.Type == entities.JobTypeFrictionSensitivity {
+		body := input.Body.FrictionSensitivity
+		if frictionFactorCount(body.OpenHole)*frictionFactorCount(body.CasedHole) > maxFrictionSensitivityRuns {
+			return nil, domainErrors.ErrTooManySensitivityRuns
+		}
+		params, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		job.Params = params
+	}
+
+	if err := s.repo.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+	s.signal()
+	return &responses.JobResponse{Job: job}, nil
+}
+
+// GetJobs retrieves the jobs of the case without their results, newest first.
+func (s *jobsService) GetJobs(ctx context.Context, input *requests.GetJobsRequest) ([]*entities.Job, error) {
+	if err := s.authorize(ctx, &input.JobRequester, input.CaseID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetJobs(ctx, input.CaseID)
+}
+
+// GetJobByID retrieves the job with the result of a succeeded calculation.
+func (s *jobsService) GetJobByID(ctx context.Context, input *requests.GetJobByIDRequest) (*responses.JobResponse, error) {
+	job, err := s.getJob(ctx, &input.JobRequester, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobResponse(job)
+}
+
+// CancelJob cancels a queued job, or stops a running one. A job running on another server is only marked
+// as cancelled, its result is discarded.
+func (s *jobsService) CancelJob(ctx context.Context, input *requests.CancelJobRequest) (*responses.JobResponse, error) {
+	job, err := s.getJob(ctx, &input.JobRequester, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Finished() {
+		return nil, domainErrors.ErrJobFinished
+	}
+
+	cancelled, err := s.repo.CancelJob(ctx, job.ID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, domainErrors.ErrJobFinished
+	}
+	s.mu.Lock()
+	if cancel, ok := s.cancels[job.ID]; ok {
+		cancel()
+	}
+	s.mu.Unlock()
+	s.events.publish(job.ID, jobEventFinished, nil)
+
+	if job, err = s.repo.GetJobByID(ctx, job.ID); err != nil {
+		return nil, err
+	}
+	return jobResponse(job)
+}
+
+// Run calculates queued jobs with jobWorkers workers until ctx is done. While it runs, the leases of the jobs
+// of this server are renewed, and jobs whose server stopped renewing their lease are requeued. Jobs interrupted
+// by the stop are requeued once their lease expires.
+func (s *jobsService) Run(ctx context.Context) {
+	s.requeueExpiredJobs(ctx)
+
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	heartbeat := time.NewTicker(jobHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		s.dispatch(ctx)
+		select {
+		case <-ctx.Done():
+			s.workers.Wait()
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		case <-heartbeat.C:
+			if err := s.repo.RenewJobLeases(ctx, s.workerID, time.Now().UTC().Add(jobLeaseDuration)); err != nil && ctx.Err() == nil {
+				logrus.Errorf("failed to renew job leases: %v", err)
+			}
+			s.requeueExpiredJobs(ctx)
+		}
+	}
+}
+
+// requeueExpiredJobs puts the jobs of stopped servers back in the queue.
+func (s *jobsService) requeueExpiredJobs(ctx context.Context) {
+	if count, err := s.repo.RequeueExpiredJobs(ctx, time.Now().UTC()); err != nil {
+		if ctx.Err() == nil {
+			logrus.Errorf("failed to requeue interrupted jobs: %v", err)
+		}
+	} else if count > 0 {
+		logrus.Infof("requeued %d interrupted jobs", count)
+	}
+}
+
+// dispatch starts due jobs while there are free workers.
+func (s *jobsService) dispatch(ctx context.Context) {
+	for {
+		select {
+		case s.slots <- struct{}{}:
+		default:
+			return
+		}
+
+		now := time.Now().UTC()
+		job, err := s.repo.ClaimJob(ctx, s.workerID, now, now.Add(jobLeaseDuration))
+		if err != nil || job == nil {
+			<-s.slots
+			if err != nil && ctx.Err() == nil {
+				logrus.Errorf("failed to claim a job: %v", err)
+			}
+			return
+		}
+		s.workers.Add(1)
+		go s.run(ctx, job)
+	}
+}
+
+// run calculates the job and saves the outcome. Failed attempts are retried with a growing delay unless
+// the error is caused by the case itself, a timed out attempt is not retried.
+func (s *jobsService) run(ctx context.Context, job *entities.Job) {
+	defer func() {
+		<-s.slots
+		s.workers.Done()
+		s.signal()
+	}()
+
+	started := *job
+	s.events.publish(job.ID, JobEventStatus, &started)
+
+	jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+	jobCtx = withProgress(jobCtx, func(done int, total int, partial interface{}) {
+		s.events.publish(job.ID, JobEventProgress, &responses.JobProgressResponse{Done: done, Total: total})
+		if partial != nil {
+			s.events.publish(job.ID, JobEventPartial, partial)
+		}
+	})
+	s.mu.Lock()
+	s.cancels[job.ID] = cancel
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.cancels, job.ID)
+		s.mu.Unlock()
+	}()
+
+	var result json.RawMessage
+	err := domainErrors.ErrJobInterrupted
+	if job.Attempts <= maxJobAttempts {
+		result, err = s.calculate(jobCtx, job)
+	}
+	if ctx.Err() != nil {
+		// The server is stopping, the job stays running until its lease expires and it is requeued
+		return
+	}
+
+	now := time.Now().UTC()
+	switch {
+	case err == nil:
+		job.Status, job.Result, job.Error, job.FinishedAt = entities.JobStatusSucceeded, result, "", &now
+	case errors.Is(jobCtx.Err(), context.DeadlineExceeded):
+		job.Status, job.Error, job.FinishedAt = entities.JobStatusFailed, fmt.Sprintf("the calculation took longer than %s", jobTimeout), &now
+	case retryableJobError(err) && job.Attempts < maxJobAttempts:
+		job.Status, job.Error = entities.JobStatusQueued, err.Error()
+		job.RunAfter = now.Add(jobRetryDelay << (job.Attempts - 1))
+	default:
+		job.Status, job.Error, job.FinishedAt = entities.JobStatusFailed, err.Error(), &now
+	}
+	// A cancelled job, or a job requeued after its lease expired, no longer runs here and keeps its state
+	saved, err := s.repo.FinishJob(ctx, job)
+	if err != nil {
+		logrus.Errorf("failed to save job %s: %v", job.ID, err)
+		return
+	}
+	if saved && job.Status == entities.JobStatusQueued {
+		s.events.publish(job.ID, JobEventStatus, job)
+	} else if saved {
+		s.events.publish(job.ID, jobEventFinished, nil)
+	}
+}
+
+// calculate runs the calculation of the job and returns its response as JSON.
+func (s *jobsService) calculate(ctx context.Context, job *entities.Job) (json.RawMessage, error) {
+	var res interface{}
+	var err error
+	switch job.Type {
+	case entities.JobTypeEffectiveTension:
+		res, err = s.torqueAndDrag.CalculateEffectiveTensionFromMLModel(ctx, job.OrganizationID, job.CaseID)
+	case entities.JobTypeWeightOnBit:
+		res, err = s.torqueAndDrag.CalculateWeightOnBitFromMlModel(ctx, job.OrganizationID, job.CaseID)
+	case entities.JobTypeSurfaceTorque:
+		res, err = s.torqueAndDrag.CalculateSurfaceTorqueFromMlModel(ctx, job.OrganizationID, job.CaseID)
+	case entities.JobTypeMinWeightOnBit:
+		res, err = s.torqueAndDrag.CalculateMinWeightFromMLModel(ctx, job.OrganizationID, job.CaseID)
+	case entities.JobTypeFrictionSensitivity:
+		input := &requests.FrictionSensitivityRequest{OrganizationID: job.OrganizationID, CaseID: job.CaseID}
+		if err = json.Unmarshal(job.Params, &input.Body); err != nil {
+			return nil, err
+		}
+		res, err = s.torqueAndDrag.CalculateFrictionSensitivity(ctx, input)
+	default:
+		return nil, fmt.Errorf("unknown job type %q", job.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(res)
+}
+
+// retryableJobError reports whether the error may go away on the next attempt. Errors of the case or
+// its data fail the job at once.
+func retryableJobError(err error) bool {
+	return !errors.Is(err, domainErrors.ErrResourceNotFound) &&
+		!errors.Is(err, domainErrors.ErrCaseNotReadyForTorqueAndDrag) &&
+		!errors.Is(err, domainErrors.ErrTooManySensitivityRuns) &&
+		!errors.Is(err, domainErrors.ErrJobInterrupted)
+}
+
+// newWorkerID returns an ID unique to this start of the server, prefixed with the host name to tell where
+// a job runs.
+func newWorkerID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%s", host, uuid.NewString())
+}
+
+// signal wakes the dispatcher without waiting.
+func (s *jobsService) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// getJob retrieves the job, jobs of other organizations are not found.
+func (s *jobsService) getJob(ctx context.Context, requester *requests.JobRequester, id string) (*entities.Job, error) {
+	job, err := s.repo.GetJobByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.OrganizationID != requester.OrganizationID {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	if err := s.authorize(ctx, requester, job.CaseID); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *jobsService) authorize(ctx context.Context, requester *requests.JobRequester, caseID string) error {
+	return s.roles.Authorize(ctx, &requests.AuthorizeRequest{
+		UserID:          requester.UserID,
+		OrganizationID:  requester.OrganizationID,
+		Scope:           entities.ScopeCase,
+		ID:              caseID,
+		Permission:      entities.PermissionRead,
+		ApiKeyScope:     requester.ApiKeyScope,
+		ApiKeyCompanyID: requester.ApiKeyCompanyID,
+	})
+}
+
+// jobResponse decodes the result of the job into the response type of its calculation.
+func jobResponse(job *entities.Job) (*responses.JobResponse, error) {
+	res := &responses.JobResponse{Job: job}
+	if len(job.Result) == 0 {
+		return res, nil
+	}
+
+	switch job.Type {
+	case entities.JobTypeEffectiveTension:
+		res.Result = &responses.EffectiveTensionFromMLModelResponse{}
+	case entities.JobTypeWeightOnBit:
+		res.Result = &responses.WeightOnBitFromMLModelResponse{}
+	case entities.JobTypeSurfaceTorque:
+		res.Result = &responses.MomentFromMLModelResponse{}
+	case entities.JobTypeMinWeightOnBit:
+		res.Result = &responses.MinWeightFromMLModelResponse{}
+	case entities.JobTypeFrictionSensitivity:
+		res.Result = &responses.FrictionSensitivityResponse{}
+	default:
+		return res, nil
+	}
+	if err := json.Unmarshal(job.Result, res.Result); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -21,7 +21,10 @@ func NewPorePressuresService(porePressureRepo repository.PorePressuresRepository
 }
 
 func (s *porePressuresService) CreatePorePressure(ctx context.Context, input *requests.CreatePorePressureRequest) error {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.CaseID); err != nil {
 		return err
 	}
 
@@ -35,11 +38,15 @@ func (s *porePressuresService) CreatePorePressure(ctx context.Context, input *re
 }
 
 func (s *porePressuresService) GetPorePressureByID(ctx context.Context, input *requests.GetPorePressureByIDRequest) (*entities.PorePressure, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopePorePressure, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.porePressuresRepo.GetPorePressureByID(ctx, input.ID)
 }
 
 func (s *porePressuresService) GetPorePressures(ctx context.Context, input *requests.GetPorePressuresRequest) ([]*entities.PorePressure, error) {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
 		return nil, err
 	}
 
@@ -47,6 +54,13 @@ func (s *porePressuresService) GetPorePressures(ctx context.Context, input *requ
 }
 
 func (s *porePressuresService) UpdatePorePressure(ctx context.Context, input *requests.UpdatePorePressureRequest) (*entities.PorePressure, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopePorePressure, input.ID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopePorePressure, input.ID); err != nil {
+		return nil, err
+	}
+
 	porePressure := &entities.PorePressure{
 		ID:       input.ID,
 		TVD:      input.Body.TVD,
@@ -58,5 +72,12 @@ func (s *porePressuresService) UpdatePorePressure(ctx context.Context, input *re
 }
 
 func (s *porePressuresService) DeletePorePressure(ctx context.Context, input *requests.DeletePorePressureRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopePorePressure, input.ID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopePorePressure, input.ID); err != nil {
+		return err
+	}
+
 	return s.porePressuresRepo.DeletePorePressure(ctx, input.ID)
 }
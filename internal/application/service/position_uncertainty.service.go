@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	"github.com/munaiplan/munaiplan-backend/pkg/wellpath"
+)
+
+const (
+	defaultUncertaintySigma = 2.0
+)
+
+type positionUncertaintyService struct {
+	commonRepo repository.CommonRepository
+	repo       repository.TrajectoriesRepository
+	toolsRepo  repository.SurveyToolsRepository
+}
+
+func NewPositionUncertaintyService(repo repository.TrajectoriesRepository, toolsRepo repository.SurveyToolsRepository, commonRepo repository.CommonRepository) *positionUncertaintyService {
+	return &positionUncertaintyService{
+		repo:       repo,
+		toolsRepo:  toolsRepo,
+		commonRepo: commonRepo,
+	}
+}
+
+// CalculatePositionUncertainty calculates the uncertainty ellipses along the trajectory from its survey program.
+func (s *positionUncertaintyService) CalculatePositionUncertainty(ctx context.Context, input *requests.PositionUncertaintyRequest) (*responses.PositionUncertaintyResponse, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.TrajectoryID); err != nil {
+		return nil, err
+	}
+
+	sigma := input.Body.Sigma
+	if sigma <= 0 {
+		sigma = defaultUncertaintySigma
+	}
+
+	trajectory, err := s.repo.GetTrajectoryByID(ctx, input.TrajectoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, toolNames, err := buildToolRuns(ctx, s.toolsRepo, trajectory, input.Body.DefaultSurveyToolID)
+	if err != nil {
+		return nil, err
+	}
+
+	stations := wellpath.Resample(trajectoryToStations(trajectory), input.Body.Step)
+	covariances, err := wellpath.PositionCovariance(stations, runs)
+	if err != nil {
+		return nil, fmt.Errorf("trajectory %s: %w", trajectory.Name, err)
+	}
+
+	response := &responses.PositionUncertaintyResponse{
+		TrajectoryID: input.TrajectoryID,
+		Sigma:        sigma,
+		Stations:     make([]responses.PositionUncertaintyStationResponse, len(stations)),
+	}
+
+	for i, station := range stations {
+		ellipse := covariances[i].Ellipse(sigma)
+		response.Stations[i] = responses.PositionUncertaintyStationResponse{
+			MD:            station.MD,
+			TVD:           station.TVD,
+			North:         station.North,
+			East:          station.East,
+			SurveyTool:    toolNames[toolRunIndex(runs, station.MD)],
+			SigmaNorth:    ellipse.SigmaNorth,
+			SigmaEast:     ellipse.SigmaEast,
+			SigmaVertical: ellipse.SigmaVertical,
+			SemiMajor:     ellipse.SemiMajor,
+			SemiMinor:     ellipse.SemiMinor,
+			MajorAzimuth:  ellipse.MajorAzimuth,
+			Vertical:      ellipse.Vertical,
+		}
+	}
+
+	return response, nil
+}
+
+// buildToolRuns converts the survey program of the trajectory into tool runs with their error models.
+// When defaultToolID is set the default tool covers every depth outside the survey program.
+// The returned names are indexed like the runs.
+func buildToolRuns(ctx context.Context, toolsRepo repository.SurveyToolsRepository, trajectory *entities.Trajectory, defaultToolID string) ([]wellpath.ToolRun, []string, error) {
+	ids := make([]string, 0, len(trajectory.SurveyProgram)+1)
+	for _, interval := range trajectory.SurveyProgram {
+		ids = append(ids, interval.SurveyToolID)
+	}
+	if defaultToolID != "" {
+		ids = append(ids, defaultToolID)
+	}
+
+	tools, err := toolsRepo.GetSurveyToolsByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	toolsMap := make(map[string]*entities.SurveyTool, len(tools))
+	for _, tool := range tools {
+		toolsMap[tool.ID] = tool
+	}
+
+	toRun := func(toolID string, from, to float64) (wellpath.ToolRun, string, error) {
+		tool, ok := toolsMap[toolID]
+		if !ok {
+			return wellpath.ToolRun{}, "", fmt.Errorf("survey tool with id %s does not exist", toolID)
+		}
+		run := wellpath.ToolRun{FromMD: from, ToMD: to, Terms: make([]wellpath.ErrorTerm, len(tool.ErrorTerms))}
+		for i, term := range tool.ErrorTerms {
+			run.Terms[i] = toErrorTerm(term)
+		}
+		return run, tool.Name, nil
+	}
+
+	var runs []wellpath.ToolRun
+	var names []string
+	for _, interval := range trajectory.SurveyProgram {
+		run, name, err := toRun(interval.SurveyToolID, interval.MDFrom, interval.MDTo)
+		if err != nil {
+			return nil, nil, err
+		}
+		runs = append(runs, run)
+		names = append(names, name)
+	}
+
+	if defaultToolID != "" {
+		run, name, err := toRun(defaultToolID, math.Inf(-1), math.Inf(1))
+		if err != nil {
+			return nil, nil, err
+		}
+		runs = append(runs, run)
+		names = append(names, name)
+	}
+
+	if len(runs) == 0 {
+		return nil, nil, fmt.Errorf("trajectory %s has no survey program", trajectory.Name)
+	}
+
+	return runs, names, nil
+}
+
+// toolRunIndex returns the index of the first tool run covering md.
+func toolRunIndex(runs []wellpath.ToolRun, md float64) int {
+	for i, run := range runs {
+		if md >= run.FromMD && md <= run.ToMD {
+			return i
+		}
+	}
+	return 0
+}
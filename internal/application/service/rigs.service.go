@@ -22,18 +22,25 @@ func NewRigsService(repo repository.RigsRepository, commonRepo repository.Common
 }
 
 func (s *rigsService) GetRigs(ctx context.Context, input *requests.GetRigsRequest) ([]*entities.Rig, error) {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
 		return nil, err
 	}
 	return s.repo.GetRigs(ctx, input.CaseID)
 }
 
 func (s *rigsService) GetRigByID(ctx context.Context, input *requests.GetRigByIDRequest) (*entities.Rig, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeRig, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetRigByID(ctx, input.ID)
 }
 
 func (s *rigsService) CreateRig(ctx context.Context, input *requests.CreateRigRequest) error {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.CaseID); err != nil {
 		return err
 	}
 
@@ -48,12 +55,26 @@ func (s *rigsService) CreateRig(ctx context.Context, input *requests.CreateRigRe
 }
 
 func (s *rigsService) UpdateRig(ctx context.Context, input *requests.UpdateRigRequest) (*entities.Rig, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeRig, input.ID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeRig, input.ID); err != nil {
+		return nil, err
+	}
+
 	rig := s.UpdateRigRequestToEntity(&input.Body)
 	rig.ID = input.ID
 	return s.repo.UpdateRig(ctx, rig)
 }
 
 func (s *rigsService) DeleteRig(ctx context.Context, input *requests.DeleteRigRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeRig, input.ID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeRig, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteRig(ctx, input.ID)
 }
 
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+)
+
+// rolePermissions lists the actions allowed by each organization role, from the weakest role to the strongest.
+var rolePermissions = map[string][]string{
+	entities.RoleViewer:   {entities.PermissionRead},
+	entities.RoleEngineer: {entities.PermissionRead, entities.PermissionWrite},
+	entities.RoleOrgAdmin: {entities.PermissionRead, entities.PermissionWrite, entities.PermissionManage},
+}
+
+type rolesService struct {
+	commonRepo repository.CommonRepository
+	repo       repository.RolesRepository
+	usersRepo  repository.UsersRepository
+}
+
+func NewRolesService(repo repository.RolesRepository, usersRepo repository.UsersRepository, commonRepo repository.CommonRepository) *rolesService {
+	return &rolesService{
+		repo:       repo,
+		usersRepo:  usersRepo,
+		commonRepo: commonRepo,
+	}
+}
+
+func (s *rolesService) GetRoleAssignments(ctx context.Context, input *requests.GetRoleAssignmentsRequest) ([]*entities.RoleAssignment, error) {
+	return s.repo.GetRoleAssignments(ctx, input.OrganizationID, input.UserID)
+}
+
+func (s *rolesService) CreateRoleAssignment(ctx context.Context, input *requests.CreateRoleAssignmentRequest) (*entities.RoleAssignment, error) {
+	if _, ok := rolePermissions[input.Body.Role]; !ok {
+		return nil, domainErrors.ErrInvalidRole
+	}
+
+	user, err := s.usersRepo.GetByID(ctx, input.Body.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.OrganizationID != input.OrganizationID {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+
+	assignment := &entities.RoleAssignment{
+		UserID:         input.Body.UserID,
+		OrganizationID: input.OrganizationID,
+		Role:           input.Body.Role,
+		CompanyID:      input.Body.CompanyID,
+		FieldID:        input.Body.FieldID,
+	}
+	if assignment.CompanyID != nil {
+		if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCompany, *assignment.CompanyID); err != nil {
+			return nil, err
+		}
+	}
+	if assignment.FieldID != nil {
+		ownership, err := s.commonRepo.GetOwnership(ctx, entities.ScopeField, *assignment.FieldID)
+		if err != nil {
+			return nil, err
+		}
+		if ownership.OrganizationID != input.OrganizationID {
+			return nil, domainErrors.ErrResourceNotFound
+		}
+		if assignment.CompanyID != nil && *assignment.CompanyID != ownership.CompanyID {
+			return nil, fmt.Errorf("field %s does not belong to company %s", *assignment.FieldID, *assignment.CompanyID)
+		}
+		assignment.CompanyID = &ownership.CompanyID
+	}
+
+	if err := s.repo.CreateRoleAssignment(ctx, assignment); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (s *rolesService) DeleteRoleAssignment(ctx context.Context, input *requests.DeleteRoleAssignmentRequest) error {
+	return s.repo.DeleteRoleAssignment(ctx, input.OrganizationID, input.ID)
+}
+
+// Authorize checks that the user may perform the action on the entity. Super admins may do everything.
+// Otherwise the most specific role assigned for the field, the company or the whole organization applies.
+// Requests with an API key are also limited by the scope and the company of the key, a key limited to a company
+// needs the entity, which must be the one the request acts on, to belong to that company.
+func (s *rolesService) Authorize(ctx context.Context, input *requests.AuthorizeRequest) error {
+	apiKey := input.ApiKeyScope != ""
+	if apiKey && !entities.ApiKeyScopeAllows(input.ApiKeyScope, input.Permission) {
+		return domainErrors.ErrPermissionDenied
+	}
+
+	superAdmin, err := s.repo.IsSuperAdmin(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+	if superAdmin && !apiKey {
+		return nil
+	}
+
+	var ownership *entities.Ownership
+	if input.ID != "" {
+		if ownership, err = s.commonRepo.GetOwnership(ctx, input.Scope, input.ID); err != nil {
+			return err
+		}
+		if ownership.OrganizationID != input.OrganizationID {
+			return domainErrors.ErrResourceNotFound
+		}
+	}
+	if input.ApiKeyCompanyID != nil && (ownership == nil || ownership.CompanyID != *input.ApiKeyCompanyID) {
+		return domainErrors.ErrPermissionDenied
+	}
+	if superAdmin {
+		return nil
+	}
+
+	assignments, err := s.repo.GetRoleAssignments(ctx, input.OrganizationID, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	role := effectiveRole(assignments, ownership)
+	if role == "" && ownership == nil && input.Permission == entities.PermissionRead && len(assignments) > 0 {
+		// Users with roles limited to a company or a field can still browse the organization
+		return nil
+	}
+	if !roleAllows(role, input.Permission) {
+		return domainErrors.ErrPermissionDenied
+	}
+	return nil
+}
+
+// CheckSuperAdmin returns ErrPermissionDenied unless the user is a platform super admin.
+func (s *rolesService) CheckSuperAdmin(ctx context.Context, userID string) error {
+	superAdmin, err := s.repo.IsSuperAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !superAdmin {
+		return domainErrors.ErrPermissionDenied
+	}
+	return nil
+}
+
+// effectiveRole returns the role of the most specific assignment that covers the entity: field before company
+// before organization. The strongest role wins between assignments of the same level.
+// Without an entity only organization wide assignments count.
+func effectiveRole(assignments []*entities.RoleAssignment, ownership *entities.Ownership) string {
+	role, level := "", 0
+	for _, assignment := range assignments {
+		assignmentLevel := 0
+		switch {
+		case assignment.FieldID != nil:
+			if ownership != nil && *assignment.FieldID == ownership.FieldID {
+				assignmentLevel = 3
+			}
+		case assignment.CompanyID != nil:
+			if ownership != nil && *assignment.CompanyID == ownership.CompanyID {
+				assignmentLevel = 2
+			}
+		default:
+			assignmentLevel = 1
+		}
+
+		if assignmentLevel > level || (assignmentLevel == level && assignmentLevel > 0 && len(rolePermissions[assignment.Role]) > len(rolePermissions[role])) {
+			role, level = assignment.Role, assignmentLevel
+		}
+	}
+	return role
+}
+
+// roleAllows reports whether the role allows the action.
+func roleAllows(role string, permission string) bool {
+	for _, allowed := range rolePermissions[role] {
+		if allowed == permission {
+			return true
+		}
+	}
+	return false
+}
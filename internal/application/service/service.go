@@ -2,18 +2,60 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/email"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/notify"
 	client "github.com/munaiplan/munaiplan-backend/internal/infrastructure/prediction_client"
+	"github.com/munaiplan/munaiplan-backend/pkg/chart"
+	"github.com/munaiplan/munaiplan-backend/pkg/pdf"
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+	"github.com/munaiplan/munaiplan-backend/pkg/witsml"
+	"github.com/xuri/excelize/v2"
 )
 
 type Users interface {
 	SignIn(ctx context.Context, input *requests.UserSignInRequest) (*responses.TokenResponse, error)
 	SignUp(ctx context.Context, input *requests.UserSignUpRequest) error
+	Refresh(ctx context.Context, input *requests.RefreshTokenRequest) (*responses.TokenResponse, error)
+	SignOut(ctx context.Context, input *requests.SignOutRequest) error
+	SignOutAll(ctx context.Context, userID string) error
+	CheckSession(ctx context.Context, sessionID string) error
+	GetProfile(ctx context.Context, userID string) (*entities.User, error)
+	UpdateProfile(ctx context.Context, input *requests.UpdateProfileRequest) (*entities.User, error)
+	ChangePassword(ctx context.Context, input *requests.ChangePasswordRequest) error
+	RequestPasswordReset(ctx context.Context, input *requests.PasswordResetRequest) error
+	ResetPassword(ctx context.Context, input *requests.PasswordResetConfirmRequest) error
+}
+
+type ApiKeys interface {
+	CreateApiKey(ctx context.Context, input *requests.CreateApiKeyRequest) (*responses.ApiKeyCreatedResponse, error)
+	GetApiKeys(ctx context.Context, input *requests.GetApiKeysRequest) ([]*entities.ApiKey, error)
+	DeleteApiKey(ctx context.Context, input *requests.DeleteApiKeyRequest) error
+	Authenticate(ctx context.Context, plainKey string) (*entities.ApiKey, error)
+}
+
+type AuditLogs interface {
+	GetAuditLogs(ctx context.Context, input *requests.GetAuditLogsRequest) ([]*entities.AuditLogEntry, error)
+}
+
+type Invitations interface {
+	CreateInvitation(ctx context.Context, input *requests.CreateInvitationRequest) (*entities.Invitation, error)
+	GetInvitations(ctx context.Context, input *requests.GetInvitationsRequest) ([]*entities.Invitation, error)
+	DeleteInvitation(ctx context.Context, input *requests.DeleteInvitationRequest) error
+}
+
+type Roles interface {
+	GetRoleAssignments(ctx context.Context, input *requests.GetRoleAssignmentsRequest) ([]*entities.RoleAssignment, error)
+	CreateRoleAssignment(ctx context.Context, input *requests.CreateRoleAssignmentRequest) (*entities.RoleAssignment, error)
+	DeleteRoleAssignment(ctx context.Context, input *requests.DeleteRoleAssignmentRequest) error
+	Authorize(ctx context.Context, input *requests.AuthorizeRequest) error
+	CheckSuperAdmin(ctx context.Context, userID string) error
 }
 
 type Organizations interface {
@@ -71,6 +113,12 @@ type Designs interface {
 	CreateDesign(ctx context.Context, input *requests.CreateDesignRequest) error
 	UpdateDesign(ctx context.Context, input *requests.UpdateDesignRequest) (*entities.Design, error)
 	DeleteDesign(ctx context.Context, input *requests.DeleteDesignRequest) error
+	FreezeDesign(ctx context.Context, input *requests.FreezeDesignRequest) (*entities.DesignRevision, error)
+	GetDesignRevisions(ctx context.Context, input *requests.GetDesignRevisionsRequest) ([]*entities.DesignRevision, error)
+	GetDesignRevision(ctx context.Context, input *requests.GetDesignRevisionRequest) (*entities.DesignRevision, error)
+	DiffDesignRevisions(ctx context.Context, input *requests.DiffDesignRevisionsRequest) (*responses.DesignRevisionsDiffResponse, error)
+	TransitionDesignStage(ctx context.Context, input *requests.TransitionDesignStageRequest) (*entities.DesignStageTransition, error)
+	GetDesignStageTransitions(ctx context.Context, input *requests.GetDesignStageTransitionsRequest) ([]*entities.DesignStageTransition, error)
 }
 
 type Trajectories interface {
@@ -139,16 +187,109 @@ type Strings interface {
 }
 
 type TorqueAndDrag interface {
-	CalculateEffectiveTensionFromMLModel(ctx context.Context, caseID string) (*responses.EffectiveTensionFromMLModelResponse, error)
-	CalculateWeightOnBitFromMlModel(ctx context.Context, caseID string) (*responses.WeightOnBitFromMLModelResponse, error)
-	CalculateSurfaceTorqueFromMlModel(ctx context.Context, caseID string) (*responses.MomentFromMLModelResponse, error)
-	CalculateMinWeightFromMLModel(ctx context.Context, caseID string) (*responses.MinWeightFromMLModelResponse, error)
+	CalculateEffectiveTensionFromMLModel(ctx context.Context, organizationID string, caseID string) (*responses.EffectiveTensionFromMLModelResponse, error)
+	CalculateWeightOnBitFromMlModel(ctx context.Context, organizationID string, caseID string) (*responses.WeightOnBitFromMLModelResponse, error)
+	CalculateSurfaceTorqueFromMlModel(ctx context.Context, organizationID string, caseID string) (*responses.MomentFromMLModelResponse, error)
+	CalculateMinWeightFromMLModel(ctx context.Context, organizationID string, caseID string) (*responses.MinWeightFromMLModelResponse, error)
+	CalculateFrictionSensitivity(ctx context.Context, input *requests.FrictionSensitivityRequest) (*responses.FrictionSensitivityResponse, error)
+	CalculateFrictionCurves(ctx context.Context, organizationID string, caseID string, factors []float64) (*responses.FrictionSensitivityResponse, error)
+	GetCalculationResults(ctx context.Context, input *requests.GetCalculationResultsRequest) ([]*entities.CalculationResult, error)
+}
+
+type Jobs interface {
+	CreateJob(ctx context.Context, input *requests.CreateJobRequest) (*responses.JobResponse, error)
+	GetJobs(ctx context.Context, input *requests.GetJobsRequest) ([]*entities.Job, error)
+	GetJobByID(ctx context.Context, input *requests.GetJobByIDRequest) (*responses.JobResponse, error)
+	CancelJob(ctx context.Context, input *requests.CancelJobRequest) (*responses.JobResponse, error)
+	SubscribeJob(ctx context.Context, input *requests.SubscribeJobRequest) (<-chan *responses.JobEvent, error)
+	// Run calculates queued jobs until ctx is done.
+	Run(ctx context.Context)
+}
+
+type DrillingData interface {
+	ImportDrillingData(ctx context.Context, input *requests.ImportDrillingDataRequest) (*responses.DrillingDataImportResponse, error)
+	GetDrillingReadings(ctx context.Context, input *requests.GetDrillingReadingsRequest) ([]*entities.DrillingReading, error)
+	DeleteDrillingReadings(ctx context.Context, input *requests.DeleteDrillingReadingsRequest) (*responses.DeleteDrillingReadingsResponse, error)
+	CalibrateFrictionFactors(ctx context.Context, input *requests.CalibrateFrictionFactorsRequest) (*responses.FrictionCalibrationResponse, error)
+}
+
+type WellLogs interface {
+	ImportWellLog(ctx context.Context, input *requests.ImportWellLogRequest) (*entities.WellLog, error)
+	GetWellLogs(ctx context.Context, input *requests.GetWellLogsRequest) ([]*entities.WellLog, error)
+	GetWellLogByID(ctx context.Context, input *requests.GetWellLogByIDRequest) (*entities.WellLog, error)
+	DeleteWellLog(ctx context.Context, input *requests.DeleteWellLogRequest) error
+	EstimatePorePressure(ctx context.Context, input *requests.EstimatePorePressureRequest) (*responses.PorePressureEstimateResponse, error)
+}
+
+type Witsml interface {
+	ExportWell(ctx context.Context, input *requests.ExportWitsmlRequest) (*witsml.Wells, error)
+	ExportWellbore(ctx context.Context, input *requests.ExportWitsmlRequest) (*witsml.Wellbores, error)
+	ExportTrajectory(ctx context.Context, input *requests.ExportWitsmlRequest) (*witsml.Trajectories, error)
+	ExportTubular(ctx context.Context, input *requests.ExportWitsmlRequest) (*witsml.Tubulars, error)
+	ExportBhaRun(ctx context.Context, input *requests.ExportWitsmlRequest) (*witsml.BhaRuns, error)
+	ImportWells(ctx context.Context, input *requests.ImportWitsmlRequest) (*responses.WitsmlImportResponse, error)
+	ImportWellbores(ctx context.Context, input *requests.ImportWitsmlRequest) (*responses.WitsmlImportResponse, error)
+	ImportTrajectories(ctx context.Context, input *requests.ImportWitsmlRequest) (*responses.WitsmlImportResponse, error)
+	ImportString(ctx context.Context, input *requests.ImportWitsmlStringRequest) (*responses.WitsmlImportResponse, error)
+}
+
+type CaseReports interface {
+	GetCaseReport(ctx context.Context, input *requests.GetCaseReportRequest) (*pdf.Document, error)
+}
+
+type Charts interface {
+	GetCaseChart(ctx context.Context, input *requests.GetCaseChartRequest) (*chart.Chart, error)
+	GetTrajectoryChart(ctx context.Context, input *requests.GetTrajectoryChartRequest) (*chart.Chart, error)
+}
+
+type CaseWorkbooks interface {
+	ExportCaseWorkbook(ctx context.Context, input *requests.ExportCaseWorkbookRequest) (*excelize.File, error)
+	ImportCaseWorkbook(ctx context.Context, input *requests.ImportCaseWorkbookRequest) (*responses.CaseWorkbookImportResponse, error)
+}
+
+type CaseComparison interface {
+	CompareCases(ctx context.Context, input *requests.CompareCasesRequest) (*responses.CaseComparisonResponse, error)
+}
+
+type AntiCollision interface {
+	ScanTrajectory(ctx context.Context, input *requests.AntiCollisionRequest) (*responses.AntiCollisionResponse, error)
+}
+
+type SurveyTools interface {
+	GetSurveyTools(ctx context.Context) ([]*entities.SurveyTool, error)
+	GetSurveyToolByID(ctx context.Context, input *requests.GetSurveyToolByIDRequest) (*entities.SurveyTool, error)
+	CreateSurveyTool(ctx context.Context, input *requests.CreateSurveyToolRequest) error
+	UpdateSurveyTool(ctx context.Context, input *requests.UpdateSurveyToolRequest) (*entities.SurveyTool, error)
+	DeleteSurveyTool(ctx context.Context, input *requests.DeleteSurveyToolRequest) error
+}
+
+type PositionUncertainty interface {
+	CalculatePositionUncertainty(ctx context.Context, input *requests.PositionUncertaintyRequest) (*responses.PositionUncertaintyResponse, error)
+}
+
+type Coordinates interface {
+	GetSupportedCRS(ctx context.Context) []*responses.CRSResponse
+	TransformCoordinates(ctx context.Context, input *requests.TransformCoordinatesRequest) (*responses.TransformCoordinatesResponse, error)
+}
+
+type Tortuosity interface {
+	GetTortuosityReport(ctx context.Context, input *requests.TortuosityReportRequest) (*responses.TortuosityReportResponse, error)
+	ApplySyntheticTortuosity(ctx context.Context, input *requests.SyntheticTortuosityRequest) error
+}
+
+type Units interface {
+	GetUnitSystems(ctx context.Context) []*responses.UnitSystemResponse
+	ResolveUnitSystem(ctx context.Context, scope string, id string) (*units.System, error)
 }
 
 type Services struct {
 	// TODO() Implement cache
 	// CatalogCache *catalog.CatalogCache
 	Users
+	Roles
+	Invitations
+	ApiKeys
+	AuditLogs
 	Companies
 	Organizations
 	Fields
@@ -165,31 +306,69 @@ type Services struct {
 	FractureGradients
 	Strings
 	TorqueAndDrag
+	Jobs
+	CaseComparison
+	CaseReports
+	Charts
+	CaseWorkbooks
+	DrillingData
+	WellLogs
+	Witsml
+	AntiCollision
+	SurveyTools
+	PositionUncertainty
+	Coordinates
+	Tortuosity
+	Units
 }
 
-func NewServices(repos *repository.Repository, jwt helpers.Jwt, mlServiceClientUrl string) *Services {
+func NewServices(repos *repository.Repository, jwt helpers.Jwt, mlServiceClientUrl string, mlServiceTimeout time.Duration, emailSender email.Sender, appUrl string, notifier notify.Notifier) *Services {
+	roles := NewRolesService(repos.Roles, repos.Users, repos.Common)
+	torqueAndDrag := NewTorqueAndDragService(
+		repos.Strings,
+		repos.Holes,
+		repos.Cases,
+		repos.CalculationResults,
+		repos.Common,
+		client.NewTorqueAndDragClient(mlServiceClientUrl, mlServiceTimeout),
+	)
+
 	return &Services{
-		Users:             NewUsersService(repos.Users, repos.Common, jwt),
-		Companies:         NewCompaniesService(repos.Companies, repos.Common),
-		Organizations:     NewOrganizationsService(repos.Organizations),
-		Fields:            NewFieldsService(repos.Fields, repos.Common),
-		Sites:             NewSitesService(repos.Sites, repos.Common),
-		Wells:             NewWellsService(repos.Wells, repos.Common),
-		Wellbores:         NewWellboresService(repos.Wellbores, repos.Common),
-		Designs:           NewDesignsService(repos.Designs, repos.Common),
-		Trajectories:      NewTrajectoriesService(repos.Trajectories, repos.Common),
-		Cases:             NewCasesService(repos.Cases, repos.Common),
-		Holes:             NewHolesService(repos.Holes, repos.Common),
-		Fluids:            NewFluidsService(repos.Fluids, repos.Common),
-		Rigs:              NewRigsService(repos.Rigs, repos.Common),
-		PorePressures:     NewPorePressuresService(repos.PorePressures, repos.Common),
-		FractureGradients: NewFractureGradientsService(repos.FractureGradients, repos.Common),
-		Strings:           NewStringsService(repos.Strings, repos.Common),
-		TorqueAndDrag: NewTorqueAndDragService(
-			repos.Strings,
-			repos.Common,
-			client.NewTorqueAndDragClient(mlServiceClientUrl),
-		),
+		Users:               NewUsersService(repos.Users, repos.Tokens, repos.Invitations, repos.Common, jwt, emailSender, appUrl),
+		Roles:               roles,
+		Invitations:         NewInvitationsService(repos.Invitations, repos.Common, emailSender, appUrl),
+		ApiKeys:             NewApiKeysService(repos.ApiKeys, repos.Common),
+		AuditLogs:           NewAuditLogsService(repos.AuditLogs),
+		Companies:           NewCompaniesService(repos.Companies, repos.Common),
+		Organizations:       NewOrganizationsService(repos.Organizations),
+		Fields:              NewFieldsService(repos.Fields, repos.Common),
+		Sites:               NewSitesService(repos.Sites, repos.Common),
+		Wells:               NewWellsService(repos.Wells, repos.Sites, repos.Common),
+		Wellbores:           NewWellboresService(repos.Wellbores, repos.Common),
+		Designs:             NewDesignsService(repos.Designs, repos.Common, roles, notifier),
+		Trajectories:        NewTrajectoriesService(repos.Trajectories, repos.Common),
+		Cases:               NewCasesService(repos.Cases, repos.Common),
+		Holes:               NewHolesService(repos.Holes, repos.Common),
+		Fluids:              NewFluidsService(repos.Fluids, repos.Common),
+		Rigs:                NewRigsService(repos.Rigs, repos.Common),
+		PorePressures:       NewPorePressuresService(repos.PorePressures, repos.Common),
+		FractureGradients:   NewFractureGradientsService(repos.FractureGradients, repos.Common),
+		Strings:             NewStringsService(repos.Strings, repos.Common),
+		TorqueAndDrag:       torqueAndDrag,
+		Jobs:                NewJobsService(repos.Jobs, roles, torqueAndDrag),
+		CaseComparison:      NewCaseComparisonService(repos.Cases, repos.Common, roles, torqueAndDrag),
+		CaseReports:         NewCaseReportsService(repos.Cases, repos.Common, torqueAndDrag),
+		Charts:              NewChartsService(repos.Cases, repos.Trajectories, repos.Common, torqueAndDrag),
+		CaseWorkbooks:       NewCaseWorkbooksService(repos.Cases, repos.Trajectories, repos.Strings, repos.Holes, repos.Fluids, repos.PorePressures, repos.FractureGradients, repos.Rigs, repos.Common, repos.Transactions, torqueAndDrag),
+		DrillingData:        NewDrillingDataService(repos.DrillingData, repos.Common, torqueAndDrag),
+		WellLogs:            NewWellLogsService(repos.WellLogs, repos.PorePressures, repos.FractureGradients, repos.Common, repos.Transactions),
+		Witsml:              NewWitsmlService(repos.Wells, repos.Wellbores, repos.Trajectories, repos.Strings, repos.Sites, repos.Common),
+		AntiCollision:       NewAntiCollisionService(repos.Trajectories, repos.SurveyTools, repos.Common),
+		SurveyTools:         NewSurveyToolsService(repos.SurveyTools, repos.Common),
+		PositionUncertainty: NewPositionUncertaintyService(repos.Trajectories, repos.SurveyTools, repos.Common),
+		Coordinates:         NewCoordinatesService(),
+		Tortuosity:          NewTortuosityService(repos.Trajectories, repos.Common),
+		Units:               NewUnitsService(repos.Common),
 		// CatalogCache: deps.CatalogCache,
 	}
 }
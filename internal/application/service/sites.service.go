@@ -21,7 +21,7 @@ func NewSitesService(repo repository.SitesRepository, commonRepo repository.Comm
 }
 
 func (s *sitesService) GetSites(ctx context.Context, input *requests.GetSitesRequest) ([]*entities.Site, error) {
-	if err := s.commonRepo.CheckIfFieldExists(ctx, input.FieldID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeField, input.FieldID); err != nil {
 		return nil, err
 	}
 
@@ -29,11 +29,15 @@ func (s *sitesService) GetSites(ctx context.Context, input *requests.GetSitesReq
 }
 
 func (s *sitesService) GetSiteByID(ctx context.Context, input *requests.GetSiteByIDRequest) (*entities.Site, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeSite, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetSiteByID(ctx, input.ID)
 }
 
 func (s *sitesService) CreateSite(ctx context.Context, input *requests.CreateSiteRequest) error {
-	if err := s.commonRepo.CheckIfFieldExists(ctx, input.FieldID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeField, input.FieldID); err != nil {
 		return err
 	}
 
@@ -46,11 +50,18 @@ func (s *sitesService) CreateSite(ctx context.Context, input *requests.CreateSit
 		State:   input.Body.State,
 		Region:  input.Body.Region,
 	}
+	if err := setSiteLocation(site, input.Body.EPSGCode, input.Body.Latitude, input.Body.Longitude, input.Body.Easting, input.Body.Northing); err != nil {
+		return err
+	}
 
 	return s.repo.CreateSite(ctx, input.FieldID, site)
 }
 
 func (s *sitesService) UpdateSite(ctx context.Context, input *requests.UpdateSiteRequest) (*entities.Site, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeSite, input.ID); err != nil {
+		return nil, err
+	}
+
 	site := &entities.Site{
 		ID:      input.ID,
 		Name:    input.Body.Name,
@@ -61,10 +72,36 @@ func (s *sitesService) UpdateSite(ctx context.Context, input *requests.UpdateSit
 		State:   input.Body.State,
 		Region:  input.Body.Region,
 	}
+	if err := setSiteLocation(site, input.Body.EPSGCode, input.Body.Latitude, input.Body.Longitude, input.Body.Easting, input.Body.Northing); err != nil {
+		return nil, err
+	}
 
 	return s.repo.UpdateSite(ctx, site)
 }
 
 func (s *sitesService) DeleteSite(ctx context.Context, input *requests.DeleteSiteRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeSite, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteSite(ctx, input.ID)
 }
+
+// setSiteLocation stores the CRS of the site and completes its location.
+func setSiteLocation(site *entities.Site, epsgCode int, latitude, longitude, easting, northing float64) error {
+	grid, latitude, longitude, err := surfaceLocation(epsgCode, latitude, longitude, easting, northing)
+	if err != nil {
+		return err
+	}
+
+	site.EPSGCode = epsgCode
+	site.Latitude = latitude
+	site.Longitude = longitude
+	site.Easting = easting
+	site.Northing = northing
+	if grid != nil {
+		site.Easting = grid.Easting
+		site.Northing = grid.Northing
+	}
+	return nil
+}
@@ -22,7 +22,7 @@ func NewStringsService(repo repository.StringsRepository, commonRepo repository.
 }
 
 func (s *stringsService) GetStrings(ctx context.Context, input *requests.GetStringsRequest) ([]*entities.String, error) {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
 		return nil, err
 	}
 
@@ -30,11 +30,18 @@ func (s *stringsService) GetStrings(ctx context.Context, input *requests.GetStri
 }
 
 func (s *stringsService) GetStringByID(ctx context.Context, input *requests.GetStringByIDRequest) (*entities.String, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeString, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetStringByID(ctx, input.ID)
 }
 
 func (s *stringsService) CreateString(ctx context.Context, input *requests.CreateStringRequest) error {
-	if err := s.commonRepo.CheckIfCaseExists(ctx, input.CaseID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.CaseID); err != nil {
 		return err
 	}
 
@@ -49,12 +56,26 @@ func (s *stringsService) CreateString(ctx context.Context, input *requests.Creat
 }
 
 func (s *stringsService) UpdateString(ctx context.Context, input *requests.UpdateStringRequest) (*entities.String, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeString, input.ID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeString, input.ID); err != nil {
+		return nil, err
+	}
+
 	updatedString := s.UpdateStringRequestToEntity(&input.Body)
 	updatedString.ID = input.ID
 	return s.repo.UpdateString(ctx, updatedString)
 }
 
 func (s *stringsService) DeleteString(ctx context.Context, input *requests.DeleteStringRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeString, input.ID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeString, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteString(ctx, input.ID)
 }
 
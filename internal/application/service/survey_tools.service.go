@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/wellpath"
+)
+
+type surveyToolsService struct {
+	commonRepo repository.CommonRepository
+	repo       repository.SurveyToolsRepository
+}
+
+func NewSurveyToolsService(repo repository.SurveyToolsRepository, commonRepo repository.CommonRepository) *surveyToolsService {
+	return &surveyToolsService{
+		repo:       repo,
+		commonRepo: commonRepo,
+	}
+}
+
+func (s *surveyToolsService) GetSurveyTools(ctx context.Context) ([]*entities.SurveyTool, error) {
+	return s.repo.GetSurveyTools(ctx)
+}
+
+func (s *surveyToolsService) GetSurveyToolByID(ctx context.Context, input *requests.GetSurveyToolByIDRequest) (*entities.SurveyTool, error) {
+	return s.repo.GetSurveyToolByID(ctx, input.ID)
+}
+
+func (s *surveyToolsService) CreateSurveyTool(ctx context.Context, input *requests.CreateSurveyToolRequest) error {
+	tool, err := s.surveyToolRequestToEntity(&input.Body)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.CreateSurveyTool(ctx, tool)
+}
+
+func (s *surveyToolsService) UpdateSurveyTool(ctx context.Context, input *requests.UpdateSurveyToolRequest) (*entities.SurveyTool, error) {
+	if err := s.commonRepo.CheckIfSurveyToolExists(ctx, input.ID); err != nil {
+		return nil, err
+	}
+
+	tool, err := s.surveyToolRequestToEntity(&input.Body)
+	if err != nil {
+		return nil, err
+	}
+	tool.ID = input.ID
+
+	return s.repo.UpdateSurveyTool(ctx, tool)
+}
+
+func (s *surveyToolsService) DeleteSurveyTool(ctx context.Context, input *requests.DeleteSurveyToolRequest) error {
+	return s.repo.DeleteSurveyTool(ctx, input.ID)
+}
+
+// surveyToolRequestToEntity maps the request to a survey tool and validates its error model.
+func (s *surveyToolsService) surveyToolRequestToEntity(input *requests.CreateSurveyToolRequestBody) (*entities.SurveyTool, error) {
+	terms := make([]*entities.SurveyToolErrorTerm, len(input.ErrorTerms))
+	for i, term := range input.ErrorTerms {
+		terms[i] = &entities.SurveyToolErrorTerm{
+			Name:        term.Name,
+			Weighting:   term.Weighting,
+			Propagation: term.Propagation,
+			Magnitude:   term.Magnitude,
+		}
+		if err := wellpath.ValidateErrorTerm(toErrorTerm(terms[i])); err != nil {
+			return nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidErrorTerm, err)
+		}
+	}
+
+	return &entities.SurveyTool{
+		Name:        input.Name,
+		Description: input.Description,
+		ErrorTerms:  terms,
+	}, nil
+}
+
+func toErrorTerm(term *entities.SurveyToolErrorTerm) wellpath.ErrorTerm {
+	return wellpath.ErrorTerm{
+		Name:        term.Name,
+		Weighting:   term.Weighting,
+		Propagation: term.Propagation,
+		Magnitude:   term.Magnitude,
+	}
+}
@@ -2,102 +2,147 @@ package service
 
 import (
 	"context"
+	"math"
 	"sort"
+	"sync"
+	"sync/atomic"
 
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	client "github.com/munaiplan/munaiplan-backend/internal/infrastructure/prediction_client"
 )
 
+const (
+	// maxFrictionSensitivityRuns limits the open hole and cased hole friction factor combinations of a sweep
+	maxFrictionSensitivityRuns = 100
+	// frictionSensitivityWorkers is the number of combinations calculated at the same time
+	frictionSensitivityWorkers = 4
+)
+
 type torqueAndDragService struct {
-	commonRepo repository.CommonRepository
-	repo       repository.StringsRepository
-	client     client.TorqueAndDragClient
+	commonRepo  repository.CommonRepository
+	repo        repository.StringsRepository
+	holesRepo   repository.HolesRepository
+	casesRepo   repository.CasesRepository
+	resultsRepo repository.CalculationResultsRepository
+	client      client.TorqueAndDragClient
 }
 
-func NewTorqueAndDragService(repo repository.StringsRepository, commonRepo repository.CommonRepository, client client.TorqueAndDragClient) *torqueAndDragService {
+func NewTorqueAndDragService(repo repository.StringsRepository, holesRepo repository.HolesRepository, casesRepo repository.CasesRepository, resultsRepo repository.CalculationResultsRepository, commonRepo repository.CommonRepository, client client.TorqueAndDragClient) *torqueAndDragService {
 	return &torqueAndDragService{
-		repo:       repo,
-		commonRepo: commonRepo,
-		client:     client,
+		repo:        repo,
+		holesRepo:   holesRepo,
+		casesRepo:   casesRepo,
+		resultsRepo: resultsRepo,
+		commonRepo:  commonRepo,
+		client:      client,
 	}
 }
 
 // CalculateEffectiveTensionFromMLModel calculates effective tension using ML model
-func (s *torqueAndDragService) CalculateEffectiveTensionFromMLModel(ctx context.Context, caseID string) (*responses.EffectiveTensionFromMLModelResponse, error) {
-	mappedData, err := s.getMappedRequestForCase(ctx, caseID)
-	if err != nil {
-		return nil, err
-	}
+func (s *torqueAndDragService) CalculateEffectiveTensionFromMLModel(ctx context.Context, organizationID string, caseID string) (*responses.EffectiveTensionFromMLModelResponse, error) {
+	var response *responses.EffectiveTensionFromMLModelResponse
+	err := s.calculateStored(ctx, organizationID, caseID, entities.CalculationTypeEffectiveTension, nil, &response, func() error {
+		mappedData, err := s.getMappedRequestForCase(ctx, organizationID, caseID)
+		if err != nil {
+			return err
+		}
+
+		// Call the external client with the mapped data
+		if response, err = s.client.CalculateEffectiveTension(ctx, *mappedData); err != nil {
+			return err
+		}
 
-	// Call the external client with the mapped data
-	response, err := s.client.CalculateEffectiveTension(*mappedData)
+		response.Depth = mappedData.MD
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	response.Depth = mappedData.MD
-
 	return response, nil
 }
 
 // CalculateWeightOnBitFromMlModel calculates weight on bit using ML model
-func (s *torqueAndDragService) CalculateWeightOnBitFromMlModel(ctx context.Context, caseID string) (*responses.WeightOnBitFromMLModelResponse, error) {
-	mappedData, err := s.getMappedRequestForCase(ctx, caseID)
-	if err != nil {
-		return nil, err
-	}
+func (s *torqueAndDragService) CalculateWeightOnBitFromMlModel(ctx context.Context, organizationID string, caseID string) (*responses.WeightOnBitFromMLModelResponse, error) {
+	var response *responses.WeightOnBitFromMLModelResponse
+	err := s.calculateStored(ctx, organizationID, caseID, entities.CalculationTypeWeightOnBit, nil, &response, func() error {
+		mappedData, err := s.getMappedRequestForCase(ctx, organizationID, caseID)
+		if err != nil {
+			return err
+		}
 
-	// Call the external client with the mapped data
-	response, err := s.client.CalculateWeightOnBit(*mappedData)
+		// Call the external client with the mapped data
+		if response, err = s.client.CalculateWeightOnBit(ctx, *mappedData); err != nil {
+			return err
+		}
+
+		response.Depth = mappedData.MD
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	response.Depth = mappedData.MD
-
 	return response, nil
 }
 
 // CalculateSurfaceTorqueFromMlModel calculates surface torque using ML model
-func (s *torqueAndDragService) CalculateSurfaceTorqueFromMlModel(ctx context.Context, caseID string) (*responses.MomentFromMLModelResponse, error) {
-	mappedData, err := s.getMappedRequestForCase(ctx, caseID)
-	if err != nil {
-		return nil, err
-	}
+func (s *torqueAndDragService) CalculateSurfaceTorqueFromMlModel(ctx context.Context, organizationID string, caseID string) (*responses.MomentFromMLModelResponse, error) {
+	var response *responses.MomentFromMLModelResponse
+	err := s.calculateStored(ctx, organizationID, caseID, entities.CalculationTypeSurfaceTorque, nil, &response, func() error {
+		mappedData, err := s.getMappedRequestForCase(ctx, organizationID, caseID)
+		if err != nil {
+			return err
+		}
+
+		// Call the external client with the mapped data
+		if response, err = s.client.CalculateMoment(ctx, *mappedData); err != nil {
+			return err
+		}
 
-	// Call the external client with the mapped data
-	response, err := s.client.CalculateMoment(*mappedData)
+		response.Depth = mappedData.MD
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	response.Depth = mappedData.MD
-
 	return response, nil
 }
 
 // CalculateMinWeightFromMlModel calculates minimum weight using ML model
-func (s *torqueAndDragService) CalculateMinWeightFromMLModel(ctx context.Context, caseID string) (*responses.MinWeightFromMLModelResponse, error) {
-	mappedData, err := s.getMappedRequestForCase(ctx, caseID)
-	if err != nil {
-		return nil, err
-	}
+func (s *torqueAndDragService) CalculateMinWeightFromMLModel(ctx context.Context, organizationID string, caseID string) (*responses.MinWeightFromMLModelResponse, error) {
+	var response *responses.MinWeightFromMLModelResponse
+	err := s.calculateStored(ctx, organizationID, caseID, entities.CalculationTypeMinWeightOnBit, nil, &response, func() error {
+		mappedData, err := s.getMappedRequestForCase(ctx, organizationID, caseID)
+		if err != nil {
+			return err
+		}
 
-	// Call the external client with the mapped data
-	response, err := s.client.CalculateMinWeight(*mappedData)
+		// Call the external client with the mapped data
+		if response, err = s.client.CalculateMinWeight(ctx, *mappedData); err != nil {
+			return err
+		}
+
+		response.Depth = mappedData.MD
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	response.Depth = mappedData.MD
-
 	return response, nil
 }
 
-func (s *torqueAndDragService) getMappedRequestForCase(ctx context.Context, caseID string) (*requests.TorqueAndDragFromMLModelRequest, error) {
+func (s *torqueAndDragService) getMappedRequestForCase(ctx context.Context, organizationID string, caseID string) (*requests.TorqueAndDragFromMLModelRequest, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, organizationID, entities.ScopeCase, caseID); err != nil {
+		return nil, err
+	}
+
 	// Fetch trajectory data by case ID
 	trajectory, err := s.commonRepo.GetTrajectoryByCaseID(ctx, caseID)
 	if err != nil {
@@ -109,6 +154,10 @@ func (s *torqueAndDragService) getMappedRequestForCase(ctx context.Context, case
 	if err != nil {
 		return nil, err
 	}
+	// The model is sent the first string only
+	if len(stringData) == 0 || !stringReadyForTorqueAndDrag(stringData[0]) {
+		return nil, domainErrors.ErrCaseNotReadyForTorqueAndDrag
+	}
 
 	// Map trajectory and string data to prepare for the client request
 	mappedData := s.mapTrajectoryToStringSections(trajectory, stringData[0])
@@ -116,6 +165,34 @@ func (s *torqueAndDragService) getMappedRequestForCase(ctx context.Context, case
 	return &mappedData, nil
 }
 
+// stringReadyForTorqueAndDrag reports whether the string has sections with every value the torque and drag model needs.
+func stringReadyForTorqueAndDrag(str *entities.String) bool {
+	if len(str.Sections) == 0 {
+		return false
+	}
+	for _, section := range str.Sections {
+		if section.AvgJointLength == nil || section.StabilizerLength == nil || section.StabilizerOD == nil ||
+			section.StabilizerID == nil || section.Weight == nil || section.FrictionCoefficient == nil || section.MinYieldStrength == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// torqueAndDragReady reports whether the case has strings and all of them are ready for the torque and drag model.
+// A single calculation only uses the first string, see getMappedRequestForCase.
+func torqueAndDragReady(caseEntity *entities.Case) bool {
+	if len(caseEntity.Strings) == 0 {
+		return false
+	}
+	for _, str := range caseEntity.Strings {
+		if !stringReadyForTorqueAndDrag(str) {
+			return false
+		}
+	}
+	return true
+}
+
 // MapTrajectoryToStringSections maps data from String sections to Trajectory units based on MD depth.
 func (s *torqueAndDragService) mapTrajectoryToStringSections(trajectory *entities.Trajectory, stringData *entities.String) requests.TorqueAndDragFromMLModelRequest {
 	var result requests.TorqueAndDragFromMLModelRequest
@@ -185,3 +262,190 @@ func (s *torqueAndDragService) mapTrajectoryToStringSections(trajectory *entitie
 
 	return result
 }
+
+// CalculateFrictionSensitivity runs the torque and drag model for every combination of the open hole and
+// cased hole friction factor ranges. Depths down to the casing shoe use the cased hole factor, deeper depths
+// the open hole factor. The result is the family of trip in, trip out and rotating curves of a broomstick chart.
+// Stored results of the same ranges are served while the case is unchanged.
+func (s *torqueAndDragService) CalculateFrictionSensitivity(ctx context.Context, input *requests.FrictionSensitivityRequest) (*responses.FrictionSensitivityResponse, error) {
+	if frictionFactorCount(input.Body.OpenHole)*frictionFactorCount(input.Body.CasedHole) > maxFrictionSensitivityRuns {
+		return nil, domainErrors.ErrTooManySensitivityRuns
+	}
+	openHole := frictionFactors(input.Body.OpenHole)
+	casedHole := frictionFactors(input.Body.CasedHole)
+
+	var res *responses.FrictionSensitivityResponse
+	err := s.calculateStored(ctx, input.OrganizationID, input.CaseID, entities.CalculationTypeFrictionSensitivity, &input.Body, &res, func() error {
+		mappedData, err := s.getMappedRequestForCase(ctx, input.OrganizationID, input.CaseID)
+		if err != nil {
+			return err
+		}
+		holes, err := s.holesRepo.GetHoles(ctx, input.CaseID)
+		if err != nil {
+			return err
+		}
+
+		res = &responses.FrictionSensitivityResponse{
+			CaseID:          input.CaseID,
+			Depth:           mappedData.MD,
+			CasingShoeDepth: casingShoeDepth(holes),
+		}
+		for _, cased := range casedHole {
+			for _, open := range openHole {
+				res.Curves = append(res.Curves, &responses.FrictionSensitivityCurveResponse{
+					OpenHoleFrictionFactor:  open,
+					CasedHoleFrictionFactor: cased,
+				})
+			}
+		}
+
+		return s.calculateFrictionSensitivityCurves(ctx, *mappedData, res.CasingShoeDepth, res.Curves)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CalculateFrictionCurves runs the torque and drag model of the case once per friction factor, with the same
+// factor in cased and open hole.
+func (s *torqueAndDragService) CalculateFrictionCurves(ctx context.Context, organizationID string, caseID string, factors []float64) (*responses.FrictionSensitivityResponse, error) {
+	var res *responses.FrictionSensitivityResponse
+	err := s.calculateStored(ctx, organizationID, caseID, entities.CalculationTypeFrictionCurves, factors, &res, func() error {
+		mappedData, err := s.getMappedRequestForCase(ctx, organizationID, caseID)
+		if err != nil {
+			return err
+		}
+
+		res = &responses.FrictionSensitivityResponse{
+			CaseID: caseID,
+			Depth:  mappedData.MD,
+		}
+		for _, factor := range factors {
+			res.Curves = append(res.Curves, &responses.FrictionSensitivityCurveResponse{
+				OpenHoleFrictionFactor:  factor,
+				CasedHoleFrictionFactor: factor,
+			})
+		}
+
+		return s.calculateFrictionSensitivityCurves(ctx, *mappedData, nil, res.Curves)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// calculateFrictionSensitivityCurves calculates the curves with frictionSensitivityWorkers workers
+// and returns the first error. The remaining curves are skipped once ctx is done. Every finished curve is
+// reported as progress.
+func (s *torqueAndDragService) calculateFrictionSensitivityCurves(ctx context.Context, data requests.TorqueAndDragFromMLModelRequest, casingShoeDepth *float64, curves []*responses.FrictionSensitivityCurveResponse) error {
+	queue := make(chan *responses.FrictionSensitivityCurveResponse)
+	errs := make([]error, frictionSensitivityWorkers)
+	var finished atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < frictionSensitivityWorkers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for curve := range queue {
+				if errs[worker] != nil {
+					continue
+				}
+				if errs[worker] = ctx.Err(); errs[worker] != nil {
+					continue
+				}
+				if errs[worker] = s.calculateFrictionSensitivityCurve(ctx, data, casingShoeDepth, curve); errs[worker] == nil {
+					reportProgress(ctx, int(finished.Add(1)), len(curves), curve)
+				}
+			}
+		}(i)
+	}
+	for _, curve := range curves {
+		queue <- curve
+	}
+	close(queue)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// calculateFrictionSensitivityCurve calculates the curves of one friction factor combination.
+// The model has no separate rotating off bottom operation, its rotary drilling results are used for rotating.
+func (s *torqueAndDragService) calculateFrictionSensitivityCurve(ctx context.Context, data requests.TorqueAndDragFromMLModelRequest, casingShoeDepth *float64, curve *responses.FrictionSensitivityCurveResponse) error {
+	data.CoefficientOfFriction = make([]float64, len(data.MD))
+	for i, md := range data.MD {
+		data.CoefficientOfFriction[i] = curve.OpenHoleFrictionFactor
+		if casingShoeDepth != nil && md <= *casingShoeDepth {
+			data.CoefficientOfFriction[i] = curve.CasedHoleFrictionFactor
+		}
+	}
+
+	tension, err := s.client.CalculateEffectiveTension(ctx, data)
+	if err != nil {
+		return err
+	}
+	moment, err := s.client.CalculateMoment(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	curve.HookLoadTripIn = tension.RunIn
+	curve.HookLoadTripOut = tension.PullUp
+	curve.HookLoadRotating = tension.RotaryDrilling
+	curve.TorqueRotating = moment.RotaryDrilling
+	return nil
+}
+
+// frictionFactorCount returns the number of friction factors of the range without listing them. Counts above
+// maxFrictionSensitivityRuns are capped, so a tiny step neither overflows nor allocates. Check it before
+// frictionFactors.
+func frictionFactorCount(factorsRange requests.FrictionFactorRange) int {
+	if factorsRange.Step == 0 {
+		return 1
+	}
+
+	count := math.Floor((factorsRange.To-factorsRange.From)/factorsRange.Step+1e-9) + 1
+	if count > maxFrictionSensitivityRuns {
+		return maxFrictionSensitivityRuns + 1
+	}
+	return int(count)
+}
+
+// frictionFactors returns the friction factors of the range, the upper bound is included. The count of the
+// range must have been checked with frictionFactorCount.
+func frictionFactors(factorsRange requests.FrictionFactorRange) []float64 {
+	count := frictionFactorCount(factorsRange)
+	if factorsRange.Step == 0 {
+		return []float64{factorsRange.From}
+	}
+
+	res := make([]float64, 0, count)
+	for i := 0; i < count; i++ {
+		// Rounding keeps 0.1 + 2 * 0.05 from becoming 0.20000000000000004
+		res = append(res, math.Round((factorsRange.From+float64(i)*factorsRange.Step)*1e6)/1e6)
+	}
+	return res
+}
+
+// casingShoeDepth returns the deepest casing base of the holes, nil means the whole well is open hole.
+func casingShoeDepth(holes []*entities.Hole) *float64 {
+	var res *float64
+	for _, hole := range holes {
+		for _, caising := range hole.Caisings {
+			depth := caising.MDBase
+			if caising.ShoeMD != nil {
+				depth = *caising.ShoeMD
+			}
+			if res == nil || depth > *res {
+				res = &depth
+			}
+		}
+	}
+	return res
+}
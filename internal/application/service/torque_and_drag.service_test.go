@@ -0,0 +1,40 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+)
+
+func TestFrictionFactorCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		factor requests.FrictionFactorRange
+		want   int
+	}{
+		{"no step", requests.FrictionFactorRange{From: 0.2, To: 0.4}, 1},
+		{"upper bound included", requests.FrictionFactorRange{From: 0.1, To: 0.4, Step: 0.05}, 7},
+		{"single factor", requests.FrictionFactorRange{From: 0.3, To: 0.3, Step: 0.1}, 1},
+		{"tiny step is capped", requests.FrictionFactorRange{From: 0, To: 1, Step: 1e-12}, maxFrictionSensitivityRuns + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := frictionFactorCount(tt.factor); got != tt.want {
+				t.Errorf("frictionFactorCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrictionFactors(t *testing.T) {
+	got := frictionFactors(requests.FrictionFactorRange{From: 0.1, To: 0.3, Step: 0.05})
+	want := []float64{0.1, 0.15, 0.2, 0.25, 0.3}
+	if len(got) != len(want) {
+		t.Fatalf("frictionFactors() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("frictionFactors()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
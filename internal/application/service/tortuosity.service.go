@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	"github.com/munaiplan/munaiplan-backend/pkg/wellpath"
+)
+
+const (
+	defaultMicroTortuosityWindow = 30.0
+	defaultStringWeightPerLength = 0.25 // kN/m, 5" drill pipe in 1.2 s.g. mud
+	courseLength30m              = 30.0
+	courseLength100ft            = 30.48
+)
+
+type tortuosityService struct {
+	commonRepo repository.CommonRepository
+	repo       repository.TrajectoriesRepository
+}
+
+func NewTortuosityService(repo repository.TrajectoriesRepository, commonRepo repository.CommonRepository) *tortuosityService {
+	return &tortuosityService{
+		repo:       repo,
+		commonRepo: commonRepo,
+	}
+}
+
+// GetTortuosityReport analyses dogleg severity and tortuosity along the trajectory. MD is expected in meters.
+func (s *tortuosityService) GetTortuosityReport(ctx context.Context, input *requests.TortuosityReportRequest) (*responses.TortuosityReportResponse, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.TrajectoryID); err != nil {
+		return nil, err
+	}
+
+	window := input.Body.MicroTortuosityWindow
+	if window <= 0 {
+		window = defaultMicroTortuosityWindow
+	}
+	weight := input.Body.StringWeightPerLength
+	if weight <= 0 {
+		weight = defaultStringWeightPerLength
+	}
+
+	trajectory, err := s.repo.GetTrajectoryByID(ctx, input.TrajectoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	stations := trajectoryToStations(trajectory)
+	dls30m := wellpath.DoglegSeverities(stations, courseLength30m)
+	dls100ft := wellpath.DoglegSeverities(stations, courseLength100ft)
+	cumulative := wellpath.CumulativeTortuosity(stations)
+	micro := wellpath.MicroTortuosity(stations, window, courseLength30m)
+
+	response := &responses.TortuosityReportResponse{
+		TrajectoryID:          input.TrajectoryID,
+		MicroTortuosityWindow: window,
+		Exceedances:           s.findExceedances(stations, dls30m, &input.Body),
+		Stations:              make([]responses.TortuosityStationResponse, len(stations)),
+	}
+	if len(stations) == 0 {
+		return response, nil
+	}
+
+	bottomTVD := stations[len(stations)-1].TVD
+	for i, station := range stations {
+		// Tension of a frictionless hanging string, the excess curvature over the window pushes it into the wall
+		tension := weight * math.Max(bottomTVD-station.TVD, 0)
+		sideForce := tension * micro[i] * math.Pi / 180 / courseLength30m
+
+		response.Stations[i] = responses.TortuosityStationResponse{
+			MD:                      station.MD,
+			Incl:                    station.Incl,
+			Azim:                    station.Azim,
+			TVD:                     station.TVD,
+			DLS30m:                  dls30m[i],
+			DLS100ft:                dls100ft[i],
+			CumulativeTortuosity:    cumulative[i],
+			MicroTortuosityIndex:    micro[i],
+			ExtraSideForcePerLength: sideForce,
+		}
+
+		if dls30m[i] > response.MaxDLS30m {
+			response.MaxDLS30m = dls30m[i]
+			response.MaxDLSMD = station.MD
+		}
+		response.MaxMicroTortuosityIndex = math.Max(response.MaxMicroTortuosityIndex, micro[i])
+		response.MaxExtraSideForcePerLength = math.Max(response.MaxExtraSideForcePerLength, sideForce)
+		if i > 0 {
+			prev := response.Stations[i-1]
+			response.TotalExtraSideForce += (prev.ExtraSideForcePerLength + sideForce) / 2 * (station.MD - prev.MD)
+		}
+	}
+
+	response.TotalTortuosity = cumulative[len(cumulative)-1]
+	if length := stations[len(stations)-1].MD - stations[0].MD; length > 0 {
+		response.AverageDLS30m = response.TotalTortuosity * courseLength30m / length
+	}
+
+	return response, nil
+}
+
+// findExceedances merges consecutive legs whose dogleg severity exceeds the limit of their hole section.
+func (s *tortuosityService) findExceedances(stations []wellpath.Station, dls30m []float64, body *requests.TortuosityReportRequestBody) []responses.DLSExceedanceResponse {
+	exceedances := make([]responses.DLSExceedanceResponse, 0)
+	var current *responses.DLSExceedanceResponse
+
+	for i := 1; i < len(stations); i++ {
+		name, limit := s.dlsLimit((stations[i-1].MD+stations[i].MD)/2, body)
+		if limit <= 0 || dls30m[i] <= limit {
+			current = nil
+			continue
+		}
+
+		if current != nil && current.Section == name && current.MDTo == stations[i-1].MD {
+			current.MDTo = stations[i].MD
+			current.MaxDLS = math.Max(current.MaxDLS, dls30m[i])
+			continue
+		}
+
+		exceedances = append(exceedances, responses.DLSExceedanceResponse{
+			Section: name,
+			MDFrom:  stations[i-1].MD,
+			MDTo:    stations[i].MD,
+			MaxDLS:  dls30m[i],
+			Limit:   limit,
+		})
+		current = &exceedances[len(exceedances)-1]
+	}
+
+	return exceedances
+}
+
+// dlsLimit returns the hole section covering md and its dogleg severity limit.
+func (s *tortuosityService) dlsLimit(md float64, body *requests.TortuosityReportRequestBody) (string, float64) {
+	for _, section := range body.Sections {
+		if md >= section.MDFrom && md <= section.MDTo {
+			return section.Name, section.MaxDLS
+		}
+	}
+	return "", body.DefaultMaxDLS
+}
+
+// ApplySyntheticTortuosity saves a copy of the trajectory with synthetic tortuosity in the same design,
+// so that cases can run T&D against a realistic well path.
+func (s *tortuosityService) ApplySyntheticTortuosity(ctx context.Context, input *requests.SyntheticTortuosityRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.TrajectoryID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeTrajectory, input.TrajectoryID); err != nil {
+		return err
+	}
+
+	trajectory, err := s.repo.GetTrajectoryByID(ctx, input.TrajectoryID)
+	if err != nil {
+		return err
+	}
+	designID, err := s.commonRepo.GetDesignIDByTrajectoryID(ctx, input.TrajectoryID)
+	if err != nil {
+		return err
+	}
+	well, err := s.commonRepo.GetWellByTrajectoryID(ctx, input.TrajectoryID)
+	if err != nil {
+		return err
+	}
+
+	// Difference between TVD and sub-sea depth is the elevation of the depth reference
+	var datum float64
+	topMD := math.Inf(1)
+	stations := make([]wellpath.Station, len(trajectory.Units))
+	for i, unit := range trajectory.Units {
+		if unit.MD < topMD {
+			topMD, datum = unit.MD, unit.TVD-unit.SubSea
+		}
+		stations[i] = wellpath.Station{
+			MD:    unit.MD,
+			Incl:  unit.Incl,
+			Azim:  unit.Azim,
+			North: unit.LocalNCoord,
+			East:  unit.LocalECoord,
+			TVD:   unit.TVD,
+		}
+	}
+	wellpath.SortByMD(stations)
+
+	tortuous, err := wellpath.ApplyTortuosity(stations, wellpath.Tortuosity{
+		Mode:      input.Body.Mode,
+		Amplitude: input.Body.Amplitude,
+		Period:    input.Body.Period,
+		Step:      input.Body.Step,
+		FromMD:    input.Body.MDFrom,
+		ToMD:      input.Body.MDTo,
+		Seed:      input.Body.Seed,
+	})
+	if err != nil {
+		return err
+	}
+
+	name := input.Body.Name
+	if name == "" {
+		name = trajectory.Name + " (" + input.Body.Mode + " tortuosity)"
+	}
+
+	copied := &entities.Trajectory{
+		Name:        name,
+		Description: trajectory.Description,
+		Units:       tortuousUnits(stations, tortuous, datum),
+	}
+	for _, header := range trajectory.Headers {
+		h := *header
+		h.ID = ""
+		copied.Headers = append(copied.Headers, &h)
+	}
+	for _, interval := range trajectory.SurveyProgram {
+		copied.SurveyProgram = append(copied.SurveyProgram, &entities.SurveyProgramInterval{
+			MDFrom:       interval.MDFrom,
+			MDTo:         interval.MDTo,
+			SurveyToolID: interval.SurveyToolID,
+		})
+	}
+	applyGlobalCoordinates(well, copied.Units)
+
+	return s.repo.CreateTrajectory(ctx, designID, copied)
+}
+
+// tortuousUnits converts the perturbed stations to trajectory units keeping the sub-sea datum and the
+// vertical section azimuth (closure direction at total depth) of the original path.
+func tortuousUnits(original, tortuous []wellpath.Station, datum float64) []*entities.TrajectoryUnit {
+	last := original[len(original)-1]
+	sectionAzimuth := math.Atan2(last.East-original[0].East, last.North-original[0].North)
+
+	units := make([]*entities.TrajectoryUnit, len(tortuous))
+	for i, station := range tortuous {
+		units[i] = &entities.TrajectoryUnit{
+			MD:          station.MD,
+			Incl:        station.Incl,
+			Azim:        station.Azim,
+			TVD:         station.TVD,
+			SubSea:      station.TVD - datum,
+			LocalNCoord: station.North,
+			LocalECoord: station.East,
+			VerticalSection: (station.North-tortuous[0].North)*math.Cos(sectionAzimuth) +
+				(station.East-tortuous[0].East)*math.Sin(sectionAzimuth),
+		}
+		if i > 0 {
+			units[i].Dogleg = wellpath.DoglegSeverity(tortuous[i-1], station, courseLength30m)
+		}
+	}
+	return units
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
@@ -21,7 +23,7 @@ func NewTrajectoriesService(repo repository.TrajectoriesRepository, commonRepo r
 }
 
 func (s *trajectoriesService) GetTrajectories(ctx context.Context, input *requests.GetTrajectoriesRequest) ([]*entities.Trajectory, error) {
-	if err := s.commonRepo.CheckIfDesignExists(ctx, input.DesignID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.DesignID); err != nil {
 		return nil, err
 	}
 
@@ -29,25 +31,66 @@ func (s *trajectoriesService) GetTrajectories(ctx context.Context, input *reques
 }
 
 func (s *trajectoriesService) GetTrajectoryByID(ctx context.Context, input *requests.GetTrajectoryByIDRequest) (*entities.Trajectory, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetTrajectoryByID(ctx, input.ID)
 }
 
 func (s *trajectoriesService) CreateTrajectory(ctx context.Context, input *requests.CreateTrajectoryRequest) error {
-	if err := s.commonRepo.CheckIfDesignExists(ctx, input.DesignID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.DesignID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeDesign, input.DesignID); err != nil {
 		return err
 	}
 
 	trajectory := s.CreateTrajectoryRequestToEntity(&input.Body)
+	if err := s.validateSurveyProgram(ctx, trajectory.SurveyProgram); err != nil {
+		return err
+	}
+
+	well, err := s.commonRepo.GetWellByDesignID(ctx, input.DesignID)
+	if err != nil {
+		return err
+	}
+	applyGlobalCoordinates(well, trajectory.Units)
+
 	return s.repo.CreateTrajectory(ctx, input.DesignID, trajectory)
 }
 
 func (s *trajectoriesService) UpdateTrajectory(ctx context.Context, input *requests.UpdateTrajectoryRequest) (*entities.Trajectory, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.ID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeTrajectory, input.ID); err != nil {
+		return nil, err
+	}
+
 	trajectory := s.UpdateTrajectoryRequestToEntity(&input.Body)
 	trajectory.ID = input.ID
+	if err := s.validateSurveyProgram(ctx, trajectory.SurveyProgram); err != nil {
+		return nil, err
+	}
+
+	well, err := s.commonRepo.GetWellByTrajectoryID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	applyGlobalCoordinates(well, trajectory.Units)
+
 	return s.repo.UpdateTrajectory(ctx, trajectory)
 }
 
 func (s *trajectoriesService) DeleteTrajectory(ctx context.Context, input *requests.DeleteTrajectoryRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.ID); err != nil {
+		return err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeTrajectory, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteTrajectory(ctx, input.ID)
 }
 
@@ -86,10 +129,11 @@ func (s *trajectoriesService) CreateTrajectoryRequestToEntity(input *requests.Cr
 	}
 
 	trajectory := &entities.Trajectory{
-		Name:        input.Name,
-		Description: input.Description,
-		Headers:     headers,
-		Units:       units,
+		Name:          input.Name,
+		Description:   input.Description,
+		Headers:       headers,
+		Units:         units,
+		SurveyProgram: toSurveyProgramEntity(input.SurveyProgram),
 	}
 
 	return trajectory
@@ -136,11 +180,49 @@ func (s *trajectoriesService) UpdateTrajectoryRequestToEntity(input *requests.Up
 	}
 
 	trajectory := &entities.Trajectory{
-		Name:        input.Name,
-		Description: input.Description,
-		Headers:     headers,
-		Units:       units,
+		Name:          input.Name,
+		Description:   input.Description,
+		Headers:       headers,
+		Units:         units,
+		SurveyProgram: toSurveyProgramEntity(input.SurveyProgram),
 	}
 
 	return trajectory
 }
+
+// validateSurveyProgram checks that the intervals are ordered, do not overlap and reference existing tools.
+func (s *trajectoriesService) validateSurveyProgram(ctx context.Context, program []*entities.SurveyProgramInterval) error {
+	for i, interval := range program {
+		if interval.MDTo <= interval.MDFrom {
+			return fmt.Errorf("survey program interval %.2f-%.2f is empty", interval.MDFrom, interval.MDTo)
+		}
+		if i > 0 && interval.MDFrom < program[i-1].MDTo {
+			return fmt.Errorf("survey program interval %.2f-%.2f overlaps the previous interval", interval.MDFrom, interval.MDTo)
+		}
+		if err := s.commonRepo.CheckIfSurveyToolExists(ctx, interval.SurveyToolID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toSurveyProgramEntity maps the survey program of a request. A nil program stays nil.
+func toSurveyProgramEntity(input []requests.SurveyProgramIntervalRequestBody) []*entities.SurveyProgramInterval {
+	if input == nil {
+		return nil
+	}
+
+	program := make([]*entities.SurveyProgramInterval, len(input))
+	for i, interval := range input {
+		program[i] = &entities.SurveyProgramInterval{
+			MDFrom:       interval.MDFrom,
+			MDTo:         interval.MDTo,
+			SurveyToolID: interval.SurveyToolID,
+		}
+	}
+
+	sort.Slice(program, func(i, j int) bool {
+		return program[i].MDFrom < program[j].MDFrom
+	})
+	return program
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+)
+
+type unitsService struct {
+	commonRepo repository.CommonRepository
+}
+
+func NewUnitsService(commonRepo repository.CommonRepository) *unitsService {
+	return &unitsService{
+		commonRepo: commonRepo,
+	}
+}
+
+func (s *unitsService) GetUnitSystems(ctx context.Context) []*responses.UnitSystemResponse {
+	systems := units.Systems()
+	res := make([]*responses.UnitSystemResponse, len(systems))
+	for i, system := range systems {
+		res[i] = &responses.UnitSystemResponse{
+			Name:  system.Name,
+			Units: make(map[string]string, len(system.Units)),
+		}
+		for quantity, unit := range system.Units {
+			res[i].Units[string(quantity)] = unit.Symbol
+		}
+	}
+	return res
+}
+
+// ResolveUnitSystem returns the active unit system of the well the entity belongs to, falling back to
+// the unit system of the field. Entities without a unit system use canonical units.
+func (s *unitsService) ResolveUnitSystem(ctx context.Context, scope string, id string) (*units.System, error) {
+	wellUnit, fieldUnit, err := s.commonRepo.GetActiveUnits(ctx, scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := wellUnit
+	if name == "" {
+		name = fieldUnit
+	}
+	if name == "" {
+		return units.Canonical, nil
+	}
+
+	system, err := units.Lookup(name)
+	if err != nil {
+		// Values stored before unit systems were validated
+		return units.Canonical, nil
+	}
+	return system, nil
+}
+
+// validateUnitSystem checks the name of an active unit system, empty means canonical units.
+func validateUnitSystem(name string) error {
+	if name == "" {
+		return nil
+	}
+	_, err := units.Lookup(name)
+	return err
+}
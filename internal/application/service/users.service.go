@@ -2,87 +2,303 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
-	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/email"
 	"github.com/sirupsen/logrus"
 )
 
 const (
 	BEARER_TOKEN_TYPE = "Bearer"
+
+	passwordResetLifetime = time.Hour
 )
 
 type usersService struct {
-	repo repository.UsersRepository
-	commonRepo repository.CommonRepository
-	jwt  helpers.Jwt
+	repo            repository.UsersRepository
+	tokensRepo      repository.TokensRepository
+	invitationsRepo repository.InvitationsRepository
+	commonRepo      repository.CommonRepository
+	jwt             helpers.Jwt
+	emailSender     email.Sender
+	appUrl          string
 }
 
-func NewUsersService(repo repository.UsersRepository, commonRepo repository.CommonRepository, jwt helpers.Jwt) *usersService {
+func NewUsersService(
+	repo repository.UsersRepository,
+	tokensRepo repository.TokensRepository,
+	invitationsRepo repository.InvitationsRepository,
+	commonRepo repository.CommonRepository,
+	jwt helpers.Jwt,
+	emailSender email.Sender,
+	appUrl string,
+) *usersService {
 	return &usersService{
-		repo: repo,
-		commonRepo: commonRepo,
-		jwt:  jwt,
+		repo:            repo,
+		tokensRepo:      tokensRepo,
+		invitationsRepo: invitationsRepo,
+		commonRepo:      commonRepo,
+		jwt:             jwt,
+		emailSender:     emailSender,
+		appUrl:          appUrl,
 	}
 }
 
+// SignUp creates the user of an invitation. The email, the organization and the role come from the invitation.
+// The user, its role and the acceptance of the invitation are saved together.
 func (s *usersService) SignUp(ctx context.Context, input *requests.UserSignUpRequest) error {
-	if err := s.commonRepo.CheckIfUserExistsByEmail(ctx, input.Body.Email); err == nil {
+	invitation, err := s.invitationsRepo.GetInvitationByTokenHash(ctx, helpers.HashToken(input.Body.InviteToken))
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrResourceNotFound) {
+			return domainErrors.ErrInvalidInvitation
+		}
+		return err
+	}
+	if !invitation.Pending(time.Now()) {
+		return domainErrors.ErrInvalidInvitation
+	}
+
+	emailAddress := helpers.NormalizeEmail(invitation.Email)
+	if err := s.commonRepo.CheckIfUserExistsByEmail(ctx, emailAddress); err == nil {
 		return domainErrors.ErrUserAlreadyExists
 	}
 
 	// Hash the password
 	hashedPassword, err := helpers.HashPassword(input.Body.Password)
 	if err != nil {
-		logrus.Errorf("Error hashing password: %s", err)
-		return nil
+		return fmt.Errorf("error hashing password: %w", err)
 	}
 
-	// Create the user
 	user := entities.User{
-		Email:    input.Body.Email,
+		Email:    emailAddress,
 		Password: hashedPassword,
 		Name:     input.Body.Name,
 		Surname:  input.Body.Surname,
 		Phone:    input.Body.Phone,
-		OrganizationID: input.OrganizationID,
 	}
-
-	// Save the user to the repository
-	err = s.repo.Create(ctx, input.OrganizationID, &user)
-	if err != nil {
+	if err = s.invitationsRepo.AcceptInvitation(ctx, invitation.ID, &user); err != nil {
 		logrus.Errorf("Error creating user: %s", err)
 		return err
 	}
-
 	return nil
 }
+
 func (s *usersService) SignIn(ctx context.Context, input *requests.UserSignInRequest) (*responses.TokenResponse, error) {
-	if err := s.commonRepo.CheckIfUserExistsByEmail(ctx, input.Email); err != nil {
+	user, err := s.repo.GetByEmail(ctx, helpers.NormalizeEmail(input.Email))
+	if errors.Is(err, domainErrors.ErrUserNotFound) {
+		return nil, domainErrors.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !helpers.CheckPasswordHash(input.Password, user.Password) {
+		return nil, domainErrors.ErrInvalidCredentials
+	}
+
+	// Every sign in starts a new session with its own refresh token family
+	sessionID, tokenID := uuid.NewString(), uuid.NewString()
+	token, err := s.jwt.CreateAccessToken(helpers.UserAccessTokenClaims{
+		UserId:         user.ID,
+		OrganizationId: user.OrganizationID,
+		SessionId:      sessionID,
+		RefreshTokenId: tokenID,
+	})
+	if err != nil {
+		logrus.Errorf("Error creating access token: %s", err)
 		return nil, err
 	}
+	family := entities.TokenFamily{
+		ID:             sessionID,
+		UserID:         user.ID,
+		OrganizationID: user.OrganizationID,
+		CurrentTokenID: tokenID,
+		ExpiresAt:      time.Unix(token.RefreshTokenExpiresAt, 0),
+	}
+	if err = s.tokensRepo.CreateTokenFamily(ctx, &family); err != nil {
+		logrus.Errorf("Error creating token family: %s", err)
+		return nil, err
+	}
+	return newTokenResponse(token), nil
+}
 
-	user, err := s.repo.GetByEmail(ctx, input.Email)
+// Refresh rotates the refresh token of the session and returns a new token pair. Presenting a refresh token
+// that was already rotated means it has leaked, so the whole session is revoked.
+func (s *usersService) Refresh(ctx context.Context, input *requests.RefreshTokenRequest) (*responses.TokenResponse, error) {
+	family, err := s.tokensRepo.GetTokenFamily(ctx, input.SessionID)
 	if err != nil {
+		if errors.Is(err, domainErrors.ErrResourceNotFound) {
+			return nil, domainErrors.ErrInvalidRefreshToken
+		}
 		return nil, err
 	}
+	if family.UserID != input.UserID || !family.Active(time.Now()) {
+		return nil, domainErrors.ErrInvalidRefreshToken
+	}
+	if family.CurrentTokenID != input.TokenID {
+		return nil, s.revokeReusedFamily(ctx, family)
+	}
 
-	if !helpers.CheckPasswordHash(input.Password, user.Password) {
-		return nil, domainErrors.ErrUserPasswordIncorrect
+	user, err := s.repo.GetByID(ctx, family.UserID)
+	if err != nil {
+		return nil, err
 	}
 
+	tokenID := uuid.NewString()
 	token, err := s.jwt.CreateAccessToken(helpers.UserAccessTokenClaims{
-		UserId: user.ID,
+		UserId:         user.ID,
 		OrganizationId: user.OrganizationID,
+		SessionId:      family.ID,
+		RefreshTokenId: tokenID,
 	})
 	if err != nil {
 		logrus.Errorf("Error creating access token: %s", err)
 		return nil, err
 	}
+	err = s.tokensRepo.RotateTokenFamily(ctx, family.ID, input.TokenID, tokenID, time.Unix(token.RefreshTokenExpiresAt, 0))
+	if errors.Is(err, domainErrors.ErrRefreshTokenReused) {
+		// Another request rotated the same token first
+		return nil, s.revokeReusedFamily(ctx, family)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newTokenResponse(token), nil
+}
+
+// SignOut revokes the session of the user.
+func (s *usersService) SignOut(ctx context.Context, input *requests.SignOutRequest) error {
+	return s.tokensRepo.RevokeTokenFamily(ctx, input.UserID, input.SessionID)
+}
+
+// SignOutAll revokes all sessions of the user.
+func (s *usersService) SignOutAll(ctx context.Context, userID string) error {
+	return s.tokensRepo.RevokeUserTokenFamilies(ctx, userID, "")
+}
+
+// CheckSession returns ErrSessionRevoked when the session of an access token was signed out or has expired.
+func (s *usersService) CheckSession(ctx context.Context, sessionID string) error {
+	family, err := s.tokensRepo.GetTokenFamily(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrResourceNotFound) {
+			return domainErrors.ErrSessionRevoked
+		}
+		return err
+	}
+	if !family.Active(time.Now()) {
+		return domainErrors.ErrSessionRevoked
+	}
+	return nil
+}
+
+// GetProfile retrieves the profile of the user.
+func (s *usersService) GetProfile(ctx context.Context, userID string) (*entities.User, error) {
+	return s.repo.GetByID(ctx, userID)
+}
+
+// UpdateProfile updates the name, the surname and the phone of the user.
+func (s *usersService) UpdateProfile(ctx context.Context, input *requests.UpdateProfileRequest) (*entities.User, error) {
+	user := &entities.User{
+		ID:      input.UserID,
+		Name:    input.Body.Name,
+		Surname: input.Body.Surname,
+		Phone:   input.Body.Phone,
+	}
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, input.UserID)
+}
+
+// ChangePassword replaces the password of the user and signs out their other sessions.
+func (s *usersService) ChangePassword(ctx context.Context, input *requests.ChangePasswordRequest) error {
+	user, err := s.repo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return err
+	}
+	if !helpers.CheckPasswordHash(input.Body.CurrentPassword, user.Password) {
+		return domainErrors.ErrUserPasswordIncorrect
+	}
+
+	hashedPassword, err := helpers.HashPassword(input.Body.NewPassword)
+	if err != nil {
+		return err
+	}
+	if err = s.repo.UpdatePassword(ctx, input.UserID, hashedPassword); err != nil {
+		return err
+	}
+	return s.tokensRepo.RevokeUserTokenFamilies(ctx, input.UserID, input.SessionID)
+}
+
+// RequestPasswordReset emails a password reset link if a user with the email exists.
+// It does not report unknown emails, so it cannot be used to find out who has an account.
+func (s *usersService) RequestPasswordReset(ctx context.Context, input *requests.PasswordResetRequest) error {
+	user, err := s.repo.GetByEmail(ctx, helpers.NormalizeEmail(input.Email))
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	token, tokenHash, err := helpers.GenerateToken()
+	if err != nil {
+		return err
+	}
+	reset := &entities.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(passwordResetLifetime),
+	}
+	if err = s.repo.CreatePasswordReset(ctx, reset); err != nil {
+		return err
+	}
+
+	return s.emailSender.Send(ctx, email.Message{
+		To:      user.Email,
+		Subject: "Reset your MunaiPlan password",
+		Body: fmt.Sprintf(
+			"Reset your password here: %s/reset-password?token=%s\n\nThe link expires on %s. If you did not ask to reset your password, ignore this email.",
+			s.appUrl, token, reset.ExpiresAt.UTC().Format(time.RFC1123),
+		),
+	})
+}
+
+// ResetPassword sets a new password with a password reset token and signs out all sessions of the user.
+func (s *usersService) ResetPassword(ctx context.Context, input *requests.PasswordResetConfirmRequest) error {
+	reset, err := s.repo.UsePasswordReset(ctx, helpers.HashToken(input.Token))
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := helpers.HashPassword(input.Password)
+	if err != nil {
+		return err
+	}
+	if err = s.repo.UpdatePassword(ctx, reset.UserID, hashedPassword); err != nil {
+		return err
+	}
+	return s.tokensRepo.RevokeUserTokenFamilies(ctx, reset.UserID, "")
+}
+
+func (s *usersService) revokeReusedFamily(ctx context.Context, family *entities.TokenFamily) error {
+	logrus.Warnf("Refresh token reuse detected for user %s, revoking session %s", family.UserID, family.ID)
+	if err := s.tokensRepo.RevokeTokenFamily(ctx, family.UserID, family.ID); err != nil {
+		return err
+	}
+	return domainErrors.ErrRefreshTokenReused
+}
+
+func newTokenResponse(token *helpers.Token) *responses.TokenResponse {
 	return &responses.TokenResponse{
 		Success:               true,
 		Token:                 token.AccessToken,
@@ -91,5 +307,5 @@ func (s *usersService) SignIn(ctx context.Context, input *requests.UserSignInReq
 		RefreshToken:          token.RefreshToken,
 		RefreshTokenType:      BEARER_TOKEN_TYPE,
 		RefreshTokenExpiresAt: token.RefreshTokenExpiresAt,
-	}, nil
+	}
 }
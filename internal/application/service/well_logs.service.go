@@ -0,0 +1,469 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	types "github.com/munaiplan/munaiplan-backend/internal/application/types/errors"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/las"
+	"github.com/munaiplan/munaiplan-backend/pkg/porepressure"
+)
+
+// Methods of the pore pressure estimation
+const (
+	porePressureMethodSonic       = "sonic"
+	porePressureMethodResistivity = "resistivity"
+)
+
+const (
+	// defaultWaterDensity is the density of the formation water in g/cm³ the normal pore pressure is calculated with
+	defaultWaterDensity = 1.03
+	// defaultShallowDensity is the bulk density in g/cm³ above the top of the log
+	defaultShallowDensity = 2.0
+	defaultPoissonRatio   = 0.25
+	// defaultEstimateInterval is the height of the true vertical depth bins in meters
+	defaultEstimateInterval = 25.0
+)
+
+// Mnemonics of the curves read from LAS files, in the order of preference
+var (
+	gammaRayMnemonics    = []string{"GR", "GRC", "GR_EDTC", "GRGC", "SGR", "CGR"}
+	resistivityMnemonics = []string{"RT", "ILD", "LLD", "RD", "RDEP", "AT90", "AHT90", "RLA5", "RESD", "RES"}
+	sonicMnemonics       = []string{"DTCO", "DTC", "DT", "DT4P", "DTP", "AC"}
+	densityMnemonics     = []string{"RHOB", "RHOZ", "DEN", "ZDEN", "DENS"}
+)
+
+// Factors from the LAS units of the curves to canonical units. Curves without a unit are taken as canonical.
+var (
+	lasDepthUnits   = map[string]float64{"M": 1, "METER": 1, "METERS": 1, "METRES": 1, "F": 0.3048, "FT": 0.3048, "FEET": 0.3048}
+	lasSonicUnits   = map[string]float64{"US/F": 1, "US/FT": 1, "USEC/FT": 1, "US/M": 0.3048, "USEC/M": 0.3048}
+	lasDensityUnits = map[string]float64{"G/C3": 1, "G/CC": 1, "G/CM3": 1, "GM/CC": 1, "K/M3": 0.001, "KG/M3": 0.001}
+)
+
+type wellLogsService struct {
+	repo                  repository.WellLogsRepository
+	porePressuresRepo     repository.PorePressuresRepository
+	fractureGradientsRepo repository.FractureGradientsRepository
+	commonRepo            repository.CommonRepository
+	transactionsRepo      repository.TransactionsRepository
+}
+
+func NewWellLogsService(repo repository.WellLogsRepository, porePressuresRepo repository.PorePressuresRepository, fractureGradientsRepo repository.FractureGradientsRepository, commonRepo repository.CommonRepository, transactionsRepo repository.TransactionsRepository) *wellLogsService {
+	return &wellLogsService{
+		repo:                  repo,
+		porePressuresRepo:     porePressuresRepo,
+		fractureGradientsRepo: fractureGradientsRepo,
+		commonRepo:            commonRepo,
+		transactionsRepo:      transactionsRepo,
+	}
+}
+
+// ImportWellLog reads the depth, gamma ray, deep resistivity, sonic and density curves of the LAS file and saves
+// them in canonical units. The file needs a sonic or a resistivity curve, depths without any of the curves are
+// left out.
+func (s *wellLogsService) ImportWellLog(ctx context.Context, input *requests.ImportWellLogRequest) (*entities.WellLog, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.WellboreID); err != nil {
+		return nil, err
+	}
+
+	file, err := las.Read(input.File)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidWellLogFile, err)
+	}
+	wellLog, err := toWellLogEntity(file)
+	if err != nil {
+		return nil, err
+	}
+	wellLog.WellboreID = input.WellboreID
+	wellLog.FileName = input.FileName
+	if wellLog.Name == "" {
+		wellLog.Name = input.FileName
+	}
+
+	if err := s.repo.CreateWellLog(ctx, wellLog); err != nil {
+		return nil, err
+	}
+	wellLog.Samples = nil
+	return wellLog, nil
+}
+
+func (s *wellLogsService) GetWellLogs(ctx context.Context, input *requests.GetWellLogsRequest) ([]*entities.WellLog, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.WellboreID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetWellLogs(ctx, input.WellboreID)
+}
+
+func (s *wellLogsService) GetWellLogByID(ctx context.Context, input *requests.GetWellLogByIDRequest) (*entities.WellLog, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.WellboreID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetWellLogByID(ctx, input.WellboreID, input.ID)
+}
+
+func (s *wellLogsService) DeleteWellLog(ctx context.Context, input *requests.DeleteWellLogRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.WellboreID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteWellLog(ctx, input.WellboreID, input.ID)
+}
+
+// EstimatePorePressure runs the Eaton method on the sonic or resistivity curve of a log of the wellbore of the
+// case. The measured depths of the log are converted to true vertical depths along the trajectory of the case,
+// samples outside the trajectory are left out. The overburden is integrated from the density curve, from the
+// Gardner density of the sonic curve without one, and the normal pore pressure is hydrostatic. The fracture
+// pressure follows from the pore pressure with the Poisson's ratio. The results are averaged in true vertical
+// depth bins, saved bins replace the pore pressures and fracture gradients of the case and keep its temperature
+// profile.
+func (s *wellLogsService) EstimatePorePressure(ctx context.Context, input *requests.EstimatePorePressureRequest) (*responses.PorePressureEstimateResponse, error) {
+	body := input.Body
+	trend, exponent, err := normalTrend(&body)
+	if err != nil {
+		return nil, err
+	}
+	waterDensity := floatOrDefault(body.WaterDensity, defaultWaterDensity)
+	shallowDensity := floatOrDefault(body.ShallowDensity, defaultShallowDensity)
+	poissonRatio := floatOrDefault(body.PoissonRatio, defaultPoissonRatio)
+	interval := floatOrDefault(body.Interval, defaultEstimateInterval)
+
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return nil, err
+	}
+	if body.Save {
+		if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.CaseID); err != nil {
+			return nil, err
+		}
+	}
+	wellboreID, err := s.commonRepo.GetWellboreIDByCaseID(ctx, input.CaseID)
+	if err != nil {
+		return nil, err
+	}
+	wellLog, err := s.repo.GetWellLogByID(ctx, wellboreID, body.WellLogID)
+	if err != nil {
+		return nil, err
+	}
+	if body.Method == porePressureMethodSonic && wellLog.SonicCurve == "" ||
+		body.Method == porePressureMethodResistivity && wellLog.ResistivityCurve == "" {
+		return nil, domainErrors.ErrWellLogCurveMissing
+	}
+	trajectory, err := s.commonRepo.GetTrajectoryByCaseID(ctx, input.CaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := trueVerticalDepthSamples(wellLog.Samples, trajectory, body.Method)
+	if len(samples) == 0 {
+		return nil, domainErrors.ErrNoWellLogSamples
+	}
+	tvds := make([]float64, len(samples))
+	densities := make([]float64, len(samples))
+	for i, sample := range samples {
+		tvds[i] = sample.tvd
+		densities[i] = sample.density
+	}
+	overburden := porepressure.Overburden(tvds, densities, shallowDensity)
+
+	bins := make(map[int]*porePressureBin)
+	for i, sample := range samples {
+		hydrostatic := porepressure.Hydrostatic(sample.tvd, waterDensity)
+		normal := trend.Value(sample.tvd)
+		var porePressure float64
+		if body.Method == porePressureMethodSonic {
+			porePressure = porepressure.EatonSonic(overburden[i], hydrostatic, sample.value, normal, exponent)
+		} else {
+			porePressure = porepressure.EatonResistivity(overburden[i], hydrostatic, sample.value, normal, exponent)
+		}
+
+		index := int(math.Floor(sample.tvd / interval))
+		bin, ok := bins[index]
+		if !ok {
+			bin = &porePressureBin{}
+			bins[index] = bin
+		}
+		bin.samples++
+		bin.tvd += sample.tvd
+		bin.overburden += overburden[i]
+		bin.hydrostatic += hydrostatic
+		bin.porePressure += porePressure
+		bin.fracturePressure += porepressure.FracturePressure(overburden[i], porePressure, poissonRatio)
+	}
+
+	res := &responses.PorePressureEstimateResponse{
+		CaseID:    input.CaseID,
+		WellLogID: wellLog.ID,
+		Method:    body.Method,
+		Exponent:  exponent,
+		Points:    make([]*responses.PorePressureEstimatePointResponse, 0, len(bins)),
+	}
+	for _, bin := range bins {
+		n := float64(bin.samples)
+		tvd := bin.tvd / n
+		porePressure, fracturePressure := bin.porePressure/n, bin.fracturePressure/n
+		res.Points = append(res.Points, &responses.PorePressureEstimatePointResponse{
+			TVD:              tvd,
+			Samples:          bin.samples,
+			Overburden:       bin.overburden / n,
+			Hydrostatic:      bin.hydrostatic / n,
+			PorePressure:     porePressure,
+			PorePressureEMW:  porepressure.EquivalentDensity(porePressure, tvd),
+			FracturePressure: fracturePressure,
+			FractureEMW:      porepressure.EquivalentDensity(fracturePressure, tvd),
+		})
+	}
+	sort.Slice(res.Points, func(i, j int) bool { return res.Points[i].TVD < res.Points[j].TVD })
+
+	if body.Save {
+		if err := s.saveEstimate(ctx, input.CaseID, res.Points, body.Replace); err != nil {
+			return nil, err
+		}
+		res.Saved = true
+	}
+	return res, nil
+}
+
+// saveEstimate replaces the pore pressures and fracture gradients of the case with the points in one transaction.
+// The temperatures of the fracture gradients follow the temperature profile of the first existing fracture gradient.
+func (s *wellLogsService) saveEstimate(ctx context.Context, caseID string, points []*responses.PorePressureEstimatePointResponse, replace bool) error {
+	porePressuresExist, err := s.commonRepo.CheckIfPorePressureExists(ctx, caseID)
+	if err != nil {
+		return err
+	}
+	fractureGradientsExist, err := s.commonRepo.CheckIfFractureGradientExists(ctx, caseID)
+	if err != nil {
+		return err
+	}
+	if (porePressuresExist || fractureGradientsExist) && !replace {
+		return types.ErrAlreadyExists
+	}
+
+	var surfaceTemperature, temperatureGradient float64
+	if fractureGradientsExist {
+		existing, err := s.fractureGradientsRepo.GetFractureGradients(ctx, caseID)
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			surfaceTemperature, temperatureGradient = existing[0].TemperatureAtSurface, existing[0].TemperatureGradient
+		}
+	}
+
+	porePressures := make([]*entities.PorePressure, 0, len(points))
+	fractureGradients := make([]*entities.FractureGradient, 0, len(points))
+	for _, point := range points {
+		porePressures = append(porePressures, &entities.PorePressure{
+			TVD:      point.TVD,
+			Pressure: point.PorePressure,
+			EMW:      point.PorePressureEMW,
+		})
+		pressure, emw := point.FracturePressure, point.FractureEMW
+		fractureGradients = append(fractureGradients, &entities.FractureGradient{
+			TemperatureAtSurface: surfaceTemperature,
+			TemperatureAtWellTVD: surfaceTemperature + temperatureGradient*point.TVD/100,
+			TemperatureGradient:  temperatureGradient,
+			WellTVD:              point.TVD,
+			Pressure:             &pressure,
+			EMW:                  &emw,
+		})
+	}
+
+	return s.transactionsRepo.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.porePressuresRepo.ReplacePorePressures(ctx, caseID, porePressures); err != nil {
+			return err
+		}
+		return s.fractureGradientsRepo.ReplaceFractureGradients(ctx, caseID, fractureGradients)
+	})
+}
+
+// porePressureBin sums the samples of a true vertical depth bin.
+type porePressureBin struct {
+	samples          int
+	tvd              float64
+	overburden       float64
+	hydrostatic      float64
+	porePressure     float64
+	fracturePressure float64
+}
+
+// estimateSample is a log sample with the value of the curve of the method at its true vertical depth. The
+// density is NaN where neither a density nor a sonic value is known.
+type estimateSample struct {
+	tvd     float64
+	value   float64
+	density float64
+}
+
+// normalTrend returns the trend through the points picked on the curve of the method and the Eaton exponent.
+func normalTrend(body *requests.EstimatePorePressureRequestBody) (*porepressure.NormalTrend, float64, error) {
+	top, bottom := body.NormalTrend.Top, body.NormalTrend.Bottom
+	topValue, bottomValue := top.Resistivity, bottom.Resistivity
+	exponent := porepressure.DefaultResistivityExponent
+	if body.Method == porePressureMethodSonic {
+		topValue, bottomValue = top.Sonic, bottom.Sonic
+		exponent = porepressure.DefaultSonicExponent
+	}
+	if topValue == nil || bottomValue == nil {
+		return nil, 0, domainErrors.ErrInvalidNormalTrend
+	}
+	if body.Exponent != nil {
+		exponent = *body.Exponent
+	}
+
+	trend, err := porepressure.NewNormalTrend(
+		porepressure.TrendPoint{TVD: top.TVD, Value: *topValue},
+		porepressure.TrendPoint{TVD: bottom.TVD, Value: *bottomValue},
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", domainErrors.ErrInvalidNormalTrend, err)
+	}
+	return trend, exponent, nil
+}
+
+// trueVerticalDepthSamples returns the samples with a positive value of the curve of the method inside the
+// trajectory, ordered by true vertical depth.
+func trueVerticalDepthSamples(samples []*entities.WellLogSample, trajectory *entities.Trajectory, method string) []estimateSample {
+	stationMDs := make([]float64, 0, len(trajectory.Units))
+	stationTVDs := make([]float64, 0, len(trajectory.Units))
+	for _, unit := range trajectory.Units {
+		stationMDs = append(stationMDs, unit.MD)
+		stationTVDs = append(stationTVDs, unit.TVD)
+	}
+	mds := make([]float64, len(samples))
+	for i, sample := range samples {
+		mds[i] = sample.MD
+	}
+	tvds := resample(stationMDs, stationTVDs, mds)
+
+	res := make([]estimateSample, 0, len(samples))
+	for i, sample := range samples {
+		value := sample.Resistivity
+		if method == porePressureMethodSonic {
+			value = sample.Sonic
+		}
+		if tvds[i] == nil || value == nil || *value <= 0 {
+			continue
+		}
+
+		density := math.NaN()
+		switch {
+		case sample.Density != nil:
+			density = *sample.Density
+		case sample.Sonic != nil && *sample.Sonic > 0:
+			density = porepressure.Gardner(*sample.Sonic)
+		}
+		res = append(res, estimateSample{tvd: *tvds[i], value: *value, density: density})
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].tvd < res[j].tvd })
+	return res
+}
+
+// toWellLogEntity converts the curves of the file to canonical units. The name is the WELL item of the file.
+func toWellLogEntity(file *las.File) (*entities.WellLog, error) {
+	index := file.Index()
+	depthUnit := index.Unit
+	if depthUnit == "" {
+		if start, ok := file.WellItem("STRT"); ok {
+			depthUnit = start.Unit
+		}
+	}
+	depthFactor, err := lasUnitFactor(lasDepthUnits, depthUnit, index.Mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	gammaRay := file.Curve(gammaRayMnemonics...)
+	resistivity := file.Curve(resistivityMnemonics...)
+	sonic := file.Curve(sonicMnemonics...)
+	density := file.Curve(densityMnemonics...)
+	if sonic == nil && resistivity == nil {
+		return nil, fmt.Errorf("%w: the file has neither a sonic nor a resistivity curve", domainErrors.ErrInvalidWellLogFile)
+	}
+	var sonicFactor, densityFactor float64
+	if sonic != nil {
+		if sonicFactor, err = lasUnitFactor(lasSonicUnits, sonic.Unit, sonic.Mnemonic); err != nil {
+			return nil, err
+		}
+	}
+	if density != nil {
+		if densityFactor, err = lasUnitFactor(lasDensityUnits, density.Unit, density.Mnemonic); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &entities.WellLog{
+		GammaRayCurve:    curveMnemonic(gammaRay),
+		ResistivityCurve: curveMnemonic(resistivity),
+		SonicCurve:       curveMnemonic(sonic),
+		DensityCurve:     curveMnemonic(density),
+	}
+	if well, ok := file.WellItem("WELL"); ok {
+		res.Name = well.Value
+	}
+	for i, md := range index.Values {
+		if math.IsNaN(md) {
+			continue
+		}
+		sample := &entities.WellLogSample{
+			MD:          md * depthFactor,
+			GammaRay:    curveValue(gammaRay, i, 1),
+			Resistivity: curveValue(resistivity, i, 1),
+			Sonic:       curveValue(sonic, i, sonicFactor),
+			Density:     curveValue(density, i, densityFactor),
+		}
+		if sample.GammaRay != nil || sample.Resistivity != nil || sample.Sonic != nil || sample.Density != nil {
+			res.Samples = append(res.Samples, sample)
+		}
+	}
+	if len(res.Samples) == 0 {
+		return nil, fmt.Errorf("%w: the curves have no values", domainErrors.ErrInvalidWellLogFile)
+	}
+	sort.SliceStable(res.Samples, func(i, j int) bool { return res.Samples[i].MD < res.Samples[j].MD })
+	res.TopDepth = res.Samples[0].MD
+	res.BottomDepth = res.Samples[len(res.Samples)-1].MD
+	return res, nil
+}
+
+func lasUnitFactor(factors map[string]float64, unit string, mnemonic string) (float64, error) {
+	unit = strings.ToUpper(strings.TrimSpace(unit))
+	if unit == "" {
+		return 1, nil
+	}
+	factor, ok := factors[unit]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown unit %q of the curve %s", domainErrors.ErrInvalidWellLogFile, unit, mnemonic)
+	}
+	return factor, nil
+}
+
+func curveMnemonic(curve *las.Curve) string {
+	if curve == nil {
+		return ""
+	}
+	return curve.Mnemonic
+}
+
+// curveValue returns the value of the curve at the index multiplied by the factor, nil for null values.
+func curveValue(curve *las.Curve, index int, factor float64) *float64 {
+	if curve == nil || math.IsNaN(curve.Values[index]) {
+		return nil
+	}
+	value := curve.Values[index] * factor
+	return &value
+}
+
+func floatOrDefault(value *float64, fallback float64) float64 {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
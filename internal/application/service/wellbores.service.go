@@ -21,7 +21,7 @@ func NewWellboresService(repo repository.WellboresRepository, commonRepo reposit
 }
 
 func (s *wellboresService) GetWellbores(ctx context.Context, input *requests.GetWellboresRequest) ([]*entities.Wellbore, error) {
-	if err := s.commonRepo.CheckIfWellExists(ctx, input.WellID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWell, input.WellID); err != nil {
 		return nil, err
 	}
 
@@ -29,11 +29,15 @@ func (s *wellboresService) GetWellbores(ctx context.Context, input *requests.Get
 }
 
 func (s *wellboresService) GetWellboreByID(ctx context.Context, input *requests.GetWellboreByIDRequest) (*entities.Wellbore, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetWellboreByID(ctx, input.ID)
 }
 
 func (s *wellboresService) CreateWellbore(ctx context.Context, input *requests.CreateWellboreRequest) error {
-	if err := s.commonRepo.CheckIfWellExists(ctx, input.WellID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWell, input.WellID); err != nil {
 		return err
 	}
 
@@ -58,6 +62,10 @@ func (s *wellboresService) CreateWellbore(ctx context.Context, input *requests.C
 }
 
 func (s *wellboresService) UpdateWellbore(ctx context.Context, input *requests.UpdateWellboreRequest) (*entities.Wellbore, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.ID); err != nil {
+		return nil, err
+	}
+
 	wellbore := &entities.Wellbore{
 		ID:                             input.ID,
 		Name:                           input.Body.Name,
@@ -80,5 +88,9 @@ func (s *wellboresService) UpdateWellbore(ctx context.Context, input *requests.U
 }
 
 func (s *wellboresService) DeleteWellbore(ctx context.Context, input *requests.DeleteWellboreRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteWellbore(ctx, input.ID)
 }
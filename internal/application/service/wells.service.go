@@ -11,17 +11,19 @@ import (
 type wellsService struct {
 	commonRepo repository.CommonRepository
 	repo       repository.WellsRepository
+	sitesRepo  repository.SitesRepository
 }
 
-func NewWellsService(repo repository.WellsRepository, commonRepo repository.CommonRepository) *wellsService {
+func NewWellsService(repo repository.WellsRepository, sitesRepo repository.SitesRepository, commonRepo repository.CommonRepository) *wellsService {
 	return &wellsService{
 		repo:       repo,
+		sitesRepo:  sitesRepo,
 		commonRepo: commonRepo,
 	}
 }
 
 func (s *wellsService) GetWells(ctx context.Context, input *requests.GetWellsRequest) ([]*entities.Well, error) {
-	if err := s.commonRepo.CheckIfSiteExists(ctx, input.SiteID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeSite, input.SiteID); err != nil {
 		return nil, err
 	}
 
@@ -29,11 +31,18 @@ func (s *wellsService) GetWells(ctx context.Context, input *requests.GetWellsReq
 }
 
 func (s *wellsService) GetWellByID(ctx context.Context, input *requests.GetWellByIDRequest) (*entities.Well, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWell, input.ID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.GetWellByID(ctx, input.ID)
 }
 
 func (s *wellsService) CreateWell(ctx context.Context, input *requests.CreateWellRequest) error {
-	if err := s.commonRepo.CheckIfSiteExists(ctx, input.SiteID); err != nil {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeSite, input.SiteID); err != nil {
+		return err
+	}
+	if err := validateUnitSystem(input.Body.ActiveWellUnit); err != nil {
 		return err
 	}
 
@@ -48,10 +57,26 @@ func (s *wellsService) CreateWell(ctx context.Context, input *requests.CreateWel
 		ActiveWellUnit:          input.Body.ActiveWellUnit,
 	}
 
+	site, err := s.sitesRepo.GetSiteByID(ctx, input.SiteID)
+	if err != nil {
+		return err
+	}
+	if err := setWellLocation(well, site, input.Body.EPSGCode, input.Body.Latitude, input.Body.Longitude, input.Body.Easting, input.Body.Northing); err != nil {
+		return err
+	}
+
 	return s.repo.CreateWell(ctx, input.SiteID, well)
 }
 
 func (s *wellsService) UpdateWell(ctx context.Context, input *requests.UpdateWellRequest) (*entities.Well, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWell, input.ID); err != nil {
+		return nil, err
+	}
+
+	if err := validateUnitSystem(input.Body.ActiveWellUnit); err != nil {
+		return nil, err
+	}
+
 	well := &entities.Well{
 		ID:                      input.ID,
 		Name:                    input.Body.Name,
@@ -64,9 +89,48 @@ func (s *wellsService) UpdateWell(ctx context.Context, input *requests.UpdateWel
 		ActiveWellUnit:          input.Body.ActiveWellUnit,
 	}
 
+	site, err := s.commonRepo.GetSiteByWellID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := setWellLocation(well, site, input.Body.EPSGCode, input.Body.Latitude, input.Body.Longitude, input.Body.Easting, input.Body.Northing); err != nil {
+		return nil, err
+	}
+
 	return s.repo.UpdateWell(ctx, well)
 }
 
 func (s *wellsService) DeleteWell(ctx context.Context, input *requests.DeleteWellRequest) error {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWell, input.ID); err != nil {
+		return err
+	}
+
 	return s.repo.DeleteWell(ctx, input.ID)
 }
+
+// setWellLocation completes the surface location of the well and stores the grid convergence and
+// scale factor at the wellhead. The CRS of the site is used when the well has none.
+func setWellLocation(well *entities.Well, site *entities.Site, epsgCode int, latitude, longitude, easting, northing float64) error {
+	code := epsgCode
+	if code == 0 {
+		code = site.EPSGCode
+	}
+
+	grid, latitude, longitude, err := surfaceLocation(code, latitude, longitude, easting, northing)
+	if err != nil {
+		return err
+	}
+
+	well.EPSGCode = code
+	well.Latitude = latitude
+	well.Longitude = longitude
+	well.Easting = easting
+	well.Northing = northing
+	if grid != nil {
+		well.Easting = grid.Easting
+		well.Northing = grid.Northing
+		well.GridConvergence = grid.GridConvergence
+		well.ScaleFactor = grid.ScaleFactor
+	}
+	return nil
+}
@@ -0,0 +1,717 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	types "github.com/munaiplan/munaiplan-backend/internal/application/types/errors"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+	"github.com/munaiplan/munaiplan-backend/pkg/witsml"
+)
+
+// uids of the coordinate reference systems written with exported wells
+const (
+	witsmlProjectedCRS  = "projected"
+	witsmlGeographicCRS = "geographic"
+)
+
+// witsmlTubularTypes are the typeTubularComp values of WITSML 1.4.1 that section types are exported as,
+// other section types are exported as "unknown" with the type kept in the description.
+var witsmlTubularTypes = map[string]bool{
+	"bit": true, "casing": true, "drill pipe": true, "drill pipe compressive": true, "drill collar": true,
+	"heavy weight drill pipe": true, "stabilizer": true, "near bit stabilizer": true, "non-magnetic collar": true,
+	"non-magnetic stabilizer": true, "mwd tool": true, "logging while drilling tool": true, "jar": true,
+	"accelerator": true, "motor": true, "mud motor": true, "crossover": true, "sub-bit": true, "sub-float": true,
+	"reamer": true, "hole opener": true, "rotary steerable tool": true, "shock sub": true, "tubing": true,
+	"liner": true, "coiled tubing": true, "kelly": true, "unknown": true,
+}
+
+type witsmlService struct {
+	commonRepo       repository.CommonRepository
+	wellsRepo        repository.WellsRepository
+	wellboresRepo    repository.WellboresRepository
+	trajectoriesRepo repository.TrajectoriesRepository
+	stringsRepo      repository.StringsRepository
+	sitesRepo        repository.SitesRepository
+}
+
+func NewWitsmlService(wellsRepo repository.WellsRepository, wellboresRepo repository.WellboresRepository, trajectoriesRepo repository.TrajectoriesRepository, stringsRepo repository.StringsRepository, sitesRepo repository.SitesRepository, commonRepo repository.CommonRepository) *witsmlService {
+	return &witsmlService{
+		commonRepo:       commonRepo,
+		wellsRepo:        wellsRepo,
+		wellboresRepo:    wellboresRepo,
+		trajectoriesRepo: trajectoriesRepo,
+		stringsRepo:      stringsRepo,
+		sitesRepo:        sitesRepo,
+	}
+}
+
+// ExportWell returns the well with its surface location. Grid coordinates refer to the projected CRS of the
+// well, latitude and longitude to its geographic base.
+func (s *witsmlService) ExportWell(ctx context.Context, input *requests.ExportWitsmlRequest) (*witsml.Wells, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWell, input.ID); err != nil {
+		return nil, err
+	}
+	well, err := s.wellsRepo.GetWellByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &witsml.Well{
+		UID:        well.ID,
+		Name:       well.Name,
+		NumGovt:    well.UniversalWellIdentifier,
+		CommonData: witsmlComments(well.Description),
+	}
+	geographic := &witsml.WellCRS{UID: witsmlGeographicCRS, Name: "Geographic", Geographic: &witsml.CRSName{}}
+	if well.EPSGCode != 0 {
+		res.WellCRS = append(res.WellCRS, witsml.NewEPSGCRS(witsmlProjectedCRS, well.EPSGCode))
+		res.WellLocation = append(res.WellLocation, &witsml.Location{
+			UID:      "grid",
+			WellCRS:  &witsml.RefName{UIDRef: witsmlProjectedCRS, Value: fmt.Sprintf("EPSG:%d", well.EPSGCode)},
+			Easting:  witsml.NewMeasure(units.Length, &well.Easting),
+			Northing: witsml.NewMeasure(units.Length, &well.Northing),
+		})
+		geographic.Name = fmt.Sprintf("Geographic base of EPSG:%d", well.EPSGCode)
+	}
+	geographic.Geographic.NameCRS.Value = geographic.Name
+	res.WellCRS = append(res.WellCRS, geographic)
+	res.WellLocation = append(res.WellLocation, &witsml.Location{
+		UID:       "geographic",
+		WellCRS:   &witsml.RefName{UIDRef: witsmlGeographicCRS, Value: geographic.Name},
+		Latitude:  witsml.NewAngle(&well.Latitude),
+		Longitude: witsml.NewAngle(&well.Longitude),
+	})
+
+	return &witsml.Wells{Wells: []*witsml.Well{res}}, nil
+}
+
+func (s *witsmlService) ExportWellbore(ctx context.Context, input *requests.ExportWitsmlRequest) (*witsml.Wellbores, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWellbore, input.ID); err != nil {
+		return nil, err
+	}
+	reference, err := s.commonRepo.GetWellboreReference(ctx, entities.ScopeWellbore, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	wellbore, err := s.wellboresRepo.GetWellboreByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &witsml.Wellbores{Wellbores: []*witsml.Wellbore{{
+		UIDWell:  reference.WellID,
+		UID:      wellbore.ID,
+		NameWell: reference.WellName,
+		Name:     wellbore.Name,
+		MD:       witsml.NewMeasure(units.Length, &wellbore.WellboreDepth),
+	}}}, nil
+}
+
+// ExportTrajectory returns the trajectory with its stations ordered by measured depth.
+func (s *witsmlService) ExportTrajectory(ctx context.Context, input *requests.ExportWitsmlRequest) (*witsml.Trajectories, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeTrajectory, input.ID); err != nil {
+		return nil, err
+	}
+	reference, err := s.commonRepo.GetWellboreReference(ctx, entities.ScopeTrajectory, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	trajectory, err := s.trajectoriesRepo.GetTrajectoryByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	well, err := s.commonRepo.GetWellByTrajectoryID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	trajectoryUnits := append([]*entities.TrajectoryUnit(nil), trajectory.Units...)
+	sort.SliceStable(trajectoryUnits, func(i, j int) bool {
+		return trajectoryUnits[i].MD < trajectoryUnits[j].MD
+	})
+	stations := make([]*witsml.TrajectoryStation, len(trajectoryUnits))
+	for i, unit := range trajectoryUnits {
+		station := &witsml.TrajectoryStation{
+			UID:             fmt.Sprintf("station-%d", i+1),
+			TypeTrajStation: witsml.StationUnknown,
+			MD:              witsml.NewMeasure(units.Length, &unit.MD),
+			TVD:             witsml.NewMeasure(units.Length, &unit.TVD),
+			Incl:            witsml.NewAngle(&unit.Incl),
+			Azi:             witsml.NewAngle(&unit.Azim),
+			DispNs:          witsml.NewMeasure(units.Length, &unit.LocalNCoord),
+			DispEw:          witsml.NewMeasure(units.Length, &unit.LocalECoord),
+			VertSect:        witsml.NewMeasure(units.Length, &unit.VerticalSection),
+			DLS:             witsml.NewMeasure(units.DoglegSeverity, &unit.Dogleg),
+		}
+		if i == 0 {
+			station.TypeTrajStation = witsml.StationTieIn
+		}
+		if well.EPSGCode != 0 {
+			station.Location = []*witsml.Location{{
+				WellCRS:  &witsml.RefName{UIDRef: witsmlProjectedCRS, Value: fmt.Sprintf("EPSG:%d", well.EPSGCode)},
+				Easting:  witsml.NewMeasure(units.Length, &unit.GlobalECoord),
+				Northing: witsml.NewMeasure(units.Length, &unit.GlobalNCoord),
+			}}
+		}
+		stations[i] = station
+	}
+
+	return &witsml.Trajectories{Trajectories: []*witsml.Trajectory{{
+		UIDWell:      reference.WellID,
+		UIDWellbore:  reference.WellboreID,
+		UID:          trajectory.ID,
+		NameWell:     reference.WellName,
+		NameWellbore: reference.WellboreName,
+		Name:         trajectory.Name,
+		Stations:     stations,
+		CommonData:   witsmlComments(trajectory.Description),
+	}}}, nil
+}
+
+// ExportTubular returns the drill string as a tubular, its components are numbered from the bit.
+func (s *witsmlService) ExportTubular(ctx context.Context, input *requests.ExportWitsmlRequest) (*witsml.Tubulars, error) {
+	reference, stringEntity, err := s.getString(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := append([]*entities.Section(nil), stringEntity.Sections...)
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].BodyMD > sections[j].BodyMD
+	})
+	components := make([]*witsml.TubularComponent, len(sections))
+	for i, section := range sections {
+		sequence := i + 1
+		component := &witsml.TubularComponent{
+			UID:             fmt.Sprintf("component-%d", sequence),
+			TypeTubularComp: strings.ToLower(strings.TrimSpace(section.Type)),
+			Sequence:        &sequence,
+			Description:     stringValue(section.Description),
+			ID:              witsml.NewMeasure(units.Diameter, &section.BodyID),
+			OD:              witsml.NewMeasure(units.Diameter, &section.BodyOD),
+			Len:             witsml.NewMeasure(units.Length, &section.BodyLength),
+			LenJointAv:      witsml.NewMeasure(units.Length, section.AvgJointLength),
+			WtPerLen:        witsml.NewMeasure(units.LinearWeight, section.Weight),
+			Grade:           stringValue(section.Grade),
+			TensYield:       witsml.NewMeasure(units.Stress, section.MinYieldStrength),
+			TypeMaterial:    stringValue(section.Material),
+			Vendor:          stringValue(section.Manufacturer),
+		}
+		if !witsmlTubularTypes[component.TypeTubularComp] {
+			component.Description = strings.TrimSpace(section.Type + " " + component.Description)
+			component.TypeTubularComp = "unknown"
+		}
+		if section.StabilizerLength != nil || section.StabilizerOD != nil {
+			component.Stabilizer = []*witsml.Stabilizer{{
+				LenBlade:  witsml.NewMeasure(units.Length, section.StabilizerLength),
+				OdBladeMx: witsml.NewMeasure(units.Diameter, section.StabilizerOD),
+			}}
+		}
+		components[i] = component
+	}
+
+	return &witsml.Tubulars{Tubulars: []*witsml.Tubular{{
+		UIDWell:          reference.WellID,
+		UIDWellbore:      reference.WellboreID,
+		UID:              stringEntity.ID,
+		NameWell:         reference.WellName,
+		NameWellbore:     reference.WellboreName,
+		Name:             stringEntity.Name,
+		TubularComponent: components,
+	}}}, nil
+}
+
+// ExportBhaRun returns the run of the drill string to its depth, it refers to the tubular by the string ID.
+func (s *witsmlService) ExportBhaRun(ctx context.Context, input *requests.ExportWitsmlRequest) (*witsml.BhaRuns, error) {
+	reference, stringEntity, err := s.getString(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &witsml.BhaRuns{BhaRuns: []*witsml.BhaRun{{
+		UIDWell:      reference.WellID,
+		UIDWellbore:  reference.WellboreID,
+		UID:          stringEntity.ID,
+		NameWell:     reference.WellName,
+		NameWellbore: reference.WellboreName,
+		Name:         stringEntity.Name,
+		Tubular:      &witsml.RefName{UIDRef: stringEntity.ID, Value: stringEntity.Name},
+		DrillingParams: []*witsml.DrillingParams{{
+			UID:        "params-1",
+			MdHoleStop: witsml.NewMeasure(units.Length, &stringEntity.Depth),
+		}},
+	}}}, nil
+}
+
+func (s *witsmlService) getString(ctx context.Context, input *requests.ExportWitsmlRequest) (*entities.WellboreReference, *entities.String, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeString, input.ID); err != nil {
+		return nil, nil, err
+	}
+	reference, err := s.commonRepo.GetWellboreReference(ctx, entities.ScopeString, input.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	stringEntity, err := s.stringsRepo.GetStringByID(ctx, input.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reference, stringEntity, nil
+}
+
+// ImportWells creates the wells of the file in the site. The CRS of the site is used for wells without a
+// projected CRS. All wells are read before the first one is created.
+func (s *witsmlService) ImportWells(ctx context.Context, input *requests.ImportWitsmlRequest) (*responses.WitsmlImportResponse, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeSite, input.ParentID); err != nil {
+		return nil, err
+	}
+	var doc witsml.Wells
+	if err := decodeWitsml(input, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Wells) == 0 {
+		return nil, fmt.Errorf("%w: the file has no well", domainErrors.ErrInvalidWitsmlFile)
+	}
+	site, err := s.sitesRepo.GetSiteByID(ctx, input.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	wells := make([]*entities.Well, len(doc.Wells))
+	for i, object := range doc.Wells {
+		if wells[i], err = toWellEntity(object, site); err != nil {
+			return nil, witsmlObjectError("well", object.UID, err)
+		}
+	}
+
+	res := &responses.WitsmlImportResponse{}
+	for i, well := range wells {
+		if err := s.wellsRepo.CreateWell(ctx, input.ParentID, well); err != nil {
+			return nil, err
+		}
+		res.Imported = append(res.Imported, &responses.WitsmlImportedObjectResponse{ID: well.ID, UID: doc.Wells[i].UID, Name: well.Name})
+	}
+	return res, nil
+}
+
+// ImportWellbores creates the wellbores of the file in the well.
+func (s *witsmlService) ImportWellbores(ctx context.Context, input *requests.ImportWitsmlRequest) (*responses.WitsmlImportResponse, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeWell, input.ParentID); err != nil {
+		return nil, err
+	}
+	var doc witsml.Wellbores
+	if err := decodeWitsml(input, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Wellbores) == 0 {
+		return nil, fmt.Errorf("%w: the file has no wellbore", domainErrors.ErrInvalidWitsmlFile)
+	}
+
+	wellbores := make([]*entities.Wellbore, len(doc.Wellbores))
+	for i, object := range doc.Wellbores {
+		if strings.TrimSpace(object.Name) == "" {
+			return nil, witsmlObjectError("wellbore", object.UID, errWitsmlMissingName)
+		}
+		md, err := object.MD.Canonical(units.Length)
+		if err != nil {
+			return nil, witsmlObjectError("wellbore", object.UID, err)
+		}
+		wellbores[i] = &entities.Wellbore{Name: strings.TrimSpace(object.Name), WellboreDepth: floatValue(md)}
+	}
+
+	res := &responses.WitsmlImportResponse{}
+	for i, wellbore := range wellbores {
+		if err := s.wellboresRepo.CreateWellbore(ctx, input.ParentID, wellbore); err != nil {
+			return nil, err
+		}
+		res.Imported = append(res.Imported, &responses.WitsmlImportedObjectResponse{ID: wellbore.ID, UID: doc.Wellbores[i].UID, Name: wellbore.Name})
+	}
+	return res, nil
+}
+
+// ImportTrajectories creates the trajectories of the file in the design. Grid coordinates of the stations
+// are derived from the well location when the well is georeferenced and taken from the file otherwise.
+func (s *witsmlService) ImportTrajectories(ctx context.Context, input *requests.ImportWitsmlRequest) (*responses.WitsmlImportResponse, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeDesign, input.ParentID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeDesign, input.ParentID); err != nil {
+		return nil, err
+	}
+	var doc witsml.Trajectories
+	if err := decodeWitsml(input, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Trajectories) == 0 {
+		return nil, fmt.Errorf("%w: the file has no trajectory", domainErrors.ErrInvalidWitsmlFile)
+	}
+	well, err := s.commonRepo.GetWellByDesignID(ctx, input.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	trajectories := make([]*entities.Trajectory, len(doc.Trajectories))
+	for i, object := range doc.Trajectories {
+		if trajectories[i], err = toTrajectoryEntity(object); err != nil {
+			return nil, witsmlObjectError("trajectory", object.UID, err)
+		}
+		applyGlobalCoordinates(well, trajectories[i].Units)
+	}
+
+	res := &responses.WitsmlImportResponse{}
+	for i, trajectory := range trajectories {
+		if err := s.trajectoriesRepo.CreateTrajectory(ctx, input.ParentID, trajectory); err != nil {
+			return nil, err
+		}
+		res.Imported = append(res.Imported, &responses.WitsmlImportedObjectResponse{ID: trajectory.ID, UID: doc.Trajectories[i].UID, Name: trajectory.Name})
+	}
+	return res, nil
+}
+
+// ImportString creates the drill string of the case from a tubular. A case holds one string, so the file
+// needs a single tubular or a bhaRun that selects one.
+func (s *witsmlService) ImportString(ctx context.Context, input *requests.ImportWitsmlStringRequest) (*responses.WitsmlImportResponse, error) {
+	if err := s.commonRepo.CheckOwnership(ctx, input.OrganizationID, entities.ScopeCase, input.CaseID); err != nil {
+		return nil, err
+	}
+	if err := s.commonRepo.CheckDesignEditable(ctx, entities.ScopeCase, input.CaseID); err != nil {
+		return nil, err
+	}
+	if exists, err := s.commonRepo.CheckIfStringExists(ctx, input.CaseID); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, types.ErrAlreadyExists
+	}
+
+	var tubulars witsml.Tubulars
+	if err := witsml.Decode(input.Tubulars, &tubulars); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidWitsmlFile, err)
+	}
+	var run *witsml.BhaRun
+	if input.BhaRuns != nil {
+		var bhaRuns witsml.BhaRuns
+		if err := witsml.Decode(input.BhaRuns, &bhaRuns); err != nil {
+			return nil, fmt.Errorf("%w: %v", domainErrors.ErrInvalidWitsmlFile, err)
+		}
+		if len(bhaRuns.BhaRuns) != 1 {
+			return nil, fmt.Errorf("%w: the bhaRun file has %d bhaRuns, a case holds one string", domainErrors.ErrInvalidWitsmlFile, len(bhaRuns.BhaRuns))
+		}
+		run = bhaRuns.BhaRuns[0]
+	}
+
+	tubular, err := selectTubular(tubulars.Tubulars, run)
+	if err != nil {
+		return nil, err
+	}
+	stringEntity, err := toStringEntity(tubular, run)
+	if err != nil {
+		return nil, witsmlObjectError("tubular", tubular.UID, err)
+	}
+
+	if err := s.stringsRepo.CreateString(ctx, input.CaseID, stringEntity); err != nil {
+		return nil, err
+	}
+	return &responses.WitsmlImportResponse{Imported: []*responses.WitsmlImportedObjectResponse{
+		{ID: stringEntity.ID, UID: tubular.UID, Name: stringEntity.Name},
+	}}, nil
+}
+
+var errWitsmlMissingName = errors.New("name is missing")
+
+func decodeWitsml(input *requests.ImportWitsmlRequest, doc witsml.Document) error {
+	if err := witsml.Decode(input.File, doc); err != nil {
+		return fmt.Errorf("%w: %v", domainErrors.ErrInvalidWitsmlFile, err)
+	}
+	return nil
+}
+
+func witsmlObjectError(object, uid string, err error) error {
+	return fmt.Errorf("%w: %s %q: %v", domainErrors.ErrInvalidWitsmlFile, object, uid, err)
+}
+
+// toWellEntity reads the name and the surface location of the well. The EPSG code is taken from the
+// projected CRS of the locations, latitude and longitude from a geographic location.
+func toWellEntity(object *witsml.Well, site *entities.Site) (*entities.Well, error) {
+	if strings.TrimSpace(object.Name) == "" {
+		return nil, errWitsmlMissingName
+	}
+	well := &entities.Well{
+		Name:                    strings.TrimSpace(object.Name),
+		UniversalWellIdentifier: object.NumGovt,
+	}
+	if object.CommonData != nil {
+		well.Description = object.CommonData.Comments
+	}
+
+	var epsgCode int
+	var latitude, longitude, easting, northing float64
+	for _, location := range object.WellLocation {
+		if location.Easting != nil || location.Northing != nil {
+			if location.WellCRS != nil {
+				if crs := object.CRS(location.WellCRS.UIDRef); crs != nil && crs.MapProjection != nil {
+					epsgCode = crs.EPSGCode()
+				}
+			}
+			e, err := location.Easting.Canonical(units.Length)
+			if err != nil {
+				return nil, err
+			}
+			n, err := location.Northing.Canonical(units.Length)
+			if err != nil {
+				return nil, err
+			}
+			easting, northing = floatValue(e), floatValue(n)
+		}
+		if location.Latitude != nil || location.Longitude != nil {
+			lat, err := location.Latitude.Degrees()
+			if err != nil {
+				return nil, err
+			}
+			lon, err := location.Longitude.Degrees()
+			if err != nil {
+				return nil, err
+			}
+			latitude, longitude = floatValue(lat), floatValue(lon)
+		}
+	}
+	if epsgCode == 0 {
+		// Grid coordinates without a projected CRS cannot be placed
+		easting, northing = 0, 0
+	}
+
+	if err := setWellLocation(well, site, epsgCode, latitude, longitude, easting, northing); err != nil {
+		return nil, err
+	}
+	return well, nil
+}
+
+// toTrajectoryEntity reads the stations ordered by measured depth. The sub sea depth is the true vertical
+// depth, the file has no datum elevation for it.
+func toTrajectoryEntity(object *witsml.Trajectory) (*entities.Trajectory, error) {
+	if strings.TrimSpace(object.Name) == "" {
+		return nil, errWitsmlMissingName
+	}
+	if len(object.Stations) == 0 {
+		return nil, fmt.Errorf("the trajectory has no stations")
+	}
+
+	trajectoryUnits := make([]*entities.TrajectoryUnit, len(object.Stations))
+	for i, station := range object.Stations {
+		if station.MD == nil {
+			return nil, fmt.Errorf("station %q: md is missing", station.UID)
+		}
+		unit := &entities.TrajectoryUnit{}
+		lengths := []struct {
+			measure *witsml.Measure
+			target  *float64
+		}{
+			{station.MD, &unit.MD},
+			{station.TVD, &unit.TVD},
+			{station.DispNs, &unit.LocalNCoord},
+			{station.DispEw, &unit.LocalECoord},
+			{station.VertSect, &unit.VerticalSection},
+		}
+		for _, field := range lengths {
+			value, err := field.measure.Canonical(units.Length)
+			if err != nil {
+				return nil, fmt.Errorf("station %q: %w", station.UID, err)
+			}
+			*field.target = floatValue(value)
+		}
+		angles := []struct {
+			measure *witsml.Measure
+			target  *float64
+		}{
+			{station.Incl, &unit.Incl},
+			{station.Azi, &unit.Azim},
+		}
+		for _, field := range angles {
+			value, err := field.measure.Degrees()
+			if err != nil {
+				return nil, fmt.Errorf("station %q: %w", station.UID, err)
+			}
+			*field.target = floatValue(value)
+		}
+		dls, err := station.DLS.Canonical(units.DoglegSeverity)
+		if err != nil {
+			return nil, fmt.Errorf("station %q: %w", station.UID, err)
+		}
+		unit.Dogleg = floatValue(dls)
+		unit.SubSea = unit.TVD
+
+		for _, location := range station.Location {
+			if location.Easting == nil && location.Northing == nil {
+				continue
+			}
+			easting, err := location.Easting.Canonical(units.Length)
+			if err != nil {
+				return nil, fmt.Errorf("station %q: %w", station.UID, err)
+			}
+			northing, err := location.Northing.Canonical(units.Length)
+			if err != nil {
+				return nil, fmt.Errorf("station %q: %w", station.UID, err)
+			}
+			unit.GlobalECoord, unit.GlobalNCoord = floatValue(easting), floatValue(northing)
+			break
+		}
+		trajectoryUnits[i] = unit
+	}
+	sort.SliceStable(trajectoryUnits, func(i, j int) bool {
+		return trajectoryUnits[i].MD < trajectoryUnits[j].MD
+	})
+
+	trajectory := &entities.Trajectory{Name: strings.TrimSpace(object.Name), Units: trajectoryUnits}
+	if object.CommonData != nil {
+		trajectory.Description = object.CommonData.Comments
+	}
+	return trajectory, nil
+}
+
+// selectTubular returns the tubular the run refers to, or the only tubular of the file without a run.
+func selectTubular(tubulars []*witsml.Tubular, run *witsml.BhaRun) (*witsml.Tubular, error) {
+	if run != nil && run.Tubular != nil && run.Tubular.UIDRef != "" {
+		for _, tubular := range tubulars {
+			if tubular.UID == run.Tubular.UIDRef {
+				return tubular, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: the bhaRun refers to tubular %q, which is not in the file", domainErrors.ErrInvalidWitsmlFile, run.Tubular.UIDRef)
+	}
+	if len(tubulars) != 1 {
+		return nil, fmt.Errorf("%w: the file has %d tubulars, add the bhaRun that refers to the string", domainErrors.ErrInvalidWitsmlFile, len(tubulars))
+	}
+	return tubulars[0], nil
+}
+
+// toStringEntity reads the components of the tubular from the bit up. The bottom of each section is placed
+// from the deepest hole depth of the run, or from the total length of the components without one, and the
+// sections are ordered from the top.
+func toStringEntity(tubular *witsml.Tubular, run *witsml.BhaRun) (*entities.String, error) {
+	if len(tubular.TubularComponent) == 0 {
+		return nil, fmt.Errorf("the tubular has no components")
+	}
+
+	components := append([]*witsml.TubularComponent(nil), tubular.TubularComponent...)
+	sort.SliceStable(components, func(i, j int) bool {
+		if components[i].Sequence == nil || components[j].Sequence == nil {
+			return false
+		}
+		return *components[i].Sequence < *components[j].Sequence
+	})
+
+	sections := make([]*entities.Section, len(components))
+	var length float64
+	for i, component := range components {
+		section, err := toSectionEntity(component)
+		if err != nil {
+			return nil, fmt.Errorf("component %q: %w", component.UID, err)
+		}
+		length += section.BodyLength
+		sections[len(components)-1-i] = section
+	}
+
+	stringEntity := &entities.String{Name: strings.TrimSpace(tubular.Name), Depth: length, Sections: sections}
+	if run != nil {
+		if name := strings.TrimSpace(run.Name); name != "" {
+			stringEntity.Name = name
+		}
+		for _, params := range run.DrillingParams {
+			depth, err := params.MdHoleStop.Canonical(units.Length)
+			if err != nil {
+				return nil, fmt.Errorf("bhaRun %q: %w", run.UID, err)
+			}
+			if depth != nil && *depth > stringEntity.Depth {
+				stringEntity.Depth = *depth
+			}
+		}
+	}
+	if stringEntity.Name == "" {
+		return nil, errWitsmlMissingName
+	}
+
+	bottom := stringEntity.Depth
+	for i := len(sections) - 1; i >= 0; i-- {
+		sections[i].BodyMD = bottom
+		bottom -= sections[i].BodyLength
+	}
+	return stringEntity, nil
+}
+
+func toSectionEntity(component *witsml.TubularComponent) (*entities.Section, error) {
+	if component.Len == nil {
+		return nil, fmt.Errorf("len is missing")
+	}
+	section := &entities.Section{
+		Type:         strings.TrimSpace(component.TypeTubularComp),
+		Description:  stringPointer(component.Description),
+		Manufacturer: stringPointer(component.Vendor),
+		Material:     stringPointer(component.TypeMaterial),
+		Grade:        stringPointer(component.Grade),
+	}
+
+	var stabilizer witsml.Stabilizer
+	if len(component.Stabilizer) > 0 {
+		stabilizer = *component.Stabilizer[0]
+	}
+	var bodyLength, bodyOD, bodyID *float64
+	measures := []struct {
+		measure  *witsml.Measure
+		quantity units.Quantity
+		target   **float64
+	}{
+		{component.Len, units.Length, &bodyLength},
+		{component.OD, units.Diameter, &bodyOD},
+		{component.ID, units.Diameter, &bodyID},
+		{component.LenJointAv, units.Length, &section.AvgJointLength},
+		{component.WtPerLen, units.LinearWeight, &section.Weight},
+		{component.TensYield, units.Stress, &section.MinYieldStrength},
+		{stabilizer.LenBlade, units.Length, &section.StabilizerLength},
+		{stabilizer.OdBladeMx, units.Diameter, &section.StabilizerOD},
+	}
+	for _, field := range measures {
+		value, err := field.measure.Canonical(field.quantity)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = value
+	}
+	section.BodyLength, section.BodyOD, section.BodyID = floatValue(bodyLength), floatValue(bodyOD), floatValue(bodyID)
+	return section, nil
+}
+
+func witsmlComments(comments string) *witsml.CommonData {
+	if comments == "" {
+		return nil
+	}
+	return &witsml.CommonData{Comments: comments}
+}
+
+func floatValue(value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func stringPointer(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,25 @@
+package requests
+
+// AntiCollisionRequestBody represents the request body for the anti-collision scan.
+// Unset values fall back to the defaults of the anti-collision service.
+// ErrorModel is either "simple" (linear radius from SurfaceErrorRadius and ErrorRadiusPerMD)
+// or "iscwsa" (error models of the survey programs scaled by Sigma).
+type AntiCollisionRequestBody struct {
+	Scope                   string   `json:"scope"`
+	Step                    float64  `json:"step" unit:"length"`
+	ErrorModel              string   `json:"error_model"`
+	Sigma                   float64  `json:"sigma"`
+	DefaultSurveyToolID     string   `json:"default_survey_tool_id"`
+	SurfaceErrorRadius      *float64 `json:"surface_error_radius" unit:"length"`
+	ErrorRadiusPerMD        *float64 `json:"error_radius_per_md"`
+	WarningSeparationFactor *float64 `json:"warning_separation_factor"`
+	MinimumSeparationFactor *float64 `json:"minimum_separation_factor"`
+	MinimumCenterToCenter   *float64 `json:"minimum_center_to_center" unit:"length"`
+}
+
+// AntiCollisionRequest represents the request for scanning a trajectory against its offset wells
+type AntiCollisionRequest struct {
+	OrganizationID string
+	TrajectoryID   string
+	Body           AntiCollisionRequestBody
+}
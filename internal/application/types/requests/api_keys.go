@@ -0,0 +1,28 @@
+package requests
+
+import (
+	"time"
+)
+
+type CreateApiKeyRequest struct {
+	UserID         string
+	OrganizationID string
+	Body           CreateApiKeyRequestBody
+}
+
+// CreateApiKeyRequestBody limits the key to reading or to reading and writing, and optionally to one company.
+type CreateApiKeyRequestBody struct {
+	Name      string     `json:"name" binding:"required,max=64"`
+	Scope     string     `json:"scope" binding:"required,oneof=read read_write"`
+	CompanyID *string    `json:"company_id" binding:"omitempty,uuid"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type GetApiKeysRequest struct {
+	UserID string
+}
+
+type DeleteApiKeyRequest struct {
+	UserID string
+	ID     string
+}
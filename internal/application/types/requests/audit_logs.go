@@ -0,0 +1,21 @@
+package requests
+
+import (
+	"time"
+)
+
+type GetAuditLogsRequest struct {
+	OrganizationID string
+	Query          GetAuditLogsRequestQuery
+}
+
+// GetAuditLogsRequestQuery filters the audit log. From is inclusive, To is exclusive, both in RFC 3339.
+type GetAuditLogsRequestQuery struct {
+	EntityType string     `form:"entityType" binding:"omitempty,max=64"`
+	EntityID   string     `form:"entityId" binding:"omitempty,uuid"`
+	UserID     string     `form:"userId" binding:"omitempty,uuid"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit      int        `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Offset     int        `form:"offset" binding:"omitempty,min=0"`
+}
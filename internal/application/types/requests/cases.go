@@ -1,44 +1,109 @@
 package requests
 
+import (
+	"io"
+
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+)
+
 // CreateCaseRequestBody represents the request body for creating a case
 type CreateCaseRequestBody struct {
 	CaseName        string  `json:"case_name"`
 	CaseDescription string  `json:"case_description"`
-	DrillDepth      float64 `json:"drill_depth"`
-	PipeSize        float64 `json:"pipe_size"`
+	DrillDepth      float64 `json:"drill_depth" unit:"length"`
+	PipeSize        float64 `json:"pipe_size" unit:"diameter"`
 }
 
 // CreateCaseRequest represents the request for creating a case
 type CreateCaseRequest struct {
-	Body        CreateCaseRequestBody
-	TrajectoryID string
+	OrganizationID string
+	Body           CreateCaseRequestBody
+	TrajectoryID   string
 }
 
 // UpdateCaseRequestBody represents the request body for updating a case
 type UpdateCaseRequestBody struct {
 	CaseName        string  `json:"case_name"`
 	CaseDescription string  `json:"case_description"`
-	DrillDepth      float64 `json:"drill_depth"`
-	PipeSize        float64 `json:"pipe_size"`
+	DrillDepth      float64 `json:"drill_depth" unit:"length"`
+	PipeSize        float64 `json:"pipe_size" unit:"diameter"`
 }
 
 // UpdateCaseRequest represents the request for updating a case
 type UpdateCaseRequest struct {
-	ID   string
-	Body UpdateCaseRequestBody
+	OrganizationID string
+	ID             string
+	Body           UpdateCaseRequestBody
 }
 
 // GetCasesRequest represents the request for getting cases
 type GetCasesRequest struct {
-	TrajectoryID string
+	OrganizationID string
+	TrajectoryID   string
 }
 
 // GetCaseByIDRequest represents the request for getting a case by ID
 type GetCaseByIDRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteCaseRequest represents the request for deleting a case
 type DeleteCaseRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
+}
+
+// CompareCasesRequestBody represents the cases to compare, the first case is the baseline
+type CompareCasesRequestBody struct {
+	CaseIDs   []string `json:"case_ids" binding:"required,min=2,max=10,dive,uuid"`
+	DepthStep float64  `json:"depth_step" binding:"omitempty,gt=0" unit:"length"`
+}
+
+// CompareCasesRequest represents the request for comparing cases. Every case is authorized on its own,
+// so the API key of the caller is passed on for the role check.
+type CompareCasesRequest struct {
+	OrganizationID  string
+	UserID          string
+	ApiKeyScope     string
+	ApiKeyCompanyID *string
+	Body            CompareCasesRequestBody
+}
+
+// GetCaseReportRequest represents the request for the engineering report of a case in the unit system Units.
+type GetCaseReportRequest struct {
+	OrganizationID string
+	CaseID         string
+	Units          *units.System
+}
+
+// ChartSizeQuery is the size of a rendered chart in pixels, the handler's default size when zero.
+type ChartSizeQuery struct {
+	Width  int `form:"width" binding:"omitempty,min=200,max=4000"`
+	Height int `form:"height" binding:"omitempty,min=200,max=4000"`
+}
+
+// GetCaseChartRequest represents the request for the chart of a case named Chart in the unit system Units.
+type GetCaseChartRequest struct {
+	OrganizationID string
+	CaseID         string
+	Chart          string
+	Units          *units.System
+	Query          ChartSizeQuery
+}
+
+// ExportCaseWorkbookRequest represents the request for the Excel workbook of a case in the unit system Units.
+type ExportCaseWorkbookRequest struct {
+	OrganizationID string
+	CaseID         string
+	Units          *units.System
+}
+
+// ImportCaseWorkbookRequest updates a case from an edited workbook. Units is the unit system of workbooks
+// that don't name theirs.
+type ImportCaseWorkbookRequest struct {
+	OrganizationID string
+	CaseID         string
+	Units          *units.System
+	File           io.Reader
 }
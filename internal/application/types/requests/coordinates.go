@@ -0,0 +1,20 @@
+package requests
+
+// CoordinatePointRequestBody represents a coordinate pair. For geographic systems X is longitude
+// and Y is latitude in degrees, for projected systems X is easting and Y is northing in meters.
+type CoordinatePointRequestBody struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// TransformCoordinatesRequestBody represents the request body for converting points between coordinate reference systems
+type TransformCoordinatesRequestBody struct {
+	SourceEPSGCode int                          `json:"source_epsg_code" binding:"required"`
+	TargetEPSGCode int                          `json:"target_epsg_code" binding:"required"`
+	Points         []CoordinatePointRequestBody `json:"points" binding:"required"`
+}
+
+// TransformCoordinatesRequest represents the request for converting points between coordinate reference systems
+type TransformCoordinatesRequest struct {
+	Body TransformCoordinatesRequestBody
+}
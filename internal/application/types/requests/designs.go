@@ -4,45 +4,110 @@ import (
 	"time"
 )
 
-// CreateDesignRequestBody represents the request body for creating a design
+// CreateDesignRequestBody represents the request body for creating a design, new designs start as prototypes
+// at revision 1. The version follows the revision and can not be set
 type CreateDesignRequestBody struct {
 	PlanName   string    `json:"plan_name"`
-	Stage      string    `json:"stage"`
-	Version    string    `json:"version"`
 	ActualDate time.Time `json:"actual_date"`
 }
 
 // CreateDesignRequest represents the request for creating a design
 type CreateDesignRequest struct {
-	Body       CreateDesignRequestBody
-	WellboreID string
+	OrganizationID string
+	Body           CreateDesignRequestBody
+	WellboreID     string
 }
 
-// UpdateDesignRequestBody represents the request body for updating a design
+// UpdateDesignRequestBody represents the request body for updating a design, the stage only changes through transitions
+// and the version only when the design is frozen
 type UpdateDesignRequestBody struct {
 	PlanName   string    `json:"plan_name"`
-	Stage      string    `json:"stage"`
-	Version    string    `json:"version"`
 	ActualDate time.Time `json:"actual_date"`
 }
 
 // UpdateDesignRequest represents the request for updating a design
 type UpdateDesignRequest struct {
-	ID   string
-	Body UpdateDesignRequestBody
+	OrganizationID string
+	ID             string
+	Body           UpdateDesignRequestBody
 }
 
 // GetDesignsRequest represents the request for getting designs
 type GetDesignsRequest struct {
-	WellboreID string
+	OrganizationID string
+	WellboreID     string
 }
 
 // GetDesignByIDRequest represents the request for getting a design by ID
 type GetDesignByIDRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteDesignRequest represents the request for deleting a design
 type DeleteDesignRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
+}
+
+// FreezeDesignRequestBody represents the request body for freezing a design
+type FreezeDesignRequestBody struct {
+	Comment string `json:"comment" binding:"max=1000"`
+}
+
+// FreezeDesignRequest represents the request for freezing the current state of a design as a revision
+type FreezeDesignRequest struct {
+	OrganizationID string
+	UserID         string
+	ID             string
+	Body           FreezeDesignRequestBody
+}
+
+// GetDesignRevisionsRequest represents the request for getting the revisions of a design
+type GetDesignRevisionsRequest struct {
+	OrganizationID string
+	ID             string
+}
+
+// GetDesignRevisionRequest represents the request for getting a revision of a design with its snapshot
+type GetDesignRevisionRequest struct {
+	OrganizationID string
+	ID             string
+	Revision       int
+}
+
+// DiffDesignRevisionsRequestQuery represents the revisions to compare
+type DiffDesignRevisionsRequestQuery struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+// DiffDesignRevisionsRequest represents the request for comparing two revisions of a design
+type DiffDesignRevisionsRequest struct {
+	OrganizationID string
+	ID             string
+	Query          DiffDesignRevisionsRequestQuery
+}
+
+// TransitionDesignStageRequestBody represents the request body for moving a design to another stage
+type TransitionDesignStageRequestBody struct {
+	Stage   string `json:"stage" binding:"required"`
+	Comment string `json:"comment" binding:"max=1000"`
+}
+
+// TransitionDesignStageRequest represents the request for moving a design to another stage. The permission
+// depends on the transition, so the API key of the caller is passed on for the role check.
+type TransitionDesignStageRequest struct {
+	OrganizationID  string
+	UserID          string
+	ApiKeyScope     string
+	ApiKeyCompanyID *string
+	ID              string
+	Body            TransitionDesignStageRequestBody
+}
+
+// GetDesignStageTransitionsRequest represents the request for getting the stage history of a design
+type GetDesignStageTransitionsRequest struct {
+	OrganizationID string
+	ID             string
 }
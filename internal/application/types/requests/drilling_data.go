@@ -0,0 +1,58 @@
+package requests
+
+import (
+	"io"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+)
+
+// ImportDrillingDataRequest imports a CSV file of actual readings. The values of the file are in Units.
+type ImportDrillingDataRequest struct {
+	OrganizationID string
+	WellboreID     string
+	File           io.Reader
+	Units          *units.System
+}
+
+type GetDrillingReadingsRequest struct {
+	OrganizationID string
+	WellboreID     string
+	Query          GetDrillingReadingsRequestQuery
+}
+
+// GetDrillingReadingsRequestQuery filters the readings. From is inclusive, To is exclusive, both in RFC 3339.
+type GetDrillingReadingsRequestQuery struct {
+	From   *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit  int        `form:"limit" binding:"omitempty,min=1,max=10000"`
+	Offset int        `form:"offset" binding:"omitempty,min=0"`
+}
+
+type DeleteDrillingReadingsRequest struct {
+	OrganizationID string
+	WellboreID     string
+	Query          DeleteDrillingReadingsRequestQuery
+}
+
+// DeleteDrillingReadingsRequestQuery limits the deleted readings to a time range, without it all readings are deleted.
+type DeleteDrillingReadingsRequestQuery struct {
+	From *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// CalibrateFrictionFactorsRequestBody selects the case whose string and trajectory model the wellbore, the readings
+// by time and the depth intervals the friction factors are fitted on.
+type CalibrateFrictionFactorsRequestBody struct {
+	CaseID          string               `json:"case_id" binding:"required,uuid"`
+	IntervalLength  float64              `json:"interval_length" binding:"omitempty,gt=0" unit:"length"`
+	FrictionFactors *FrictionFactorRange `json:"friction_factors"`
+	From            *time.Time           `json:"from"`
+	To              *time.Time           `json:"to"`
+}
+
+type CalibrateFrictionFactorsRequest struct {
+	OrganizationID string
+	WellboreID     string
+	Body           CalibrateFrictionFactorsRequestBody
+}
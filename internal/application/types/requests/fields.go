@@ -10,8 +10,9 @@ type CreateFieldRequestBody struct {
 
 // CreateFieldRequest represents the request for creating a field
 type CreateFieldRequest struct {
-	Body      CreateFieldRequestBody
-	CompanyID string
+	OrganizationID string
+	Body           CreateFieldRequestBody
+	CompanyID      string
 }
 
 // UpdateFieldRequestBody represents the request body for updating a field
@@ -24,21 +25,25 @@ type UpdateFieldRequestBody struct {
 
 // UpdateFieldRequest represents the request for updating a field
 type UpdateFieldRequest struct {
-	ID   string
-	Body UpdateFieldRequestBody
+	OrganizationID string
+	ID             string
+	Body           UpdateFieldRequestBody
 }
 
 // GetFieldsRequest represents the request for getting fields
 type GetFieldsRequest struct {
-	CompanyID string
+	OrganizationID string
+	CompanyID      string
 }
 
 // GetFieldByIDRequest represents the request for getting a field by ID
 type GetFieldByIDRequest struct {
-	ID        string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteFieldRequest represents the request for deleting a field
 type DeleteFieldRequest struct {
-	ID        string
+	OrganizationID string
+	ID             string
 }
@@ -2,43 +2,52 @@ package requests
 
 // CreateFractureGradientRequestBody represents the request body for creating a fracture gradient
 type CreateFractureGradientRequestBody struct {
-	TemperatureAtSurface float64 `json:"temperature_at_surface"`
-	TemperatureAtWellTVD float64 `json:"temperature_at_well_tvd"`
-	TemperatureGradient  float64 `json:"temperature_gradient"`
-	WellTVD              float64 `json:"well_tvd"`
+	TemperatureAtSurface float64  `json:"temperature_at_surface" unit:"temperature"`
+	TemperatureAtWellTVD float64  `json:"temperature_at_well_tvd" unit:"temperature"`
+	TemperatureGradient  float64  `json:"temperature_gradient" unit:"temperature_gradient"`
+	WellTVD              float64  `json:"well_tvd" unit:"length"`
+	Pressure             *float64 `json:"pressure" binding:"omitempty,gte=0" unit:"pressure"`
+	EMW                  *float64 `json:"emw" binding:"omitempty,gt=0" unit:"density"`
 }
 
 // CreateFractureGradientRequest represents the request for creating a fracture gradient
 type CreateFractureGradientRequest struct {
-	Body   CreateFractureGradientRequestBody
-	CaseID string
+	OrganizationID string
+	Body           CreateFractureGradientRequestBody
+	CaseID         string
 }
 
 // UpdateFractureGradientRequestBody represents the request body for updating a fracture gradient
 type UpdateFractureGradientRequestBody struct {
-	TemperatureAtSurface float64 `json:"temperature_at_surface"`
-	TemperatureAtWellTVD float64 `json:"temperature_at_well_tvd"`
-	TemperatureGradient  float64 `json:"temperature_gradient"`
-	WellTVD              float64 `json:"well_tvd"`
+	TemperatureAtSurface float64  `json:"temperature_at_surface" unit:"temperature"`
+	TemperatureAtWellTVD float64  `json:"temperature_at_well_tvd" unit:"temperature"`
+	TemperatureGradient  float64  `json:"temperature_gradient" unit:"temperature_gradient"`
+	WellTVD              float64  `json:"well_tvd" unit:"length"`
+	Pressure             *float64 `json:"pressure" binding:"omitempty,gte=0" unit:"pressure"`
+	EMW                  *float64 `json:"emw" binding:"omitempty,gt=0" unit:"density"`
 }
 
 // UpdateFractureGradientRequest represents the request for updating a fracture gradient
 type UpdateFractureGradientRequest struct {
-	ID   string
-	Body UpdateFractureGradientRequestBody
+	OrganizationID string
+	ID             string
+	Body           UpdateFractureGradientRequestBody
 }
 
 // GetFractureGradientsRequest represents the request for getting fracture gradients
 type GetFractureGradientsRequest struct {
-	CaseID string
+	OrganizationID string
+	CaseID         string
 }
 
 // GetFractureGradientByIDRequest represents the request for getting a fracture gradient by ID
 type GetFractureGradientByIDRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteFractureGradientRequest represents the request for deleting a fracture gradient
 type DeleteFractureGradientRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
@@ -2,21 +2,21 @@ package requests
 
 // CreateCaisingRequestBody represents the request body for creating a caising associated with a hole.
 type CreateCaisingRequestBody struct {
-	MDTop                 float64  `json:"md_top"`
-	MDBase                float64  `json:"md_base"`
-	Length                float64  `json:"length"`
-	ShoeMD                *float64 `json:"shoe_md,omitempty"`
-	OD                    float64  `json:"od"`
-	VD                    float64  `json:"vd"`
-	DriftID               float64  `json:"drift_id"`
-	EffectiveHoleDiameter float64  `json:"effective_hole_diameter"`
-	Weight                float64  `json:"weight"`
+	MDTop                 float64  `json:"md_top" unit:"length"`
+	MDBase                float64  `json:"md_base" unit:"length"`
+	Length                float64  `json:"length" unit:"length"`
+	ShoeMD                *float64 `json:"shoe_md,omitempty" unit:"length"`
+	OD                    float64  `json:"od" unit:"diameter"`
+	VD                    float64  `json:"vd" unit:"length"`
+	DriftID               float64  `json:"drift_id" unit:"diameter"`
+	EffectiveHoleDiameter float64  `json:"effective_hole_diameter" unit:"diameter"`
+	Weight                float64  `json:"weight" unit:"linear_weight"`
 	Grade                 string   `json:"grade"`
-	MinYieldStrength      float64  `json:"min_yield_strength"`
-	BurstRating           float64  `json:"burst_rating"`
-	CollapseRating        float64  `json:"collapse_rating"`
+	MinYieldStrength      float64  `json:"min_yield_strength" unit:"stress"`
+	BurstRating           float64  `json:"burst_rating" unit:"pressure"`
+	CollapseRating        float64  `json:"collapse_rating" unit:"pressure"`
 	FrictionFactorCaising float64  `json:"friction_factor_caising"`
-	LinearCapacityCaising float64  `json:"linear_capacity_caising"`
+	LinearCapacityCaising float64  `json:"linear_capacity_caising" unit:"linear_capacity"`
 	DescriptionCaising    *string  `json:"description_caising,omitempty"`
 	ManufacturerCaising   *string  `json:"manufacturer_caising,omitempty"`
 	ModelCaising          *string  `json:"model_caising,omitempty"`
@@ -25,21 +25,21 @@ type CreateCaisingRequestBody struct {
 // UpdateCaisingRequestBody represents the request body for updating a caising associated with a hole.
 type UpdateCaisingRequestBody struct {
 	ID                    string  `json:"id"`
-	MDTop                 float64  `json:"md_top"`
-	MDBase                float64  `json:"md_base"`
-	Length                float64  `json:"length"`
-	ShoeMD                *float64 `json:"shoe_md,omitempty"`
-	OD                    float64  `json:"od"`
-	VD                    float64  `json:"vd"`
-	DriftID               float64  `json:"drift_id"`
-	EffectiveHoleDiameter float64  `json:"effective_hole_diameter"`
-	Weight                float64  `json:"weight"`
+	MDTop                 float64  `json:"md_top" unit:"length"`
+	MDBase                float64  `json:"md_base" unit:"length"`
+	Length                float64  `json:"length" unit:"length"`
+	ShoeMD                *float64 `json:"shoe_md,omitempty" unit:"length"`
+	OD                    float64  `json:"od" unit:"diameter"`
+	VD                    float64  `json:"vd" unit:"length"`
+	DriftID               float64  `json:"drift_id" unit:"diameter"`
+	EffectiveHoleDiameter float64  `json:"effective_hole_diameter" unit:"diameter"`
+	Weight                float64  `json:"weight" unit:"linear_weight"`
 	Grade                 string   `json:"grade"`
-	MinYieldStrength      float64  `json:"min_yield_strength"`
-	BurstRating           float64  `json:"burst_rating"`
-	CollapseRating        float64  `json:"collapse_rating"`
+	MinYieldStrength      float64  `json:"min_yield_strength" unit:"stress"`
+	BurstRating           float64  `json:"burst_rating" unit:"pressure"`
+	CollapseRating        float64  `json:"collapse_rating" unit:"pressure"`
 	FrictionFactorCaising float64  `json:"friction_factor_caising"`
-	LinearCapacityCaising float64  `json:"linear_capacity_caising"`
+	LinearCapacityCaising float64  `json:"linear_capacity_caising" unit:"linear_capacity"`
 	DescriptionCaising    *string  `json:"description_caising,omitempty"`
 	ManufacturerCaising   *string  `json:"manufacturer_caising,omitempty"`
 	ModelCaising          *string  `json:"model_caising,omitempty"`
@@ -47,13 +47,13 @@ type UpdateCaisingRequestBody struct {
 
 // CreateHoleRequestBody represents the request body for creating a hole.
 type CreateHoleRequestBody struct {
-	OpenHoleMDTop           float64                     `json:"open_hole_md_top"`
-	OpenHoleMDBase          float64                     `json:"open_hole_md_base"`
-	OpenHoleLength          float64                     `json:"open_hole_length"`
-	OpenHoleVD              float64                     `json:"open_hole_vd"`
-	EffectiveDiameter       float64                     `json:"effective_diameter"`
+	OpenHoleMDTop           float64                     `json:"open_hole_md_top" unit:"length"`
+	OpenHoleMDBase          float64                     `json:"open_hole_md_base" unit:"length"`
+	OpenHoleLength          float64                     `json:"open_hole_length" unit:"length"`
+	OpenHoleVD              float64                     `json:"open_hole_vd" unit:"length"`
+	EffectiveDiameter       float64                     `json:"effective_diameter" unit:"diameter"`
 	FrictionFactorOpenHole  float64                     `json:"friction_factor_open_hole"`
-	LinearCapacityOpenHole  float64                     `json:"linear_capacity_open_hole"`
+	LinearCapacityOpenHole  float64                     `json:"linear_capacity_open_hole" unit:"linear_capacity"`
 	VolumeExcess            *float64                    `json:"volume_excess,omitempty"`
 	DescriptionOpenHole     *string                     `json:"description_open_hole,omitempty"`
 	TrippingInCasing        float64                     `json:"tripping_in_casing"`
@@ -73,13 +73,13 @@ type CreateHoleRequestBody struct {
 
 // UpdateHoleRequestBody represents the request body for updating a hole.
 type UpdateHoleRequestBody struct {
-	OpenHoleMDTop           float64                     `json:"open_hole_md_top"`
-	OpenHoleMDBase          float64                     `json:"open_hole_md_base"`
-	OpenHoleLength          float64                     `json:"open_hole_length"`
-	OpenHoleVD              float64                     `json:"open_hole_vd"`
-	EffectiveDiameter       float64                     `json:"effective_diameter"`
+	OpenHoleMDTop           float64                     `json:"open_hole_md_top" unit:"length"`
+	OpenHoleMDBase          float64                     `json:"open_hole_md_base" unit:"length"`
+	OpenHoleLength          float64                     `json:"open_hole_length" unit:"length"`
+	OpenHoleVD              float64                     `json:"open_hole_vd" unit:"length"`
+	EffectiveDiameter       float64                     `json:"effective_diameter" unit:"diameter"`
 	FrictionFactorOpenHole  float64                     `json:"friction_factor_open_hole"`
-	LinearCapacityOpenHole  float64                     `json:"linear_capacity_open_hole"`
+	LinearCapacityOpenHole  float64                     `json:"linear_capacity_open_hole" unit:"linear_capacity"`
 	VolumeExcess            *float64                    `json:"volume_excess,omitempty"`
 	DescriptionOpenHole     *string                     `json:"description_open_hole,omitempty"`
 	TrippingInCasing        float64                     `json:"tripping_in_casing"`
@@ -99,27 +99,32 @@ type UpdateHoleRequestBody struct {
 
 // CreateHoleRequest represents the request for creating a hole.
 type CreateHoleRequest struct {
-		Body   CreateHoleRequestBody
-	CaseID string
+	OrganizationID string
+	Body           CreateHoleRequestBody
+	CaseID         string
 }
 
 // UpdateHoleRequest represents the request for updating a hole.
 type UpdateHoleRequest struct {
-	ID    string
-	Body  UpdateHoleRequestBody
+	OrganizationID string
+	ID             string
+	Body           UpdateHoleRequestBody
 }
 
 // GetHolesRequest represents the request for getting holes by case ID.
 type GetHolesRequest struct {
-	CaseID string
+	OrganizationID string
+	CaseID         string
 }
 
 // GetHoleByIDRequest represents the request for getting a hole by its ID.
 type GetHoleByIDRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteHoleRequest represents the request for deleting a hole.
 type DeleteHoleRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
@@ -0,0 +1,21 @@
+package requests
+
+type CreateInvitationRequest struct {
+	OrganizationID string
+	UserID         string
+	Body           CreateInvitationRequestBody
+}
+
+type CreateInvitationRequestBody struct {
+	Email string `json:"email" binding:"required,email,max=64"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type GetInvitationsRequest struct {
+	OrganizationID string
+}
+
+type DeleteInvitationRequest struct {
+	OrganizationID string
+	ID             string
+}
@@ -0,0 +1,50 @@
+package requests
+
+import "github.com/munaiplan/munaiplan-backend/pkg/units"
+
+// JobRequester is who accesses a job, their role on the case of the job is checked for every request.
+// ApiKeyScope and ApiKeyCompanyID are set for requests authenticated with an API key.
+type JobRequester struct {
+	OrganizationID  string
+	UserID          string
+	ApiKeyScope     string
+	ApiKeyCompanyID *string
+}
+
+// CreateJobRequestBody is a calculation of a case, friction sensitivity jobs need the friction factor ranges.
+type CreateJobRequestBody struct {
+	CaseID              string                          `json:"case_id" binding:"required,uuid"`
+	Type                string                          `json:"type" binding:"required,oneof=effective-tension weight-on-bit surface-torque min-weight-on-bit friction-sensitivity"`
+	FrictionSensitivity *FrictionSensitivityRequestBody `json:"friction_sensitivity" binding:"required_if=Type friction-sensitivity"`
+}
+
+// CreateJobRequest represents the request for queueing a calculation
+type CreateJobRequest struct {
+	JobRequester
+	Body CreateJobRequestBody
+}
+
+// GetJobsRequest represents the request for the jobs of a case
+type GetJobsRequest struct {
+	JobRequester
+	CaseID string
+}
+
+// GetJobByIDRequest represents the request for a job with its result
+type GetJobByIDRequest struct {
+	JobRequester
+	ID string
+}
+
+// CancelJobRequest represents the request for cancelling a queued or running job
+type CancelJobRequest struct {
+	JobRequester
+	ID string
+}
+
+// SubscribeJobRequest represents the request for the events of a job in the unit system Units
+type SubscribeJobRequest struct {
+	JobRequester
+	ID    string
+	Units *units.System
+}
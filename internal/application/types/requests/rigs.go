@@ -2,69 +2,74 @@ package requests
 
 // CreateRigRequestBody represents the request body for creating a rig.
 type CreateRigRequestBody struct {
-	BlockRating                     *float64 `json:"block_rating,omitempty"`
-	TorqueRating                    *float64 `json:"torque_rating,omitempty"`
-	RatedWorkingPressure            float64  `json:"rated_working_pressure"`
-	BopPressureRating               float64  `json:"bop_pressure_rating"`
-	SurfacePressureLoss             float64  `json:"surface_pressure_loss"`
-	StandpipeLength                 *float64 `json:"standpipe_length,omitempty"`
-	StandpipeInternalDiameter       *float64 `json:"standpipe_internal_diameter,omitempty"`
-	HoseLength                      *float64 `json:"hose_length,omitempty"`
-	HoseInternalDiameter            *float64 `json:"hose_internal_diameter,omitempty"`
-	SwivelLength                    *float64 `json:"swivel_length,omitempty"`
-	SwivelInternalDiameter          *float64 `json:"swivel_internal_diameter,omitempty"`
-	KellyLength                     *float64 `json:"kelly_length,omitempty"`
-	KellyInternalDiameter           *float64 `json:"kelly_internal_diameter,omitempty"`
-	PumpDischargeLineLength         *float64 `json:"pump_discharge_line_length,omitempty"`
-	PumpDischargeLineInternalDiameter *float64 `json:"pump_discharge_line_internal_diameter,omitempty"`
-	TopDriveStackupLength           *float64 `json:"top_drive_stackup_length,omitempty"`
-	TopDriveStackupInternalDiameter *float64 `json:"top_drive_stackup_internal_diameter,omitempty"`
+	BlockRating                     *float64 `json:"block_rating,omitempty" unit:"force"`
+	TorqueRating                    *float64 `json:"torque_rating,omitempty" unit:"torque"`
+	RatedWorkingPressure            float64  `json:"rated_working_pressure" unit:"pressure"`
+	BopPressureRating               float64  `json:"bop_pressure_rating" unit:"pressure"`
+	SurfacePressureLoss             float64  `json:"surface_pressure_loss" unit:"pressure"`
+	StandpipeLength                 *float64 `json:"standpipe_length,omitempty" unit:"length"`
+	StandpipeInternalDiameter       *float64 `json:"standpipe_internal_diameter,omitempty" unit:"diameter"`
+	HoseLength                      *float64 `json:"hose_length,omitempty" unit:"length"`
+	HoseInternalDiameter            *float64 `json:"hose_internal_diameter,omitempty" unit:"diameter"`
+	SwivelLength                    *float64 `json:"swivel_length,omitempty" unit:"length"`
+	SwivelInternalDiameter          *float64 `json:"swivel_internal_diameter,omitempty" unit:"diameter"`
+	KellyLength                     *float64 `json:"kelly_length,omitempty" unit:"length"`
+	KellyInternalDiameter           *float64 `json:"kelly_internal_diameter,omitempty" unit:"diameter"`
+	PumpDischargeLineLength         *float64 `json:"pump_discharge_line_length,omitempty" unit:"length"`
+	PumpDischargeLineInternalDiameter *float64 `json:"pump_discharge_line_internal_diameter,omitempty" unit:"diameter"`
+	TopDriveStackupLength           *float64 `json:"top_drive_stackup_length,omitempty" unit:"length"`
+	TopDriveStackupInternalDiameter *float64 `json:"top_drive_stackup_internal_diameter,omitempty" unit:"diameter"`
 }
 
 // UpdateRigRequestBody represents the request body for updating a rig.
 type UpdateRigRequestBody struct {
-	BlockRating                     *float64 `json:"block_rating,omitempty"`
-	TorqueRating                    *float64 `json:"torque_rating,omitempty"`
-	RatedWorkingPressure            float64  `json:"rated_working_pressure"`
-	BopPressureRating               float64  `json:"bop_pressure_rating"`
-	SurfacePressureLoss             float64  `json:"surface_pressure_loss"`
-	StandpipeLength                 *float64 `json:"standpipe_length,omitempty"`
-	StandpipeInternalDiameter       *float64 `json:"standpipe_internal_diameter,omitempty"`
-	HoseLength                      *float64 `json:"hose_length,omitempty"`
-	HoseInternalDiameter            *float64 `json:"hose_internal_diameter,omitempty"`
-	SwivelLength                    *float64 `json:"swivel_length,omitempty"`
-	SwivelInternalDiameter          *float64 `json:"swivel_internal_diameter,omitempty"`
-	KellyLength                     *float64 `json:"kelly_length,omitempty"`
-	KellyInternalDiameter           *float64 `json:"kelly_internal_diameter,omitempty"`
-	PumpDischargeLineLength         *float64 `json:"pump_discharge_line_length,omitempty"`
-	PumpDischargeLineInternalDiameter *float64 `json:"pump_discharge_line_internal_diameter,omitempty"`
-	TopDriveStackupLength           *float64 `json:"top_drive_stackup_length,omitempty"`
-	TopDriveStackupInternalDiameter *float64 `json:"top_drive_stackup_internal_diameter,omitempty"`
+	BlockRating                     *float64 `json:"block_rating,omitempty" unit:"force"`
+	TorqueRating                    *float64 `json:"torque_rating,omitempty" unit:"torque"`
+	RatedWorkingPressure            float64  `json:"rated_working_pressure" unit:"pressure"`
+	BopPressureRating               float64  `json:"bop_pressure_rating" unit:"pressure"`
+	SurfacePressureLoss             float64  `json:"surface_pressure_loss" unit:"pressure"`
+	StandpipeLength                 *float64 `json:"standpipe_length,omitempty" unit:"length"`
+	StandpipeInternalDiameter       *float64 `json:"standpipe_internal_diameter,omitempty" unit:"diameter"`
+	HoseLength                      *float64 `json:"hose_length,omitempty" unit:"length"`
+	HoseInternalDiameter            *float64 `json:"hose_internal_diameter,omitempty" unit:"diameter"`
+	SwivelLength                    *float64 `json:"swivel_length,omitempty" unit:"length"`
+	SwivelInternalDiameter          *float64 `json:"swivel_internal_diameter,omitempty" unit:"diameter"`
+	KellyLength                     *float64 `json:"kelly_length,omitempty" unit:"length"`
+	KellyInternalDiameter           *float64 `json:"kelly_internal_diameter,omitempty" unit:"diameter"`
+	PumpDischargeLineLength         *float64 `json:"pump_discharge_line_length,omitempty" unit:"length"`
+	PumpDischargeLineInternalDiameter *float64 `json:"pump_discharge_line_internal_diameter,omitempty" unit:"diameter"`
+	TopDriveStackupLength           *float64 `json:"top_drive_stackup_length,omitempty" unit:"length"`
+	TopDriveStackupInternalDiameter *float64 `json:"top_drive_stackup_internal_diameter,omitempty" unit:"diameter"`
 }
 
 // CreateRigRequest represents the full request for creating a rig.
 type CreateRigRequest struct {
-	Body   CreateRigRequestBody
-	CaseID string
+	OrganizationID string
+	Body           CreateRigRequestBody
+	CaseID         string
 }
 
 // UpdateRigRequest represents the full request for updating a rig.
 type UpdateRigRequest struct {
-	ID    string
-	Body  UpdateRigRequestBody
+	OrganizationID string
+	ID             string
+	Body           UpdateRigRequestBody
 }
 
 // GetRigsRequest represents the request for retrieving all rigs for a case.
 type GetRigsRequest struct {
-	CaseID string
+	OrganizationID string
+	CaseID         string
 }
 
 // GetRigByIDRequest represents the request for retrieving a specific rig by ID.
 type GetRigByIDRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteRigRequest represents the request for deleting a specific rig by ID.
 type DeleteRigRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
@@ -0,0 +1,42 @@
+package requests
+
+// CreateRoleAssignmentRequestBody represents the request body for assigning a role.
+// The role applies to the whole organization unless a company or a field is set.
+type CreateRoleAssignmentRequestBody struct {
+	UserID    string  `json:"user_id" binding:"required"`
+	Role      string  `json:"role" binding:"required"`
+	CompanyID *string `json:"company_id"`
+	FieldID   *string `json:"field_id"`
+}
+
+// CreateRoleAssignmentRequest represents the request for assigning a role
+type CreateRoleAssignmentRequest struct {
+	OrganizationID string
+	Body           CreateRoleAssignmentRequestBody
+}
+
+// GetRoleAssignmentsRequest represents the request for getting the role assignments of an organization
+type GetRoleAssignmentsRequest struct {
+	OrganizationID string
+	UserID         string
+}
+
+// DeleteRoleAssignmentRequest represents the request for deleting a role assignment
+type DeleteRoleAssignmentRequest struct {
+	OrganizationID string
+	ID             string
+}
+
+// AuthorizeRequest represents the permission check of a user for an entity.
+// Scope and ID are empty for actions on the organization itself.
+// ApiKeyScope and ApiKeyCompanyID are set for requests authenticated with an API key, whose scope
+// further limits the roles of the user.
+type AuthorizeRequest struct {
+	UserID          string
+	OrganizationID  string
+	Scope           string
+	ID              string
+	Permission      string
+	ApiKeyScope     string
+	ApiKeyCompanyID *string
+}
@@ -1,50 +1,67 @@
 package requests
 
-// CreateSiteRequestBody represents the request body for creating a site
+// CreateSiteRequestBody represents the request body for creating a site.
+// The location is given either by easting/northing or by latitude/longitude in the datum of EPSGCode,
+// the other pair is calculated. Grid coordinates win when both are set.
 type CreateSiteRequestBody struct {
-	Name    string  `json:"name"`
-	Area    float64 `json:"area"`
-	Block   string  `json:"block"`
-	Azimuth float64 `json:"azimuth"`
-	Country string  `json:"country"`
-	State   string  `json:"state"`
-	Region  string  `json:"region"`
+	Name      string  `json:"name"`
+	Area      float64 `json:"area"`
+	Block     string  `json:"block"`
+	Azimuth   float64 `json:"azimuth"`
+	Country   string  `json:"country"`
+	State     string  `json:"state"`
+	Region    string  `json:"region"`
+	EPSGCode  int     `json:"epsg_code"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Easting   float64 `json:"easting"`
+	Northing  float64 `json:"northing"`
 }
 
 // CreateSiteRequest represents the request for creating a site
 type CreateSiteRequest struct {
-	Body    CreateSiteRequestBody
-	FieldID string
+	OrganizationID string
+	Body           CreateSiteRequestBody
+	FieldID        string
 }
 
 // UpdateSiteRequestBody represents the request body for updating a site
 type UpdateSiteRequestBody struct {
-	Name    string  `json:"name"`
-	Area    float64 `json:"area"`
-	Block   string  `json:"block"`
-	Azimuth float64 `json:"azimuth"`
-	Country string  `json:"country"`
-	State   string  `json:"state"`
-	Region  string  `json:"region"`
+	Name      string  `json:"name"`
+	Area      float64 `json:"area"`
+	Block     string  `json:"block"`
+	Azimuth   float64 `json:"azimuth"`
+	Country   string  `json:"country"`
+	State     string  `json:"state"`
+	Region    string  `json:"region"`
+	EPSGCode  int     `json:"epsg_code"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Easting   float64 `json:"easting"`
+	Northing  float64 `json:"northing"`
 }
 
 // UpdateSiteRequest represents the request for updating a site
 type UpdateSiteRequest struct {
-	ID   string
-	Body UpdateSiteRequestBody
+	OrganizationID string
+	ID             string
+	Body           UpdateSiteRequestBody
 }
 
 // GetSitesRequest represents the request for getting sites
 type GetSitesRequest struct {
-	FieldID string
+	OrganizationID string
+	FieldID        string
 }
 
 // GetSiteByIDRequest represents the request for getting a site by ID
 type GetSiteByIDRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteSiteRequest represents the request for deleting a site
 type DeleteSiteRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
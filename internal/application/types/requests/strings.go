@@ -3,7 +3,7 @@ package requests
 // CreateStringRequestBody represents the request body for creating a String along with its Sections.
 type CreateStringRequestBody struct {
 	Name     string                     `json:"name"`
-	Depth    float64                    `json:"depth"`
+	Depth    float64                    `json:"depth" unit:"length"`
 	Sections []CreateSectionRequestBody `json:"sections"`
 }
 
@@ -12,32 +12,33 @@ type CreateSectionRequestBody struct {
 	Description         *string  `json:"description,omitempty"`
 	Manufacturer        *string  `json:"manufacturer,omitempty"`
 	Type                string   `json:"type"`
-	BodyMD              float64  `json:"body_md"`
-	BodyLength          float64  `json:"body_length"`
-	BodyOD              float64  `json:"body_od"`
-	BodyID              float64  `json:"body_id"`
-	AvgJointLength      *float64 `json:"avg_joint_length,omitempty"`
-	StabilizerLength    *float64 `json:"stabilizer_length,omitempty"`
-	StabilizerOD        *float64 `json:"stabilizer_od,omitempty"`
-	StabilizerID        *float64 `json:"stabilizer_id,omitempty"`
-	Weight              *float64 `json:"weight,omitempty"`
+	BodyMD              float64  `json:"body_md" unit:"length"`
+	BodyLength          float64  `json:"body_length" unit:"length"`
+	BodyOD              float64  `json:"body_od" unit:"diameter"`
+	BodyID              float64  `json:"body_id" unit:"diameter"`
+	AvgJointLength      *float64 `json:"avg_joint_length,omitempty" unit:"length"`
+	StabilizerLength    *float64 `json:"stabilizer_length,omitempty" unit:"length"`
+	StabilizerOD        *float64 `json:"stabilizer_od,omitempty" unit:"diameter"`
+	StabilizerID        *float64 `json:"stabilizer_id,omitempty" unit:"diameter"`
+	Weight              *float64 `json:"weight,omitempty" unit:"linear_weight"`
 	Material            *string  `json:"material,omitempty"`
 	Grade               *string  `json:"grade,omitempty"`
 	Class               *int     `json:"class,omitempty"`
 	FrictionCoefficient *float64 `json:"friction_coefficient,omitempty"`
-	MinYieldStrength    *float64 `json:"min_yield_strength,omitempty"`
+	MinYieldStrength    *float64 `json:"min_yield_strength,omitempty" unit:"stress"`
 }
 
 // CreateStringRequest represents the request for creating a String with Sections.
 type CreateStringRequest struct {
-	Body   CreateStringRequestBody
-	CaseID string
+	OrganizationID string
+	Body           CreateStringRequestBody
+	CaseID         string
 }
 
 // UpdateStringRequestBody represents the request body for updating a String and its associated Sections.
 type UpdateStringRequestBody struct {
 	Name     string                     `json:"name"`
-	Depth    float64                    `json:"depth"`
+	Depth    float64                    `json:"depth" unit:"length"`
 	Sections []UpdateSectionRequestBody `json:"sections"`
 }
 
@@ -47,39 +48,43 @@ type UpdateSectionRequestBody struct {
 	Description         *string  `json:"description,omitempty"`
 	Manufacturer        *string  `json:"manufacturer,omitempty"`
 	Type                string   `json:"type"`
-	BodyMD              float64  `json:"body_md"`
-	BodyLength          float64  `json:"body_length"`
-	BodyOD              float64  `json:"body_od"`
-	BodyID              float64  `json:"body_id"`
-	AvgJointLength      *float64 `json:"avg_joint_length,omitempty"`
-	StabilizerLength    *float64 `json:"stabilizer_length,omitempty"`
-	StabilizerOD        *float64 `json:"stabilizer_od,omitempty"`
-	StabilizerID        *float64 `json:"stabilizer_id,omitempty"`
-	Weight              *float64 `json:"weight,omitempty"`
+	BodyMD              float64  `json:"body_md" unit:"length"`
+	BodyLength          float64  `json:"body_length" unit:"length"`
+	BodyOD              float64  `json:"body_od" unit:"diameter"`
+	BodyID              float64  `json:"body_id" unit:"diameter"`
+	AvgJointLength      *float64 `json:"avg_joint_length,omitempty" unit:"length"`
+	StabilizerLength    *float64 `json:"stabilizer_length,omitempty" unit:"length"`
+	StabilizerOD        *float64 `json:"stabilizer_od,omitempty" unit:"diameter"`
+	StabilizerID        *float64 `json:"stabilizer_id,omitempty" unit:"diameter"`
+	Weight              *float64 `json:"weight,omitempty" unit:"linear_weight"`
 	Material            *string  `json:"material,omitempty"`
 	Grade               *string  `json:"grade,omitempty"`
 	Class               *int     `json:"class,omitempty"`
 	FrictionCoefficient *float64 `json:"friction_coefficient,omitempty"`
-	MinYieldStrength    *float64 `json:"min_yield_strength,omitempty"`
+	MinYieldStrength    *float64 `json:"min_yield_strength,omitempty" unit:"stress"`
 }
 
 // UpdateStringRequest represents the request for updating a String with its Sections.
 type UpdateStringRequest struct {
-	ID   string
-	Body UpdateStringRequestBody
+	OrganizationID string
+	ID             string
+	Body           UpdateStringRequestBody
 }
 
 // GetStringsRequest represents the request for retrieving Strings by Case ID.
 type GetStringsRequest struct {
-	CaseID string
+	OrganizationID string
+	CaseID         string
 }
 
 // GetStringByIDRequest represents the request for retrieving a String by its ID.
 type GetStringByIDRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteStringRequest represents the request for deleting a String by its ID.
 type DeleteStringRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
@@ -0,0 +1,59 @@
+package requests
+
+// SurveyToolErrorTermRequestBody represents one error term of a survey tool error model
+type SurveyToolErrorTermRequestBody struct {
+	Name        string  `json:"name" binding:"required"`
+	Weighting   string  `json:"weighting" binding:"required"`
+	Propagation string  `json:"propagation" binding:"required"`
+	Magnitude   float64 `json:"magnitude"`
+}
+
+// CreateSurveyToolRequestBody represents the request body for creating a survey tool
+type CreateSurveyToolRequestBody struct {
+	Name        string                           `json:"name" binding:"required"`
+	Description string                           `json:"description"`
+	ErrorTerms  []SurveyToolErrorTermRequestBody `json:"error_terms"`
+}
+
+// CreateSurveyToolRequest represents the request for creating a survey tool
+type CreateSurveyToolRequest struct {
+	Body CreateSurveyToolRequestBody
+}
+
+// UpdateSurveyToolRequest represents the request for updating a survey tool
+type UpdateSurveyToolRequest struct {
+	ID   string
+	Body CreateSurveyToolRequestBody // The structure is identical to the create request
+}
+
+// GetSurveyToolByIDRequest represents the request for getting a survey tool by ID
+type GetSurveyToolByIDRequest struct {
+	ID string
+}
+
+// DeleteSurveyToolRequest represents the request for deleting a survey tool
+type DeleteSurveyToolRequest struct {
+	ID string
+}
+
+// SurveyProgramIntervalRequestBody represents an MD interval of the survey program surveyed with one tool
+type SurveyProgramIntervalRequestBody struct {
+	MDFrom       float64 `json:"md_from" unit:"length"`
+	MDTo         float64 `json:"md_to" unit:"length"`
+	SurveyToolID string  `json:"survey_tool_id"`
+}
+
+// PositionUncertaintyRequestBody represents the request body for calculating position uncertainty.
+// DefaultSurveyToolID is used for the depths that are not covered by the survey program.
+type PositionUncertaintyRequestBody struct {
+	Sigma               float64 `json:"sigma"`
+	Step                float64 `json:"step" unit:"length"`
+	DefaultSurveyToolID string  `json:"default_survey_tool_id"`
+}
+
+// PositionUncertaintyRequest represents the request for calculating position uncertainty of a trajectory
+type PositionUncertaintyRequest struct {
+	OrganizationID string
+	TrajectoryID   string
+	Body           PositionUncertaintyRequestBody
+}
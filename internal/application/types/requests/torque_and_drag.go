@@ -22,3 +22,28 @@ type TorqueAndDragFromMLModelRequest struct {
 	CoefficientOfFriction []float64 `json:"Coefficient_of_Friction" binding:"required"`
 	MinimumYieldStrength  []float64 `json:"Minimum_Yield_Strength" binding:"required"`
 }
+
+// FrictionFactorRange is a range of friction factors from From to To with Step, no step means only From.
+// Steps finer than 0.001 are below the precision of friction factors.
+type FrictionFactorRange struct {
+	From float64 `json:"from" binding:"gte=0,lte=1"`
+	To   float64 `json:"to" binding:"gtefield=From,lte=1"`
+	Step float64 `json:"step" binding:"omitempty,gte=0.001"`
+}
+
+type FrictionSensitivityRequestBody struct {
+	OpenHole  FrictionFactorRange `json:"open_hole" binding:"required"`
+	CasedHole FrictionFactorRange `json:"cased_hole" binding:"required"`
+}
+
+type FrictionSensitivityRequest struct {
+	OrganizationID string
+	CaseID         string
+	Body           FrictionSensitivityRequestBody
+}
+
+// GetCalculationResultsRequest represents the request for the stored calculation results of a case.
+type GetCalculationResultsRequest struct {
+	OrganizationID string
+	CaseID         string
+}
@@ -0,0 +1,46 @@
+package requests
+
+// DLSLimitRequestBody represents the dogleg severity limit of a hole section in degrees per 30 m
+type DLSLimitRequestBody struct {
+	Name   string  `json:"name"`
+	MDFrom float64 `json:"md_from" unit:"length"`
+	MDTo   float64 `json:"md_to" unit:"length"`
+	MaxDLS float64 `json:"max_dls" binding:"required" unit:"dls"`
+}
+
+// TortuosityReportRequestBody represents the request body for the tortuosity report.
+// DefaultMaxDLS applies to the depths that are not covered by the sections, zero disables it.
+// StringWeightPerLength is the buoyed weight of the string used for the side force estimate.
+type TortuosityReportRequestBody struct {
+	MicroTortuosityWindow float64               `json:"micro_tortuosity_window" unit:"length"`
+	DefaultMaxDLS         float64               `json:"default_max_dls" unit:"dls"`
+	Sections              []DLSLimitRequestBody `json:"sections"`
+	StringWeightPerLength float64               `json:"string_weight_per_length" unit:"linear_force"`
+}
+
+// TortuosityReportRequest represents the request for the tortuosity report of a trajectory
+type TortuosityReportRequest struct {
+	OrganizationID string
+	TrajectoryID   string
+	Body           TortuosityReportRequestBody
+}
+
+// SyntheticTortuosityRequestBody represents the request body for applying synthetic tortuosity.
+// Mode is "sinusoidal" or "random", Amplitude is in degrees.
+type SyntheticTortuosityRequestBody struct {
+	Name      string  `json:"name"`
+	Mode      string  `json:"mode" binding:"required"`
+	Amplitude float64 `json:"amplitude" binding:"required"`
+	Period    float64 `json:"period" unit:"length"`
+	Step      float64 `json:"step" unit:"length"`
+	MDFrom    float64 `json:"md_from" unit:"length"`
+	MDTo      float64 `json:"md_to" unit:"length"`
+	Seed      int64   `json:"seed"`
+}
+
+// SyntheticTortuosityRequest represents the request for creating a copy of a trajectory with synthetic tortuosity
+type SyntheticTortuosityRequest struct {
+	OrganizationID string
+	TrajectoryID   string
+	Body           SyntheticTortuosityRequestBody
+}
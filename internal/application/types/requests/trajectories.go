@@ -1,5 +1,7 @@
 package requests
 
+import "github.com/munaiplan/munaiplan-backend/pkg/units"
+
 // CreateTrajectoryHeaderRequestBody represents the request body for updating a trajectory header
 type CreateTrajectoryHeaderRequestBody struct {
 	Customer         string  `json:"customer"`
@@ -10,23 +12,23 @@ type CreateTrajectoryHeaderRequestBody struct {
 	Structure        string  `json:"structure"`
 	JobNumber        string  `json:"job_number"`
 	Wellhead         string  `json:"wellhead"`
-	KellyBushingElev float64 `json:"kelly_bushing_elev"`
+	KellyBushingElev float64 `json:"kelly_bushing_elev" unit:"length"`
 	Profile          string  `json:"profile"`
 }
 
 // CreateTrajectoryUnitRequestBody represents the request body for creating or updating a trajectory unit
 type CreateTrajectoryUnitRequestBody struct {
-	MD              float64 `json:"md"`
+	MD              float64 `json:"md" unit:"length"`
 	Incl            float64 `json:"incl"`
 	Azim            float64 `json:"azim"`
-	SubSea          float64 `json:"sub_sea"`
-	TVD             float64 `json:"tvd"`
-	LocalNCoord     float64 `json:"local_n_coord"`
-	LocalECoord     float64 `json:"local_e_coord"`
+	SubSea          float64 `json:"sub_sea" unit:"length"`
+	TVD             float64 `json:"tvd" unit:"length"`
+	LocalNCoord     float64 `json:"local_n_coord" unit:"length"`
+	LocalECoord     float64 `json:"local_e_coord" unit:"length"`
 	GlobalNCoord    float64 `json:"global_n_coord"`
 	GlobalECoord    float64 `json:"global_e_coord"`
-	Dogleg          float64 `json:"dogleg"`
-	VerticalSection float64 `json:"vertical_section"`
+	Dogleg          float64 `json:"dogleg" unit:"dls"`
+	VerticalSection float64 `json:"vertical_section" unit:"length"`
 }
 
 // UpdateTrajectoryHeaderRequestBody represents the request body for updating a trajectory header
@@ -40,65 +42,82 @@ type UpdateTrajectoryHeaderRequestBody struct {
 	Structure        string  `json:"structure"`
 	JobNumber        string  `json:"job_number"`
 	Wellhead         string  `json:"wellhead"`
-	KellyBushingElev float64 `json:"kelly_bushing_elev"`
+	KellyBushingElev float64 `json:"kelly_bushing_elev" unit:"length"`
 	Profile          string  `json:"profile"`
 }
 
 // UpdateTrajectoryUnitRequestBody represents the request body for updating a trajectory unit
 type UpdateTrajectoryUnitRequestBody struct {
 	ID              *string `json:"id"`
-	MD              float64 `json:"md"`
+	MD              float64 `json:"md" unit:"length"`
 	Incl            float64 `json:"incl"`
 	Azim            float64 `json:"azim"`
-	SubSea          float64 `json:"sub_sea"`
-	TVD             float64 `json:"tvd"`
-	LocalNCoord     float64 `json:"local_n_coord"`
-	LocalECoord     float64 `json:"local_e_coord"`
+	SubSea          float64 `json:"sub_sea" unit:"length"`
+	TVD             float64 `json:"tvd" unit:"length"`
+	LocalNCoord     float64 `json:"local_n_coord" unit:"length"`
+	LocalECoord     float64 `json:"local_e_coord" unit:"length"`
 	GlobalNCoord    float64 `json:"global_n_coord"`
 	GlobalECoord    float64 `json:"global_e_coord"`
-	Dogleg          float64 `json:"dogleg"`
-	VerticalSection float64 `json:"vertical_section"`
+	Dogleg          float64 `json:"dogleg" unit:"dls"`
+	VerticalSection float64 `json:"vertical_section" unit:"length"`
 }
 
 // CreateTrajectoryRequestBody represents the request body for creating a trajectory
 type CreateTrajectoryRequestBody struct {
-	Name        string                              `json:"name"`
-	Description string                              `json:"description"`
-	Headers     []CreateTrajectoryHeaderRequestBody `json:"headers"`
-	Units       []CreateTrajectoryUnitRequestBody   `json:"units"`
+	Name          string                              `json:"name"`
+	Description   string                              `json:"description"`
+	Headers       []CreateTrajectoryHeaderRequestBody `json:"headers"`
+	Units         []CreateTrajectoryUnitRequestBody   `json:"units"`
+	SurveyProgram []SurveyProgramIntervalRequestBody  `json:"survey_program"`
 }
 
-// UpdateTrajectoryRequestBody represents the request body for creating a trajectory
+// UpdateTrajectoryRequestBody represents the request body for creating a trajectory.
+// The survey program is left unchanged when it is omitted.
 type UpdateTrajectoryRequestBody struct {
-	Name        string                              `json:"name"`
-	Description string                              `json:"description"`
-	Headers     []UpdateTrajectoryHeaderRequestBody `json:"headers"`
-	Units       []UpdateTrajectoryUnitRequestBody   `json:"units"`
+	Name          string                              `json:"name"`
+	Description   string                              `json:"description"`
+	Headers       []UpdateTrajectoryHeaderRequestBody `json:"headers"`
+	Units         []UpdateTrajectoryUnitRequestBody   `json:"units"`
+	SurveyProgram []SurveyProgramIntervalRequestBody  `json:"survey_program"`
 }
 
 // CreateTrajectoryRequest represents the request for creating a trajectory
 type CreateTrajectoryRequest struct {
-	Body     CreateTrajectoryRequestBody
-	DesignID string
+	OrganizationID string
+	Body           CreateTrajectoryRequestBody
+	DesignID       string
 }
 
 // UpdateTrajectoryRequest represents the request for updating a trajectory
 type UpdateTrajectoryRequest struct {
-	ID   string
-	Body UpdateTrajectoryRequestBody // The structure is identical to the create request
+	OrganizationID string
+	ID             string
+	Body           UpdateTrajectoryRequestBody // The structure is identical to the create request
 }
 
 // GetTrajectoriesRequest represents the request for getting trajectories
 type GetTrajectoriesRequest struct {
-	DesignID string
+	OrganizationID string
+	DesignID       string
 }
 
 // GetTrajectoryByIDRequest represents the request for getting a trajectory by ID
 type GetTrajectoryByIDRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteTrajectoryRequest represents the request for deleting a trajectory
 type DeleteTrajectoryRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
+}
+
+// GetTrajectoryChartRequest represents the request for the chart of a trajectory named Chart in the unit system Units
+type GetTrajectoryChartRequest struct {
+	OrganizationID string
+	TrajectoryID   string
+	Chart          string
+	Units          *units.System
+	Query          ChartSizeQuery
 }
@@ -6,14 +6,58 @@ type UserSignInRequest struct {
 }
 
 type UserSignUpRequest struct {
-	Body           UserSignUpRequestBody
-	OrganizationID string
+	Body UserSignUpRequestBody
 }
 
+// UserSignUpRequestBody accepts an invitation. The email and the organization come from the invitation.
 type UserSignUpRequestBody struct {
-	Email    string `json:"email" binding:"required,email,max=64"`
+	InviteToken string `json:"invite_token" binding:"required"`
+	Password    string `json:"password" binding:"required,min=8,max=64"`
+	Name        string `json:"name" binding:"required,min=3,max=32"`
+	Surname     string `json:"surname" binding:"required,min=3,max=32"`
+	Phone       string `json:"phone" binding:"omitempty,min=10,max=20"`
+}
+
+// RefreshTokenRequest is built from the claims of a verified refresh token.
+type RefreshTokenRequest struct {
+	UserID    string
+	SessionID string
+	TokenID   string
+}
+
+type SignOutRequest struct {
+	UserID    string
+	SessionID string
+}
+
+type UpdateProfileRequest struct {
+	UserID string
+	Body   UpdateProfileRequestBody
+}
+
+type UpdateProfileRequestBody struct {
+	Name    string `json:"name" binding:"required,min=3,max=32"`
+	Surname string `json:"surname" binding:"required,min=3,max=32"`
+	Phone   string `json:"phone" binding:"omitempty,min=10,max=20"`
+}
+
+// ChangePasswordRequest keeps the session of the request signed in, other sessions are revoked.
+type ChangePasswordRequest struct {
+	UserID    string
+	SessionID string
+	Body      ChangePasswordRequestBody
+}
+
+type ChangePasswordRequestBody struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=64"`
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email,max=64"`
+}
+
+type PasswordResetConfirmRequest struct {
+	Token    string `json:"token" binding:"required"`
 	Password string `json:"password" binding:"required,min=8,max=64"`
-	Name     string `json:"name" binding:"required,min=3,max=32"`
-	Surname  string `json:"surname" binding:"required,min=3,max=32"`
-	Phone    string `json:"phone" binding:"omitempty,min=10,max=20"`
 }
@@ -0,0 +1,65 @@
+package requests
+
+import "io"
+
+// ImportWellLogRequest imports a LAS 1.2 or 2.0 file into the wellbore. The curves are converted from the
+// units of the file.
+type ImportWellLogRequest struct {
+	OrganizationID string
+	WellboreID     string
+	FileName       string
+	File           io.Reader
+}
+
+type GetWellLogsRequest struct {
+	OrganizationID string
+	WellboreID     string
+}
+
+type GetWellLogByIDRequest struct {
+	OrganizationID string
+	WellboreID     string
+	ID             string
+}
+
+type DeleteWellLogRequest struct {
+	OrganizationID string
+	WellboreID     string
+	ID             string
+}
+
+// EstimatePorePressureRequest estimates the pore and fracture pressure of a case from a log of its wellbore.
+type EstimatePorePressureRequest struct {
+	OrganizationID string
+	CaseID         string
+	Body           EstimatePorePressureRequestBody
+}
+
+// EstimatePorePressureRequestBody selects the log, the curve the Eaton method runs on and the normal compaction
+// trend picked on it. Save stores the binned result as the pore pressures and fracture gradients of the case,
+// Replace allows overwriting existing ones.
+type EstimatePorePressureRequestBody struct {
+	WellLogID      string             `json:"well_log_id" binding:"required,uuid"`
+	Method         string             `json:"method" binding:"required,oneof=sonic resistivity"`
+	NormalTrend    NormalTrendRequest `json:"normal_trend" binding:"required"`
+	Exponent       *float64           `json:"exponent" binding:"omitempty,gt=0"`
+	WaterDensity   *float64           `json:"water_density" binding:"omitempty,gt=0" unit:"density"`
+	ShallowDensity *float64           `json:"shallow_density" binding:"omitempty,gt=0" unit:"density"`
+	PoissonRatio   *float64           `json:"poisson_ratio" binding:"omitempty,gt=0,lt=0.5"`
+	Interval       *float64           `json:"interval" binding:"omitempty,gt=0" unit:"length"`
+	Save           bool               `json:"save"`
+	Replace        bool               `json:"replace"`
+}
+
+// NormalTrendRequest is the normal compaction trend through two points, a straight line on a semi-logarithmic plot.
+type NormalTrendRequest struct {
+	Top    NormalTrendPointRequest `json:"top" binding:"required"`
+	Bottom NormalTrendPointRequest `json:"bottom" binding:"required"`
+}
+
+// NormalTrendPointRequest is a point of the trend, it needs the value of the curve the method runs on.
+type NormalTrendPointRequest struct {
+	TVD         float64  `json:"tvd" binding:"gte=0" unit:"length"`
+	Sonic       *float64 `json:"sonic" binding:"omitempty,gt=0" unit:"slowness"`
+	Resistivity *float64 `json:"resistivity" binding:"omitempty,gt=0"`
+}
@@ -4,61 +4,66 @@ package requests
 type CreateWellboreRequestBody struct {
 	Name                           string  `json:"name"`
 	BottomHoleLocation             string  `json:"bottom_hole_location"`
-	WellboreDepth                  float64 `json:"wellbore_depth"`
-	AverageHookLoad                float64 `json:"average_hook_load"`
-	RiserPressure                  float64 `json:"riser_pressure"`
-	AverageInletFlow               float64 `json:"average_inlet_flow"`
+	WellboreDepth                  float64 `json:"wellbore_depth" unit:"length"`
+	AverageHookLoad                float64 `json:"average_hook_load" unit:"force"`
+	RiserPressure                  float64 `json:"riser_pressure" unit:"pressure"`
+	AverageInletFlow               float64 `json:"average_inlet_flow" unit:"flow_rate"`
 	AverageColumnRotationFrequency float64 `json:"average_column_rotation_frequency"`
 	MaximumColumnRotationFrequency float64 `json:"maximum_column_rotation_frequency"`
-	AverageWeightOnBit             float64 `json:"average_weight_on_bit"`
-	MaximumWeightOnBit             float64 `json:"maximum_weight_on_bit"`
-	AverageTorque                  float64 `json:"average_torque"`
-	MaximumTorque                  float64 `json:"maximum_torque"`
+	AverageWeightOnBit             float64 `json:"average_weight_on_bit" unit:"force"`
+	MaximumWeightOnBit             float64 `json:"maximum_weight_on_bit" unit:"force"`
+	AverageTorque                  float64 `json:"average_torque" unit:"torque"`
+	MaximumTorque                  float64 `json:"maximum_torque" unit:"torque"`
 	DownStaticFriction             float64 `json:"down_static_friction"`
-	DepthInterval                  float64 `json:"depth_interval"`
+	DepthInterval                  float64 `json:"depth_interval" unit:"length"`
 }
 
 // CreateWellboreRequest represents the request for creating a wellbore
 type CreateWellboreRequest struct {
-	Body   CreateWellboreRequestBody
-	WellID string
+	OrganizationID string
+	Body           CreateWellboreRequestBody
+	WellID         string
 }
 
 // UpdateWellboreRequestBody represents the request body for updating a wellbore
 type UpdateWellboreRequestBody struct {
 	Name                           string  `json:"name"`
 	BottomHoleLocation             string  `json:"bottom_hole_location"`
-	WellboreDepth                  float64 `json:"wellbore_depth"`
-	AverageHookLoad                float64 `json:"average_hook_load"`
-	RiserPressure                  float64 `json:"riser_pressure"`
-	AverageInletFlow               float64 `json:"average_inlet_flow"`
+	WellboreDepth                  float64 `json:"wellbore_depth" unit:"length"`
+	AverageHookLoad                float64 `json:"average_hook_load" unit:"force"`
+	RiserPressure                  float64 `json:"riser_pressure" unit:"pressure"`
+	AverageInletFlow               float64 `json:"average_inlet_flow" unit:"flow_rate"`
 	AverageColumnRotationFrequency float64 `json:"average_column_rotation_frequency"`
 	MaximumColumnRotationFrequency float64 `json:"maximum_column_rotation_frequency"`
-	AverageWeightOnBit             float64 `json:"average_weight_on_bit"`
-	MaximumWeightOnBit             float64 `json:"maximum_weight_on_bit"`
-	AverageTorque                  float64 `json:"average_torque"`
-	MaximumTorque                  float64 `json:"maximum_torque"`
+	AverageWeightOnBit             float64 `json:"average_weight_on_bit" unit:"force"`
+	MaximumWeightOnBit             float64 `json:"maximum_weight_on_bit" unit:"force"`
+	AverageTorque                  float64 `json:"average_torque" unit:"torque"`
+	MaximumTorque                  float64 `json:"maximum_torque" unit:"torque"`
 	DownStaticFriction             float64 `json:"down_static_friction"`
-	DepthInterval                  float64 `json:"depth_interval"`
+	DepthInterval                  float64 `json:"depth_interval" unit:"length"`
 }
 
 // UpdateWellboreRequest represents the request for updating a wellbore
 type UpdateWellboreRequest struct {
-	ID   string
-	Body UpdateWellboreRequestBody
+	OrganizationID string
+	ID             string
+	Body           UpdateWellboreRequestBody
 }
 
 // GetWellboresRequest represents the request for getting wellbores
 type GetWellboresRequest struct {
-	WellID string
+	OrganizationID string
+	WellID         string
 }
 
 // GetWellboreByIDRequest represents the request for getting a wellbore by ID
 type GetWellboreByIDRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteWellboreRequest represents the request for deleting a wellbore
 type DeleteWellboreRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
@@ -1,52 +1,68 @@
 package requests
 
-// CreateWellRequestBody represents the request body for creating a well
+// CreateWellRequestBody represents the request body for creating a well.
+// The surface location follows the rules of the site location, EPSGCode defaults to the CRS of the site.
 type CreateWellRequestBody struct {
-	Name                    string `json:"name"`
-	Description             string `json:"description"`
-	Location                string `json:"location"`
-	UniversalWellIdentifier string `json:"universal_well_identifier"`
-	Type                    string `json:"type"`
-	WellNumber              string `json:"well_number"`
-	WorkingGroup            string `json:"working_group"`
-	ActiveWellUnit          string `json:"active_well_unit"`
+	Name                    string  `json:"name"`
+	Description             string  `json:"description"`
+	Location                string  `json:"location"`
+	UniversalWellIdentifier string  `json:"universal_well_identifier"`
+	Type                    string  `json:"type"`
+	WellNumber              string  `json:"well_number"`
+	WorkingGroup            string  `json:"working_group"`
+	ActiveWellUnit          string  `json:"active_well_unit"`
+	EPSGCode                int     `json:"epsg_code"`
+	Latitude                float64 `json:"latitude"`
+	Longitude               float64 `json:"longitude"`
+	Easting                 float64 `json:"easting"`
+	Northing                float64 `json:"northing"`
 }
 
 // CreateWellRequest represents the request for creating a well
 type CreateWellRequest struct {
-	Body   CreateWellRequestBody
-	SiteID string
+	OrganizationID string
+	Body           CreateWellRequestBody
+	SiteID         string
 }
 
 // UpdateWellRequestBody represents the request body for updating a well
 type UpdateWellRequestBody struct {
-	Name                    string `json:"name"`
-	Description             string `json:"description"`
-	Location                string `json:"location"`
-	UniversalWellIdentifier string `json:"universal_well_identifier"`
-	Type                    string `json:"type"`
-	WellNumber              string `json:"well_number"`
-	WorkingGroup            string `json:"working_group"`
-	ActiveWellUnit          string `json:"active_well_unit"`
+	Name                    string  `json:"name"`
+	Description             string  `json:"description"`
+	Location                string  `json:"location"`
+	UniversalWellIdentifier string  `json:"universal_well_identifier"`
+	Type                    string  `json:"type"`
+	WellNumber              string  `json:"well_number"`
+	WorkingGroup            string  `json:"working_group"`
+	ActiveWellUnit          string  `json:"active_well_unit"`
+	EPSGCode                int     `json:"epsg_code"`
+	Latitude                float64 `json:"latitude"`
+	Longitude               float64 `json:"longitude"`
+	Easting                 float64 `json:"easting"`
+	Northing                float64 `json:"northing"`
 }
 
 // UpdateWellRequest represents the request for updating a well
 type UpdateWellRequest struct {
-	ID   string
-	Body UpdateWellRequestBody
+	OrganizationID string
+	ID             string
+	Body           UpdateWellRequestBody
 }
 
 // GetWellsRequest represents the request for getting wells
 type GetWellsRequest struct {
-	SiteID string
+	OrganizationID string
+	SiteID         string
 }
 
 // GetWellByIDRequest represents the request for getting a well by ID
 type GetWellByIDRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
 
 // DeleteWellRequest represents the request for deleting a well
 type DeleteWellRequest struct {
-	ID string
+	OrganizationID string
+	ID             string
 }
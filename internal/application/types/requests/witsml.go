@@ -0,0 +1,25 @@
+package requests
+
+import "io"
+
+// ImportWitsmlRequest imports the objects of a WITSML 1.4.1 file under the parent ParentID. The values of
+// the file are converted from their uom attributes.
+type ImportWitsmlRequest struct {
+	OrganizationID string
+	ParentID       string
+	File           io.Reader
+}
+
+// ImportWitsmlStringRequest imports the drill string of a case from a tubulars file. BhaRuns is optional,
+// its bhaRun names the string, selects the tubular and sets the string depth.
+type ImportWitsmlStringRequest struct {
+	OrganizationID string
+	CaseID         string
+	Tubulars       io.Reader
+	BhaRuns        io.Reader
+}
+
+type ExportWitsmlRequest struct {
+	OrganizationID string
+	ID             string
+}
@@ -0,0 +1,43 @@
+package responses
+
+// AntiCollisionStationResponse represents the separation between the reference and an offset trajectory at one reference MD.
+type AntiCollisionStationResponse struct {
+	ReferenceMD      float64 `json:"reference_md" unit:"length"`
+	ReferenceTVD     float64 `json:"reference_tvd" unit:"length"`
+	OffsetMD         float64 `json:"offset_md" unit:"length"`
+	OffsetTVD        float64 `json:"offset_tvd" unit:"length"`
+	CenterToCenter   float64 `json:"center_to_center" unit:"length"`
+	ReferenceRadius  float64 `json:"reference_radius" unit:"length"`
+	OffsetRadius     float64 `json:"offset_radius" unit:"length"`
+	SeparationFactor float64 `json:"separation_factor"`
+	Status           string  `json:"status"`
+}
+
+// AntiCollisionOffsetResponse represents the scan result against one offset trajectory.
+type AntiCollisionOffsetResponse struct {
+	WellID                string                         `json:"well_id"`
+	WellName              string                         `json:"well_name"`
+	WellboreID            string                         `json:"wellbore_id"`
+	WellboreName          string                         `json:"wellbore_name"`
+	DesignID              string                         `json:"design_id"`
+	TrajectoryID          string                         `json:"trajectory_id"`
+	TrajectoryName        string                         `json:"trajectory_name"`
+	MinCenterToCenter     float64                        `json:"min_center_to_center" unit:"length"`
+	MinSeparationFactor   float64                        `json:"min_separation_factor"`
+	ClosestApproach       *AntiCollisionStationResponse  `json:"closest_approach"`
+	MinSeparationFactorAt *AntiCollisionStationResponse  `json:"min_separation_factor_at"`
+	Status                string                         `json:"status"`
+	SkipReason            string                         `json:"skip_reason,omitempty"`
+	Stations              []AntiCollisionStationResponse `json:"stations"`
+}
+
+// AntiCollisionResponse represents the result of an anti-collision scan of a trajectory.
+type AntiCollisionResponse struct {
+	TrajectoryID            string                        `json:"trajectory_id"`
+	Scope                   string                        `json:"scope"`
+	WarningSeparationFactor float64                       `json:"warning_separation_factor"`
+	MinimumSeparationFactor float64                       `json:"minimum_separation_factor"`
+	MinimumCenterToCenter   float64                       `json:"minimum_center_to_center" unit:"length"`
+	Status                  string                        `json:"status"`
+	Offsets                 []AntiCollisionOffsetResponse `json:"offsets"`
+}
@@ -0,0 +1,17 @@
+package responses
+
+import (
+	"time"
+)
+
+// ApiKeyCreatedResponse represents a new API key. The key itself is only returned once, on creation.
+type ApiKeyCreatedResponse struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Scope     string     `json:"scope"`
+	CompanyID *string    `json:"company_id"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	Key       string     `json:"key"`
+}
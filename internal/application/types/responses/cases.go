@@ -0,0 +1,59 @@
+package responses
+
+import (
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/pkg/jsondiff"
+)
+
+// CaseComparisonResponse represents cases compared against the first one. The curves of every case are
+// resampled on the common depth grid and are null where the case has no result for the depth.
+type CaseComparisonResponse struct {
+	BaselineCaseID string                        `json:"baseline_case_id"`
+	Depth          []float64                     `json:"depth" unit:"length"`
+	Cases          []*CaseComparisonCaseResponse `json:"cases"`
+}
+
+// CaseComparisonCaseResponse represents one compared case. InputChanges lists the differences of the case and its
+// components from the baseline with values in canonical units, components are matched by their position.
+// Error is set when the calculation of the case failed, the other cases are still compared.
+type CaseComparisonCaseResponse struct {
+	CaseID         string                        `json:"case_id"`
+	CaseName       string                        `json:"case_name"`
+	TrajectoryID   string                        `json:"trajectory_id"`
+	TrajectoryName string                        `json:"trajectory_name"`
+	Error          string                        `json:"error,omitempty"`
+	InputChanges   []jsondiff.Change             `json:"input_changes"`
+	Curves         CaseComparisonCurvesResponse  `json:"curves"`
+	Summary        CaseComparisonSummaryResponse `json:"summary"`
+}
+
+// CaseComparisonCurvesResponse represents the calculation results of a case on the common depth grid.
+// MudWeight is the static density of the case fluid. It is not an equivalent circulating density, annular
+// friction losses are not modeled because fluids have no rheology.
+type CaseComparisonCurvesResponse struct {
+	HookLoadPullUp     []*float64 `json:"hook_load_pull_up" unit:"force"`
+	HookLoadRunIn      []*float64 `json:"hook_load_run_in" unit:"force"`
+	HookLoadRotary     []*float64 `json:"hook_load_rotary" unit:"force"`
+	TorqueRotary       []*float64 `json:"torque_rotary" unit:"torque"`
+	WeightOnBitRotary  []*float64 `json:"weight_on_bit_rotary" unit:"force"`
+	WeightOnBitSliding []*float64 `json:"weight_on_bit_sliding" unit:"force"`
+	MudWeight          []*float64 `json:"mud_weight" unit:"density"`
+}
+
+// CaseComparisonSummaryResponse represents the maxima of a case and their deltas from the baseline.
+type CaseComparisonSummaryResponse struct {
+	MaxHookLoad         *float64 `json:"max_hook_load" unit:"force"`
+	MaxTorque           *float64 `json:"max_torque" unit:"torque"`
+	MaxWeightOnBit      *float64 `json:"max_weight_on_bit" unit:"force"`
+	MaxMudWeight        *float64 `json:"max_mud_weight" unit:"density"`
+	DeltaMaxHookLoad    *float64 `json:"delta_max_hook_load" unit:"force"`
+	DeltaMaxTorque      *float64 `json:"delta_max_torque" unit:"torque"`
+	DeltaMaxWeightOnBit *float64 `json:"delta_max_weight_on_bit" unit:"force"`
+	DeltaMaxMudWeight   *float64 `json:"delta_max_mud_weight" unit:"density"`
+}
+
+// CaseWorkbookImportResponse names the sheets of the workbook that updated the case and holds the updated case.
+type CaseWorkbookImportResponse struct {
+	ImportedSheets []string       `json:"imported_sheets"`
+	Case           *entities.Case `json:"case"`
+}
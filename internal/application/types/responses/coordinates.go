@@ -0,0 +1,25 @@
+package responses
+
+// CRSResponse represents a supported coordinate reference system.
+type CRSResponse struct {
+	EPSGCode  int    `json:"epsg_code"`
+	Name      string `json:"name"`
+	Datum     string `json:"datum"`
+	Projected bool   `json:"projected"`
+}
+
+// CoordinatePointResponse represents a converted point. Grid convergence in degrees and
+// point scale factor are set only for projected target systems.
+type CoordinatePointResponse struct {
+	X               float64 `json:"x"`
+	Y               float64 `json:"y"`
+	GridConvergence float64 `json:"grid_convergence,omitempty"`
+	ScaleFactor     float64 `json:"scale_factor,omitempty"`
+}
+
+// TransformCoordinatesResponse represents points converted between coordinate reference systems.
+type TransformCoordinatesResponse struct {
+	SourceEPSGCode int                       `json:"source_epsg_code"`
+	TargetEPSGCode int                       `json:"target_epsg_code"`
+	Points         []CoordinatePointResponse `json:"points"`
+}
@@ -0,0 +1,14 @@
+package responses
+
+import (
+	"github.com/munaiplan/munaiplan-backend/pkg/jsondiff"
+)
+
+// DesignRevisionsDiffResponse represents the field by field changes between two revisions of a design.
+// Values are in canonical units, as they are stored in the snapshots.
+type DesignRevisionsDiffResponse struct {
+	DesignID string            `json:"design_id"`
+	From     int               `json:"from"`
+	To       int               `json:"to"`
+	Changes  []jsondiff.Change `json:"changes"`
+}
@@ -0,0 +1,47 @@
+package responses
+
+// DrillingDataImportResponse reports the imported and the skipped rows of a file with the first row errors.
+type DrillingDataImportResponse struct {
+	Imported int      `json:"imported"`
+	Skipped  int      `json:"skipped"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// DeleteDrillingReadingsResponse reports the number of deleted readings.
+type DeleteDrillingReadingsResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// FrictionCalibrationResponse holds the friction factors that fit the actual readings best per depth interval.
+type FrictionCalibrationResponse struct {
+	WellboreID     string                                 `json:"wellbore_id"`
+	CaseID         string                                 `json:"case_id"`
+	IntervalLength float64                                `json:"interval_length" unit:"length"`
+	Intervals      []*FrictionCalibrationIntervalResponse `json:"intervals"`
+}
+
+// FrictionCalibrationIntervalResponse holds the fits of the operations in a bit depth interval. Operations
+// without enough readings in the interval have no fit.
+type FrictionCalibrationIntervalResponse struct {
+	Top      float64                      `json:"top" unit:"length"`
+	Bottom   float64                      `json:"bottom" unit:"length"`
+	TripIn   *HookLoadFrictionFitResponse `json:"trip_in,omitempty"`
+	TripOut  *HookLoadFrictionFitResponse `json:"trip_out,omitempty"`
+	Rotating *TorqueFrictionFitResponse   `json:"rotating,omitempty"`
+}
+
+// HookLoadFrictionFitResponse is the friction factor whose modelled hook load has the smallest root mean square
+// error against the readings.
+type HookLoadFrictionFitResponse struct {
+	FrictionFactor float64 `json:"friction_factor"`
+	RMSError       float64 `json:"rms_error" unit:"force"`
+	Readings       int     `json:"readings"`
+}
+
+// TorqueFrictionFitResponse is the friction factor whose modelled surface torque has the smallest root mean square
+// error against the readings.
+type TorqueFrictionFitResponse struct {
+	FrictionFactor float64 `json:"friction_factor"`
+	RMSError       float64 `json:"rms_error" unit:"torque"`
+	Readings       int     `json:"readings"`
+}
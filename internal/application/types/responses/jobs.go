@@ -0,0 +1,31 @@
+package responses
+
+import "github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+
+// JobResponse is a job with the result of a succeeded calculation, which has the response type
+// of the synchronous calculation of the same type.
+type JobResponse struct {
+	*entities.Job
+	Result interface{} `json:"result,omitempty"`
+}
+
+// JobEvent is an event of a job stream, Name is the event type and Data is sent as JSON.
+type JobEvent struct {
+	Name string
+	Data interface{}
+}
+
+// JobProgressResponse is the number of finished steps of a calculation, e.g. friction sensitivity curves.
+type JobProgressResponse struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// JobResultBatchResponse is the part of the result of a job from FromDepth to ToDepth. Batch counts from 1.
+type JobResultBatchResponse struct {
+	Batch     int         `json:"batch"`
+	Batches   int         `json:"batches"`
+	FromDepth float64     `json:"from_depth" unit:"length"`
+	ToDepth   float64     `json:"to_depth" unit:"length"`
+	Result    interface{} `json:"result"`
+}
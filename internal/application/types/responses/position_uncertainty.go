@@ -0,0 +1,25 @@
+package responses
+
+// PositionUncertaintyStationResponse represents the position uncertainty at one station.
+// Ellipse axes and the vertical uncertainty are scaled by the requested sigma.
+type PositionUncertaintyStationResponse struct {
+	MD            float64 `json:"md" unit:"length"`
+	TVD           float64 `json:"tvd" unit:"length"`
+	North         float64 `json:"north" unit:"length"`
+	East          float64 `json:"east" unit:"length"`
+	SurveyTool    string  `json:"survey_tool"`
+	SigmaNorth    float64 `json:"sigma_north" unit:"length"`
+	SigmaEast     float64 `json:"sigma_east" unit:"length"`
+	SigmaVertical float64 `json:"sigma_vertical" unit:"length"`
+	SemiMajor     float64 `json:"semi_major" unit:"length"`
+	SemiMinor     float64 `json:"semi_minor" unit:"length"`
+	MajorAzimuth  float64 `json:"major_azimuth"`
+	Vertical      float64 `json:"vertical" unit:"length"`
+}
+
+// PositionUncertaintyResponse represents the position uncertainty along a trajectory.
+type PositionUncertaintyResponse struct {
+	TrajectoryID string                               `json:"trajectory_id"`
+	Sigma        float64                              `json:"sigma"`
+	Stations     []PositionUncertaintyStationResponse `json:"stations"`
+}
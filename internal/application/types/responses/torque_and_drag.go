@@ -2,45 +2,63 @@ package responses
 
 // EffectiveTensionResponse represents the response structure for the Effective Tension prediction.
 type EffectiveTensionFromMLModelResponse struct {
-	Depth                           []float64 `json:"Глубина"`
-	TowerLoadCapacity               []float64 `json:"Грузоподъёмность вышки"`
-	RotaryDrilling                  []float64 `json:"Бурение ротором"`
-	HelicalBucklingWithoutRotation  []float64 `json:"Спиральный изгиб(без вращения)"`
-	PullUp                          []float64 `json:"Подъём"`
-	SinusoidalBucklingAllOperations []float64 `json:"Синусоидальный изгиб(все операции)"`
-	RunIn                           []float64 `json:"Спуск"`
-	DrillingGZD                     []float64 `json:"Бурение ГЗД"`
-	HelicalBucklingWithRotation     []float64 `json:"Спиральный изгиб(с вращением)"`
-	TensionLimit                    []float64 `json:"Предел натяжения"`
+	Depth                           []float64 `json:"Глубина" unit:"length"`
+	TowerLoadCapacity               []float64 `json:"Грузоподъёмность вышки" unit:"force"`
+	RotaryDrilling                  []float64 `json:"Бурение ротором" unit:"force"`
+	HelicalBucklingWithoutRotation  []float64 `json:"Спиральный изгиб(без вращения)" unit:"force"`
+	PullUp                          []float64 `json:"Подъём" unit:"force"`
+	SinusoidalBucklingAllOperations []float64 `json:"Синусоидальный изгиб(все операции)" unit:"force"`
+	RunIn                           []float64 `json:"Спуск" unit:"force"`
+	DrillingGZD                     []float64 `json:"Бурение ГЗД" unit:"force"`
+	HelicalBucklingWithRotation     []float64 `json:"Спиральный изгиб(с вращением)" unit:"force"`
+	TensionLimit                    []float64 `json:"Предел натяжения" unit:"force"`
 }
 
 // WeightOnBitFromMlModel represents the response structure for the Weight on Bit prediction.
 type WeightOnBitFromMLModelResponse struct {
-	Depth                           []float64 `json:"Глубина"`
-	TowerLoadCapacity               []float64 `json:"Грузоподъёмность вышки"`
-	RotaryDrilling                  []float64 `json:"Бурение ротором"`
-	PullUp                          []float64 `json:"Подъём"`
-	RunIn                           []float64 `json:"Спуск"`
-	DrillingGZD                     []float64 `json:"Бурение ГЗД"`
-	MinWeightForHelicalBucklingRun  []float64 `json:"Мин. вес до спирального изгиба (спуск)"`
-	MaxWeightBeforeYieldLimitPullUp []float64 `json:"Макс. вес до предела текучести (подъём)"`
+	Depth                           []float64 `json:"Глубина" unit:"length"`
+	TowerLoadCapacity               []float64 `json:"Грузоподъёмность вышки" unit:"force"`
+	RotaryDrilling                  []float64 `json:"Бурение ротором" unit:"force"`
+	PullUp                          []float64 `json:"Подъём" unit:"force"`
+	RunIn                           []float64 `json:"Спуск" unit:"force"`
+	DrillingGZD                     []float64 `json:"Бурение ГЗД" unit:"force"`
+	MinWeightForHelicalBucklingRun  []float64 `json:"Мин. вес до спирального изгиба (спуск)" unit:"force"`
+	MaxWeightBeforeYieldLimitPullUp []float64 `json:"Макс. вес до предела текучести (подъём)" unit:"force"`
 }
 
 // MomentFromMlModelResponse represents the response structure for the Moment prediction.
 type MomentFromMLModelResponse struct {
-	Depth          []float64 `json:"Глубина"`
-	RotaryDrilling []float64 `json:"Бурение ротором"`
-	PullUp         []float64 `json:"Подъём"`
-	MakeUpTorque   []float64 `json:"Make-up Torque"`
-	RunIn          []float64 `json:"Спуск"`
-	TorqueOnMakeUp []float64 `json:"Момент свинчивания"`
+	Depth          []float64 `json:"Глубина" unit:"length"`
+	RotaryDrilling []float64 `json:"Бурение ротором" unit:"torque"`
+	PullUp         []float64 `json:"Подъём" unit:"torque"`
+	MakeUpTorque   []float64 `json:"Make-up Torque" unit:"torque"`
+	RunIn          []float64 `json:"Спуск" unit:"torque"`
+	TorqueOnMakeUp []float64 `json:"Момент свинчивания" unit:"torque"`
 }
 
 // MinWeightFromMLModelResponse represents the response structure for the Min Weight on Bit prediction.
 type MinWeightFromMLModelResponse struct {
-	Depth                                             []float64 `json:"Глубина"`
-	MinWeightOnBitForHelicalBucklingRotaryDrilling    []float64 `json:"Мин. вес на долоте до спирального изгиба (бурение ротором)"`
-	MinWeightOnBitForSinusoidalBucklingGZDDrilling    []float64 `json:"Мин. вес на долоте до синусоидального изгиба (бурение ГЗД)"`
-	MinWeightOnBitForSinusoidalBucklingRotaryDrilling []float64 `json:"Мин. вес на долоте до синусоидального изгиба (бурение ротором)"`
-	MinWeightOnBitForHelicalBucklingGZDDrilling       []float64 `json:"Мин. вес на долоте до спирального изгиба (бурение ГЗД)"`
+	Depth                                             []float64 `json:"Глубина" unit:"length"`
+	MinWeightOnBitForHelicalBucklingRotaryDrilling    []float64 `json:"Мин. вес на долоте до спирального изгиба (бурение ротором)" unit:"force"`
+	MinWeightOnBitForSinusoidalBucklingGZDDrilling    []float64 `json:"Мин. вес на долоте до синусоидального изгиба (бурение ГЗД)" unit:"force"`
+	MinWeightOnBitForSinusoidalBucklingRotaryDrilling []float64 `json:"Мин. вес на долоте до синусоидального изгиба (бурение ротором)" unit:"force"`
+	MinWeightOnBitForHelicalBucklingGZDDrilling       []float64 `json:"Мин. вес на долоте до спирального изгиба (бурение ГЗД)" unit:"force"`
+}
+
+// FrictionSensitivityResponse is the broomstick chart data of a case: one set of curves per friction factor combination.
+type FrictionSensitivityResponse struct {
+	CaseID          string                              `json:"case_id"`
+	Depth           []float64                           `json:"depth" unit:"length"`
+	CasingShoeDepth *float64                            `json:"casing_shoe_depth,omitempty" unit:"length"`
+	Curves          []*FrictionSensitivityCurveResponse `json:"curves"`
+}
+
+// FrictionSensitivityCurveResponse holds the hook load and torque curves of one friction factor combination.
+type FrictionSensitivityCurveResponse struct {
+	OpenHoleFrictionFactor  float64   `json:"open_hole_friction_factor"`
+	CasedHoleFrictionFactor float64   `json:"cased_hole_friction_factor"`
+	HookLoadTripIn          []float64 `json:"hook_load_trip_in" unit:"force"`
+	HookLoadTripOut         []float64 `json:"hook_load_trip_out" unit:"force"`
+	HookLoadRotating        []float64 `json:"hook_load_rotating" unit:"force"`
+	TorqueRotating          []float64 `json:"torque_rotating" unit:"torque"`
 }
@@ -0,0 +1,39 @@
+package responses
+
+// TortuosityStationResponse represents the tortuosity indicators at one station. Dogleg severities and the
+// micro-tortuosity index are in degrees per 30 m or per 100 ft, the extra side force is per unit length.
+type TortuosityStationResponse struct {
+	MD                      float64 `json:"md" unit:"length"`
+	Incl                    float64 `json:"incl"`
+	Azim                    float64 `json:"azim"`
+	TVD                     float64 `json:"tvd" unit:"length"`
+	DLS30m                  float64 `json:"dls_30m"`
+	DLS100ft                float64 `json:"dls_100ft"`
+	CumulativeTortuosity    float64 `json:"cumulative_tortuosity"`
+	MicroTortuosityIndex    float64 `json:"micro_tortuosity_index"`
+	ExtraSideForcePerLength float64 `json:"extra_side_force_per_length" unit:"linear_force"`
+}
+
+// DLSExceedanceResponse represents an interval where the dogleg severity exceeds the limit of its section.
+type DLSExceedanceResponse struct {
+	Section string  `json:"section"`
+	MDFrom  float64 `json:"md_from" unit:"length"`
+	MDTo    float64 `json:"md_to" unit:"length"`
+	MaxDLS  float64 `json:"max_dls" unit:"dls"`
+	Limit   float64 `json:"limit" unit:"dls"`
+}
+
+// TortuosityReportResponse represents the tortuosity and dogleg severity quality report of a trajectory.
+type TortuosityReportResponse struct {
+	TrajectoryID               string                      `json:"trajectory_id"`
+	MicroTortuosityWindow      float64                     `json:"micro_tortuosity_window" unit:"length"`
+	TotalTortuosity            float64                     `json:"total_tortuosity"`
+	AverageDLS30m              float64                     `json:"average_dls_30m"`
+	MaxDLS30m                  float64                     `json:"max_dls_30m"`
+	MaxDLSMD                   float64                     `json:"max_dls_md" unit:"length"`
+	MaxMicroTortuosityIndex    float64                     `json:"max_micro_tortuosity_index"`
+	MaxExtraSideForcePerLength float64                     `json:"max_extra_side_force_per_length" unit:"linear_force"`
+	TotalExtraSideForce        float64                     `json:"total_extra_side_force" unit:"force"`
+	Exceedances                []DLSExceedanceResponse     `json:"exceedances"`
+	Stations                   []TortuosityStationResponse `json:"stations"`
+}
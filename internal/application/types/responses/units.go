@@ -0,0 +1,7 @@
+package responses
+
+// UnitSystemResponse represents a unit system with the unit symbol of every quantity.
+type UnitSystemResponse struct {
+	Name  string            `json:"name"`
+	Units map[string]string `json:"units"`
+}
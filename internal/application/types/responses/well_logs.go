@@ -0,0 +1,24 @@
+package responses
+
+// PorePressureEstimateResponse is the Eaton estimate of a case, binned by true vertical depth. Saved tells
+// whether the bins were stored as the pore pressures and fracture gradients of the case.
+type PorePressureEstimateResponse struct {
+	CaseID    string                               `json:"case_id"`
+	WellLogID string                               `json:"well_log_id"`
+	Method    string                               `json:"method"`
+	Exponent  float64                              `json:"exponent"`
+	Saved     bool                                 `json:"saved"`
+	Points    []*PorePressureEstimatePointResponse `json:"points"`
+}
+
+// PorePressureEstimatePointResponse holds the mean values of the samples in a bin at their mean depth.
+type PorePressureEstimatePointResponse struct {
+	TVD              float64 `json:"tvd" unit:"length"`
+	Samples          int     `json:"samples"`
+	Overburden       float64 `json:"overburden" unit:"pressure"`
+	Hydrostatic      float64 `json:"hydrostatic" unit:"pressure"`
+	PorePressure     float64 `json:"pore_pressure" unit:"pressure"`
+	PorePressureEMW  float64 `json:"pore_pressure_emw" unit:"density"`
+	FracturePressure float64 `json:"fracture_pressure" unit:"pressure"`
+	FractureEMW      float64 `json:"fracture_emw" unit:"density"`
+}
@@ -0,0 +1,13 @@
+package responses
+
+// WitsmlImportResponse lists the objects created from a WITSML file.
+type WitsmlImportResponse struct {
+	Imported []*WitsmlImportedObjectResponse `json:"imported"`
+}
+
+// WitsmlImportedObjectResponse is a created object with the uid it had in the file.
+type WitsmlImportedObjectResponse struct {
+	ID   string `json:"id"`
+	UID  string `json:"uid,omitempty"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+// Области поиска соседних траекторий для анализа сближения
+const (
+	AntiCollisionScopeSite  = "site"
+	AntiCollisionScopeField = "field"
+)
+
+// Траектория соседней скважины для анализа сближения
+type OffsetTrajectory struct {
+	WellID       string      `json:"well_id"`
+	WellName     string      `json:"well_name"`
+	WellboreID   string      `json:"wellbore_id"`
+	WellboreName string      `json:"wellbore_name"`
+	DesignID     string      `json:"design_id"`
+	Trajectory   *Trajectory `json:"trajectory"`
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// Префикс персональных API-ключей, по нему middleware отличает ключи от JWT
+const ApiKeyPrefix = "mp_"
+
+// Области действия API-ключей
+const (
+	ApiKeyScopeRead      = "read"
+	ApiKeyScopeReadWrite = "read_write"
+)
+
+// Персональный API-ключ пользователя для скриптов. Хранится только хеш ключа
+type ApiKey struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	OrganizationID string     `json:"organization_id"`
+	Name           string     `json:"name"`
+	Prefix         string     `json:"prefix"`
+	KeyHash        string     `json:"-"`
+	Scope          string     `json:"scope"`
+	CompanyID      *string    `json:"company_id"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	LastUsedAt     *time.Time `json:"last_used_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// IsApiKey reports whether a credential of the Authorization header is an API key rather than a JWT.
+func IsApiKey(credential string) bool {
+	return strings.HasPrefix(credential, ApiKeyPrefix)
+}
+
+// ApiKeyScopeAllows reports whether an API key scope allows the action. API keys never allow managing the organization.
+func ApiKeyScopeAllows(scope string, permission string) bool {
+	switch permission {
+	case PermissionRead:
+		return scope == ApiKeyScopeRead || scope == ApiKeyScopeReadWrite
+	case PermissionWrite:
+		return scope == ApiKeyScopeReadWrite
+	default:
+		return false
+	}
+}
+
+// Expired reports whether the key has expired at now.
+func (k *ApiKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+)
+
+// Операции, которые записываются в журнал аудита
+const (
+	AuditOperationCreate = "create"
+	AuditOperationUpdate = "update"
+	AuditOperationDelete = "delete"
+)
+
+// Изменение одного поля: значение до и после операции
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Запись журнала аудита изменений инженерных данных. Журнал только дополняется
+type AuditLogEntry struct {
+	ID             string                 `json:"id"`
+	UserID         *string                `json:"user_id"`
+	OrganizationID *string                `json:"organization_id"`
+	ApiKeyID       *string                `json:"api_key_id"`
+	EntityType     string                 `json:"entity_type"`
+	EntityID       string                 `json:"entity_id"`
+	Operation      string                 `json:"operation"`
+	Changes        map[string]AuditChange `json:"changes"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// Фильтр журнала аудита. Пустые поля не ограничивают выборку
+type AuditLogFilter struct {
+	EntityType string
+	EntityID   string
+	UserID     string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
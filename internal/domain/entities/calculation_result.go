@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Движки расчёта момента и сопротивлений
+const (
+	CalculationEngineMLModel = "ml-model"
+)
+
+// Виды расчётов момента и сопротивлений
+const (
+	CalculationTypeEffectiveTension    = "effective-tension"
+	CalculationTypeWeightOnBit         = "weight-on-bit"
+	CalculationTypeSurfaceTorque       = "surface-torque"
+	CalculationTypeMinWeightOnBit      = "min-weight-on-bit"
+	CalculationTypeFrictionSensitivity = "friction-sensitivity"
+	CalculationTypeFrictionCurves      = "friction-curves"
+)
+
+// Сохранённый результат расчёта по кейсу. InputHash — хэш траектории, секций колонн, стволов, растворов,
+// буровой установки и параметров расчёта; результат устаревает при изменении любого из них
+type CalculationResult struct {
+	ID        string          `json:"id"`
+	CaseID    string          `json:"case_id"`
+	Engine    string          `json:"engine"`
+	Type      string          `json:"type"`
+	InputHash string          `json:"input_hash"`
+	Params    json.RawMessage `json:"params,omitempty"`
+	Result    json.RawMessage `json:"-"`
+	Stale     bool            `json:"stale"`
+	CreatedAt time.Time       `json:"created_at"`
+}
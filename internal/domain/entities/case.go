@@ -6,8 +6,8 @@ type Case struct {
 	ID                string              `json:"id"`
 	CaseName          string              `json:"case_name"`
 	CaseDescription   string              `json:"case_description"`
-	DrillDepth        float64             `json:"drill_depth"`
-	PipeSize          float64             `json:"pipe_size"`
+	DrillDepth        float64             `json:"drill_depth" unit:"length"`
+	PipeSize          float64             `json:"pipe_size" unit:"diameter"`
 	IsComplete        bool                `json:"is_complete"`
 	CreatedAt         time.Time           `json:"created_at"`
 	Fluids            []*Fluid            `json:"fluids"`
@@ -1,14 +1,84 @@
 package entities
 
-import "time"
+import (
+    "strconv"
+    "time"
+)
 
-// План или Дизайн (под стволом скажины)
+// Стадии дизайна
+const (
+    DesignStagePrototype = "prototype"
+    DesignStagePlanned   = "planned"
+    DesignStageApproved  = "approved"
+    DesignStageActual    = "actual"
+    DesignStageArchived  = "archived"
+)
+
+// Разрешенные переходы между стадиями и разрешение роли, которое нужно для каждого из них
+var designStageTransitions = map[string]map[string]string{
+    DesignStagePrototype: {DesignStagePlanned: PermissionWrite, DesignStageArchived: PermissionWrite},
+    DesignStagePlanned:   {DesignStagePrototype: PermissionWrite, DesignStageApproved: PermissionManage, DesignStageArchived: PermissionWrite},
+    DesignStageApproved:  {DesignStagePlanned: PermissionManage, DesignStageActual: PermissionManage, DesignStageArchived: PermissionManage},
+    DesignStageActual:    {DesignStageArchived: PermissionManage},
+    DesignStageArchived:  {DesignStagePrototype: PermissionManage},
+}
+
+// План или Дизайн (под стволом скажины). Version повторяет Revision и меняется только при заморозке ревизии
 type Design struct {
     ID           string        `json:"id"`
     PlanName     string        `json:"plan_name"`
     Stage        string        `json:"stage"`
     Version      string        `json:"version"`
+    Revision     int           `json:"revision"`
     ActualDate   time.Time     `json:"actual_date"`
     Trajectories []*Trajectory `json:"trajectories"`
     CreatedAt    time.Time     `json:"created_at"`
-}
\ No newline at end of file
+}
+
+// Замороженная ревизия дизайна со снимком траекторий, кейсов и их компонентов
+type DesignRevision struct {
+    ID        string    `json:"id"`
+    DesignID  string    `json:"design_id"`
+    Revision  int       `json:"revision"`
+    Comment   string    `json:"comment"`
+    CreatedBy *string   `json:"created_by"`
+    CreatedAt time.Time `json:"created_at"`
+    Snapshot  *Design   `json:"snapshot,omitempty"`
+}
+
+// Переход дизайна из одной стадии в другую. Revision задается, если при переходе была заморожена ревизия
+type DesignStageTransition struct {
+    ID        string    `json:"id"`
+    DesignID  string    `json:"design_id"`
+    FromStage string    `json:"from_stage"`
+    ToStage   string    `json:"to_stage"`
+    Comment   string    `json:"comment"`
+    UserID    *string   `json:"user_id"`
+    Revision  *int      `json:"revision"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// DesignVersion returns the version of a design at the revision. Versions used to be free text,
+// now they follow the revision and only change when the design is frozen.
+func DesignVersion(revision int) string {
+    return strconv.Itoa(revision)
+}
+
+// IsDesignStage reports whether stage is one of the design stages.
+func IsDesignStage(stage string) bool {
+    _, ok := designStageTransitions[stage]
+    return ok
+}
+
+// DesignStageTransitionPermission returns the permission needed to move a design from one stage to another,
+// ok is false if the transition is not allowed.
+func DesignStageTransitionPermission(from string, to string) (permission string, ok bool) {
+    permission, ok = designStageTransitions[from][to]
+    return permission, ok
+}
+
+// DesignStageLocked reports whether the trajectories and cases of a design in the stage are locked against edits.
+// Approved and later designs only change through a new version.
+func DesignStageLocked(stage string) bool {
+    return stage == DesignStageApproved || stage == DesignStageActual || stage == DesignStageArchived
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+)
+
+// Фактический замер параметров бурения ствола скважины по времени и глубине долота. Отсутствующие показания пустые
+type DrillingReading struct {
+	ID                string    `json:"id"`
+	WellboreID        string    `json:"wellbore_id"`
+	Time              time.Time `json:"time"`
+	BitDepth          float64   `json:"bit_depth" unit:"length"`
+	HookLoad          *float64  `json:"hook_load,omitempty" unit:"force"`
+	SurfaceTorque     *float64  `json:"surface_torque,omitempty" unit:"torque"`
+	WeightOnBit       *float64  `json:"weight_on_bit,omitempty" unit:"force"`
+	RPM               *float64  `json:"rpm,omitempty"`
+	FlowRate          *float64  `json:"flow_rate,omitempty" unit:"flow_rate"`
+	StandpipePressure *float64  `json:"standpipe_pressure,omitempty" unit:"pressure"`
+}
+
+// Фильтр фактических замеров по времени. From включительно, To не включительно, пустой Limit не ограничивает выборку
+type DrillingReadingFilter struct {
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
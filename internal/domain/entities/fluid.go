@@ -7,7 +7,7 @@ type Fluid struct {
 	ID            string     `json:"id"`
 	Name          string     `json:"name"`
 	Description   string     `json:"description"`
-	Density       float64    `json:"density"`
+	Density       float64    `json:"density" unit:"density"`
 	FluidBaseType *FluidType `json:"fluid_base_type"`
 	BaseFluid     *FluidType `json:"base_fluid"`
 	CreatedAt     time.Time  `json:"created_at"`
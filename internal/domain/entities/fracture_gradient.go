@@ -2,12 +2,15 @@ package entities
 
 import "time"
 
-// FractureGradient represents the fracture gradient data in the domain layer.
+// FractureGradient represents the fracture gradient data in the domain layer. Pressure and EMW are the
+// fracture pressure at WellTVD, rows with only a temperature profile have none.
 type FractureGradient struct {
 	ID                   string    `json:"id"`
-	TemperatureAtSurface float64   `json:"temperature_at_surface"`
-	TemperatureAtWellTVD float64   `json:"temperature_at_well_tvd"`
-	TemperatureGradient  float64   `json:"temperature_gradient"`
-	WellTVD              float64   `json:"well_tvd"`
+	TemperatureAtSurface float64   `json:"temperature_at_surface" unit:"temperature"`
+	TemperatureAtWellTVD float64   `json:"temperature_at_well_tvd" unit:"temperature"`
+	TemperatureGradient  float64   `json:"temperature_gradient" unit:"temperature_gradient"`
+	WellTVD              float64   `json:"well_tvd" unit:"length"`
+	Pressure             *float64  `json:"pressure,omitempty" unit:"pressure"`
+	EMW                  *float64  `json:"emw,omitempty" unit:"density"`
 	CreatedAt            time.Time `json:"created_at"`
 }
@@ -0,0 +1,48 @@
+package entities
+
+// Уровни иерархии от организации до компонентов кейса
+const (
+	ScopeOrganization     = "organization"
+	ScopeCompany          = "company"
+	ScopeField            = "field"
+	ScopeSite             = "site"
+	ScopeWell             = "well"
+	ScopeWellbore         = "wellbore"
+	ScopeDesign           = "design"
+	ScopeTrajectory       = "trajectory"
+	ScopeCase             = "case"
+	ScopeHole             = "hole"
+	ScopeFluid            = "fluid"
+	ScopeRig              = "rig"
+	ScopeString           = "string"
+	ScopePorePressure     = "pore_pressure"
+	ScopeFractureGradient = "fracture_gradient"
+)
+
+// Организация, компания и месторождение, к которым относится сущность
+type Ownership struct {
+	OrganizationID string
+	CompanyID      string
+	FieldID        string
+}
+
+// Скважина и ствол, к которым относится сущность
+type WellboreReference struct {
+	WellID       string
+	WellName     string
+	WellboreID   string
+	WellboreName string
+}
+
+// Названия уровней иерархии, к которым относится сущность. Уровни ниже сущности пустые
+type HierarchyNames struct {
+	CompanyName    string
+	FieldName      string
+	SiteName       string
+	WellName       string
+	WellboreName   string
+	DesignName     string
+	DesignStage    string
+	TrajectoryName string
+	CaseName       string
+}
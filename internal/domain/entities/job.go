@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Состояния задания расчёта
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// Виды заданий расчёта момента и сопротивлений
+const (
+	JobTypeEffectiveTension    = "effective-tension"
+	JobTypeWeightOnBit         = "weight-on-bit"
+	JobTypeSurfaceTorque       = "surface-torque"
+	JobTypeMinWeightOnBit      = "min-weight-on-bit"
+	JobTypeFrictionSensitivity = "friction-sensitivity"
+)
+
+// Задание асинхронного расчёта по кейсу. Параметры и результат хранятся в JSON в канонических единицах,
+// задание в очереди с RunAfter ждёт повторной попытки. WorkerID — сервер, который выполняет задание
+type Job struct {
+	ID             string          `json:"id"`
+	OrganizationID string          `json:"organization_id"`
+	CaseID         string          `json:"case_id"`
+	Type           string          `json:"type"`
+	Status         string          `json:"status"`
+	Params         json.RawMessage `json:"params,omitempty"`
+	Result         json.RawMessage `json:"-"`
+	Error          string          `json:"error,omitempty"`
+	Attempts       int             `json:"attempts"`
+	RunAfter       time.Time       `json:"run_after"`
+	CreatedAt      time.Time       `json:"created_at"`
+	StartedAt      *time.Time      `json:"started_at,omitempty"`
+	FinishedAt     *time.Time      `json:"finished_at,omitempty"`
+	WorkerID       string          `json:"-"`
+}
+
+// Finished reports whether the job will not run again.
+func (j *Job) Finished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed || j.Status == JobStatusCancelled
+}
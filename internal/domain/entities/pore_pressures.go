@@ -5,8 +5,8 @@ import "time"
 // PorePressure represents the Pore Pressure entity with fields for relevant data.
 type PorePressure struct {
 	ID        string    `json:"id"`
-	TVD       float64   `json:"tvd"`
-	Pressure  float64   `json:"pressure"`
-	EMW       float64   `json:"emw"`
+	TVD       float64   `json:"tvd" unit:"length"`
+	Pressure  float64   `json:"pressure" unit:"pressure"`
+	EMW       float64   `json:"emw" unit:"density"`
 	CreatedAt time.Time `json:"created_at"`
 }
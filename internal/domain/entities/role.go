@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+)
+
+// Роли пользователей. Суперадминистратор платформы задается флагом пользователя, а не назначением
+const (
+	RoleSuperAdmin = "super_admin"
+	RoleOrgAdmin   = "org_admin"
+	RoleEngineer   = "engineer"
+	RoleViewer     = "viewer"
+)
+
+// Действия, которые разрешают роли
+const (
+	PermissionRead   = "read"
+	PermissionWrite  = "write"
+	PermissionManage = "manage"
+)
+
+// Назначение роли пользователю во всей организации или только в компании или месторождении
+type RoleAssignment struct {
+	ID             string    `json:"id"`
+	UserID         string    `json:"user_id"`
+	OrganizationID string    `json:"organization_id"`
+	CompanyID      *string   `json:"company_id"`
+	FieldID        *string   `json:"field_id"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -4,13 +4,18 @@ package entities
 
 // Куст (под месторождением)
 type Site struct {
-	ID      string  `json:"id"`
-	Name    string  `json:"name"`
-	Area    float64 `json:"area"`
-	Block   string  `json:"block"`
-	Azimuth float64 `json:"azimuth"`
-	Country string  `json:"country"`
-	State   string  `json:"state"`
-	Region  string  `json:"region"`
-	Wells   []*Well `json:"wells"`
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Area      float64 `json:"area"`
+	Block     string  `json:"block"`
+	Azimuth   float64 `json:"azimuth"`
+	Country   string  `json:"country"`
+	State     string  `json:"state"`
+	Region    string  `json:"region"`
+	EPSGCode  int     `json:"epsg_code"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Easting   float64 `json:"easting"`
+	Northing  float64 `json:"northing"`
+	Wells     []*Well `json:"wells"`
 }
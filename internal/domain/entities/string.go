@@ -6,7 +6,7 @@ import "time"
 type String struct {
 	ID        string     `json:"id"`
 	Name      string     `json:"name"`
-	Depth     float64    `json:"depth"`
+	Depth     float64    `json:"depth" unit:"length"`
 	CreatedAt time.Time  `json:"created_at"`
 	Sections  []*Section `json:"sections"`
 }
@@ -17,19 +17,19 @@ type Section struct {
 	Description         *string   `json:"description,omitempty"`
 	Manufacturer        *string   `json:"manufacturer,omitempty"`
 	Type                string    `json:"type"`
-	BodyMD              float64   `json:"body_md"`
-	BodyLength          float64   `json:"body_length"`
-	BodyOD              float64   `json:"body_od"`
-	BodyID              float64   `json:"body_id"`
-	AvgJointLength      *float64  `json:"avg_joint_length,omitempty"`
-	StabilizerLength    *float64  `json:"stabilizer_length,omitempty"`
-	StabilizerOD        *float64  `json:"stabilizer_od,omitempty"`
-	StabilizerID        *float64  `json:"stabilizer_id,omitempty"`
-	Weight              *float64  `json:"weight,omitempty"`
+	BodyMD              float64   `json:"body_md" unit:"length"`
+	BodyLength          float64   `json:"body_length" unit:"length"`
+	BodyOD              float64   `json:"body_od" unit:"diameter"`
+	BodyID              float64   `json:"body_id" unit:"diameter"`
+	AvgJointLength      *float64  `json:"avg_joint_length,omitempty" unit:"length"`
+	StabilizerLength    *float64  `json:"stabilizer_length,omitempty" unit:"length"`
+	StabilizerOD        *float64  `json:"stabilizer_od,omitempty" unit:"diameter"`
+	StabilizerID        *float64  `json:"stabilizer_id,omitempty" unit:"diameter"`
+	Weight              *float64  `json:"weight,omitempty" unit:"linear_weight"`
 	Material            *string   `json:"material,omitempty"`
 	Grade               *string   `json:"grade,omitempty"`
 	Class               *int      `json:"class,omitempty"`
 	FrictionCoefficient *float64  `json:"friction_coefficient,omitempty"`
-	MinYieldStrength    *float64  `json:"min_yield_strength,omitempty"`
+	MinYieldStrength    *float64  `json:"min_yield_strength,omitempty" unit:"stress"`
 	CreatedAt           time.Time `json:"created_at"`
 }
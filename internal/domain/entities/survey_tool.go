@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// Инструмент инклинометрии (MWD, гироскоп и т.д.) с моделью погрешностей
+type SurveyTool struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	ErrorTerms  []*SurveyToolErrorTerm `json:"error_terms"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// Составляющая модели погрешностей инструмента (по ISCWSA)
+type SurveyToolErrorTerm struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Weighting   string  `json:"weighting"`
+	Propagation string  `json:"propagation"`
+	Magnitude   float64 `json:"magnitude"`
+}
+
+// Интервал программы инклинометрии траектории
+type SurveyProgramInterval struct {
+	ID           string    `json:"id"`
+	MDFrom       float64   `json:"md_from" unit:"length"`
+	MDTo         float64   `json:"md_to" unit:"length"`
+	SurveyToolID string    `json:"survey_tool_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
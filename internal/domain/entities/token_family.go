@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+)
+
+// Семейство refresh-токенов одной сессии входа. Действителен только последний выданный токен семейства
+type TokenFamily struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	OrganizationID string     `json:"organization_id"`
+	CurrentTokenID string     `json:"-"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Active reports whether the session of the family can still be used at now.
+func (f *TokenFamily) Active(now time.Time) bool {
+	return f.RevokedAt == nil && now.Before(f.ExpiresAt)
+}
@@ -3,28 +3,29 @@ package entities
 import "time"
 
 type Trajectory struct {
-	ID          string              `json:"id"`
-	Name        string              `json:"name"`
-	Description string              `json:"description"`
-	Headers     []*TrajectoryHeader `json:"headers"`
-	Units       []*TrajectoryUnit   `json:"units"`
-	Cases       []*Case             `json:"cases"`
-	CreatedAt   time.Time           `json:"created_at"`
+	ID            string                   `json:"id"`
+	Name          string                   `json:"name"`
+	Description   string                   `json:"description"`
+	Headers       []*TrajectoryHeader      `json:"headers"`
+	Units         []*TrajectoryUnit        `json:"units"`
+	SurveyProgram []*SurveyProgramInterval `json:"survey_program"`
+	Cases         []*Case                  `json:"cases"`
+	CreatedAt     time.Time                `json:"created_at"`
 }
 
 type TrajectoryUnit struct {
 	ID              string    `json:"id"`
-	MD              float64   `json:"md"`
+	MD              float64   `json:"md" unit:"length"`
 	Incl            float64   `json:"incl"`
 	Azim            float64   `json:"azim"`
-	SubSea          float64   `json:"sub_sea"`
-	TVD             float64   `json:"tvd"`
-	LocalNCoord     float64   `json:"local_n_coord"`
-	LocalECoord     float64   `json:"local_e_coord"`
+	SubSea          float64   `json:"sub_sea" unit:"length"`
+	TVD             float64   `json:"tvd" unit:"length"`
+	LocalNCoord     float64   `json:"local_n_coord" unit:"length"`
+	LocalECoord     float64   `json:"local_e_coord" unit:"length"`
 	GlobalNCoord    float64   `json:"global_n_coord"`
 	GlobalECoord    float64   `json:"global_e_coord"`
-	Dogleg          float64   `json:"dogleg"`
-	VerticalSection float64   `json:"vertical_section"`
+	Dogleg          float64   `json:"dogleg" unit:"dls"`
+	VerticalSection float64   `json:"vertical_section" unit:"length"`
 	CreatedAt       time.Time `json:"created_at"`
 }
 
@@ -38,7 +39,7 @@ type TrajectoryHeader struct {
 	Structure        string    `json:"structure"`
 	JobNumber        string    `json:"job_number"`
 	Wellhead         string    `json:"wellhead"`
-	KellyBushingElev float64   `json:"kelly_bushing_elev"`
+	KellyBushingElev float64   `json:"kelly_bushing_elev" unit:"length"`
 	Profile          string    `json:"profile"`
 	CreatedAt        time.Time `json:"created_at"`
 }
@@ -13,6 +13,7 @@ type User struct {
 	Surname        string    `json:"surname"`
 	Email          string    `json:"email"`
 	Phone          string    `json:"phone"`
-	Password       string    `json:"password"`
+	Password       string    `json:"-"`
+	IsSuperAdmin   bool      `json:"is_super_admin"`
 	CreatedAt      time.Time `json:"registeredAt"`
 }
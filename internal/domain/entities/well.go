@@ -16,6 +16,13 @@ type Well struct {
 	WellNumber              string      `json:"well_number"`
 	WorkingGroup            string      `json:"working_group"`
 	ActiveWellUnit          string      `json:"active_well_unit"`
+	EPSGCode                int         `json:"epsg_code"`
+	Latitude                float64     `json:"latitude"`
+	Longitude               float64     `json:"longitude"`
+	Easting                 float64     `json:"easting"`
+	Northing                float64     `json:"northing"`
+	GridConvergence         float64     `json:"grid_convergence"`
+	ScaleFactor             float64     `json:"scale_factor"`
 	Wellbores               []*Wellbore `json:"wellbores"`
 	CreatedAt               time.Time   `json:"created_at"`
 }
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+)
+
+// Каротаж ствола скважины, загруженный из LAS файла. В полях кривых - мнемоники исходных кривых, пустые для отсутствующих
+type WellLog struct {
+	ID               string           `json:"id"`
+	WellboreID       string           `json:"wellbore_id"`
+	Name             string           `json:"name"`
+	FileName         string           `json:"file_name"`
+	TopDepth         float64          `json:"top_depth" unit:"length"`
+	BottomDepth      float64          `json:"bottom_depth" unit:"length"`
+	GammaRayCurve    string           `json:"gamma_ray_curve,omitempty"`
+	ResistivityCurve string           `json:"resistivity_curve,omitempty"`
+	SonicCurve       string           `json:"sonic_curve,omitempty"`
+	DensityCurve     string           `json:"density_curve,omitempty"`
+	Samples          []*WellLogSample `json:"samples,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+}
+
+// Значения кривых каротажа на измеренной глубине. Гамма-каротаж в API, сопротивление в Ом·м, отсутствующие значения пустые
+type WellLogSample struct {
+	MD          float64  `json:"md" unit:"length"`
+	GammaRay    *float64 `json:"gamma_ray,omitempty"`
+	Resistivity *float64 `json:"resistivity,omitempty"`
+	Sonic       *float64 `json:"sonic,omitempty" unit:"slowness"`
+	Density     *float64 `json:"density,omitempty" unit:"density"`
+}
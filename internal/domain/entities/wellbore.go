@@ -10,18 +10,18 @@ type Wellbore struct {
 	ID                             string    `json:"id"`
 	Name                           string    `json:"name"`
 	BottomHoleLocation             string    `json:"bottom_hole_location"`
-	WellboreDepth                  float64   `json:"wellbore_depth"`
-	AverageHookLoad                float64   `json:"average_hook_load"`
-	RiserPressure                  float64   `json:"riser_pressure"`
-	AverageInletFlow               float64   `json:"average_inlet_flow"`
+	WellboreDepth                  float64   `json:"wellbore_depth" unit:"length"`
+	AverageHookLoad                float64   `json:"average_hook_load" unit:"force"`
+	RiserPressure                  float64   `json:"riser_pressure" unit:"pressure"`
+	AverageInletFlow               float64   `json:"average_inlet_flow" unit:"flow_rate"`
 	AverageColumnRotationFrequency float64   `json:"average_column_rotation_frequency"`
 	MaximumColumnRotationFrequency float64   `json:"maximum_column_rotation_frequency"`
-	AverageWeightOnBit             float64   `json:"average_weight_on_bit"`
-	MaximumWeightOnBit             float64   `json:"maximum_weight_on_bit"`
-	AverageTorque                  float64   `json:"average_torque"`
-	MaximumTorque                  float64   `json:"maximum_torque"`
+	AverageWeightOnBit             float64   `json:"average_weight_on_bit" unit:"force"`
+	MaximumWeightOnBit             float64   `json:"maximum_weight_on_bit" unit:"force"`
+	AverageTorque                  float64   `json:"average_torque" unit:"torque"`
+	MaximumTorque                  float64   `json:"maximum_torque" unit:"torque"`
 	DownStaticFriction             float64   `json:"down_static_friction"`
-	DepthInterval                  float64   `json:"depth_interval"`
+	DepthInterval                  float64   `json:"depth_interval" unit:"length"`
 	Designs                        []*Design `json:"designs"`
 	CreatedAt                      time.Time `json:"created_at"`
 }
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+type ApiKeysRepository interface {
+	CreateApiKey(ctx context.Context, key *entities.ApiKey) error
+	GetApiKeys(ctx context.Context, userId string) ([]*entities.ApiKey, error)
+	GetApiKeyByHash(ctx context.Context, keyHash string) (*entities.ApiKey, error)
+	DeleteApiKey(ctx context.Context, userId string, id string) error
+	TouchApiKey(ctx context.Context, id string, usedAt time.Time) error
+}
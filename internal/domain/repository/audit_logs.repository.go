@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+// AuditLogsRepository only reads the audit log. Entries are written by the database driver for every change.
+type AuditLogsRepository interface {
+	GetAuditLogs(ctx context.Context, organizationId string, filter *entities.AuditLogFilter) ([]*entities.AuditLogEntry, error)
+}
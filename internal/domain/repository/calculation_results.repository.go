@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+type CalculationResultsRepository interface {
+	// CreateCalculationResult stores the result and sets its ID and creation time.
+	CreateCalculationResult(ctx context.Context, result *entities.CalculationResult) error
+	// GetLatestCalculationResult retrieves the newest result of the calculation that is not stale, nil when there is none.
+	GetLatestCalculationResult(ctx context.Context, caseID string, engine string, calculationType string, inputHash string) (*entities.CalculationResult, error)
+	// GetCalculationResults retrieves the results of the case without their values, newest first.
+	GetCalculationResults(ctx context.Context, caseID string) ([]*entities.CalculationResult, error)
+}
@@ -9,6 +9,7 @@ import (
 type CasesRepository interface {
 	CreateCase(ctx context.Context, trajectoryID string, caseEntity *entities.Case) error
 	GetCaseByID(ctx context.Context, id string) (*entities.Case, error)
+	GetCaseWithComponents(ctx context.Context, id string) (*entities.Case, error)
 	GetCases(ctx context.Context, trajectoryID string) ([]*entities.Case, error)
 	UpdateCase(ctx context.Context, caseEntity *entities.Case) (*entities.Case, error)
 	DeleteCase(ctx context.Context, id string) error
@@ -17,6 +17,7 @@ type CommonRepository interface {
 	CheckIfDesignExists(ctx context.Context, designId string) error
 	CheckIfTrajectoryExists(ctx context.Context, trajectoryId string) error
 	CheckIfCaseExists(ctx context.Context, caseId string) error
+	CheckIfSurveyToolExists(ctx context.Context, surveyToolId string) error
 	CheckCaseCompleteness(ctx context.Context, caseID string) (bool, error)
 	CheckIfFluidExists(ctx context.Context, fluidId string) (bool, error)
 	CheckIfRigExists(ctx context.Context, rigId string) (bool, error)
@@ -25,4 +26,15 @@ type CommonRepository interface {
 	CheckIfPorePressureExists(ctx context.Context, porePressureId string) (bool, error)
 	CheckIfFractureGradientExists(ctx context.Context, fractureGradientId string) (bool, error)
 	GetTrajectoryByCaseID(ctx context.Context, caseID string) (*entities.Trajectory, error)
+	GetSiteByWellID(ctx context.Context, wellID string) (*entities.Site, error)
+	GetWellByDesignID(ctx context.Context, designID string) (*entities.Well, error)
+	GetWellByTrajectoryID(ctx context.Context, trajectoryID string) (*entities.Well, error)
+	GetDesignIDByTrajectoryID(ctx context.Context, trajectoryID string) (string, error)
+	GetWellboreIDByCaseID(ctx context.Context, caseID string) (string, error)
+	GetOwnership(ctx context.Context, scope string, id string) (*entities.Ownership, error)
+	GetWellboreReference(ctx context.Context, scope string, id string) (*entities.WellboreReference, error)
+	GetHierarchyNames(ctx context.Context, scope string, id string) (*entities.HierarchyNames, error)
+	CheckOwnership(ctx context.Context, organizationId string, scope string, id string) error
+	CheckDesignEditable(ctx context.Context, scope string, id string) error
+	GetActiveUnits(ctx context.Context, scope string, id string) (string, string, error)
 }
@@ -12,4 +12,9 @@ type DesignsRepository interface {
 	GetDesigns(ctx context.Context, wellboreID string) ([]*entities.Design, error)
 	UpdateDesign(ctx context.Context, design *entities.Design) (*entities.Design, error)
 	DeleteDesign(ctx context.Context, id string) error
-}
\ No newline at end of file
+	FreezeDesign(ctx context.Context, revision *entities.DesignRevision) error
+	GetDesignRevisions(ctx context.Context, designID string) ([]*entities.DesignRevision, error)
+	GetDesignRevision(ctx context.Context, designID string, revision int) (*entities.DesignRevision, error)
+	TransitionDesignStage(ctx context.Context, transition *entities.DesignStageTransition, freeze bool) error
+	GetDesignStageTransitions(ctx context.Context, designID string) ([]*entities.DesignStageTransition, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+type DrillingDataRepository interface {
+	// SaveDrillingReadings inserts the readings, a reading of the same wellbore and time replaces the stored one.
+	SaveDrillingReadings(ctx context.Context, readings []*entities.DrillingReading) error
+	// GetDrillingReadings retrieves the readings of the wellbore matching the filter, oldest first.
+	GetDrillingReadings(ctx context.Context, wellboreID string, filter *entities.DrillingReadingFilter) ([]*entities.DrillingReading, error)
+	// DeleteDrillingReadings deletes the readings of the wellbore in the time range of the filter and returns their number.
+	DeleteDrillingReadings(ctx context.Context, wellboreID string, filter *entities.DrillingReadingFilter) (int64, error)
+}
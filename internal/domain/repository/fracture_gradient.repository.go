@@ -12,4 +12,6 @@ type FractureGradientsRepository interface {
 	CreateFractureGradient(ctx context.Context, caseID string, fractureGradient *entities.FractureGradient) error
 	UpdateFractureGradient(ctx context.Context, fractureGradient *entities.FractureGradient) (*entities.FractureGradient, error)
 	DeleteFractureGradient(ctx context.Context, id string) error
+	// ReplaceFractureGradients deletes the fracture gradients of the case and creates the given ones in one transaction.
+	ReplaceFractureGradients(ctx context.Context, caseID string, fractureGradients []*entities.FractureGradient) error
 }
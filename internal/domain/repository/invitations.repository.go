@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+type InvitationsRepository interface {
+	CreateInvitation(ctx context.Context, invitation *entities.Invitation) error
+	GetInvitations(ctx context.Context, organizationId string) ([]*entities.Invitation, error)
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*entities.Invitation, error)
+	AcceptInvitation(ctx context.Context, id string, user *entities.User) error
+	DeleteInvitation(ctx context.Context, organizationId string, id string) error
+}
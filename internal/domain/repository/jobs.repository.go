@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+type JobsRepository interface {
+	// CreateJob stores the job and sets its ID and creation time.
+	CreateJob(ctx context.Context, job *entities.Job) error
+	GetJobByID(ctx context.Context, id string) (*entities.Job, error)
+	// GetJobs retrieves the jobs of the case without their results, newest first.
+	GetJobs(ctx context.Context, caseID string) ([]*entities.Job, error)
+	// ClaimJob marks the oldest queued job due at now as running on the worker with a lease until leaseUntil
+	// and returns it, nil when no job is due.
+	ClaimJob(ctx context.Context, workerID string, now time.Time, leaseUntil time.Time) (*entities.Job, error)
+	// RenewJobLeases extends the leases of the jobs running on the worker until leaseUntil.
+	RenewJobLeases(ctx context.Context, workerID string, leaseUntil time.Time) error
+	// FinishJob saves the status, result, error and retry time of a job running on its worker. False when the job
+	// no longer runs there.
+	FinishJob(ctx context.Context, job *entities.Job) (bool, error)
+	// CancelJob cancels a queued or running job. False when the job has already finished.
+	CancelJob(ctx context.Context, id string, now time.Time) (bool, error)
+	// RequeueExpiredJobs puts the running jobs whose lease expired before now back in the queue and returns
+	// their number.
+	RequeueExpiredJobs(ctx context.Context, now time.Time) (int64, error)
+}
@@ -12,4 +12,6 @@ type PorePressuresRepository interface {
 	GetPorePressures(ctx context.Context, caseID string) ([]*entities.PorePressure, error)
 	UpdatePorePressure(ctx context.Context, porePressure *entities.PorePressure) (*entities.PorePressure, error)
 	DeletePorePressure(ctx context.Context, id string) error
+	// ReplacePorePressures deletes the pore pressures of the case and creates the given ones in one transaction.
+	ReplacePorePressures(ctx context.Context, caseID string, porePressures []*entities.PorePressure) error
 }
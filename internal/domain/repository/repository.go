@@ -6,43 +6,65 @@ import (
 )
 
 type Repository struct {
-	Common               CommonRepository
-	Users                UsersRepository
-	Companies            CompaniesRepository
-	Organizations        OrganizationsRepository
-	Fields               FieldsRepository
-	Sites                SitesRepository
-	Wells                WellsRepository
-	Wellbores            WellboresRepository
-	Designs              DesignsRepository
-	Trajectories         TrajectoriesRepository
-	Cases                CasesRepository
-	Holes                HolesRepository
-	Fluids               FluidsRepository
-	Rigs                 RigsRepository
-	PorePressures        PorePressuresRepository
-	FractureGradients    FractureGradientsRepository
-	Strings              StringsRepository
+	Common             CommonRepository
+	Users              UsersRepository
+	Companies          CompaniesRepository
+	Organizations      OrganizationsRepository
+	Fields             FieldsRepository
+	Sites              SitesRepository
+	Wells              WellsRepository
+	Wellbores          WellboresRepository
+	Designs            DesignsRepository
+	Trajectories       TrajectoriesRepository
+	Cases              CasesRepository
+	Holes              HolesRepository
+	Fluids             FluidsRepository
+	Rigs               RigsRepository
+	PorePressures      PorePressuresRepository
+	FractureGradients  FractureGradientsRepository
+	Strings            StringsRepository
+	SurveyTools        SurveyToolsRepository
+	Roles              RolesRepository
+	Tokens             TokensRepository
+	Invitations        InvitationsRepository
+	ApiKeys            ApiKeysRepository
+	AuditLogs          AuditLogsRepository
+	DrillingData       DrillingDataRepository
+	WellLogs           WellLogsRepository
+	Jobs               JobsRepository
+	CalculationResults CalculationResultsRepository
+	Transactions       TransactionsRepository
 }
 
 func NewRepositories(db *gorm.DB) *Repository {
 	return &Repository{
-		Common:               postgres.NewCommonRepository(db),
-		Users:                postgres.NewUsersRepository(db),
-		Companies:            postgres.NewCompaniesRepository(db),
-		Organizations:        postgres.NewOrganizationsRepository(db),
-		Fields:               postgres.NewFieldsRepository(db),
-		Sites:                postgres.NewSitesRepository(db),
-		Wells:                postgres.NewWellsRepository(db),
-		Wellbores:            postgres.NewWellboresRepository(db),
-		Designs:              postgres.NewDesignsRepository(db),
-		Trajectories:         postgres.NewTrajectoriesRepository(db),
-		Cases:                postgres.NewCasesRepository(db),
-		Holes:                postgres.NewHolesRepository(db),
-		Fluids:               postgres.NewFluidsRepository(db),
-		Rigs:                 postgres.NewRigsRepository(db),
-		PorePressures:        postgres.NewPorePressuresRepository(db),
-		FractureGradients:    postgres.NewFractureGradientsRepository(db),
-		Strings:              postgres.NewStringsRepository(db),
+		Common:             postgres.NewCommonRepository(db),
+		Users:              postgres.NewUsersRepository(db),
+		Companies:          postgres.NewCompaniesRepository(db),
+		Organizations:      postgres.NewOrganizationsRepository(db),
+		Fields:             postgres.NewFieldsRepository(db),
+		Sites:              postgres.NewSitesRepository(db),
+		Wells:              postgres.NewWellsRepository(db),
+		Wellbores:          postgres.NewWellboresRepository(db),
+		Designs:            postgres.NewDesignsRepository(db),
+		Trajectories:       postgres.NewTrajectoriesRepository(db),
+		Cases:              postgres.NewCasesRepository(db),
+		Holes:              postgres.NewHolesRepository(db),
+		Fluids:             postgres.NewFluidsRepository(db),
+		Rigs:               postgres.NewRigsRepository(db),
+		PorePressures:      postgres.NewPorePressuresRepository(db),
+		FractureGradients:  postgres.NewFractureGradientsRepository(db),
+		Strings:            postgres.NewStringsRepository(db),
+		SurveyTools:        postgres.NewSurveyToolsRepository(db),
+		Roles:              postgres.NewRolesRepository(db),
+		Tokens:             postgres.NewTokensRepository(db),
+		Invitations:        postgres.NewInvitationsRepository(db),
+		ApiKeys:            postgres.NewApiKeysRepository(db),
+		AuditLogs:          postgres.NewAuditLogsRepository(db),
+		DrillingData:       postgres.NewDrillingDataRepository(db),
+		WellLogs:           postgres.NewWellLogsRepository(db),
+		Jobs:               postgres.NewJobsRepository(db),
+		CalculationResults: postgres.NewCalculationResultsRepository(db),
+		Transactions:       postgres.NewTransactionsRepository(db),
 	}
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+type RolesRepository interface {
+	GetRoleAssignments(ctx context.Context, organizationId string, userId string) ([]*entities.RoleAssignment, error)
+	CreateRoleAssignment(ctx context.Context, assignment *entities.RoleAssignment) error
+	DeleteRoleAssignment(ctx context.Context, organizationId string, id string) error
+	IsSuperAdmin(ctx context.Context, userId string) (bool, error)
+}
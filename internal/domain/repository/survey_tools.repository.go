@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+type SurveyToolsRepository interface {
+	GetSurveyTools(ctx context.Context) ([]*entities.SurveyTool, error)
+	GetSurveyToolByID(ctx context.Context, id string) (*entities.SurveyTool, error)
+	GetSurveyToolsByIDs(ctx context.Context, ids []string) ([]*entities.SurveyTool, error)
+	CreateSurveyTool(ctx context.Context, tool *entities.SurveyTool) error
+	UpdateSurveyTool(ctx context.Context, tool *entities.SurveyTool) (*entities.SurveyTool, error)
+	DeleteSurveyTool(ctx context.Context, id string) error
+}
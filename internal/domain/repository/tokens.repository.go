@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+type TokensRepository interface {
+	CreateTokenFamily(ctx context.Context, family *entities.TokenFamily) error
+	GetTokenFamily(ctx context.Context, id string) (*entities.TokenFamily, error)
+	RotateTokenFamily(ctx context.Context, id string, currentTokenId string, newTokenId string, expiresAt time.Time) error
+	RevokeTokenFamily(ctx context.Context, userId string, id string) error
+	RevokeUserTokenFamilies(ctx context.Context, userId string, exceptId string) error
+}
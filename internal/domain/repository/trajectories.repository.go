@@ -12,4 +12,5 @@ type TrajectoriesRepository interface {
 	CreateTrajectory(ctx context.Context, designID string, trajectory *entities.Trajectory) error
 	UpdateTrajectory(ctx context.Context, trajectory *entities.Trajectory) (*entities.Trajectory, error)
 	DeleteTrajectory(ctx context.Context, id string) error
+	GetOffsetTrajectories(ctx context.Context, trajectoryID string, scope string) ([]*entities.OffsetTrajectory, error)
 }
\ No newline at end of file
@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+type TransactionsRepository interface {
+	// Transaction runs fn in one database transaction, rolled back when fn returns an error. The repositories
+	// that fn calls with its context take part in the transaction.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
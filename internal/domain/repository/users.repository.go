@@ -7,7 +7,11 @@ import (
 )
 
 type UsersRepository interface {
-    Create(ctx context.Context, organizationId string, user *entities.User) error
-    GetByEmail(ctx context.Context, email string) (*entities.User, error)
-    // Update(ctx context.Context, user domain.User) error
-}
\ No newline at end of file
+	Create(ctx context.Context, organizationId string, user *entities.User) error
+	GetByEmail(ctx context.Context, email string) (*entities.User, error)
+	GetByID(ctx context.Context, id string) (*entities.User, error)
+	Update(ctx context.Context, user *entities.User) error
+	UpdatePassword(ctx context.Context, id string, password string) error
+	CreatePasswordReset(ctx context.Context, reset *entities.PasswordReset) error
+	UsePasswordReset(ctx context.Context, tokenHash string) (*entities.PasswordReset, error)
+}
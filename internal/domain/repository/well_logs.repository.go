@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+type WellLogsRepository interface {
+	// CreateWellLog creates the log with its samples and sets its ID.
+	CreateWellLog(ctx context.Context, wellLog *entities.WellLog) error
+	// GetWellLogs retrieves the logs of the wellbore without their samples, newest first.
+	GetWellLogs(ctx context.Context, wellboreID string) ([]*entities.WellLog, error)
+	// GetWellLogByID retrieves the log of the wellbore with its samples ordered by depth.
+	GetWellLogByID(ctx context.Context, wellboreID string, id string) (*entities.WellLog, error)
+	DeleteWellLog(ctx context.Context, wellboreID string, id string) error
+}
@@ -6,6 +6,9 @@ var (
 	ErrUserNotFound          = errors.New("user doesn't exists")
 	ErrUserAlreadyExists     = errors.New("user with such email already exists")
 	ErrUserPasswordIncorrect = errors.New("password incorrect")
+	// ErrInvalidCredentials is returned by sign in for unknown emails and wrong passwords alike, so the
+	// response doesn't tell which emails have an account
+	ErrInvalidCredentials = errors.New("email or password is incorrect")
 )
 
 
@@ -15,3 +18,79 @@ var (
 	ErrCompanyWasNotUpdated = errors.New("company was not updated")
 	ErrCompanyWasNotDeleted = errors.New("company was not deleted")
 )
+
+var (
+	ErrResourceNotFound = errors.New("resource not found")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrInvalidRole      = errors.New("invalid role")
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, the session is revoked")
+	ErrSessionRevoked      = errors.New("session is revoked or expired")
+)
+
+var (
+	ErrInvalidInvitation         = errors.New("invitation is invalid or expired")
+	ErrInvalidPasswordResetToken = errors.New("password reset token is invalid or expired")
+)
+
+var (
+	ErrInvalidApiKey      = errors.New("invalid or expired API key")
+	ErrInvalidApiKeyScope = errors.New("invalid API key scope")
+	ErrApiKeyNotAllowed   = errors.New("this action requires signing in, API keys are not accepted")
+)
+
+var (
+	ErrInvalidTimeRange = errors.New("the start of the time range must be before its end")
+)
+
+var (
+	ErrInvalidDesignStage     = errors.New("invalid design stage")
+	ErrInvalidStageTransition = errors.New("the design can't move to this stage from its current stage")
+	ErrDesignStageChanged     = errors.New("the stage of the design was changed by another request")
+	ErrDesignLocked           = errors.New("the design is approved and locked, move it back to planned to change it")
+)
+
+var (
+	ErrUnknownAntiCollisionScope = errors.New("unknown anti-collision scope")
+	ErrUnknownErrorModel         = errors.New("unknown anti-collision error model")
+	ErrWellNotGeoreferenced      = errors.New("the well has no surface location in a projected coordinate reference system, set the coordinate reference system and surface location of the site and its wells")
+)
+
+var (
+	ErrInvalidErrorTerm = errors.New("invalid error term")
+	ErrSurveyToolInUse  = errors.New("survey tool is used in a survey program")
+)
+
+var (
+	ErrCaseNotReadyForTorqueAndDrag = errors.New("the case needs a drill string whose sections have joint, stabilizer, weight, friction and yield data")
+	ErrTooManySensitivityRuns       = errors.New("too many friction factor combinations, narrow the ranges or increase the steps")
+)
+
+var (
+	ErrInvalidDrillingDataFile = errors.New("invalid drilling data file")
+	ErrCaseNotInWellbore       = errors.New("the case does not belong to the wellbore")
+	ErrNotEnoughDrillingData   = errors.New("not enough trip in, trip out or rotating readings to fit friction factors")
+)
+
+var ErrInvalidWitsmlFile = errors.New("invalid witsml file")
+
+var (
+	ErrInvalidWellLogFile  = errors.New("invalid well log file")
+	ErrInvalidNormalTrend  = errors.New("the normal compaction trend needs two points at different depths with values of the curve of the method")
+	ErrWellLogCurveMissing = errors.New("the well log has no curve for the method")
+	ErrNoWellLogSamples    = errors.New("the well log has no samples of the curve along the trajectory of the case")
+)
+
+var (
+	ErrInvalidWorkbookFile = errors.New("invalid workbook file")
+	ErrUnknownWorkbookRow  = errors.New("the workbook has a row of a component the case does not have")
+	ErrUnknownChart        = errors.New("unknown chart")
+)
+
+var (
+	ErrJobFinished    = errors.New("the job has already finished")
+	ErrJobInterrupted = errors.New("the job was interrupted too many times")
+)
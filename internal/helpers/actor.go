@@ -0,0 +1,23 @@
+package helpers
+
+import "context"
+
+type actorCtxKey struct{}
+
+// Actor is the user on whose behalf a request changes data. ApiKeyID is set for requests made with an API key.
+type Actor struct {
+	UserID         string
+	OrganizationID string
+	ApiKeyID       string
+}
+
+// WithActor returns a copy of ctx carrying the actor, for the audit log.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorCtxKey{}, actor)
+}
+
+// ActorFromContext returns the actor of ctx, if any.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorCtxKey{}).(Actor)
+	return actor, ok
+}
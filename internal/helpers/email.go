@@ -0,0 +1,9 @@
+package helpers
+
+import "strings"
+
+// NormalizeEmail trims and lowercases an email address. Emails are stored and compared normalized,
+// so addresses that only differ in case belong to the same user.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
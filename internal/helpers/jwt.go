@@ -41,16 +41,23 @@ type tokenClaims struct {
 	jwt.StandardClaims
 	UserId         string `json:"user_id"`
 	OrganizationId string `json:"organization_id"`
+	SessionId      string `json:"session_id"`
 }
 
+// refreshTokenClaims carries the ID of the refresh token in the standard jti claim.
 type refreshTokenClaims struct {
 	jwt.StandardClaims
-	UserId string `json:"user_id"`
+	UserId    string `json:"user_id"`
+	SessionId string `json:"session_id"`
 }
 
+// UserAccessTokenClaims are the claims of a token pair. SessionId is the ID of the refresh token family
+// and RefreshTokenId is the ID of the refresh token within it.
 type UserAccessTokenClaims struct {
 	UserId         string `json:"user_id"`
 	OrganizationId string `json:"organization_id"`
+	SessionId      string `json:"session_id"`
+	RefreshTokenId string `json:"-"`
 }
 
 func NewJwt() (*jwtStructure, error) {
@@ -78,6 +85,7 @@ func (j *jwtStructure) CreateAccessToken(claims UserAccessTokenClaims) (*Token,
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id":         claims.UserId,
 		"organization_id": claims.OrganizationId,
+		"session_id":      claims.SessionId,
 		"exp":             expirationTime,
 	})
 	tokenString, err := token.SignedString([]byte(j.userAccessTokenSecret))
@@ -90,8 +98,10 @@ func (j *jwtStructure) CreateAccessToken(claims UserAccessTokenClaims) (*Token,
 
 	refreshExpirationTime := time.Now().Add(time.Duration(j.refreshTokenLifetimeMinutes) * time.Minute).Unix()
 	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": claims.UserId,
-		"exp":     refreshExpirationTime,
+		"user_id":    claims.UserId,
+		"session_id": claims.SessionId,
+		"jti":        claims.RefreshTokenId,
+		"exp":        refreshExpirationTime,
 	})
 	refreshTokenString, err := refreshToken.SignedString([]byte(j.refreshTokenSecret))
 	if err != nil {
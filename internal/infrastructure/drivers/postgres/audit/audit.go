@@ -0,0 +1,303 @@
+// Package audit records every create, update and delete made through GORM in the audit_logs table.
+// The rows are read as JSON before and after each statement, and the changed columns are written
+// in the same transaction as the change itself, so a change is never saved without its audit entry.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	beforeRowsKey = "audit:before_rows"
+)
+
+// ignoredTables are not audited: the audit log itself, authentication data that changes on every sign in or request,
+// design revisions, which are immutable copies of data that is already audited, actual drilling readings
+// and well log samples, which are bulk imported measurements rather than engineering data, calculation
+// jobs, whose state changes as they run, and stored calculation results, which are derived from audited data.
+var ignoredTables = map[string]bool{
+	"audit_logs":          true,
+	"token_families":      true,
+	"password_resets":     true,
+	"api_keys":            true,
+	"design_revisions":    true,
+	"drilling_readings":   true,
+	"well_log_samples":    true,
+	"jobs":                true,
+	"calculation_results": true,
+}
+
+// hiddenColumns never appear in the audit log.
+var hiddenColumns = map[string]bool{
+	"password":   true,
+	"token_hash": true,
+	"key_hash":   true,
+}
+
+// bookkeepingColumns are left out of update diffs, they change with every update.
+var bookkeepingColumns = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+}
+
+type rows map[string]map[string]interface{}
+
+// Register adds the audit callbacks to db.
+func Register(db *gorm.DB) error {
+	callback := db.Callback()
+	if err := callback.Create().Before("gorm:create").Register("audit:before_create", loadBeforeRows(true)); err != nil {
+		return err
+	}
+	if err := callback.Create().After("gorm:create").Register("audit:after_create", record(entities.AuditOperationCreate)); err != nil {
+		return err
+	}
+	if err := callback.Update().Before("gorm:update").Register("audit:before_update", loadBeforeRows(false)); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Register("audit:after_update", record(entities.AuditOperationUpdate)); err != nil {
+		return err
+	}
+	if err := callback.Delete().Before("gorm:delete").Register("audit:before_delete", loadBeforeRows(false)); err != nil {
+		return err
+	}
+	return callback.Delete().After("gorm:delete").Register("audit:after_delete", record(entities.AuditOperationDelete))
+}
+
+// loadBeforeRows keeps the rows the statement is about to change. Plain creates change no existing rows,
+// but creates of associations with ON CONFLICT may update rows that already exist.
+func loadBeforeRows(create bool) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if !audited(db) {
+			return
+		}
+		stmt := db.Statement
+
+		ids := primaryKeys(stmt)
+		var conditions []clause.Expression
+		if create {
+			if _, ok := stmt.Clauses["ON CONFLICT"]; !ok || len(ids) == 0 {
+				return
+			}
+		} else {
+			conditions = whereConditions(stmt)
+		}
+		if len(ids) == 0 && len(conditions) == 0 {
+			return
+		}
+
+		before, err := loadRows(db, ids, conditions)
+		if err != nil {
+			db.AddError(fmt.Errorf("audit: %w", err))
+			return
+		}
+		db.InstanceSet(beforeRowsKey, before)
+	}
+}
+
+// record compares the rows before and after the statement and writes an audit entry for every changed row.
+func record(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || !audited(db) {
+			return
+		}
+		stmt := db.Statement
+
+		before := rows{}
+		if value, ok := db.InstanceGet(beforeRowsKey); ok {
+			before = value.(rows)
+		}
+		ids := primaryKeys(stmt)
+		if operation != entities.AuditOperationCreate {
+			ids = ids[:0]
+			for id := range before {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			return
+		}
+		after, err := loadRows(db, ids, nil)
+		if err != nil {
+			db.AddError(fmt.Errorf("audit: %w", err))
+			return
+		}
+
+		actor, _ := helpers.ActorFromContext(stmt.Context)
+		var logs []models.AuditLog
+		for _, id := range sortedIDs(before, after) {
+			entryOperation := operation
+			var changes map[string]entities.AuditChange
+			switch {
+			case operation == entities.AuditOperationDelete:
+				changes = diff(before[id], nil, false)
+			case operation == entities.AuditOperationCreate && before[id] == nil:
+				changes = diff(nil, after[id], false)
+			default:
+				entryOperation = entities.AuditOperationUpdate
+				changes = diff(before[id], after[id], true)
+			}
+			if len(changes) == 0 {
+				continue
+			}
+
+			log, err := newAuditLog(actor, stmt.Table, id, entryOperation, changes)
+			if err != nil {
+				db.AddError(fmt.Errorf("audit: %w", err))
+				return
+			}
+			logs = append(logs, *log)
+		}
+		if len(logs) == 0 {
+			return
+		}
+
+		if err := db.Session(&gorm.Session{NewDB: true}).Create(&logs).Error; err != nil {
+			db.AddError(fmt.Errorf("audit: %w", err))
+		}
+	}
+}
+
+func audited(db *gorm.DB) bool {
+	return db.Error == nil && db.Statement.Table != "" && !ignoredTables[db.Statement.Table]
+}
+
+// primaryKeys returns the primary keys set on the model or the values of the statement.
+func primaryKeys(stmt *gorm.Statement) []interface{} {
+	if stmt.Schema == nil || stmt.Schema.PrioritizedPrimaryField == nil {
+		return nil
+	}
+	field := stmt.Schema.PrioritizedPrimaryField
+
+	var ids []interface{}
+	add := func(value reflect.Value) {
+		value = reflect.Indirect(value)
+		if value.Kind() != reflect.Struct || value.Type() != stmt.Schema.ModelType {
+			return
+		}
+		if id, zero := field.ValueOf(stmt.Context, value); !zero {
+			ids = append(ids, fmt.Sprint(id))
+		}
+	}
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			add(stmt.ReflectValue.Index(i))
+		}
+	case reflect.Struct:
+		add(stmt.ReflectValue)
+	}
+	return ids
+}
+
+func whereConditions(stmt *gorm.Statement) []clause.Expression {
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			return where.Exprs
+		}
+	}
+	return nil
+}
+
+// loadRows reads the rows of the statement table by primary key and conditions as JSON, including soft deleted rows.
+func loadRows(db *gorm.DB, ids []interface{}, conditions []clause.Expression) (rows, error) {
+	stmt := db.Statement
+	// A new session keeps the connection, the transaction and the context of the statement, but none of its clauses
+	query := db.Session(&gorm.Session{NewDB: true}).Table(stmt.Table)
+	if len(ids) > 0 {
+		query = query.Where(clause.IN{Column: clause.Column{Table: stmt.Table, Name: "id"}, Values: ids})
+	}
+	if len(conditions) > 0 {
+		query = query.Clauses(clause.Where{Exprs: conditions})
+	}
+
+	var jsonRows []string
+	if err := query.Pluck(fmt.Sprintf("row_to_json(%s)::text", stmt.Quote(stmt.Table)), &jsonRows).Error; err != nil {
+		return nil, err
+	}
+
+	res := rows{}
+	for _, jsonRow := range jsonRows {
+		var row map[string]interface{}
+		if err := json.Unmarshal([]byte(jsonRow), &row); err != nil {
+			return nil, err
+		}
+		id, ok := row["id"].(string)
+		if !ok {
+			continue
+		}
+		for column := range hiddenColumns {
+			delete(row, column)
+		}
+		res[id] = row
+	}
+	return res, nil
+}
+
+// diff returns the columns whose values differ between the rows. Missing rows count as all null.
+func diff(before, after map[string]interface{}, skipBookkeeping bool) map[string]entities.AuditChange {
+	changes := map[string]entities.AuditChange{}
+	for _, row := range []map[string]interface{}{before, after} {
+		for column := range row {
+			if _, seen := changes[column]; seen || (skipBookkeeping && bookkeepingColumns[column]) {
+				continue
+			}
+			if !reflect.DeepEqual(before[column], after[column]) {
+				changes[column] = entities.AuditChange{Before: before[column], After: after[column]}
+			}
+		}
+	}
+	return changes
+}
+
+func sortedIDs(before, after rows) []string {
+	var ids []string
+	for id := range before {
+		ids = append(ids, id)
+	}
+	for id := range after {
+		if _, ok := before[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func newAuditLog(actor helpers.Actor, table string, id string, operation string, changes map[string]entities.AuditChange) (*models.AuditLog, error) {
+	entityID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuditLog{
+		UserID:         optionalUUID(actor.UserID),
+		OrganizationID: optionalUUID(actor.OrganizationID),
+		ApiKeyID:       optionalUUID(actor.ApiKeyID),
+		EntityType:     table,
+		EntityID:       entityID,
+		Operation:      operation,
+		Changes:        string(changesJSON),
+	}, nil
+}
+
+func optionalUUID(id string) *uuid.UUID {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
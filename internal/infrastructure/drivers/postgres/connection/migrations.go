@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const migrationsDir = "internal/infrastructure/drivers/postgres/setup/migrations"
+
+// migrationsLockKey is the key of the advisory lock that keeps instances starting at the same time
+// from applying the same migration twice.
+const migrationsLockKey = 7_245_301
+
+// runMigrations applies the one-off SQL files in migrationsDir that have not been applied yet, in the order of
+// their names. Unlike the setup files, which run on every start, each migration runs once in its own transaction
+// and is recorded in schema_migrations by its file name.
+func runMigrations(db *gorm.DB) error {
+	err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version varchar(255) PRIMARY KEY,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`).Error
+	if err != nil {
+		return fmt.Errorf("could not create schema_migrations: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.sql"))
+	if err != nil {
+		return fmt.Errorf("could not list migrations: %v", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		version := strings.TrimSuffix(filepath.Base(file), ".sql")
+		if err = applyMigration(db, version, file); err != nil {
+			return fmt.Errorf("could not apply migration %s: %v", version, err)
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(db *gorm.DB, version string, file string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationsLockKey).Error; err != nil {
+			return err
+		}
+
+		var applied int64
+		if err := tx.Raw("SELECT count(*) FROM schema_migrations WHERE version = ?", version).Scan(&applied).Error; err != nil {
+			return err
+		}
+		if applied > 0 {
+			return nil
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err = tx.Exec(string(content)).Error; err != nil {
+			return err
+		}
+		if err = tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version).Error; err != nil {
+			return err
+		}
+
+		logrus.Infof("Applied migration %s", version)
+		return nil
+	})
+}
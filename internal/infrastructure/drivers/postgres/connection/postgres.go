@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/audit"
 	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
 	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
@@ -81,15 +82,29 @@ func NewDatabase() *Database {
 		err = db.AutoMigrate(
 			&models.Organization{},
 			&models.User{},
+			&models.RoleAssignment{},
+			&models.TokenFamily{},
+			&models.Invitation{},
+			&models.PasswordReset{},
+			&models.ApiKey{},
+			&models.AuditLog{},
 			&models.Company{},
 			&models.Field{},
 			&models.Site{},
 			&models.Well{},
 			&models.Wellbore{},
 			&models.Design{},
+			&models.DesignRevision{},
+			&models.DesignStageTransition{},
+			&models.DrillingReading{},
+			&models.WellLog{},
+			&models.WellLogSample{},
 			&models.Trajectory{},
 			&models.TrajectoryHeader{},
 			&models.TrajectoryUnit{},
+			&models.SurveyTool{},
+			&models.SurveyToolErrorTerm{},
+			&models.SurveyProgramInterval{},
 			&models.Case{},
 			&models.Hole{},
 			&models.Caising{},
@@ -101,6 +116,8 @@ func NewDatabase() *Database {
 			&models.PorePressure{},
 			&models.FractureGradient{},
 			&models.Rig{},
+			&models.Job{},
+			&models.CalculationResult{},
 		)
 		if err != nil {
 			logrus.Fatalf("failed to auto-migrate database: %v", err)
@@ -111,10 +128,18 @@ func NewDatabase() *Database {
 		}
 		logrus.Print("Indexes created")
 
+		if err = runMigrations(db); err != nil {
+			logrus.Fatalf("failed to run migrations: %v", err)
+		}
+
 		if err = execSqlFromFile(db, "internal/infrastructure/drivers/postgres/setup/seed.sql"); err != nil {
 			logrus.Fatalf("failed to execute seed sql file: %v", err)
 		}
 
+		if err = audit.Register(db); err != nil {
+			logrus.Fatalf("failed to register audit callbacks: %v", err)
+		}
+
 		dbInstance = &Database{Conn: db}
 		logrus.Info("Database connection established and migrated")
 	})
@@ -23,16 +23,102 @@ type Organization struct {
 
 // User model with UUID primary key.
 type User struct {
+	ID              uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt       time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt       gorm.DeletedAt   `gorm:"index" json:"deleted_at"`
+	OrganizationID  uuid.UUID        `gorm:"type:uuid;not null" json:"organization_id"`
+	Name            string           `gorm:"not null" json:"name"`
+	Surname         string           `gorm:"not null" json:"surname"`
+	Email           string           `gorm:"type:varchar(255);not null" json:"email"`
+	Password        string           `gorm:"type:varchar(70);not null" json:"password"`
+	Phone           string           `gorm:"type:varchar(20)" json:"phone"`
+	IsSuperAdmin    bool             `gorm:"not null;default:false" json:"is_super_admin"`
+	RoleAssignments []RoleAssignment `gorm:"constraint:OnDelete:CASCADE;" json:"role_assignments"`
+}
+
+// RoleAssignment model with UUID primary key. Company and field are set for assignments limited to them.
+type RoleAssignment struct {
+	ID             uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	UserID         uuid.UUID      `gorm:"type:uuid;not null" json:"user_id"`
+	OrganizationID uuid.UUID      `gorm:"type:uuid;not null" json:"organization_id"`
+	CompanyID      *uuid.UUID     `gorm:"type:uuid" json:"company_id"`
+	FieldID        *uuid.UUID     `gorm:"type:uuid" json:"field_id"`
+	Role           string         `gorm:"type:varchar(32);not null" json:"role"`
+}
+
+// Invitation model with UUID primary key. Only the hash of the invitation token is stored.
+type Invitation struct {
 	ID             uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 	OrganizationID uuid.UUID      `gorm:"type:uuid;not null" json:"organization_id"`
-	Name           string         `gorm:"not null" json:"name"`
-	Surname        string         `gorm:"not null" json:"surname"`
 	Email          string         `gorm:"type:varchar(255);not null" json:"email"`
-	Password       string         `gorm:"type:varchar(70);not null" json:"password"`
-	Phone          string         `gorm:"type:varchar(20)" json:"phone"`
+	Role           string         `gorm:"type:varchar(32);not null" json:"role"`
+	InvitedBy      uuid.UUID      `gorm:"type:uuid;not null" json:"invited_by"`
+	TokenHash      string         `gorm:"type:varchar(64);not null" json:"token_hash"`
+	ExpiresAt      time.Time      `gorm:"not null" json:"expires_at"`
+	AcceptedAt     *time.Time     `json:"accepted_at"`
+}
+
+// PasswordReset model with UUID primary key. Only the hash of the reset token is stored.
+type PasswordReset struct {
+	ID        uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	UserID    uuid.UUID      `gorm:"type:uuid;not null" json:"user_id"`
+	TokenHash string         `gorm:"type:varchar(64);not null" json:"token_hash"`
+	ExpiresAt time.Time      `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time     `json:"used_at"`
+}
+
+// ApiKey model with UUID primary key. Only the hash of the key is stored, Prefix is kept to recognize the key.
+type ApiKey struct {
+	ID             uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	UserID         uuid.UUID      `gorm:"type:uuid;not null" json:"user_id"`
+	OrganizationID uuid.UUID      `gorm:"type:uuid;not null" json:"organization_id"`
+	Name           string         `gorm:"type:varchar(64);not null" json:"name"`
+	Prefix         string         `gorm:"type:varchar(16);not null" json:"prefix"`
+	KeyHash        string         `gorm:"type:varchar(64);not null" json:"key_hash"`
+	Scope          string         `gorm:"type:varchar(16);not null" json:"scope"`
+	CompanyID      *uuid.UUID     `gorm:"type:uuid" json:"company_id"`
+	ExpiresAt      *time.Time     `json:"expires_at"`
+	LastUsedAt     *time.Time     `json:"last_used_at"`
+}
+
+// AuditLog model with UUID primary key. Rows are never updated or deleted, so there is no DeletedAt.
+// Changes holds the before and after values of the changed columns as JSON.
+type AuditLog struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UserID         *uuid.UUID `gorm:"type:uuid" json:"user_id"`
+	OrganizationID *uuid.UUID `gorm:"type:uuid" json:"organization_id"`
+	ApiKeyID       *uuid.UUID `gorm:"type:uuid" json:"api_key_id"`
+	EntityType     string     `gorm:"type:varchar(64);not null" json:"entity_type"`
+	EntityID       uuid.UUID  `gorm:"type:uuid;not null" json:"entity_id"`
+	Operation      string     `gorm:"type:varchar(16);not null" json:"operation"`
+	Changes        string     `gorm:"type:jsonb;not null" json:"changes"`
+}
+
+// TokenFamily model with UUID primary key. CurrentTokenID is the ID of the only refresh token of the family that can be used.
+type TokenFamily struct {
+	ID             uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	UserID         uuid.UUID      `gorm:"type:uuid;not null" json:"user_id"`
+	OrganizationID uuid.UUID      `gorm:"type:uuid;not null" json:"organization_id"`
+	CurrentTokenID uuid.UUID      `gorm:"type:uuid;not null" json:"current_token_id"`
+	ExpiresAt      time.Time      `gorm:"not null" json:"expires_at"`
+	RevokedAt      *time.Time     `json:"revoked_at"`
 }
 
 // Company model with UUID primary key and foreign key.
@@ -79,6 +165,11 @@ type Site struct {
 	Country   string         `json:"country"`
 	State     string         `json:"state"`
 	Region    string         `json:"region"`
+	EPSGCode  int            `gorm:"column:epsg_code" json:"epsg_code"`
+	Latitude  float64        `json:"latitude"`
+	Longitude float64        `json:"longitude"`
+	Easting   float64        `json:"easting"`
+	Northing  float64        `json:"northing"`
 	Wells     []Well         `gorm:"constraint:OnDelete:CASCADE;" json:"wells"`
 }
 
@@ -97,6 +188,13 @@ type Well struct {
 	WellNumber              string         `json:"well_number"`
 	WorkingGroup            string         `json:"working_group"`
 	ActiveWellUnit          string         `json:"active_well_unit"`
+	EPSGCode                int            `gorm:"column:epsg_code" json:"epsg_code"`
+	Latitude                float64        `json:"latitude"`
+	Longitude               float64        `json:"longitude"`
+	Easting                 float64        `json:"easting"`
+	Northing                float64        `json:"northing"`
+	GridConvergence         float64        `json:"grid_convergence"`
+	ScaleFactor             float64        `json:"scale_factor"`
 	Wellbores               []Wellbore     `gorm:"constraint:OnDelete:CASCADE;" json:"wellbores"`
 }
 
@@ -124,7 +222,8 @@ type Wellbore struct {
 	Designs                        []Design       `gorm:"constraint:OnDelete:CASCADE;" json:"designs"`
 }
 
-// Design model with UUID primary key and foreign key.
+// Design model with UUID primary key and foreign key. The chk_designs_stage constraint, added by a migration,
+// limits the stage to the stages of the approval workflow.
 type Design struct {
 	ID           uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	CreatedAt    time.Time      `gorm:"autoCreateTime" json:"created_at"`
@@ -132,24 +231,51 @@ type Design struct {
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 	WellboreID   uuid.UUID      `gorm:"type:uuid;not null" json:"wellbore_id"`
 	PlanName     string         `json:"plan_name"`
-	Stage        string         `json:"stage"`
+	Stage        string         `gorm:"not null;default:'prototype'" json:"stage"`
 	Version      string         `json:"version"`
+	Revision     int            `gorm:"not null;default:1" json:"revision"`
 	ActualDate   time.Time      `json:"actual_date"`
 	Trajectories []Trajectory   `gorm:"constraint:OnDelete:CASCADE;" json:"trajectories"`
 }
 
+// DesignStageTransition records a design moving from one stage to another.
+type DesignStageTransition struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	DesignID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"design_id"`
+	Design    Design     `gorm:"foreignKey:DesignID;constraint:OnDelete:CASCADE;" json:"-"`
+	FromStage string     `gorm:"type:varchar(16);not null" json:"from_stage"`
+	ToStage   string     `gorm:"type:varchar(16);not null" json:"to_stage"`
+	Comment   string     `gorm:"type:text" json:"comment"`
+	UserID    *uuid.UUID `gorm:"type:uuid" json:"user_id"`
+	Revision  *int       `json:"revision"`
+}
+
+// DesignRevision is an immutable snapshot of a design tree, the snapshot is the design entity as JSON.
+type DesignRevision struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	DesignID  uuid.UUID  `gorm:"type:uuid;not null" json:"design_id"`
+	Design    Design     `gorm:"foreignKey:DesignID;constraint:OnDelete:CASCADE;" json:"-"`
+	Revision  int        `gorm:"not null" json:"revision"`
+	Comment   string     `gorm:"type:text" json:"comment"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+	Snapshot  string     `gorm:"type:jsonb;not null" json:"snapshot"`
+}
+
 // Trajectory model with UUID primary key and foreign key.
 type Trajectory struct {
-	ID          uuid.UUID          `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	CreatedAt   time.Time          `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt   gorm.DeletedAt     `gorm:"index" json:"deleted_at"`
-	DesignID    uuid.UUID          `gorm:"type:uuid;not null" json:"design_id"`
-	Name        string             `gorm:"type:varchar(255)" json:"name"`
-	Description string             `json:"description"`
-	Headers     []TrajectoryHeader `gorm:"constraint:OnDelete:CASCADE;" json:"headers"`
-	Units       []TrajectoryUnit   `gorm:"constraint:OnDelete:CASCADE;" json:"units"`
-	Cases       []Case             `gorm:"constraint:OnDelete:CASCADE;" json:"cases"`
+	ID            uuid.UUID               `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt     time.Time               `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time               `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt          `gorm:"index" json:"deleted_at"`
+	DesignID      uuid.UUID               `gorm:"type:uuid;not null" json:"design_id"`
+	Name          string                  `gorm:"type:varchar(255)" json:"name"`
+	Description   string                  `json:"description"`
+	Headers       []TrajectoryHeader      `gorm:"constraint:OnDelete:CASCADE;" json:"headers"`
+	Units         []TrajectoryUnit        `gorm:"constraint:OnDelete:CASCADE;" json:"units"`
+	SurveyProgram []SurveyProgramInterval `gorm:"constraint:OnDelete:CASCADE;" json:"survey_program"`
+	Cases         []Case                  `gorm:"constraint:OnDelete:CASCADE;" json:"cases"`
 }
 
 // TrajectoryHeader model with UUID primary key and foreign key.
@@ -187,6 +313,39 @@ type TrajectoryUnit struct {
 	VerticalSection float64   `json:"vertical_section"`
 }
 
+// SurveyTool model with UUID primary key.
+type SurveyTool struct {
+	ID          uuid.UUID             `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt   time.Time             `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time             `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt        `gorm:"index" json:"deleted_at"`
+	Name        string                `gorm:"type:varchar(255);not null" json:"name"`
+	Description string                `json:"description"`
+	ErrorTerms  []SurveyToolErrorTerm `gorm:"constraint:OnDelete:CASCADE;" json:"error_terms"`
+}
+
+// SurveyToolErrorTerm model with UUID primary key and foreign key.
+type SurveyToolErrorTerm struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	SurveyToolID uuid.UUID `gorm:"type:uuid;not null" json:"survey_tool_id"`
+	Name         string    `gorm:"type:varchar(64);not null" json:"name"`
+	Weighting    string    `gorm:"type:varchar(32);not null" json:"weighting"`
+	Propagation  string    `gorm:"type:varchar(32);not null" json:"propagation"`
+	Magnitude    float64   `gorm:"not null" json:"magnitude"`
+}
+
+// SurveyProgramInterval model with UUID primary key and foreign keys.
+type SurveyProgramInterval struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	TrajectoryID uuid.UUID  `gorm:"type:uuid;not null" json:"trajectory_id"`
+	SurveyToolID uuid.UUID  `gorm:"type:uuid;not null" json:"survey_tool_id"`
+	SurveyTool   SurveyTool `gorm:"foreignKey:SurveyToolID;constraint:OnDelete:RESTRICT;" json:"-"`
+	MDFrom       float64    `gorm:"not null" json:"md_from"`
+	MDTo         float64    `gorm:"not null" json:"md_to"`
+}
+
 // Case model with UUID primary key and foreign key.
 type Case struct {
 	ID                uuid.UUID          `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
@@ -377,6 +536,8 @@ type FractureGradient struct {
 	TemperatureAtWellTVD float64        `gorm:"not null" json:"temperature_at_well_tvd"`
 	TemperatureGradient  float64        `gorm:"not null" json:"temperature_gradient"`
 	WellTVD              float64        `gorm:"not null" json:"well_tvd"`
+	Pressure             *float64       `json:"pressure"`
+	EMW                  *float64       `json:"emw"`
 }
 
 // Rig model with UUID primary key and foreign key.
@@ -405,3 +566,84 @@ type Rig struct {
 	TopDriveStackupLength             *float64       `json:"top_drive_stackup_length,omitempty"`
 	TopDriveStackupInternalDiameter   *float64       `json:"top_drive_stackup_internal_diameter,omitempty"`
 }
+
+// DrillingReading is an actual drilling data reading of a wellbore, one row per wellbore and time.
+type DrillingReading struct {
+	ID                uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	WellboreID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_drilling_readings_wellbore_time,priority:1" json:"wellbore_id"`
+	Wellbore          Wellbore  `gorm:"foreignKey:WellboreID;constraint:OnDelete:CASCADE;" json:"-"`
+	Time              time.Time `gorm:"not null;uniqueIndex:idx_drilling_readings_wellbore_time,priority:2" json:"time"`
+	BitDepth          float64   `gorm:"not null" json:"bit_depth"`
+	HookLoad          *float64  `json:"hook_load"`
+	SurfaceTorque     *float64  `json:"surface_torque"`
+	WeightOnBit       *float64  `json:"weight_on_bit"`
+	RPM               *float64  `json:"rpm"`
+	FlowRate          *float64  `json:"flow_rate"`
+	StandpipePressure *float64  `json:"standpipe_pressure"`
+}
+
+// Job is an asynchronous calculation of a case. Params and Result are JSON, queued jobs run once RunAfter has passed.
+// A running job belongs to the server in WorkerID while its lease is renewed, an expired lease means the server stopped.
+type Job struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+	OrganizationID uuid.UUID  `gorm:"type:uuid;not null" json:"organization_id"`
+	CaseID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"case_id"`
+	Case           Case       `gorm:"foreignKey:CaseID;constraint:OnDelete:CASCADE;" json:"-"`
+	Type           string     `gorm:"type:varchar(32);not null" json:"type"`
+	Status         string     `gorm:"type:varchar(16);not null;index:idx_jobs_status_run_after,priority:1" json:"status"`
+	Params         *string    `gorm:"type:jsonb" json:"params"`
+	Result         *string    `gorm:"type:jsonb" json:"result"`
+	Error          string     `json:"error"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	RunAfter       time.Time  `gorm:"not null;index:idx_jobs_status_run_after,priority:2" json:"run_after"`
+	StartedAt      *time.Time `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at"`
+	WorkerID       string     `gorm:"type:varchar(255);not null;default:''" json:"worker_id"`
+	LeaseExpiresAt *time.Time `gorm:"index" json:"lease_expires_at"`
+}
+
+// CalculationResult is a stored result of a calculation of a case. Database triggers mark the results of a case
+// stale when its trajectory, strings, holes, fluids or rigs change.
+type CalculationResult struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	CaseID    uuid.UUID `gorm:"type:uuid;not null;index:idx_calculation_results_lookup,priority:1" json:"case_id"`
+	Case      Case      `gorm:"foreignKey:CaseID;constraint:OnDelete:CASCADE;" json:"-"`
+	Engine    string    `gorm:"type:varchar(32);not null;index:idx_calculation_results_lookup,priority:2" json:"engine"`
+	Type      string    `gorm:"type:varchar(32);not null;index:idx_calculation_results_lookup,priority:3" json:"type"`
+	InputHash string    `gorm:"type:char(64);not null;index:idx_calculation_results_lookup,priority:4" json:"input_hash"`
+	Params    *string   `gorm:"type:jsonb" json:"params"`
+	Result    *string   `gorm:"type:jsonb" json:"result"`
+	Stale     bool      `gorm:"not null;default:false" json:"stale"`
+}
+
+// WellLog is a log of a wellbore imported from a LAS file, its curves are stored as samples.
+type WellLog struct {
+	ID               uuid.UUID       `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CreatedAt        time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	WellboreID       uuid.UUID       `gorm:"type:uuid;not null;index" json:"wellbore_id"`
+	Wellbore         Wellbore        `gorm:"foreignKey:WellboreID;constraint:OnDelete:CASCADE;" json:"-"`
+	Name             string          `gorm:"not null" json:"name"`
+	FileName         string          `gorm:"not null" json:"file_name"`
+	TopDepth         float64         `gorm:"not null" json:"top_depth"`
+	BottomDepth      float64         `gorm:"not null" json:"bottom_depth"`
+	GammaRayCurve    string          `json:"gamma_ray_curve"`
+	ResistivityCurve string          `json:"resistivity_curve"`
+	SonicCurve       string          `json:"sonic_curve"`
+	DensityCurve     string          `json:"density_curve"`
+	Samples          []WellLogSample `gorm:"foreignKey:WellLogID" json:"samples"`
+}
+
+// WellLogSample holds the curve values of a well log at a measured depth.
+type WellLogSample struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	WellLogID   uuid.UUID `gorm:"type:uuid;not null;index:idx_well_log_samples_log_md,priority:1" json:"well_log_id"`
+	WellLog     WellLog   `gorm:"foreignKey:WellLogID;constraint:OnDelete:CASCADE;" json:"-"`
+	MD          float64   `gorm:"not null;index:idx_well_log_samples_log_md,priority:2" json:"md"`
+	GammaRay    *float64  `json:"gamma_ray"`
+	Resistivity *float64  `json:"resistivity"`
+	Sonic       *float64  `json:"sonic"`
+	Density     *float64  `json:"density"`
+}
@@ -1,3 +1,87 @@
 CREATE UNIQUE INDEX IF NOT EXISTS idx_organizations_email ON organizations (email) WHERE deleted_at IS NULL;
 CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email) WHERE deleted_at IS NULL;
 CREATE UNIQUE INDEX IF NOT EXISTS idx_companies_name ON companies (name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_survey_tools_name ON survey_tools (name) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_role_assignments_user ON role_assignments (user_id, organization_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_token_families_user ON token_families (user_id) WHERE revoked_at IS NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invitations_token_hash ON invitations (token_hash);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_password_resets_token_hash ON password_resets (token_hash);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys (key_hash);
+CREATE INDEX IF NOT EXISTS idx_api_keys_user ON api_keys (user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_audit_logs_organization_created ON audit_logs (organization_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity_type, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user ON audit_logs (user_id, created_at);
+
+-- The audit log is append-only
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS trg_audit_logs_append_only ON audit_logs;
+CREATE TRIGGER trg_audit_logs_append_only BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+
+-- Design revisions are immutable. Designs are soft deleted, a revision is only deleted along with its design
+-- when the design is hard deleted, the cascade runs after the design row is gone
+CREATE UNIQUE INDEX IF NOT EXISTS idx_design_revisions_design_revision ON design_revisions (design_id, revision);
+CREATE OR REPLACE FUNCTION design_revisions_immutable() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND NOT EXISTS (SELECT 1 FROM designs WHERE id = OLD.design_id) THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'design revisions are immutable';
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS trg_design_revisions_immutable ON design_revisions;
+CREATE TRIGGER trg_design_revisions_immutable BEFORE UPDATE OR DELETE ON design_revisions
+    FOR EACH ROW EXECUTE FUNCTION design_revisions_immutable();
+
+-- Stored calculation results of a case become stale once its trajectory, strings, holes, fluids or rigs change.
+-- Soft deletes are updates, so they fire the triggers as well
+CREATE OR REPLACE FUNCTION calculation_results_mark_stale() RETURNS trigger AS $$
+DECLARE
+    changed jsonb;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := to_jsonb(OLD);
+    ELSE
+        changed := to_jsonb(NEW);
+    END IF;
+
+    IF TG_TABLE_NAME = 'trajectory_units' THEN
+        UPDATE calculation_results SET stale = true WHERE NOT stale AND case_id IN (
+            SELECT id FROM cases WHERE trajectory_id = (changed->>'trajectory_id')::uuid);
+    ELSIF TG_TABLE_NAME = 'caisings' THEN
+        UPDATE calculation_results SET stale = true WHERE NOT stale AND case_id IN (
+            SELECT case_id FROM holes WHERE id = (changed->>'hole_id')::uuid);
+    ELSIF TG_TABLE_NAME = 'sections' THEN
+        UPDATE calculation_results SET stale = true WHERE NOT stale AND case_id IN (
+            SELECT case_id FROM strings WHERE id = (changed->>'string_id')::uuid);
+    ELSE
+        UPDATE calculation_results SET stale = true WHERE NOT stale AND case_id = (changed->>'case_id')::uuid;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS trg_trajectory_units_calculation_results_stale ON trajectory_units;
+CREATE TRIGGER trg_trajectory_units_calculation_results_stale AFTER INSERT OR UPDATE OR DELETE ON trajectory_units
+    FOR EACH ROW EXECUTE FUNCTION calculation_results_mark_stale();
+DROP TRIGGER IF EXISTS trg_strings_calculation_results_stale ON strings;
+CREATE TRIGGER trg_strings_calculation_results_stale AFTER INSERT OR UPDATE OR DELETE ON strings
+    FOR EACH ROW EXECUTE FUNCTION calculation_results_mark_stale();
+DROP TRIGGER IF EXISTS trg_sections_calculation_results_stale ON sections;
+CREATE TRIGGER trg_sections_calculation_results_stale AFTER INSERT OR UPDATE OR DELETE ON sections
+    FOR EACH ROW EXECUTE FUNCTION calculation_results_mark_stale();
+DROP TRIGGER IF EXISTS trg_holes_calculation_results_stale ON holes;
+CREATE TRIGGER trg_holes_calculation_results_stale AFTER INSERT OR UPDATE OR DELETE ON holes
+    FOR EACH ROW EXECUTE FUNCTION calculation_results_mark_stale();
+DROP TRIGGER IF EXISTS trg_caisings_calculation_results_stale ON caisings;
+CREATE TRIGGER trg_caisings_calculation_results_stale AFTER INSERT OR UPDATE OR DELETE ON caisings
+    FOR EACH ROW EXECUTE FUNCTION calculation_results_mark_stale();
+DROP TRIGGER IF EXISTS trg_fluids_calculation_results_stale ON fluids;
+CREATE TRIGGER trg_fluids_calculation_results_stale AFTER INSERT OR UPDATE OR DELETE ON fluids
+    FOR EACH ROW EXECUTE FUNCTION calculation_results_mark_stale();
+DROP TRIGGER IF EXISTS trg_rigs_calculation_results_stale ON rigs;
+CREATE TRIGGER trg_rigs_calculation_results_stale AFTER INSERT OR UPDATE OR DELETE ON rigs
+    FOR EACH ROW EXECUTE FUNCTION calculation_results_mark_stale();
@@ -0,0 +1,16 @@
+-- Users signed up before roles existed have no role assignment and can not do anything.
+-- The earliest user of each organization becomes its admin, the other users become engineers
+WITH ranked AS (
+    SELECT id, organization_id,
+        row_number() OVER (PARTITION BY organization_id ORDER BY created_at, id) AS position
+    FROM users
+    WHERE deleted_at IS NULL
+)
+INSERT INTO role_assignments (id, user_id, organization_id, role, created_at, updated_at)
+SELECT uuid_generate_v4(), r.id, r.organization_id,
+    CASE WHEN r.position = 1 THEN 'org_admin' ELSE 'engineer' END,
+    now(), now()
+FROM ranked r
+WHERE NOT EXISTS (
+    SELECT 1 FROM role_assignments ra WHERE ra.user_id = r.id AND ra.deleted_at IS NULL
+);
@@ -0,0 +1,32 @@
+-- Stages were free text before the approval workflow. The values used before are mapped to the stages they meant,
+-- anything else starts over as a prototype. Every changed design gets a transition that keeps the old value
+WITH legacy AS (
+    SELECT id, stage AS old_stage,
+        CASE lower(btrim(coalesce(stage, '')))
+            WHEN 'prototype' THEN 'prototype'
+            WHEN 'proto' THEN 'prototype'
+            WHEN 'draft' THEN 'prototype'
+            WHEN 'plan' THEN 'planned'
+            WHEN 'planned' THEN 'planned'
+            WHEN 'planning' THEN 'planned'
+            WHEN 'approved' THEN 'approved'
+            WHEN 'actual' THEN 'actual'
+            WHEN 'as drilled' THEN 'actual'
+            WHEN 'drilled' THEN 'actual'
+            WHEN 'definitive' THEN 'actual'
+            WHEN 'archive' THEN 'archived'
+            WHEN 'archived' THEN 'archived'
+            ELSE 'prototype'
+        END AS new_stage
+    FROM designs
+    WHERE stage IS NULL OR stage NOT IN ('prototype', 'planned', 'approved', 'actual', 'archived')
+), transitions AS (
+    INSERT INTO design_stage_transitions (id, created_at, design_id, from_stage, to_stage, comment)
+    SELECT uuid_generate_v4(), now(), id, left(coalesce(old_stage, ''), 16), new_stage,
+        format('Legacy stage %L mapped to %s', old_stage, new_stage)
+    FROM legacy
+)
+UPDATE designs d SET stage = legacy.new_stage FROM legacy WHERE d.id = legacy.id;
+
+ALTER TABLE designs ADD CONSTRAINT chk_designs_stage
+    CHECK (stage IN ('prototype', 'planned', 'approved', 'actual', 'archived'));
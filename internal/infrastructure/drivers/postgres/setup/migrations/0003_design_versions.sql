@@ -0,0 +1,18 @@
+-- Versions were free text, from now on the version of a design is its revision. The replaced text is kept
+-- in the audit log of the design
+WITH changed AS (
+    SELECT d.id, d.version AS old_version, d.revision::text AS new_version, c.organization_id
+    FROM designs d
+    JOIN wellbores wb ON wb.id = d.wellbore_id
+    JOIN wells w ON w.id = wb.well_id
+    JOIN sites s ON s.id = w.site_id
+    JOIN fields f ON f.id = s.field_id
+    JOIN companies c ON c.id = f.company_id
+    WHERE d.version IS DISTINCT FROM d.revision::text
+), logged AS (
+    INSERT INTO audit_logs (id, created_at, organization_id, entity_type, entity_id, operation, changes)
+    SELECT uuid_generate_v4(), now(), organization_id, 'designs', id, 'update',
+        jsonb_build_object('version', jsonb_build_object('before', old_version, 'after', new_version))
+    FROM changed
+)
+UPDATE designs d SET version = changed.new_version FROM changed WHERE d.id = changed.id;
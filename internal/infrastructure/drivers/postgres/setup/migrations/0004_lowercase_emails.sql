@@ -0,0 +1,20 @@
+-- Emails are stored in lower case, so the unique index on users.email does not tell addresses apart by case.
+-- Users whose emails only differ in case have to be merged by hand before this runs
+DO $$
+DECLARE
+    duplicates text;
+BEGIN
+    SELECT string_agg(email, ', ') INTO duplicates FROM (
+        SELECT lower(btrim(email)) AS email FROM users WHERE deleted_at IS NULL GROUP BY lower(btrim(email)) HAVING count(*) > 1
+    ) d;
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'users with emails that only differ in case: %', duplicates;
+    END IF;
+END;
+$$;
+
+UPDATE users SET email = lower(btrim(email)) WHERE email <> lower(btrim(email));
+UPDATE invitations SET email = lower(btrim(email)) WHERE email <> lower(btrim(email));
+
+ALTER TABLE users ADD CONSTRAINT chk_users_email_lower CHECK (email = lower(email));
+ALTER TABLE invitations ADD CONSTRAINT chk_invitations_email_lower CHECK (email = lower(email));
@@ -0,0 +1,5 @@
+-- Jobs claimed before leases existed may still run on servers of the previous version. Such a server stops an
+-- attempt after the job timeout of 10 minutes, so the jobs get a lease that long instead of being requeued at once.
+UPDATE jobs
+SET lease_expires_at = now() + interval '10 minutes'
+WHERE status = 'running' AND lease_expires_at IS NULL;
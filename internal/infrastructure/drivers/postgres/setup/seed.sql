@@ -31,6 +31,19 @@ BEGIN
         (uuid_generate_v4(), org_id, 'Alex', 'Johnson', 'test2johnson@gmail.com', crypt('password3', gen_salt('bf')), '3333333333', now(), now())
         ON CONFLICT (email) WHERE deleted_at IS NULL DO NOTHING;
 
+        -- Platform super admin and organization roles of the test users
+        UPDATE users SET is_super_admin = true WHERE email = 'test1@gmail.com';
+
+        INSERT INTO role_assignments (id, user_id, organization_id, role, created_at, updated_at)
+        SELECT uuid_generate_v4(), u.id, org_id, r.role, now(), now()
+        FROM users u, (VALUES
+            ('test1@gmail.com', 'org_admin'),
+            ('test2@gmail.com', 'engineer'),
+            ('test2johnson@gmail.com', 'viewer')
+        ) AS r(email, role)
+        WHERE u.email = r.email AND u.deleted_at IS NULL
+        AND NOT EXISTS (SELECT 1 FROM role_assignments ra WHERE ra.user_id = u.id AND ra.deleted_at IS NULL);
+
         -- Insert Companies
         INSERT INTO companies (id, organization_id, name, division, "group", representative, address, phone, created_at, updated_at)
         VALUES
@@ -86,6 +99,47 @@ BEGIN
                 (uuid_generate_v4(), 'Water', now(), now());
                 END IF;
 
+                -- Insert Survey Tools with simplified ISCWSA error models
+                IF NOT EXISTS (SELECT 1 FROM survey_tools WHERE name = 'MWD' AND deleted_at IS NULL) THEN
+                    WITH tool AS (
+                        INSERT INTO survey_tools (id, name, description, created_at, updated_at)
+                        VALUES (uuid_generate_v4(), 'MWD', 'Standard magnetic MWD survey', now(), now())
+                        RETURNING id
+                    )
+                    INSERT INTO survey_tool_error_terms (id, survey_tool_id, name, weighting, propagation, magnitude, created_at)
+                    SELECT uuid_generate_v4(), tool.id, t.name, t.weighting, t.propagation, t.magnitude, now()
+                    FROM tool, (VALUES
+                        ('DRFR', 'depth', 'random', 0.35),
+                        ('DSFS', 'depth_scale', 'systematic', 0.00056),
+                        ('SAG', 'inclination_sin', 'systematic', 0.2),
+                        ('XYM', 'inclination', 'systematic', 0.1),
+                        ('ABIZ', 'inclination', 'random', 0.1),
+                        ('DEC', 'azimuth', 'global', 0.36),
+                        ('DBH', 'azimuth', 'systematic', 0.3),
+                        ('AMIL', 'azimuth_sin_inc', 'systematic', 0.25),
+                        ('MBIZ', 'azimuth', 'random', 0.15)
+                    ) AS t(name, weighting, propagation, magnitude);
+                END IF;
+
+                IF NOT EXISTS (SELECT 1 FROM survey_tools WHERE name = 'Gyro' AND deleted_at IS NULL) THEN
+                    WITH tool AS (
+                        INSERT INTO survey_tools (id, name, description, created_at, updated_at)
+                        VALUES (uuid_generate_v4(), 'Gyro', 'North seeking gyro survey', now(), now())
+                        RETURNING id
+                    )
+                    INSERT INTO survey_tool_error_terms (id, survey_tool_id, name, weighting, propagation, magnitude, created_at)
+                    SELECT uuid_generate_v4(), tool.id, t.name, t.weighting, t.propagation, t.magnitude, now()
+                    FROM tool, (VALUES
+                        ('DRFR', 'depth', 'random', 0.35),
+                        ('DSFS', 'depth_scale', 'systematic', 0.00056),
+                        ('SAG', 'inclination_sin', 'systematic', 0.2),
+                        ('XYM', 'inclination', 'systematic', 0.1),
+                        ('GINC', 'inclination', 'random', 0.05),
+                        ('GAZ', 'azimuth', 'systematic', 0.1),
+                        ('GRND', 'azimuth', 'random', 0.05)
+                    ) AS t(name, weighting, propagation, magnitude);
+                END IF;
+
             END;
                 
         END;
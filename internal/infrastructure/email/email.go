@@ -0,0 +1,59 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers emails. Implementations for real mail providers can be plugged in next to the
+// file sender used for development.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type fileSender struct {
+	dir string
+}
+
+// NewFileSender returns a Sender that writes every message to a file in dir and logs it,
+// so emails can be read locally without a mail server.
+func NewFileSender(dir string) Sender {
+	return &fileSender{dir: dir}
+}
+
+// Send writes the message to a file named after the time and the recipient.
+func (s *fileSender) Send(ctx context.Context, msg Message) error {
+	logrus.Infof("Email to %s: %s", msg.To, msg.Subject)
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+	name := fmt.Sprintf("%s_%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), sanitizeFileName(msg.To))
+	content := fmt.Sprintf("To: %s\nSubject: %s\nDate: %s\n\n%s\n", msg.To, msg.Subject, time.Now().UTC().Format(time.RFC1123Z), msg.Body)
+	if err := os.WriteFile(filepath.Join(s.dir, name), []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	return nil
+}
+
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -27,17 +27,18 @@ func NewRouter(services *service.Services, authMiddleware *middleware.AuthMiddle
 }
 
 func (r *Router) Init(cfg *configs.Config) *gin.Engine {
-	// Init gin handler
-	router := gin.Default()
-
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Init gin handler. The access token is stripped from the query before the logger sees the URL
+	router := gin.New()
 
 	router.Use(
 		gin.Recovery(),
+		middleware.StripAccessToken,
 		gin.Logger(),
 		corsMiddleware,
 	)
 
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
 	// Init router
 	router.GET("/ping", func(c *gin.Context) {
 		c.String(http.StatusOK, "pong")
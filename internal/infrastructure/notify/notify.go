@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Notification is an event worth telling people about, such as a design moving to another stage.
+type Notification struct {
+	Event          string                 `json:"event"`
+	OrganizationID string                 `json:"organization_id"`
+	Subject        string                 `json:"subject"`
+	Message        string                 `json:"message"`
+	Data           map[string]interface{} `json:"data"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// Notifier delivers notifications. Implementations for chat or email can be plugged in next to the
+// log and webhook notifiers.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+type logNotifier struct{}
+
+// NewLogNotifier returns a Notifier that only logs notifications, for development and deployments
+// without a notification channel.
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+// Notify logs the notification.
+func (n *logNotifier) Notify(ctx context.Context, notification Notification) error {
+	logrus.WithFields(logrus.Fields{
+		"event":           notification.Event,
+		"organization_id": notification.OrganizationID,
+	}).Infof("Notification: %s. %s", notification.Subject, notification.Message)
+	return nil
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier returns a Notifier that posts every notification as JSON to url.
+func NewWebhookNotifier(url string) Notifier {
+	return &webhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify posts the notification to the webhook and fails on any non 2xx response.
+func (n *webhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
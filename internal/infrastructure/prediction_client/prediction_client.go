@@ -4,6 +4,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,14 +15,14 @@ import (
 )
 
 const (
-	timeoutSeconds = 10
+	defaultTimeout = 10 * time.Second
 )
 
 type TorqueAndDragClient interface {
-	CalculateEffectiveTension(data requests.TorqueAndDragFromMLModelRequest) (*responses.EffectiveTensionFromMLModelResponse, error)
-	CalculateWeightOnBit(data requests.TorqueAndDragFromMLModelRequest) (*responses.WeightOnBitFromMLModelResponse, error)
-	CalculateMoment(data requests.TorqueAndDragFromMLModelRequest) (*responses.MomentFromMLModelResponse, error)
-	CalculateMinWeight(data requests.TorqueAndDragFromMLModelRequest) (*responses.MinWeightFromMLModelResponse, error)
+	CalculateEffectiveTension(ctx context.Context, data requests.TorqueAndDragFromMLModelRequest) (*responses.EffectiveTensionFromMLModelResponse, error)
+	CalculateWeightOnBit(ctx context.Context, data requests.TorqueAndDragFromMLModelRequest) (*responses.WeightOnBitFromMLModelResponse, error)
+	CalculateMoment(ctx context.Context, data requests.TorqueAndDragFromMLModelRequest) (*responses.MomentFromMLModelResponse, error)
+	CalculateMinWeight(ctx context.Context, data requests.TorqueAndDragFromMLModelRequest) (*responses.MinWeightFromMLModelResponse, error)
 }
 
 type torqueAndDragClient struct {
@@ -29,20 +30,25 @@ type torqueAndDragClient struct {
 	baseURL string
 }
 
-func NewTorqueAndDragClient(baseURL string) TorqueAndDragClient {
+// NewTorqueAndDragClient creates a client of the prediction service, a request taking longer than timeout fails.
+// Zero timeout means the default of 10 seconds.
+func NewTorqueAndDragClient(baseURL string, timeout time.Duration) TorqueAndDragClient {
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
 	return &torqueAndDragClient{
 		client: &http.Client{
-			Timeout: time.Duration(timeoutSeconds) * time.Second,
+			Timeout: timeout,
 		},
 		baseURL: baseURL,
 	}
 }
 
 // CalculateEffectiveTension sends a request to the FastAPI service to calculate effective tension
-func (c *torqueAndDragClient) CalculateEffectiveTension(data requests.TorqueAndDragFromMLModelRequest) (*responses.EffectiveTensionFromMLModelResponse, error) {
+func (c *torqueAndDragClient) CalculateEffectiveTension(ctx context.Context, data requests.TorqueAndDragFromMLModelRequest) (*responses.EffectiveTensionFromMLModelResponse, error) {
 	url := fmt.Sprintf("%s/effect_na/", c.baseURL)
 
-	req, err := preparePostRequest(url, data)
+	req, err := preparePostRequest(ctx, url, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare request: %v", err)
 	}
@@ -68,10 +74,10 @@ func (c *torqueAndDragClient) CalculateEffectiveTension(data requests.TorqueAndD
 }
 
 // CalculateWeightOnBit sends a request to the FastAPI service to calculate weight on bit
-func (c *torqueAndDragClient) CalculateWeightOnBit(data requests.TorqueAndDragFromMLModelRequest) (*responses.WeightOnBitFromMLModelResponse, error) {
+func (c *torqueAndDragClient) CalculateWeightOnBit(ctx context.Context, data requests.TorqueAndDragFromMLModelRequest) (*responses.WeightOnBitFromMLModelResponse, error) {
 	url := fmt.Sprintf("%s/ves_na_kru/", c.baseURL)
 
-	req, err := preparePostRequest(url, data)
+	req, err := preparePostRequest(ctx, url, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare request: %v", err)
 	}
@@ -97,10 +103,10 @@ func (c *torqueAndDragClient) CalculateWeightOnBit(data requests.TorqueAndDragFr
 }
 
 // // CalculateMoment sends a request to the FastAPI service to calculate moment
-func (c *torqueAndDragClient) CalculateMoment(data requests.TorqueAndDragFromMLModelRequest) (*responses.MomentFromMLModelResponse, error) {
+func (c *torqueAndDragClient) CalculateMoment(ctx context.Context, data requests.TorqueAndDragFromMLModelRequest) (*responses.MomentFromMLModelResponse, error) {
 	url := fmt.Sprintf("%s/moment/", c.baseURL)
 
-	req, err := preparePostRequest(url, data)
+	req, err := preparePostRequest(ctx, url, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare request: %v", err)
 	}
@@ -126,10 +132,10 @@ func (c *torqueAndDragClient) CalculateMoment(data requests.TorqueAndDragFromMLM
 }
 
 // CalculateMinWeight sends a request to the FastAPI service to calculate minimum weight
-func (c *torqueAndDragClient) CalculateMinWeight(data requests.TorqueAndDragFromMLModelRequest) (*responses.MinWeightFromMLModelResponse, error) {
+func (c *torqueAndDragClient) CalculateMinWeight(ctx context.Context, data requests.TorqueAndDragFromMLModelRequest) (*responses.MinWeightFromMLModelResponse, error) {
 	url := fmt.Sprintf("%s/min_ves/", c.baseURL)
 
-	req, err := preparePostRequest(url, data)
+	req, err := preparePostRequest(ctx, url, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare request: %v", err)
 	}
@@ -155,7 +161,7 @@ func (c *torqueAndDragClient) CalculateMinWeight(data requests.TorqueAndDragFrom
 }
 
 // preparePostRequest prepares an HTTP POST request with the given URL and data, setting the appropriate headers.
-func preparePostRequest(url string, data requests.TorqueAndDragFromMLModelRequest) (*http.Request, error) {
+func preparePostRequest(ctx context.Context, url string, data requests.TorqueAndDragFromMLModelRequest) (*http.Request, error) {
 	// Serialize the data to JSON
 	payload, err := json.Marshal(data)
 	if err != nil {
@@ -163,7 +169,7 @@ func preparePostRequest(url string, data requests.TorqueAndDragFromMLModelReques
 	}
 
 	// Set up the HTTP request
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %v", err)
 	}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// apiKeyTouchInterval limits how often the last used time of a key is written
+	apiKeyTouchInterval = time.Minute
+)
+
+type apiKeysRepository struct {
+	db *gorm.DB
+}
+
+func NewApiKeysRepository(db *gorm.DB) *apiKeysRepository {
+	return &apiKeysRepository{db: db}
+}
+
+// CreateApiKey creates an API key.
+func (r *apiKeysRepository) CreateApiKey(ctx context.Context, key *entities.ApiKey) error {
+	gormKey, err := toGormApiKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Create(gormKey).Error; err != nil {
+		return err
+	}
+	key.ID = gormKey.ID.String()
+	key.CreatedAt = gormKey.CreatedAt
+	return nil
+}
+
+// GetApiKeys retrieves the API keys of the user.
+func (r *apiKeysRepository) GetApiKeys(ctx context.Context, userId string) ([]*entities.ApiKey, error) {
+	var keys []*models.ApiKey
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userId).Order("created_at").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.ApiKey, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, toDomainApiKey(key))
+	}
+	return res, nil
+}
+
+// GetApiKeyByHash retrieves an API key by the hash of the key.
+func (r *apiKeysRepository) GetApiKeyByHash(ctx context.Context, keyHash string) (*entities.ApiKey, error) {
+	var key models.ApiKey
+	err := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).First(&key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainApiKey(&key), nil
+}
+
+// DeleteApiKey revokes an API key of the user.
+func (r *apiKeysRepository) DeleteApiKey(ctx context.Context, userId string, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userId).Delete(&models.ApiKey{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrResourceNotFound
+	}
+	return nil
+}
+
+// TouchApiKey records the use of an API key. Uses within apiKeyTouchInterval of the recorded one are not written,
+// so scripts sending many requests do not update the row on every request.
+func (r *apiKeysRepository) TouchApiKey(ctx context.Context, id string, usedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.ApiKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, usedAt.Add(-apiKeyTouchInterval)).
+		UpdateColumn("last_used_at", usedAt).Error
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+)
+
+type auditLogsRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogsRepository(db *gorm.DB) *auditLogsRepository {
+	return &auditLogsRepository{db: db}
+}
+
+// GetAuditLogs retrieves the audit log entries of the organization matching the filter, newest first.
+func (r *auditLogsRepository) GetAuditLogs(ctx context.Context, organizationId string, filter *entities.AuditLogFilter) ([]*entities.AuditLogEntry, error) {
+	query := r.db.WithContext(ctx).Where("organization_id = ?", organizationId)
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var logs []*models.AuditLog
+	if err := query.Order("created_at DESC, id").Limit(filter.Limit).Offset(filter.Offset).Find(&logs).Error; err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.AuditLogEntry, 0, len(logs))
+	for _, log := range logs {
+		entry, err := toDomainAuditLog(log)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, entry)
+	}
+	return res, nil
+}
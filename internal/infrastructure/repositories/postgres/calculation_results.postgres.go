@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+)
+
+type calculationResultsRepository struct {
+	db *gorm.DB
+}
+
+func NewCalculationResultsRepository(db *gorm.DB) *calculationResultsRepository {
+	return &calculationResultsRepository{db: db}
+}
+
+func (r *calculationResultsRepository) CreateCalculationResult(ctx context.Context, result *entities.CalculationResult) error {
+	row, err := toGormCalculationResult(result)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return err
+	}
+
+	result.ID, result.CreatedAt = row.ID.String(), row.CreatedAt
+	return nil
+}
+
+func (r *calculationResultsRepository) GetLatestCalculationResult(ctx context.Context, caseID string, engine string, calculationType string, inputHash string) (*entities.CalculationResult, error) {
+	var rows []*models.CalculationResult
+	err := r.db.WithContext(ctx).
+		Where("case_id = ? AND engine = ? AND type = ? AND input_hash = ? AND NOT stale", caseID, engine, calculationType, inputHash).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return toDomainCalculationResult(rows[0]), nil
+}
+
+func (r *calculationResultsRepository) GetCalculationResults(ctx context.Context, caseID string) ([]*entities.CalculationResult, error) {
+	var rows []*models.CalculationResult
+	err := r.db.WithContext(ctx).Omit("result").Where("case_id = ?", caseID).Order("created_at DESC").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.CalculationResult, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, toDomainCalculationResult(row))
+	}
+	return res, nil
+}
@@ -28,7 +28,7 @@ func (r *casesRepository) CreateCase(ctx context.Context, trajectoryID string, c
 	}
 	gormCase.TrajectoryID = trajectoryId
 
-	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(gormCase).Error; err != nil {
 			return err
 		}
@@ -42,7 +42,7 @@ func (r *casesRepository) CreateCase(ctx context.Context, trajectoryID string, c
 // GetCaseByID fetches a case by its ID from the database
 func (r *casesRepository) GetCaseByID(ctx context.Context, id string) (*entities.Case, error) {
 	var gormCase models.Case
-	result := r.db.WithContext(ctx).Where("id = ?", id).First(&gormCase)
+	result := conn(ctx, r.db).Where("id = ?", id).First(&gormCase)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -51,11 +51,22 @@ func (r *casesRepository) GetCaseByID(ctx context.Context, id string) (*entities
 	return res, nil
 }
 
+// GetCaseWithComponents fetches a case with its holes, strings, fluids, pressures, gradients and rigs
+func (r *casesRepository) GetCaseWithComponents(ctx context.Context, id string) (*entities.Case, error) {
+	var gormCase models.Case
+	result := preloadCaseComponents(conn(ctx, r.db), "").Where("id = ?", id).First(&gormCase)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return toDomainCase(&gormCase), nil
+}
+
 // GetCases fetches all cases for a given trajectory ID from the database
 func (r *casesRepository) GetCases(ctx context.Context, trajectoryID string) ([]*entities.Case, error) {
 	var gormCases []*models.Case
 	var res []*entities.Case
-	result := r.db.WithContext(ctx).Where("trajectory_id = ?", trajectoryID).Find(&gormCases)
+	result := conn(ctx, r.db).Where("trajectory_id = ?", trajectoryID).Find(&gormCases)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -71,7 +82,7 @@ func (r *casesRepository) GetCases(ctx context.Context, trajectoryID string) ([]
 func (r *casesRepository) UpdateCase(ctx context.Context, caseEntity *entities.Case) (*entities.Case, error) {
 	gormCase := toGormCase(caseEntity)
 	oldCase := models.Case{}
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		query := tx.WithContext(ctx).Where("id = ?", caseEntity.ID).First(&oldCase)
 		if query.Error != nil {
 			return query.Error
@@ -98,7 +109,7 @@ func (r *casesRepository) UpdateCase(ctx context.Context, caseEntity *entities.C
 
 // DeleteCase deletes a case by its ID from the database
 func (r *casesRepository) DeleteCase(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Case{})
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&models.Case{})
 	if result.Error != nil {
 		return result.Error
 	}
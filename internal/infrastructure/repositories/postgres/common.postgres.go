@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
 	"gorm.io/gorm"
 )
@@ -127,6 +128,17 @@ func (r *commonRepository) CheckIfCaseExists(ctx context.Context, caseId string)
 	return nil
 }
 
+func (r *commonRepository) CheckIfSurveyToolExists(ctx context.Context, surveyToolId string) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.SurveyTool{}).Where("id = ?", surveyToolId).Count(&count).Error; err != nil {
+		return fmt.Errorf("error checking survey tool existence: %w", err)
+	}
+	if count == 0 {
+		return fmt.Errorf("survey tool with id %s does not exist", surveyToolId)
+	}
+	return nil
+}
+
 // CheckIfStringExists checks if a string component exists for a given caseID
 func (r *commonRepository) CheckIfStringExists(ctx context.Context, caseId string) (bool, error) {
 	return r.checkComponentExists(ctx, caseId, &models.String{})
@@ -231,6 +243,7 @@ func (r *commonRepository) GetTrajectoryByCaseID(ctx context.Context, caseID str
 	result := r.db.WithContext(ctx).
 		Preload("Headers").
 		Preload("Units").
+		Preload("SurveyProgram", orderSurveyProgram).
 		Where("id IN (?)",
 			r.db.Model(&models.Case{}).Select("trajectory_id").Where("id = ?", caseID)).
 		First(&trajectory)
@@ -241,3 +254,289 @@ func (r *commonRepository) GetTrajectoryByCaseID(ctx context.Context, caseID str
 
 	return toDomainTrajectory(&trajectory), nil
 }
+
+// GetSiteByWellID retrieves the site of the given well without its wells.
+func (r *commonRepository) GetSiteByWellID(ctx context.Context, wellID string) (*entities.Site, error) {
+	var site models.Site
+	result := r.db.WithContext(ctx).
+		Where("id IN (?)",
+			r.db.Model(&models.Well{}).Select("site_id").Where("id = ?", wellID)).
+		First(&site)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return toDomainSite(&site), nil
+}
+
+// GetWellByDesignID retrieves the well that owns the given design without its wellbores.
+func (r *commonRepository) GetWellByDesignID(ctx context.Context, designID string) (*entities.Well, error) {
+	var well models.Well
+	result := r.db.WithContext(ctx).
+		Where("id IN (?)",
+			r.db.Model(&models.Wellbore{}).Select("well_id").Where("id IN (?)",
+				r.db.Model(&models.Design{}).Select("wellbore_id").Where("id = ?", designID))).
+		First(&well)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return toDomainWell(&well), nil
+}
+
+// GetWellByTrajectoryID retrieves the well that owns the given trajectory without its wellbores.
+func (r *commonRepository) GetWellByTrajectoryID(ctx context.Context, trajectoryID string) (*entities.Well, error) {
+	var well models.Well
+	result := r.db.WithContext(ctx).
+		Where("id IN (?)",
+			r.db.Model(&models.Wellbore{}).Select("well_id").Where("id IN (?)",
+				r.db.Model(&models.Design{}).Select("wellbore_id").Where("id IN (?)",
+					r.db.Model(&models.Trajectory{}).Select("design_id").Where("id = ?", trajectoryID)))).
+		First(&well)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return toDomainWell(&well), nil
+}
+
+// GetDesignIDByTrajectoryID retrieves the ID of the design that owns the given trajectory.
+func (r *commonRepository) GetDesignIDByTrajectoryID(ctx context.Context, trajectoryID string) (string, error) {
+	var trajectory models.Trajectory
+	result := r.db.WithContext(ctx).Select("design_id").Where("id = ?", trajectoryID).First(&trajectory)
+	if result.Error != nil {
+		return "", result.Error
+	}
+
+	return trajectory.DesignID.String(), nil
+}
+
+// GetWellboreIDByCaseID retrieves the ID of the wellbore the case belongs to.
+func (r *commonRepository) GetWellboreIDByCaseID(ctx context.Context, caseID string) (string, error) {
+	var wellboreIDs []string
+	result := r.db.WithContext(ctx).Table("cases").
+		Joins("JOIN trajectories ON trajectories.id = cases.trajectory_id AND trajectories.deleted_at IS NULL").
+		Joins("JOIN designs ON designs.id = trajectories.design_id AND designs.deleted_at IS NULL").
+		Where("cases.id = ? AND cases.deleted_at IS NULL", caseID).
+		Pluck("designs.wellbore_id", &wellboreIDs)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if len(wellboreIDs) == 0 {
+		return "", domainErrors.ErrResourceNotFound
+	}
+
+	return wellboreIDs[0], nil
+}
+
+// scopeParents maps a hierarchy level to its table, the column with the parent ID and the parent level.
+var scopeParents = map[string]struct {
+	table  string
+	column string
+	parent string
+}{
+	entities.ScopeCompany:          {table: "companies", column: "organization_id", parent: entities.ScopeOrganization},
+	entities.ScopeField:            {table: "fields", column: "company_id", parent: entities.ScopeCompany},
+	entities.ScopeSite:             {table: "sites", column: "field_id", parent: entities.ScopeField},
+	entities.ScopeWell:             {table: "wells", column: "site_id", parent: entities.ScopeSite},
+	entities.ScopeWellbore:         {table: "wellbores", column: "well_id", parent: entities.ScopeWell},
+	entities.ScopeDesign:           {table: "designs", column: "wellbore_id", parent: entities.ScopeWellbore},
+	entities.ScopeTrajectory:       {table: "trajectories", column: "design_id", parent: entities.ScopeDesign},
+	entities.ScopeCase:             {table: "cases", column: "trajectory_id", parent: entities.ScopeTrajectory},
+	entities.ScopeHole:             {table: "holes", column: "case_id", parent: entities.ScopeCase},
+	entities.ScopeFluid:            {table: "fluids", column: "case_id", parent: entities.ScopeCase},
+	entities.ScopeRig:              {table: "rigs", column: "case_id", parent: entities.ScopeCase},
+	entities.ScopeString:           {table: "strings", column: "case_id", parent: entities.ScopeCase},
+	entities.ScopePorePressure:     {table: "pore_pressures", column: "case_id", parent: entities.ScopeCase},
+	entities.ScopeFractureGradient: {table: "fracture_gradients", column: "case_id", parent: entities.ScopeCase},
+}
+
+// GetOwnership joins the entity with its parents up to the company and returns the organization, company
+// and field it belongs to. The field is empty for companies. Missing entities return ErrResourceNotFound.
+func (r *commonRepository) GetOwnership(ctx context.Context, scope string, id string) (*entities.Ownership, error) {
+	level, ok := scopeParents[scope]
+	if !ok {
+		return nil, fmt.Errorf("unknown scope %s", scope)
+	}
+
+	query := r.db.WithContext(ctx).Table(level.table).Where(level.table+".id = ? AND "+level.table+".deleted_at IS NULL", id)
+	joinedField := scope == entities.ScopeField
+	for level.parent != entities.ScopeOrganization {
+		parent := scopeParents[level.parent]
+		query = query.Joins(fmt.Sprintf("JOIN %[1]s ON %[1]s.id = %[2]s.%[3]s AND %[1]s.deleted_at IS NULL", parent.table, level.table, level.column))
+		joinedField = joinedField || level.parent == entities.ScopeField
+		level = parent
+	}
+
+	columns := []string{"companies.organization_id AS organization_id", "companies.id AS company_id"}
+	if joinedField {
+		columns = append(columns, "fields.id AS field_id")
+	}
+
+	var ownership entities.Ownership
+	if err := query.Select(columns).Scan(&ownership).Error; err != nil {
+		return nil, err
+	}
+	if ownership.OrganizationID == "" {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	return &ownership, nil
+}
+
+// GetWellboreReference joins the entity with its parents up to the well and returns the IDs and names of the
+// well and wellbore it belongs to. Scopes above the wellbore are not supported.
+func (r *commonRepository) GetWellboreReference(ctx context.Context, scope string, id string) (*entities.WellboreReference, error) {
+	level, ok := scopeParents[scope]
+	if !ok {
+		return nil, fmt.Errorf("unknown scope %s", scope)
+	}
+
+	query := r.db.WithContext(ctx).Table(level.table).Where(level.table+".id = ? AND "+level.table+".deleted_at IS NULL", id)
+	for level.parent != entities.ScopeWell {
+		if level.parent == entities.ScopeOrganization {
+			return nil, fmt.Errorf("scope %s is above the wellbore", scope)
+		}
+		parent := scopeParents[level.parent]
+		query = query.Joins(fmt.Sprintf("JOIN %[1]s ON %[1]s.id = %[2]s.%[3]s AND %[1]s.deleted_at IS NULL", parent.table, level.table, level.column))
+		level = parent
+	}
+	query = query.Joins("JOIN wells ON wells.id = wellbores.well_id AND wells.deleted_at IS NULL")
+
+	var reference entities.WellboreReference
+	columns := []string{"wells.id AS well_id", "wells.name AS well_name", "wellbores.id AS wellbore_id", "wellbores.name AS wellbore_name"}
+	if err := query.Select(columns).Scan(&reference).Error; err != nil {
+		return nil, err
+	}
+	if reference.WellboreID == "" {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	return &reference, nil
+}
+
+// scopeNameColumns are the columns with the names of the hierarchy levels.
+var scopeNameColumns = map[string][]string{
+	entities.ScopeCompany:    {"companies.name AS company_name"},
+	entities.ScopeField:      {"fields.name AS field_name"},
+	entities.ScopeSite:       {"sites.name AS site_name"},
+	entities.ScopeWell:       {"wells.name AS well_name"},
+	entities.ScopeWellbore:   {"wellbores.name AS wellbore_name"},
+	entities.ScopeDesign:     {"designs.plan_name AS design_name", "designs.stage AS design_stage"},
+	entities.ScopeTrajectory: {"trajectories.name AS trajectory_name"},
+	entities.ScopeCase:       {"cases.case_name AS case_name"},
+}
+
+// GetHierarchyNames joins the entity with its parents up to the company and returns their names.
+// Missing entities return ErrResourceNotFound.
+func (r *commonRepository) GetHierarchyNames(ctx context.Context, scope string, id string) (*entities.HierarchyNames, error) {
+	level, ok := scopeParents[scope]
+	if !ok {
+		return nil, fmt.Errorf("unknown scope %s", scope)
+	}
+
+	query := r.db.WithContext(ctx).Table(level.table).Where(level.table+".id = ? AND "+level.table+".deleted_at IS NULL", id)
+	columns := append([]string{}, scopeNameColumns[scope]...)
+	for level.parent != entities.ScopeOrganization {
+		parent := scopeParents[level.parent]
+		query = query.Joins(fmt.Sprintf("JOIN %[1]s ON %[1]s.id = %[2]s.%[3]s AND %[1]s.deleted_at IS NULL", parent.table, level.table, level.column))
+		columns = append(columns, scopeNameColumns[level.parent]...)
+		level = parent
+	}
+
+	var names entities.HierarchyNames
+	if err := query.Select(columns).Scan(&names).Error; err != nil {
+		return nil, err
+	}
+	if names.CompanyName == "" {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	return &names, nil
+}
+
+// CheckOwnership checks that the entity belongs to the organization.
+// Missing entities and entities of other organizations both return ErrResourceNotFound.
+func (r *commonRepository) CheckOwnership(ctx context.Context, organizationId string, scope string, id string) error {
+	ownership, err := r.GetOwnership(ctx, scope, id)
+	if err != nil {
+		return err
+	}
+	if ownership.OrganizationID != organizationId {
+		return domainErrors.ErrResourceNotFound
+	}
+	return nil
+}
+
+// CheckDesignEditable joins the entity with its parents up to the design and returns ErrDesignLocked
+// if the stage of the design locks it against edits. Missing and deleted entities return ErrResourceNotFound.
+func (r *commonRepository) CheckDesignEditable(ctx context.Context, scope string, id string) error {
+	level, ok := scopeParents[scope]
+	if !ok {
+		return fmt.Errorf("unknown scope %s", scope)
+	}
+
+	query := r.db.WithContext(ctx).Table(level.table).Where(level.table+".id = ? AND "+level.table+".deleted_at IS NULL", id)
+	for scope != entities.ScopeDesign {
+		parent, ok := scopeParents[level.parent]
+		if !ok {
+			return fmt.Errorf("scope %s is above the design", scope)
+		}
+		query = query.Joins(fmt.Sprintf("JOIN %[1]s ON %[1]s.id = %[2]s.%[3]s AND %[1]s.deleted_at IS NULL", parent.table, level.table, level.column))
+		scope, level = level.parent, parent
+	}
+
+	var stage string
+	result := query.Select("designs.stage").Scan(&stage)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrResourceNotFound
+	}
+	if entities.DesignStageLocked(stage) {
+		return domainErrors.ErrDesignLocked
+	}
+	return nil
+}
+
+// GetActiveUnits walks up from the entity to its well and field and returns their active unit systems.
+// The well unit is empty for entities above the well.
+func (r *commonRepository) GetActiveUnits(ctx context.Context, scope string, id string) (string, string, error) {
+	db := r.db.WithContext(ctx)
+
+	for scope != entities.ScopeWell && scope != entities.ScopeField {
+		parent, ok := scopeParents[scope]
+		if !ok {
+			return "", "", fmt.Errorf("unknown scope %s", scope)
+		}
+		var parentID string
+		if err := db.Table(parent.table).Select(parent.column).Where("id = ? AND deleted_at IS NULL", id).Scan(&parentID).Error; err != nil {
+			return "", "", err
+		}
+		if parentID == "" {
+			return "", "", fmt.Errorf("%s with id %s does not exist", scope, id)
+		}
+		scope, id = parent.parent, parentID
+	}
+
+	var wellUnit string
+	if scope == entities.ScopeWell {
+		var well models.Well
+		if err := db.Select("site_id", "active_well_unit").Where("id = ?", id).First(&well).Error; err != nil {
+			return "", "", err
+		}
+		var site models.Site
+		if err := db.Select("field_id").Where("id = ?", well.SiteID).First(&site).Error; err != nil {
+			return "", "", err
+		}
+		wellUnit, id = well.ActiveWellUnit, site.FieldID.String()
+	}
+
+	var field models.Field
+	if err := db.Select("active_field_unit").Where("id = ?", id).First(&field).Error; err != nil {
+		return "", "", err
+	}
+
+	return wellUnit, field.ActiveFieldUnit, nil
+}
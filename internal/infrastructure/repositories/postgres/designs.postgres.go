@@ -2,13 +2,17 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"reflect"
 
 	"github.com/google/uuid"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
 	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type designsRepository struct {
@@ -100,3 +104,201 @@ func (r *designsRepository) DeleteDesign(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// FreezeDesign snapshots the design with its trajectories, cases and their components as the current revision
+// of the design and bumps the revision of the design. The design row is locked, so concurrent freezes get
+// consecutive revisions.
+func (r *designsRepository) FreezeDesign(ctx context.Context, revision *entities.DesignRevision) error {
+	var gormRevision *models.DesignRevision
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		design, err := lockDesign(tx, revision.DesignID)
+		if err != nil {
+			return err
+		}
+		gormRevision, err = freezeDesign(tx, design, revision.Comment, revision.CreatedBy)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := toDomainDesignRevision(gormRevision)
+	if err != nil {
+		return err
+	}
+	*revision = *res
+	return nil
+}
+
+// TransitionDesignStage moves the design to the next stage and records the transition. The stage of the design
+// must still be the stage the transition starts from, otherwise ErrDesignStageChanged is returned. With freeze,
+// the design is frozen as a revision in the same transaction.
+func (r *designsRepository) TransitionDesignStage(ctx context.Context, transition *entities.DesignStageTransition, freeze bool) error {
+	gormTransition, err := toGormDesignStageTransition(transition)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		design, err := lockDesign(tx, transition.DesignID)
+		if err != nil {
+			return err
+		}
+		if design.Stage != transition.FromStage {
+			return domainErrors.ErrDesignStageChanged
+		}
+
+		if err := tx.Model(design).Update("stage", transition.ToStage).Error; err != nil {
+			return err
+		}
+
+		// The snapshot is taken after the stage update, so it records the design in its new stage
+		if freeze {
+			revision, err := freezeDesign(tx, design, transition.Comment, transition.UserID)
+			if err != nil {
+				return err
+			}
+			gormTransition.Revision = &revision.Revision
+		}
+		return tx.Create(gormTransition).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	*transition = *toDomainDesignStageTransition(gormTransition)
+	return nil
+}
+
+// GetDesignStageTransitions retrieves the stage history of the design, oldest first.
+func (r *designsRepository) GetDesignStageTransitions(ctx context.Context, designID string) ([]*entities.DesignStageTransition, error) {
+	var transitions []*models.DesignStageTransition
+	if err := r.db.WithContext(ctx).Where("design_id = ?", designID).Order("created_at, id").Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.DesignStageTransition, 0, len(transitions))
+	for _, transition := range transitions {
+		res = append(res, toDomainDesignStageTransition(transition))
+	}
+	return res, nil
+}
+
+// lockDesign selects the design for update.
+func lockDesign(tx *gorm.DB, id string) (*models.Design, error) {
+	var design models.Design
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&design).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &design, nil
+}
+
+// freezeDesign snapshots the locked design as its current revision and bumps the revision of the design,
+// along with its version, which follows the revision.
+func freezeDesign(tx *gorm.DB, design *models.Design, comment string, createdBy *string) (*models.DesignRevision, error) {
+	revision := &models.DesignRevision{Comment: comment}
+	if createdBy != nil {
+		userID, err := uuid.Parse(*createdBy)
+		if err != nil {
+			return nil, err
+		}
+		revision.CreatedBy = &userID
+	}
+
+	var tree models.Design
+	if err := preloadDesignTree(tx).Where("id = ?", design.ID).First(&tree).Error; err != nil {
+		return nil, err
+	}
+	snapshot, err := json.Marshal(toDomainDesign(&tree))
+	if err != nil {
+		return nil, err
+	}
+
+	revision.DesignID = design.ID
+	revision.Revision = design.Revision
+	revision.Snapshot = string(snapshot)
+	if err := tx.Create(revision).Error; err != nil {
+		return nil, err
+	}
+
+	next := design.Revision + 1
+	err = tx.Model(design).Updates(map[string]interface{}{"revision": next, "version": entities.DesignVersion(next)}).Error
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// GetDesignRevisions retrieves the revisions of the design without their snapshots, newest first.
+func (r *designsRepository) GetDesignRevisions(ctx context.Context, designID string) ([]*entities.DesignRevision, error) {
+	var revisions []*models.DesignRevision
+	err := r.db.WithContext(ctx).
+		Omit("snapshot").
+		Where("design_id = ?", designID).
+		Order("revision DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.DesignRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		domainRevision, err := toDomainDesignRevision(revision)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, domainRevision)
+	}
+	return res, nil
+}
+
+// GetDesignRevision retrieves a revision of the design with its snapshot.
+func (r *designsRepository) GetDesignRevision(ctx context.Context, designID string, revision int) (*entities.DesignRevision, error) {
+	var gormRevision models.DesignRevision
+	err := r.db.WithContext(ctx).Where("design_id = ? AND revision = ?", designID, revision).First(&gormRevision).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainDesignRevision(&gormRevision)
+}
+
+// preloadDesignTree preloads everything below a design that makes up a well program.
+func preloadDesignTree(db *gorm.DB) *gorm.DB {
+	db = db.
+		Preload("Trajectories", orderByCreatedAt).
+		Preload("Trajectories.Headers").
+		Preload("Trajectories.Units", func(db *gorm.DB) *gorm.DB {
+			return db.Order("md")
+		}).
+		Preload("Trajectories.SurveyProgram", orderSurveyProgram).
+		Preload("Trajectories.Cases", orderByCreatedAt)
+	return preloadCaseComponents(db, "Trajectories.Cases.")
+}
+
+// preloadCaseComponents preloads the components of the cases found under prefix, which is empty for a case itself.
+func preloadCaseComponents(db *gorm.DB, prefix string) *gorm.DB {
+	return db.
+		Preload(prefix+"Holes", orderByCreatedAt).
+		Preload(prefix+"Holes.Caisings", orderByCreatedAt).
+		Preload(prefix+"Strings", orderByCreatedAt).
+		Preload(prefix+"Strings.Sections", orderByCreatedAt).
+		Preload(prefix+"Fluids", orderByCreatedAt).
+		Preload(prefix+"Fluids.FluidBaseType").
+		Preload(prefix+"Fluids.BaseFluid").
+		Preload(prefix+"PorePressures", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tvd")
+		}).
+		Preload(prefix+"FractureGradients", orderByCreatedAt).
+		Preload(prefix+"Rigs", orderByCreatedAt)
+}
+
+func orderByCreatedAt(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at, id")
+}
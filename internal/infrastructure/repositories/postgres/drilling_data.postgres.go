@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// drillingReadingsBatchSize keeps the parameters of one insert below the PostgreSQL limit
+	drillingReadingsBatchSize = 1000
+)
+
+type drillingDataRepository struct {
+	db *gorm.DB
+}
+
+func NewDrillingDataRepository(db *gorm.DB) *drillingDataRepository {
+	return &drillingDataRepository{db: db}
+}
+
+// SaveDrillingReadings inserts the readings in batches in one transaction. Uploading the same file again
+// replaces the readings instead of duplicating them. Of readings with the same wellbore and time only the
+// last one is kept, an upsert cannot change a row twice.
+func (r *drillingDataRepository) SaveDrillingReadings(ctx context.Context, readings []*entities.DrillingReading) error {
+	type readingKey struct {
+		wellboreID string
+		time       time.Time
+	}
+	positions := make(map[readingKey]int, len(readings))
+	rows := make([]*models.DrillingReading, 0, len(readings))
+	for _, reading := range readings {
+		row, err := toGormDrillingReading(reading)
+		if err != nil {
+			return err
+		}
+
+		key := readingKey{wellboreID: reading.WellboreID, time: reading.Time.UTC()}
+		if i, ok := positions[key]; ok {
+			rows[i] = row
+			continue
+		}
+		positions[key] = len(rows)
+		rows = append(rows, row)
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "wellbore_id"}, {Name: "time"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"bit_depth", "hook_load", "surface_torque", "weight_on_bit", "rpm", "flow_rate", "standpipe_pressure",
+		}),
+	}).CreateInBatches(rows, drillingReadingsBatchSize).Error
+}
+
+// GetDrillingReadings retrieves the readings of the wellbore matching the filter, oldest first.
+func (r *drillingDataRepository) GetDrillingReadings(ctx context.Context, wellboreID string, filter *entities.DrillingReadingFilter) ([]*entities.DrillingReading, error) {
+	query := filterDrillingReadings(r.db.WithContext(ctx), wellboreID, filter).Order("time")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var rows []*models.DrillingReading
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.DrillingReading, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, toDomainDrillingReading(row))
+	}
+	return res, nil
+}
+
+// DeleteDrillingReadings deletes the readings of the wellbore in the time range of the filter.
+func (r *drillingDataRepository) DeleteDrillingReadings(ctx context.Context, wellboreID string, filter *entities.DrillingReadingFilter) (int64, error) {
+	result := filterDrillingReadings(r.db.WithContext(ctx), wellboreID, filter).Delete(&models.DrillingReading{})
+	return result.RowsAffected, result.Error
+}
+
+func filterDrillingReadings(db *gorm.DB, wellboreID string, filter *entities.DrillingReadingFilter) *gorm.DB {
+	query := db.Where("wellbore_id = ?", wellboreID)
+	if filter.From != nil {
+		query = query.Where("time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("time < ?", *filter.To)
+	}
+	return query
+}
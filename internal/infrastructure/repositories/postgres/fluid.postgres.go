@@ -25,7 +25,7 @@ func (r *fluidsRepository) CreateFluid(ctx context.Context, caseID string, fluid
 		return err
 	}
 
-	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(gormFluid).Error; err != nil {
 			return err
 		}
@@ -38,7 +38,7 @@ func (r *fluidsRepository) CreateFluid(ctx context.Context, caseID string, fluid
 // GetFluidByID retrieves a fluid by its ID from the database.
 func (r *fluidsRepository) GetFluidByID(ctx context.Context, id string) (*entities.Fluid, error) {
 	var fluid models.Fluid
-	result := r.db.WithContext(ctx).Preload("FluidBaseType").Preload("BaseFluid").Where("id = ?", id).First(&fluid)
+	result := conn(ctx, r.db).Preload("FluidBaseType").Preload("BaseFluid").Where("id = ?", id).First(&fluid)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -50,7 +50,7 @@ func (r *fluidsRepository) GetFluidByID(ctx context.Context, id string) (*entiti
 func (r *fluidsRepository) GetFluids(ctx context.Context, caseID string) ([]*entities.Fluid, error) {
 	var fluids []*models.Fluid
 	var res []*entities.Fluid
-	result := r.db.WithContext(ctx).Preload("FluidBaseType").Preload("BaseFluid").Where("case_id = ?", caseID).Find(&fluids)
+	result := conn(ctx, r.db).Preload("FluidBaseType").Preload("BaseFluid").Where("case_id = ?", caseID).Find(&fluids)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -66,7 +66,7 @@ func (r *fluidsRepository) GetFluids(ctx context.Context, caseID string) ([]*ent
 func (r *fluidsRepository) UpdateFluid(ctx context.Context, fluid *entities.Fluid) (*entities.Fluid, error) {
 	var updatedFluid models.Fluid
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		var existingFluid models.Fluid
 		if err := tx.Preload("FluidBaseType").Preload("BaseFluid").Where("id = ?", fluid.ID).First(&existingFluid).Error; err != nil {
 			return err
@@ -93,7 +93,7 @@ func (r *fluidsRepository) UpdateFluid(ctx context.Context, fluid *entities.Flui
 
 // DeleteFluid deletes a fluid from the database.
 func (r *fluidsRepository) DeleteFluid(ctx context.Context, id string) error {
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		result := tx.Where("id = ?", id).Delete(&models.Fluid{})
 		if result.Error != nil {
 			return result.Error
@@ -111,7 +111,7 @@ func (r *fluidsRepository) DeleteFluid(ctx context.Context, id string) error {
 func (r *fluidsRepository) GetFluidTypes(ctx context.Context) ([]*entities.FluidType, error) {
 	var fluidTypes []*models.FluidType
 	var res []*entities.FluidType
-	result := r.db.WithContext(ctx).Find(&fluidTypes)
+	result := conn(ctx, r.db).Find(&fluidTypes)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -26,7 +26,7 @@ func (r *fractureGradientsRepository) CreateFractureGradient(ctx context.Context
 	}
 	gormFractureGradient.CaseID = caseId
 
-	result := r.db.WithContext(ctx).Create(gormFractureGradient)
+	result := conn(ctx, r.db).Create(gormFractureGradient)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -37,7 +37,7 @@ func (r *fractureGradientsRepository) CreateFractureGradient(ctx context.Context
 // GetFractureGradientByID retrieves a FractureGradient by its ID.
 func (r *fractureGradientsRepository) GetFractureGradientByID(ctx context.Context, id string) (*entities.FractureGradient, error) {
 	var fractureGradient models.FractureGradient
-	result := r.db.WithContext(ctx).Where("id = ?", id).First(&fractureGradient)
+	result := conn(ctx, r.db).Where("id = ?", id).First(&fractureGradient)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -50,7 +50,7 @@ func (r *fractureGradientsRepository) GetFractureGradients(ctx context.Context,
 	var fractureGradients []*models.FractureGradient
 	var res []*entities.FractureGradient
 
-	result := r.db.WithContext(ctx).Where("case_id = ?", caseID).Find(&fractureGradients)
+	result := conn(ctx, r.db).Where("case_id = ?", caseID).Find(&fractureGradients)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -66,14 +66,14 @@ func (r *fractureGradientsRepository) UpdateFractureGradient(ctx context.Context
 	var oldFractureGradient models.FractureGradient
 
 	// Find the existing record
-	err := r.db.WithContext(ctx).Where("id = ?", fractureGradient.ID).First(&oldFractureGradient).Error
+	err := conn(ctx, r.db).Where("id = ?", fractureGradient.ID).First(&oldFractureGradient).Error
 	if err != nil {
 		return nil, err
 	}
 
 	// Update the record
 	gormFractureGradient := toGormFractureGradient(fractureGradient)
-	if err := r.db.WithContext(ctx).Model(&oldFractureGradient).Updates(gormFractureGradient).Error; err != nil {
+	if err := conn(ctx, r.db).Model(&oldFractureGradient).Updates(gormFractureGradient).Error; err != nil {
 		return nil, err
 	}
 
@@ -82,7 +82,7 @@ func (r *fractureGradientsRepository) UpdateFractureGradient(ctx context.Context
 
 // DeleteFractureGradient deletes a FractureGradient record by ID.
 func (r *fractureGradientsRepository) DeleteFractureGradient(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.FractureGradient{})
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&models.FractureGradient{})
 	if result.Error != nil {
 		return result.Error
 	}
@@ -91,3 +91,27 @@ func (r *fractureGradientsRepository) DeleteFractureGradient(ctx context.Context
 	}
 	return nil
 }
+
+// ReplaceFractureGradients deletes the fracture gradients of the case and creates the given ones in one transaction.
+func (r *fractureGradientsRepository) ReplaceFractureGradients(ctx context.Context, caseID string, fractureGradients []*entities.FractureGradient) error {
+	caseId, err := uuid.Parse(caseID)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]*models.FractureGradient, len(fractureGradients))
+	for i, fractureGradient := range fractureGradients {
+		rows[i] = toGormFractureGradient(fractureGradient)
+		rows[i].CaseID = caseId
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("case_id = ?", caseID).Delete(&models.FractureGradient{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(rows).Error
+	})
+}
@@ -30,7 +30,7 @@ func (r *holesRepository) CreateHole(ctx context.Context, caseID string, hole *e
 
 	fmt.Println("len of input.Body.Caisings at repo", len(hole.Caisings))
 
-	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(gormHole).Error; err != nil {
 			return err
 		}
@@ -43,7 +43,7 @@ func (r *holesRepository) CreateHole(ctx context.Context, caseID string, hole *e
 // GetHoleByID retrieves a hole by its ID from the database, along with associated caisings.
 func (r *holesRepository) GetHoleByID(ctx context.Context, id string) (*entities.Hole, error) {
 	var hole models.Hole
-	result := r.db.WithContext(ctx).Preload("Caisings").Where("id = ?", id).First(&hole)
+	result := conn(ctx, r.db).Preload("Caisings").Where("id = ?", id).First(&hole)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -55,7 +55,7 @@ func (r *holesRepository) GetHoleByID(ctx context.Context, id string) (*entities
 func (r *holesRepository) GetHoles(ctx context.Context, caseID string) ([]*entities.Hole, error) {
 	var holes []*models.Hole
 	var res []*entities.Hole
-	result := r.db.WithContext(ctx).Preload("Caisings").Where("case_id = ?", caseID).Find(&holes)
+	result := conn(ctx, r.db).Preload("Caisings").Where("case_id = ?", caseID).Find(&holes)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -71,7 +71,7 @@ func (r *holesRepository) GetHoles(ctx context.Context, caseID string) ([]*entit
 func (r *holesRepository) UpdateHole(ctx context.Context, hole *entities.Hole) (*entities.Hole, error) {
 	var updatedHole models.Hole
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		var existingHole models.Hole
 		if err := tx.Preload("Caisings").Where("id = ?", hole.ID).First(&existingHole).Error; err != nil {
 			return err
@@ -127,7 +127,7 @@ func (r *holesRepository) UpdateHole(ctx context.Context, hole *entities.Hole) (
 
 // DeleteHole deletes a hole and associated caisings from the database.
 func (r *holesRepository) DeleteHole(ctx context.Context, id string) error {
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		result := tx.Where("id = ?", id).Delete(&models.Hole{})
 		if result.Error != nil {
 			return result.Error
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type invitationsRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationsRepository(db *gorm.DB) *invitationsRepository {
+	return &invitationsRepository{db: db}
+}
+
+// CreateInvitation creates an invitation.
+func (r *invitationsRepository) CreateInvitation(ctx context.Context, invitation *entities.Invitation) error {
+	gormInvitation, err := toGormInvitation(invitation)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Create(gormInvitation).Error; err != nil {
+		return err
+	}
+	invitation.ID = gormInvitation.ID.String()
+	invitation.CreatedAt = gormInvitation.CreatedAt
+	return nil
+}
+
+// GetInvitations retrieves the invitations of an organization, newest first.
+func (r *invitationsRepository) GetInvitations(ctx context.Context, organizationId string) ([]*entities.Invitation, error) {
+	var invitations []*models.Invitation
+	if err := r.db.WithContext(ctx).Where("organization_id = ?", organizationId).Order("created_at DESC").Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.Invitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		res = append(res, toDomainInvitation(invitation))
+	}
+	return res, nil
+}
+
+// GetInvitationByTokenHash retrieves an invitation by the hash of its token.
+func (r *invitationsRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*entities.Invitation, error) {
+	var invitation models.Invitation
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&invitation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainInvitation(&invitation), nil
+}
+
+// AcceptInvitation marks a pending invitation as accepted and creates its user with the role of the invitation,
+// in one transaction, so a failed sign up leaves neither a user without a role nor a used invitation behind.
+// The user is created in the organization of the invitation and its ID is set on user.
+func (r *invitationsRepository) AcceptInvitation(ctx context.Context, id string, user *entities.User) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var invitation models.Invitation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&invitation).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainErrors.ErrInvalidInvitation
+		}
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if invitation.AcceptedAt != nil || !invitation.ExpiresAt.After(now) {
+			return domainErrors.ErrInvalidInvitation
+		}
+		if err = tx.Model(&invitation).Update("accepted_at", now).Error; err != nil {
+			return err
+		}
+
+		gormUser := toGormUser(user)
+		gormUser.OrganizationID = invitation.OrganizationID
+		if err = tx.Create(&gormUser).Error; err != nil {
+			return err
+		}
+		assignment := models.RoleAssignment{
+			UserID:         gormUser.ID,
+			OrganizationID: invitation.OrganizationID,
+			Role:           invitation.Role,
+		}
+		if err = tx.Create(&assignment).Error; err != nil {
+			return err
+		}
+
+		user.ID = gormUser.ID.String()
+		user.OrganizationID = invitation.OrganizationID.String()
+		return nil
+	})
+}
+
+// DeleteInvitation deletes an invitation of the organization.
+func (r *invitationsRepository) DeleteInvitation(ctx context.Context, organizationId string, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND organization_id = ?", id, organizationId).Delete(&models.Invitation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrResourceNotFound
+	}
+	return nil
+}
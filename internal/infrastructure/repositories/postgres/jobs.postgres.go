@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+)
+
+type jobsRepository struct {
+	db *gorm.DB
+}
+
+func NewJobsRepository(db *gorm.DB) *jobsRepository {
+	return &jobsRepository{db: db}
+}
+
+func (r *jobsRepository) CreateJob(ctx context.Context, job *entities.Job) error {
+	row, err := toGormJob(job)
+	if err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(row).Error; err != nil {
+		return err
+	}
+
+	job.ID, job.CreatedAt = row.ID.String(), row.CreatedAt
+	return nil
+}
+
+// GetJobByID retrieves the job with its result, a missing job returns ErrResourceNotFound.
+func (r *jobsRepository) GetJobByID(ctx context.Context, id string) (*entities.Job, error) {
+	var row models.Job
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainJob(&row), nil
+}
+
+func (r *jobsRepository) GetJobs(ctx context.Context, caseID string) ([]*entities.Job, error) {
+	var rows []*models.Job
+	err := r.db.WithContext(ctx).Omit("result").Where("case_id = ?", caseID).Order("created_at DESC").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.Job, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, toDomainJob(row))
+	}
+	return res, nil
+}
+
+// ClaimJob takes the job in one statement, SKIP LOCKED lets several servers claim jobs at the same time
+// without running a job twice.
+func (r *jobsRepository) ClaimJob(ctx context.Context, workerID string, now time.Time, leaseUntil time.Time) (*entities.Job, error) {
+	var rows []*models.Job
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE jobs SET status = ?, attempts = attempts + 1, started_at = ?, updated_at = ?,
+			worker_id = ?, lease_expires_at = ?
+		WHERE id = (
+			SELECT id FROM jobs WHERE status = ? AND run_after <= ?
+			ORDER BY run_after, created_at LIMIT 1 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		entities.JobStatusRunning, now, now, workerID, leaseUntil, entities.JobStatusQueued, now,
+	).Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return toDomainJob(rows[0]), nil
+}
+
+func (r *jobsRepository) RenewJobLeases(ctx context.Context, workerID string, leaseUntil time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Job{}).
+		Where("worker_id = ? AND status = ?", workerID, entities.JobStatusRunning).
+		Update("lease_expires_at", leaseUntil).Error
+}
+
+func (r *jobsRepository) FinishJob(ctx context.Context, job *entities.Job) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Job{}).
+		Where("id = ? AND status = ? AND worker_id = ?", job.ID, entities.JobStatusRunning, job.WorkerID).
+		Updates(map[string]interface{}{
+			"status":           job.Status,
+			"result":           jsonColumn(job.Result),
+			"error":            job.Error,
+			"run_after":        job.RunAfter,
+			"finished_at":      job.FinishedAt,
+			"lease_expires_at": nil,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *jobsRepository) CancelJob(ctx context.Context, id string, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Job{}).
+		Where("id = ? AND status IN ?", id, []string{entities.JobStatusQueued, entities.JobStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":           entities.JobStatusCancelled,
+			"finished_at":      now,
+			"lease_expires_at": nil,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// RequeueExpiredJobs makes the interrupted jobs due at once. Jobs of running servers keep their lease renewed
+// and are left alone. The attempt of an interrupted job stays counted, so a job that keeps stopping the server
+// fails once it runs out of attempts.
+func (r *jobsRepository) RequeueExpiredJobs(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.Job{}).
+		Where("status = ? AND (lease_expires_at IS NULL OR lease_expires_at < ?)", entities.JobStatusRunning, now).
+		Updates(map[string]interface{}{
+			"status":           entities.JobStatusQueued,
+			"run_after":        now,
+			"lease_expires_at": nil,
+		})
+	return result.RowsAffected, result.Error
+}
@@ -27,13 +27,13 @@ func (r *porePressuresRepository) CreatePorePressure(ctx context.Context, caseID
 		return err
 	}
 
-	return r.db.WithContext(ctx).Create(gormPorePressure).Error
+	return conn(ctx, r.db).Create(gormPorePressure).Error
 }
 
 // GetPorePressureByID retrieves a PorePressure entry by its ID.
 func (r *porePressuresRepository) GetPorePressureByID(ctx context.Context, id string) (*entities.PorePressure, error) {
 	var porePressureModel models.PorePressure
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&porePressureModel).Error
+	err := conn(ctx, r.db).Where("id = ?", id).First(&porePressureModel).Error
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +46,7 @@ func (r *porePressuresRepository) GetPorePressures(ctx context.Context, caseID s
 	var porePressureModels []models.PorePressure
 	var porePressures []*entities.PorePressure
 
-	err := r.db.WithContext(ctx).Where("case_id = ?", caseID).Find(&porePressureModels).Error
+	err := conn(ctx, r.db).Where("case_id = ?", caseID).Find(&porePressureModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -63,14 +63,14 @@ func (r *porePressuresRepository) UpdatePorePressure(ctx context.Context, porePr
 	var existingPorePressure models.PorePressure
 
 	// Find the existing record
-	err := r.db.WithContext(ctx).Where("id = ?", porePressure.ID).First(&existingPorePressure).Error
+	err := conn(ctx, r.db).Where("id = ?", porePressure.ID).First(&existingPorePressure).Error
 	if err != nil {
 		return nil, err
 	}
 
 	// Update the record
 	gormPorePressure := toGormPorePressure(porePressure)
-	if err := r.db.WithContext(ctx).Model(&existingPorePressure).Updates(gormPorePressure).Error; err != nil {
+	if err := conn(ctx, r.db).Model(&existingPorePressure).Updates(gormPorePressure).Error; err != nil {
 		return nil, err
 	}
 
@@ -79,7 +79,7 @@ func (r *porePressuresRepository) UpdatePorePressure(ctx context.Context, porePr
 
 // DeletePorePressure deletes a PorePressure entry from the database by its ID.
 func (r *porePressuresRepository) DeletePorePressure(ctx context.Context, id string) error {
-	res := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.PorePressure{})
+	res := conn(ctx, r.db).Where("id = ?", id).Delete(&models.PorePressure{})
 	if res.Error != nil {
 		return res.Error
 	}
@@ -89,3 +89,27 @@ func (r *porePressuresRepository) DeletePorePressure(ctx context.Context, id str
 
 	return nil
 }
+
+// ReplacePorePressures deletes the pore pressures of the case and creates the given ones in one transaction.
+func (r *porePressuresRepository) ReplacePorePressures(ctx context.Context, caseID string, porePressures []*entities.PorePressure) error {
+	caseId, err := uuid.Parse(caseID)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]*models.PorePressure, len(porePressures))
+	for i, porePressure := range porePressures {
+		rows[i] = toGormPorePressure(porePressure)
+		rows[i].CaseID = caseId
+	}
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("case_id = ?", caseID).Delete(&models.PorePressure{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(rows).Error
+	})
+}
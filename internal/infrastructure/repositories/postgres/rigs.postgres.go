@@ -26,7 +26,7 @@ func (r *rigsRepository) CreateRig(ctx context.Context, caseID string, rig *enti
 		return err
 	}
 
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(gormRig).Error; err != nil {
 			return err
 		}
@@ -37,7 +37,7 @@ func (r *rigsRepository) CreateRig(ctx context.Context, caseID string, rig *enti
 // GetRigByID retrieves a rig by its ID from the database.
 func (r *rigsRepository) GetRigByID(ctx context.Context, id string) (*entities.Rig, error) {
 	var rigModel models.Rig
-	result := r.db.WithContext(ctx).Where("id = ?", id).First(&rigModel)
+	result := conn(ctx, r.db).Where("id = ?", id).First(&rigModel)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -49,7 +49,7 @@ func (r *rigsRepository) GetRigByID(ctx context.Context, id string) (*entities.R
 func (r *rigsRepository) GetRigs(ctx context.Context, caseID string) ([]*entities.Rig, error) {
 	var rigsModel []models.Rig
 	var res []*entities.Rig
-	result := r.db.WithContext(ctx).Where("case_id = ?", caseID).Find(&rigsModel)
+	result := conn(ctx, r.db).Where("case_id = ?", caseID).Find(&rigsModel)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -64,7 +64,7 @@ func (r *rigsRepository) GetRigs(ctx context.Context, caseID string) ([]*entitie
 func (r *rigsRepository) UpdateRig(ctx context.Context, rig *entities.Rig) (*entities.Rig, error) {
 	var existingRig models.Rig
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Where("id = ?", rig.ID).First(&existingRig).Error; err != nil {
 			return err
 		}
@@ -92,7 +92,7 @@ func (r *rigsRepository) UpdateRig(ctx context.Context, rig *entities.Rig) (*ent
 
 // DeleteRig deletes a rig from the database by its ID.
 func (r *rigsRepository) DeleteRig(ctx context.Context, id string) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		result := tx.Where("id = ?", id).Delete(&models.Rig{})
 		if result.Error != nil {
 			return result.Error
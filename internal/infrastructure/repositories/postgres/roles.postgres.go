@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+)
+
+type rolesRepository struct {
+	db *gorm.DB
+}
+
+func NewRolesRepository(db *gorm.DB) *rolesRepository {
+	return &rolesRepository{db: db}
+}
+
+// GetRoleAssignments retrieves the role assignments of an organization, limited to one user when userId is set.
+func (r *rolesRepository) GetRoleAssignments(ctx context.Context, organizationId string, userId string) ([]*entities.RoleAssignment, error) {
+	var assignments []*models.RoleAssignment
+	query := r.db.WithContext(ctx).Where("organization_id = ?", organizationId)
+	if userId != "" {
+		query = query.Where("user_id = ?", userId)
+	}
+	if err := query.Order("created_at").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.RoleAssignment, 0, len(assignments))
+	for _, assignment := range assignments {
+		res = append(res, toDomainRoleAssignment(assignment))
+	}
+	return res, nil
+}
+
+// CreateRoleAssignment creates a role assignment.
+func (r *rolesRepository) CreateRoleAssignment(ctx context.Context, assignment *entities.RoleAssignment) error {
+	gormAssignment, err := toGormRoleAssignment(assignment)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Create(gormAssignment).Error; err != nil {
+		return err
+	}
+	assignment.ID = gormAssignment.ID.String()
+	return nil
+}
+
+// DeleteRoleAssignment deletes a role assignment of the organization.
+func (r *rolesRepository) DeleteRoleAssignment(ctx context.Context, organizationId string, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND organization_id = ?", id, organizationId).Delete(&models.RoleAssignment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrResourceNotFound
+	}
+	return nil
+}
+
+// IsSuperAdmin reports whether the user is a platform super admin.
+func (r *rolesRepository) IsSuperAdmin(ctx context.Context, userId string) (bool, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Select("is_super_admin").Where("id = ?", userId).First(&user).Error; err != nil {
+		return false, err
+	}
+	return user.IsSuperAdmin, nil
+}
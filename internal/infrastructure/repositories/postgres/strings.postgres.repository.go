@@ -28,18 +28,24 @@ func (r *stringsRepository) CreateString(ctx context.Context, caseID string, str
 	}
 	gormString.CaseID = caseUUID
 
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(gormString).Error; err != nil {
 			return err
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	stringEntity.ID = gormString.ID.String()
+	return nil
 }
 
 // GetStringByID retrieves a string by its ID, along with associated sections.
 func (r *stringsRepository) GetStringByID(ctx context.Context, id string) (*entities.String, error) {
 	var gormString models.String
-	result := r.db.WithContext(ctx).Preload("Sections").Where("id = ?", id).First(&gormString)
+	result := conn(ctx, r.db).Preload("Sections").Where("id = ?", id).First(&gormString)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -51,7 +57,7 @@ func (r *stringsRepository) GetStringByID(ctx context.Context, id string) (*enti
 func (r *stringsRepository) GetStrings(ctx context.Context, caseID string) ([]*entities.String, error) {
 	var gormStrings []models.String
 	var res []*entities.String
-	result := r.db.WithContext(ctx).Preload("Sections").Where("case_id = ?", caseID).Find(&gormStrings)
+	result := conn(ctx, r.db).Preload("Sections").Where("case_id = ?", caseID).Find(&gormStrings)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -66,7 +72,7 @@ func (r *stringsRepository) GetStrings(ctx context.Context, caseID string) ([]*e
 func (r *stringsRepository) UpdateString(ctx context.Context, stringEntity *entities.String) (*entities.String, error) {
 	var updatedString models.String
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		var existingString models.String
 		if err := tx.Preload("Sections").Where("id = ?", stringEntity.ID).First(&existingString).Error; err != nil {
 			return err
@@ -122,7 +128,7 @@ func (r *stringsRepository) UpdateString(ctx context.Context, stringEntity *enti
 
 // DeleteString deletes a string and its associated sections from the database.
 func (r *stringsRepository) DeleteString(ctx context.Context, id string) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		result := tx.Where("id = ?", id).Delete(&models.String{})
 		if result.Error != nil {
 			return result.Error
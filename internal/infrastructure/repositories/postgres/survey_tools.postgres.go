@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+)
+
+type surveyToolsRepository struct {
+	db *gorm.DB
+}
+
+func NewSurveyToolsRepository(db *gorm.DB) *surveyToolsRepository {
+	return &surveyToolsRepository{db: db}
+}
+
+// GetSurveyTools retrieves all survey tools with their error models.
+func (r *surveyToolsRepository) GetSurveyTools(ctx context.Context) ([]*entities.SurveyTool, error) {
+	var tools []*models.SurveyTool
+	var res []*entities.SurveyTool
+	if err := r.db.WithContext(ctx).Preload("ErrorTerms").Order("name").Find(&tools).Error; err != nil {
+		return nil, err
+	}
+
+	for _, tool := range tools {
+		res = append(res, toDomainSurveyTool(tool))
+	}
+	return res, nil
+}
+
+// GetSurveyToolByID retrieves a survey tool by its ID.
+func (r *surveyToolsRepository) GetSurveyToolByID(ctx context.Context, id string) (*entities.SurveyTool, error) {
+	var tool models.SurveyTool
+	if err := r.db.WithContext(ctx).Preload("ErrorTerms").Where("id = ?", id).First(&tool).Error; err != nil {
+		return nil, err
+	}
+
+	return toDomainSurveyTool(&tool), nil
+}
+
+// GetSurveyToolsByIDs retrieves the survey tools with the given IDs.
+func (r *surveyToolsRepository) GetSurveyToolsByIDs(ctx context.Context, ids []string) ([]*entities.SurveyTool, error) {
+	var tools []*models.SurveyTool
+	var res []*entities.SurveyTool
+	if len(ids) == 0 {
+		return res, nil
+	}
+	if err := r.db.WithContext(ctx).Preload("ErrorTerms").Where("id IN ?", ids).Find(&tools).Error; err != nil {
+		return nil, err
+	}
+
+	for _, tool := range tools {
+		res = append(res, toDomainSurveyTool(tool))
+	}
+	return res, nil
+}
+
+// CreateSurveyTool creates a new survey tool together with its error terms.
+func (r *surveyToolsRepository) CreateSurveyTool(ctx context.Context, tool *entities.SurveyTool) error {
+	gormTool := toGormSurveyTool(tool)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(gormTool).Error
+	})
+}
+
+// UpdateSurveyTool updates a survey tool and replaces its error terms.
+func (r *surveyToolsRepository) UpdateSurveyTool(ctx context.Context, tool *entities.SurveyTool) (*entities.SurveyTool, error) {
+	var updatedTool models.SurveyTool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existingTool models.SurveyTool
+		if err := tx.Where("id = ?", tool.ID).First(&existingTool).Error; err != nil {
+			return err
+		}
+
+		gormTool := toGormSurveyTool(tool)
+		terms := gormTool.ErrorTerms
+		gormTool.ErrorTerms = nil
+		if err := tx.Model(&existingTool).Updates(gormTool).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("survey_tool_id = ?", existingTool.ID).Delete(&models.SurveyToolErrorTerm{}).Error; err != nil {
+			return err
+		}
+		for _, term := range terms {
+			term.ID = uuid.Nil
+			term.SurveyToolID = existingTool.ID
+			if err := tx.Create(&term).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Preload("ErrorTerms").Where("id = ?", tool.ID).First(&updatedTool).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainSurveyTool(&updatedTool), nil
+}
+
+// DeleteSurveyTool deletes a survey tool from the database.
+func (r *surveyToolsRepository) DeleteSurveyTool(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.SurveyProgramInterval{}).Where("survey_tool_id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return domainErrors.ErrSurveyToolInUse
+		}
+
+		result := tx.Where("id = ?", id).Delete(&models.SurveyTool{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+)
+
+type tokensRepository struct {
+	db *gorm.DB
+}
+
+func NewTokensRepository(db *gorm.DB) *tokensRepository {
+	return &tokensRepository{db: db}
+}
+
+// CreateTokenFamily creates the token family of a new session.
+func (r *tokensRepository) CreateTokenFamily(ctx context.Context, family *entities.TokenFamily) error {
+	gormFamily, err := toGormTokenFamily(family)
+	if err != nil {
+		return err
+	}
+
+	if err := r.db.WithContext(ctx).Create(gormFamily).Error; err != nil {
+		return err
+	}
+	family.ID = gormFamily.ID.String()
+	family.CreatedAt = gormFamily.CreatedAt
+	return nil
+}
+
+// GetTokenFamily retrieves a token family by its ID.
+func (r *tokensRepository) GetTokenFamily(ctx context.Context, id string) (*entities.TokenFamily, error) {
+	familyId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+
+	var family models.TokenFamily
+	err = r.db.WithContext(ctx).Where("id = ?", familyId).First(&family).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainTokenFamily(&family), nil
+}
+
+// RotateTokenFamily replaces the current token of an active family. The update only succeeds for the token
+// that is current at the time of the update, so of two concurrent rotations with the same token only one wins.
+func (r *tokensRepository) RotateTokenFamily(ctx context.Context, id string, currentTokenId string, newTokenId string, expiresAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.TokenFamily{}).
+		Where("id = ? AND current_token_id = ? AND revoked_at IS NULL", id, currentTokenId).
+		Updates(map[string]interface{}{
+			"current_token_id": newTokenId,
+			"expires_at":       expiresAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// RevokeTokenFamily revokes a token family of the user.
+func (r *tokensRepository) RevokeTokenFamily(ctx context.Context, userId string, id string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.TokenFamily{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userId).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeUserTokenFamilies revokes all token families of the user except the one with exceptId, if it is set.
+func (r *tokensRepository) RevokeUserTokenFamilies(ctx context.Context, userId string, exceptId string) error {
+	query := r.db.WithContext(ctx).
+		Model(&models.TokenFamily{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId)
+	if exceptId != "" {
+		query = query.Where("id <> ?", exceptId)
+	}
+	return query.Update("revoked_at", time.Now()).Error
+}
@@ -27,20 +27,24 @@ func (r *trajectoriesRepository) CreateTrajectory(ctx context.Context, designID
 		return err
 	}
 
-	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(gormTrajectory).Error; err != nil {
 			return err
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	trajectory.ID = gormTrajectory.ID.String()
+	return nil
 }
 
 // GetTrajectoryByID retrieves a trajectory by its ID from the database.
 func (r *trajectoriesRepository) GetTrajectoryByID(ctx context.Context, id string) (*entities.Trajectory, error) {
 	var trajectory models.Trajectory
-	result := r.db.WithContext(ctx).Preload("Headers").Preload("Units").Where("id = ?", id).First(&trajectory)
+	result := conn(ctx, r.db).Preload("Headers").Preload("Units").Preload("SurveyProgram", orderSurveyProgram).Where("id = ?", id).First(&trajectory)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -52,7 +56,7 @@ func (r *trajectoriesRepository) GetTrajectoryByID(ctx context.Context, id strin
 func (r *trajectoriesRepository) GetTrajectories(ctx context.Context, designID string) ([]*entities.Trajectory, error) {
 	var trajectories []*models.Trajectory
 	var res []*entities.Trajectory
-	result := r.db.WithContext(ctx).Preload("Headers").Preload("Units").Where("design_id = ?", designID).Find(&trajectories)
+	result := conn(ctx, r.db).Preload("Headers").Preload("Units").Preload("SurveyProgram", orderSurveyProgram).Where("design_id = ?", designID).Find(&trajectories)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -68,13 +72,15 @@ func (r *trajectoriesRepository) GetTrajectories(ctx context.Context, designID s
 func (r *trajectoriesRepository) UpdateTrajectory(ctx context.Context, trajectory *entities.Trajectory) (*entities.Trajectory, error) {
 	var updatedTrajectory models.Trajectory
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		var existingTrajectory models.Trajectory
 		if err := tx.Preload("Headers").Preload("Units").Where("id = ?", trajectory.ID).First(&existingTrajectory).Error; err != nil {
 			return err
 		}
 
 		gormTrajectory := toGormTrajectory(trajectory)
+		surveyProgram := gormTrajectory.SurveyProgram
+		gormTrajectory.SurveyProgram = nil
 		if err := tx.Model(&existingTrajectory).Updates(gormTrajectory).Error; err != nil {
 			return err
 		}
@@ -137,7 +143,21 @@ func (r *trajectoriesRepository) UpdateTrajectory(ctx context.Context, trajector
 			}
 		}
 
-		if err := tx.Preload("Headers").Preload("Units").Where("id = ?", trajectory.ID).First(&updatedTrajectory).Error; err != nil {
+		// The survey program is replaced as a whole when it is present in the update
+		if trajectory.SurveyProgram != nil {
+			if err := tx.Where("trajectory_id = ?", existingTrajectory.ID).Delete(&models.SurveyProgramInterval{}).Error; err != nil {
+				return err
+			}
+			for _, interval := range surveyProgram {
+				interval.ID = uuid.Nil
+				interval.TrajectoryID = existingTrajectory.ID
+				if err := tx.Create(&interval).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Preload("Headers").Preload("Units").Preload("SurveyProgram", orderSurveyProgram).Where("id = ?", trajectory.ID).First(&updatedTrajectory).Error; err != nil {
 			return err
 		}
 
@@ -154,7 +174,7 @@ func (r *trajectoriesRepository) UpdateTrajectory(ctx context.Context, trajector
 
 // DeleteTrajectory deletes a trajectory from the database.
 func (r *trajectoriesRepository) DeleteTrajectory(ctx context.Context, id string) error {
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		result := tx.Where("id = ?", id).Delete(&models.Trajectory{})
 		if result.Error != nil {
 			return result.Error
@@ -168,3 +188,104 @@ func (r *trajectoriesRepository) DeleteTrajectory(ctx context.Context, id string
 
 	return err
 }
+
+// GetOffsetTrajectories retrieves all trajectories of the other wells located on the same site
+// (or field, depending on scope) as the given trajectory, together with their well information.
+func (r *trajectoriesRepository) GetOffsetTrajectories(ctx context.Context, trajectoryID string, scope string) ([]*entities.OffsetTrajectory, error) {
+	var reference struct {
+		WellID  uuid.UUID
+		SiteID  uuid.UUID
+		FieldID uuid.UUID
+	}
+
+	err := conn(ctx, r.db).
+		Table("trajectories").
+		Select("wells.id AS well_id, sites.id AS site_id, sites.field_id AS field_id").
+		Joins("JOIN designs ON designs.id = trajectories.design_id AND designs.deleted_at IS NULL").
+		Joins("JOIN wellbores ON wellbores.id = designs.wellbore_id AND wellbores.deleted_at IS NULL").
+		Joins("JOIN wells ON wells.id = wellbores.well_id AND wells.deleted_at IS NULL").
+		Joins("JOIN sites ON sites.id = wells.site_id AND sites.deleted_at IS NULL").
+		Where("trajectories.id = ? AND trajectories.deleted_at IS NULL", trajectoryID).
+		Take(&reference).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		TrajectoryID uuid.UUID
+		WellID       uuid.UUID
+		WellName     string
+		WellboreID   uuid.UUID
+		WellboreName string
+		DesignID     uuid.UUID
+	}
+
+	query := conn(ctx, r.db).
+		Table("trajectories").
+		Select("trajectories.id AS trajectory_id, wells.id AS well_id, wells.name AS well_name, "+
+			"wellbores.id AS wellbore_id, wellbores.name AS wellbore_name, designs.id AS design_id").
+		Joins("JOIN designs ON designs.id = trajectories.design_id AND designs.deleted_at IS NULL").
+		Joins("JOIN wellbores ON wellbores.id = designs.wellbore_id AND wellbores.deleted_at IS NULL").
+		Joins("JOIN wells ON wells.id = wellbores.well_id AND wells.deleted_at IS NULL").
+		Joins("JOIN sites ON sites.id = wells.site_id AND sites.deleted_at IS NULL").
+		Where("trajectories.deleted_at IS NULL AND wells.id <> ?", reference.WellID)
+
+	if scope == entities.AntiCollisionScopeField {
+		query = query.Where("sites.field_id = ?", reference.FieldID)
+	} else {
+		query = query.Where("sites.id = ?", reference.SiteID)
+	}
+
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return []*entities.OffsetTrajectory{}, nil
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.TrajectoryID
+	}
+
+	var trajectories []*models.Trajectory
+	err = conn(ctx, r.db).
+		Preload("Units", func(db *gorm.DB) *gorm.DB {
+			return db.Order("md")
+		}).
+		Preload("SurveyProgram", orderSurveyProgram).
+		Where("id IN ?", ids).
+		Find(&trajectories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	trajectoriesMap := make(map[uuid.UUID]*models.Trajectory, len(trajectories))
+	for _, trajectory := range trajectories {
+		trajectoriesMap[trajectory.ID] = trajectory
+	}
+
+	res := make([]*entities.OffsetTrajectory, 0, len(rows))
+	for _, row := range rows {
+		trajectory, ok := trajectoriesMap[row.TrajectoryID]
+		if !ok {
+			continue
+		}
+		res = append(res, &entities.OffsetTrajectory{
+			WellID:       row.WellID.String(),
+			WellName:     row.WellName,
+			WellboreID:   row.WellboreID.String(),
+			WellboreName: row.WellboreName,
+			DesignID:     row.DesignID.String(),
+			Trajectory:   toDomainTrajectory(trajectory),
+		})
+	}
+
+	return res, nil
+}
+
+// orderSurveyProgram orders the preloaded survey program intervals by depth.
+func orderSurveyProgram(db *gorm.DB) *gorm.DB {
+	return db.Order("md_from")
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txCtxKey is the context key of the transaction of TransactionsRepository.
+type txCtxKey struct{}
+
+type transactionsRepository struct {
+	db *gorm.DB
+}
+
+func NewTransactionsRepository(db *gorm.DB) *transactionsRepository {
+	return &transactionsRepository{db: db}
+}
+
+// Transaction passes the transaction to fn in the context, a transaction started inside it becomes a savepoint.
+func (r *transactionsRepository) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txCtxKey{}, tx))
+	})
+}
+
+// conn returns the transaction of the context, or db when the context has none.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txCtxKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
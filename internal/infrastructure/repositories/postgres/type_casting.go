@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"encoding/json"
+
 	"github.com/google/uuid"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
 	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
@@ -92,14 +94,19 @@ func toGormCompany(company *entities.Company) *models.Company {
 // toDomainSite maps the GORM Site model to the domain Site entity.
 func toDomainSite(siteModel *models.Site) *entities.Site {
 	site := entities.Site{
-		ID:      siteModel.ID.String(),
-		Name:    siteModel.Name,
-		Area:    siteModel.Area,
-		Block:   siteModel.Block,
-		Azimuth: siteModel.Azimuth,
-		Country: siteModel.Country,
-		State:   siteModel.State,
-		Region:  siteModel.Region,
+		ID:        siteModel.ID.String(),
+		Name:      siteModel.Name,
+		Area:      siteModel.Area,
+		Block:     siteModel.Block,
+		Azimuth:   siteModel.Azimuth,
+		Country:   siteModel.Country,
+		State:     siteModel.State,
+		Region:    siteModel.Region,
+		EPSGCode:  siteModel.EPSGCode,
+		Latitude:  siteModel.Latitude,
+		Longitude: siteModel.Longitude,
+		Easting:   siteModel.Easting,
+		Northing:  siteModel.Northing,
 	}
 
 	for _, well := range siteModel.Wells {
@@ -117,14 +124,19 @@ func toGormSite(site *entities.Site) *models.Site {
 	}
 
 	newSite := &models.Site{
-		ID:      siteID,
-		Name:    site.Name,
-		Area:    site.Area,
-		Block:   site.Block,
-		Azimuth: site.Azimuth,
-		Country: site.Country,
-		State:   site.State,
-		Region:  site.Region,
+		ID:        siteID,
+		Name:      site.Name,
+		Area:      site.Area,
+		Block:     site.Block,
+		Azimuth:   site.Azimuth,
+		Country:   site.Country,
+		State:     site.State,
+		Region:    site.Region,
+		EPSGCode:  site.EPSGCode,
+		Latitude:  site.Latitude,
+		Longitude: site.Longitude,
+		Easting:   site.Easting,
+		Northing:  site.Northing,
 	}
 
 	for _, well := range site.Wells {
@@ -147,6 +159,13 @@ func toDomainWell(wellModel *models.Well) *entities.Well {
 		WellNumber:              wellModel.WellNumber,
 		WorkingGroup:            wellModel.WorkingGroup,
 		ActiveWellUnit:          wellModel.ActiveWellUnit,
+		EPSGCode:                wellModel.EPSGCode,
+		Latitude:                wellModel.Latitude,
+		Longitude:               wellModel.Longitude,
+		Easting:                 wellModel.Easting,
+		Northing:                wellModel.Northing,
+		GridConvergence:         wellModel.GridConvergence,
+		ScaleFactor:             wellModel.ScaleFactor,
 	}
 
 	for _, wellbore := range wellModel.Wellbores {
@@ -173,6 +192,13 @@ func toGormWell(well *entities.Well) *models.Well {
 		WellNumber:              well.WellNumber,
 		WorkingGroup:            well.WorkingGroup,
 		ActiveWellUnit:          well.ActiveWellUnit,
+		EPSGCode:                well.EPSGCode,
+		Latitude:                well.Latitude,
+		Longitude:               well.Longitude,
+		Easting:                 well.Easting,
+		Northing:                well.Northing,
+		GridConvergence:         well.GridConvergence,
+		ScaleFactor:             well.ScaleFactor,
 	}
 
 	for _, wellbore := range well.Wellbores {
@@ -290,7 +316,9 @@ func toDomainDesign(designModel *models.Design) *entities.Design {
 		PlanName:   designModel.PlanName,
 		Stage:      designModel.Stage,
 		Version:    designModel.Version,
+		Revision:   designModel.Revision,
 		ActualDate: designModel.ActualDate,
+		CreatedAt:  designModel.CreatedAt,
 	}
 
 	for _, trajectory := range designModel.Trajectories {
@@ -312,6 +340,7 @@ func toGormDesign(design *entities.Design) *models.Design {
 		PlanName:   design.PlanName,
 		Stage:      design.Stage,
 		Version:    design.Version,
+		Revision:   design.Revision,
 		ActualDate: design.ActualDate,
 	}
 
@@ -327,6 +356,7 @@ func toGormDesign(design *entities.Design) *models.Design {
 func toDomainTrajectory(trajectoryModel *models.Trajectory) *entities.Trajectory {
 	headers := make([]*entities.TrajectoryHeader, len(trajectoryModel.Headers))
 	units := make([]*entities.TrajectoryUnit, len(trajectoryModel.Units))
+	surveyProgram := make([]*entities.SurveyProgramInterval, len(trajectoryModel.SurveyProgram))
 	cases := make([]*entities.Case, len(trajectoryModel.Cases))
 
 	for i, h := range trajectoryModel.Headers {
@@ -337,18 +367,23 @@ func toDomainTrajectory(trajectoryModel *models.Trajectory) *entities.Trajectory
 		units[i] = toDomainTrajectoryUnit(&u)
 	}
 
+	for i, interval := range trajectoryModel.SurveyProgram {
+		surveyProgram[i] = toDomainSurveyProgramInterval(&interval)
+	}
+
 	for i, c := range trajectoryModel.Cases {
 		cases[i] = toDomainCase(&c)
 	}
 
 	return &entities.Trajectory{
-		ID:          trajectoryModel.ID.String(),
-		Name:        trajectoryModel.Name,
-		Description: trajectoryModel.Description,
-		Headers:     headers,
-		Units:       units,
-		Cases:       cases,
-		CreatedAt:   trajectoryModel.CreatedAt,
+		ID:            trajectoryModel.ID.String(),
+		Name:          trajectoryModel.Name,
+		Description:   trajectoryModel.Description,
+		Headers:       headers,
+		Units:         units,
+		SurveyProgram: surveyProgram,
+		Cases:         cases,
+		CreatedAt:     trajectoryModel.CreatedAt,
 	}
 }
 
@@ -361,6 +396,7 @@ func toGormTrajectory(trajectory *entities.Trajectory) *models.Trajectory {
 
 	headers := make([]models.TrajectoryHeader, len(trajectory.Headers))
 	units := make([]models.TrajectoryUnit, len(trajectory.Units))
+	surveyProgram := make([]models.SurveyProgramInterval, len(trajectory.SurveyProgram))
 	cases := make([]models.Case, len(trajectory.Cases))
 	for i, h := range trajectory.Headers {
 		headers[i] = *toGormTrajectoryHeader(h)
@@ -370,17 +406,22 @@ func toGormTrajectory(trajectory *entities.Trajectory) *models.Trajectory {
 		units[i] = *toGormTrajectoryUnit(u)
 	}
 
+	for i, interval := range trajectory.SurveyProgram {
+		surveyProgram[i] = *toGormSurveyProgramInterval(interval)
+	}
+
 	for i, c := range trajectory.Cases {
 		cases[i] = *toGormCase(c)
 	}
 
 	return &models.Trajectory{
-		ID:          trajectoryID,
-		Name:        trajectory.Name,
-		Description: trajectory.Description,
-		Headers:     headers,
-		Units:       units,
-		Cases:       cases,
+		ID:            trajectoryID,
+		Name:          trajectory.Name,
+		Description:   trajectory.Description,
+		Headers:       headers,
+		Units:         units,
+		SurveyProgram: surveyProgram,
+		Cases:         cases,
 	}
 }
 
@@ -466,6 +507,88 @@ func toDomainTrajectoryUnit(unitModel *models.TrajectoryUnit) *entities.Trajecto
 	}
 }
 
+// toDomainSurveyProgramInterval maps the GORM SurveyProgramInterval model to the domain SurveyProgramInterval entity.
+func toDomainSurveyProgramInterval(intervalModel *models.SurveyProgramInterval) *entities.SurveyProgramInterval {
+	return &entities.SurveyProgramInterval{
+		ID:           intervalModel.ID.String(),
+		MDFrom:       intervalModel.MDFrom,
+		MDTo:         intervalModel.MDTo,
+		SurveyToolID: intervalModel.SurveyToolID.String(),
+		CreatedAt:    intervalModel.CreatedAt,
+	}
+}
+
+// toGormSurveyProgramInterval maps the domain SurveyProgramInterval entity to the GORM SurveyProgramInterval model.
+func toGormSurveyProgramInterval(interval *entities.SurveyProgramInterval) *models.SurveyProgramInterval {
+	intervalID, err := validateGormId(interval.ID)
+	if err != nil {
+		return nil
+	}
+	toolID, err := validateGormId(interval.SurveyToolID)
+	if err != nil {
+		return nil
+	}
+
+	return &models.SurveyProgramInterval{
+		ID:           intervalID,
+		MDFrom:       interval.MDFrom,
+		MDTo:         interval.MDTo,
+		SurveyToolID: toolID,
+	}
+}
+
+// toDomainSurveyTool maps the GORM SurveyTool model to the domain SurveyTool entity.
+func toDomainSurveyTool(toolModel *models.SurveyTool) *entities.SurveyTool {
+	terms := make([]*entities.SurveyToolErrorTerm, len(toolModel.ErrorTerms))
+	for i, term := range toolModel.ErrorTerms {
+		terms[i] = &entities.SurveyToolErrorTerm{
+			ID:          term.ID.String(),
+			Name:        term.Name,
+			Weighting:   term.Weighting,
+			Propagation: term.Propagation,
+			Magnitude:   term.Magnitude,
+		}
+	}
+
+	return &entities.SurveyTool{
+		ID:          toolModel.ID.String(),
+		Name:        toolModel.Name,
+		Description: toolModel.Description,
+		ErrorTerms:  terms,
+		CreatedAt:   toolModel.CreatedAt,
+	}
+}
+
+// toGormSurveyTool maps the domain SurveyTool entity to the GORM SurveyTool model.
+func toGormSurveyTool(tool *entities.SurveyTool) *models.SurveyTool {
+	toolID, err := validateGormId(tool.ID)
+	if err != nil {
+		return nil
+	}
+
+	terms := make([]models.SurveyToolErrorTerm, len(tool.ErrorTerms))
+	for i, term := range tool.ErrorTerms {
+		termID, err := validateGormId(term.ID)
+		if err != nil {
+			return nil
+		}
+		terms[i] = models.SurveyToolErrorTerm{
+			ID:          termID,
+			Name:        term.Name,
+			Weighting:   term.Weighting,
+			Propagation: term.Propagation,
+			Magnitude:   term.Magnitude,
+		}
+	}
+
+	return &models.SurveyTool{
+		ID:          toolID,
+		Name:        tool.Name,
+		Description: tool.Description,
+		ErrorTerms:  terms,
+	}
+}
+
 // toDomainCase maps the GORM Case model to the domain Case entity.
 func toDomainCase(caseModel *models.Case) *entities.Case {
 	newCase := entities.Case{
@@ -476,12 +599,12 @@ func toDomainCase(caseModel *models.Case) *entities.Case {
 		PipeSize:          caseModel.PipeSize,
 		CreatedAt:         caseModel.CreatedAt,
 		IsComplete:        caseModel.IsComplete,
-		Holes:             make([]*entities.Hole, len(caseModel.Holes)),
-		Fluids:            make([]*entities.Fluid, len(caseModel.Fluids)),
-		Strings:           make([]*entities.String, len(caseModel.Strings)),
-		PorePressures:     make([]*entities.PorePressure, len(caseModel.PorePressures)),
-		FractureGradients: make([]*entities.FractureGradient, len(caseModel.FractureGradients)),
-		Rigs:              make([]*entities.Rig, len(caseModel.Rigs)),
+		Holes:             make([]*entities.Hole, 0, len(caseModel.Holes)),
+		Fluids:            make([]*entities.Fluid, 0, len(caseModel.Fluids)),
+		Strings:           make([]*entities.String, 0, len(caseModel.Strings)),
+		PorePressures:     make([]*entities.PorePressure, 0, len(caseModel.PorePressures)),
+		FractureGradients: make([]*entities.FractureGradient, 0, len(caseModel.FractureGradients)),
+		Rigs:              make([]*entities.Rig, 0, len(caseModel.Rigs)),
 	}
 
 	for _, hole := range caseModel.Holes {
@@ -530,7 +653,7 @@ func toGormCase(caseEntity *entities.Case) *models.Case {
 		CaseDescription: caseEntity.CaseDescription,
 		DrillDepth:      caseEntity.DrillDepth,
 		PipeSize:        caseEntity.PipeSize,
-		Holes:           make([]models.Hole, len(caseEntity.Holes)),
+		Holes:           make([]models.Hole, 0, len(caseEntity.Holes)),
 	}
 
 	for _, hole := range caseEntity.Holes {
@@ -808,6 +931,8 @@ func toDomainFractureGradient(model *models.FractureGradient) *entities.Fracture
 		TemperatureAtWellTVD: model.TemperatureAtWellTVD,
 		TemperatureGradient:  model.TemperatureGradient,
 		WellTVD:              model.WellTVD,
+		Pressure:             model.Pressure,
+		EMW:                  model.EMW,
 		CreatedAt:            model.CreatedAt,
 	}
 }
@@ -825,6 +950,8 @@ func toGormFractureGradient(entity *entities.FractureGradient) *models.FractureG
 		TemperatureAtWellTVD: entity.TemperatureAtWellTVD,
 		TemperatureGradient:  entity.TemperatureGradient,
 		WellTVD:              entity.WellTVD,
+		Pressure:             entity.Pressure,
+		EMW:                  entity.EMW,
 		CreatedAt:            entity.CreatedAt,
 	}
 }
@@ -930,3 +1057,477 @@ func validateGormId(id string) (uuid.UUID, error) {
 	}
 	return newId, nil
 }
+
+// toDomainRoleAssignment maps the GORM RoleAssignment model to the domain RoleAssignment entity.
+func toDomainRoleAssignment(assignment *models.RoleAssignment) *entities.RoleAssignment {
+	res := &entities.RoleAssignment{
+		ID:             assignment.ID.String(),
+		UserID:         assignment.UserID.String(),
+		OrganizationID: assignment.OrganizationID.String(),
+		Role:           assignment.Role,
+		CreatedAt:      assignment.CreatedAt,
+	}
+	if assignment.CompanyID != nil {
+		companyID := assignment.CompanyID.String()
+		res.CompanyID = &companyID
+	}
+	if assignment.FieldID != nil {
+		fieldID := assignment.FieldID.String()
+		res.FieldID = &fieldID
+	}
+	return res
+}
+
+// toGormRoleAssignment maps the domain RoleAssignment entity to the GORM RoleAssignment model.
+func toGormRoleAssignment(assignment *entities.RoleAssignment) (*models.RoleAssignment, error) {
+	id, err := validateGormId(assignment.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(assignment.UserID)
+	if err != nil {
+		return nil, types.ErrInvalidUUID
+	}
+	organizationID, err := uuid.Parse(assignment.OrganizationID)
+	if err != nil {
+		return nil, types.ErrInvalidUUID
+	}
+
+	res := &models.RoleAssignment{
+		ID:             id,
+		UserID:         userID,
+		OrganizationID: organizationID,
+		Role:           assignment.Role,
+	}
+	if assignment.CompanyID != nil {
+		companyID, err := uuid.Parse(*assignment.CompanyID)
+		if err != nil {
+			return nil, types.ErrInvalidUUID
+		}
+		res.CompanyID = &companyID
+	}
+	if assignment.FieldID != nil {
+		fieldID, err := uuid.Parse(*assignment.FieldID)
+		if err != nil {
+			return nil, types.ErrInvalidUUID
+		}
+		res.FieldID = &fieldID
+	}
+	return res, nil
+}
+
+// toDomainTokenFamily maps the GORM TokenFamily model to the domain TokenFamily entity.
+func toDomainTokenFamily(family *models.TokenFamily) *entities.TokenFamily {
+	return &entities.TokenFamily{
+		ID:             family.ID.String(),
+		UserID:         family.UserID.String(),
+		OrganizationID: family.OrganizationID.String(),
+		CurrentTokenID: family.CurrentTokenID.String(),
+		ExpiresAt:      family.ExpiresAt,
+		RevokedAt:      family.RevokedAt,
+		CreatedAt:      family.CreatedAt,
+	}
+}
+
+// toGormTokenFamily maps the domain TokenFamily entity to the GORM TokenFamily model.
+func toGormTokenFamily(family *entities.TokenFamily) (*models.TokenFamily, error) {
+	id, err := validateGormId(family.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(family.UserID)
+	if err != nil {
+		return nil, types.ErrInvalidUUID
+	}
+	organizationID, err := uuid.Parse(family.OrganizationID)
+	if err != nil {
+		return nil, types.ErrInvalidUUID
+	}
+	currentTokenID, err := uuid.Parse(family.CurrentTokenID)
+	if err != nil {
+		return nil, types.ErrInvalidUUID
+	}
+
+	return &models.TokenFamily{
+		ID:             id,
+		UserID:         userID,
+		OrganizationID: organizationID,
+		CurrentTokenID: currentTokenID,
+		ExpiresAt:      family.ExpiresAt,
+		RevokedAt:      family.RevokedAt,
+	}, nil
+}
+
+// toDomainInvitation maps the GORM Invitation model to the domain Invitation entity.
+func toDomainInvitation(invitation *models.Invitation) *entities.Invitation {
+	return &entities.Invitation{
+		ID:             invitation.ID.String(),
+		OrganizationID: invitation.OrganizationID.String(),
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		InvitedBy:      invitation.InvitedBy.String(),
+		TokenHash:      invitation.TokenHash,
+		ExpiresAt:      invitation.ExpiresAt,
+		AcceptedAt:     invitation.AcceptedAt,
+		CreatedAt:      invitation.CreatedAt,
+	}
+}
+
+// toGormInvitation maps the domain Invitation entity to the GORM Invitation model.
+func toGormInvitation(invitation *entities.Invitation) (*models.Invitation, error) {
+	id, err := validateGormId(invitation.ID)
+	if err != nil {
+		return nil, err
+	}
+	organizationID, err := uuid.Parse(invitation.OrganizationID)
+	if err != nil {
+		return nil, types.ErrInvalidUUID
+	}
+	invitedBy, err := uuid.Parse(invitation.InvitedBy)
+	if err != nil {
+		return nil, types.ErrInvalidUUID
+	}
+
+	return &models.Invitation{
+		ID:             id,
+		OrganizationID: organizationID,
+		Email:          invitation.Email,
+		Role:           invitation.Role,
+		InvitedBy:      invitedBy,
+		TokenHash:      invitation.TokenHash,
+		ExpiresAt:      invitation.ExpiresAt,
+		AcceptedAt:     invitation.AcceptedAt,
+	}, nil
+}
+
+// toDomainApiKey maps the GORM ApiKey model to the domain ApiKey entity.
+func toDomainApiKey(key *models.ApiKey) *entities.ApiKey {
+	res := &entities.ApiKey{
+		ID:             key.ID.String(),
+		UserID:         key.UserID.String(),
+		OrganizationID: key.OrganizationID.String(),
+		Name:           key.Name,
+		Prefix:         key.Prefix,
+		KeyHash:        key.KeyHash,
+		Scope:          key.Scope,
+		ExpiresAt:      key.ExpiresAt,
+		LastUsedAt:     key.LastUsedAt,
+		CreatedAt:      key.CreatedAt,
+	}
+	if key.CompanyID != nil {
+		companyID := key.CompanyID.String()
+		res.CompanyID = &companyID
+	}
+	return res
+}
+
+// toGormApiKey maps the domain ApiKey entity to the GORM ApiKey model.
+func toGormApiKey(key *entities.ApiKey) (*models.ApiKey, error) {
+	id, err := validateGormId(key.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(key.UserID)
+	if err != nil {
+		return nil, types.ErrInvalidUUID
+	}
+	organizationID, err := uuid.Parse(key.OrganizationID)
+	if err != nil {
+		return nil, types.ErrInvalidUUID
+	}
+
+	res := &models.ApiKey{
+		ID:             id,
+		UserID:         userID,
+		OrganizationID: organizationID,
+		Name:           key.Name,
+		Prefix:         key.Prefix,
+		KeyHash:        key.KeyHash,
+		Scope:          key.Scope,
+		ExpiresAt:      key.ExpiresAt,
+		LastUsedAt:     key.LastUsedAt,
+	}
+	if key.CompanyID != nil {
+		companyID, err := uuid.Parse(*key.CompanyID)
+		if err != nil {
+			return nil, types.ErrInvalidUUID
+		}
+		res.CompanyID = &companyID
+	}
+	return res, nil
+}
+
+// toDomainAuditLog maps the GORM AuditLog model to the domain AuditLogEntry entity.
+func toDomainAuditLog(log *models.AuditLog) (*entities.AuditLogEntry, error) {
+	res := &entities.AuditLogEntry{
+		ID:         log.ID.String(),
+		EntityType: log.EntityType,
+		EntityID:   log.EntityID.String(),
+		Operation:  log.Operation,
+		CreatedAt:  log.CreatedAt,
+	}
+	if err := json.Unmarshal([]byte(log.Changes), &res.Changes); err != nil {
+		return nil, err
+	}
+	if log.UserID != nil {
+		userID := log.UserID.String()
+		res.UserID = &userID
+	}
+	if log.OrganizationID != nil {
+		organizationID := log.OrganizationID.String()
+		res.OrganizationID = &organizationID
+	}
+	if log.ApiKeyID != nil {
+		apiKeyID := log.ApiKeyID.String()
+		res.ApiKeyID = &apiKeyID
+	}
+	return res, nil
+}
+
+// toDomainDesignRevision maps the GORM DesignRevision model to the domain DesignRevision entity.
+// The snapshot is only decoded when it was selected.
+func toDomainDesignRevision(revision *models.DesignRevision) (*entities.DesignRevision, error) {
+	res := &entities.DesignRevision{
+		ID:        revision.ID.String(),
+		DesignID:  revision.DesignID.String(),
+		Revision:  revision.Revision,
+		Comment:   revision.Comment,
+		CreatedAt: revision.CreatedAt,
+	}
+	if revision.CreatedBy != nil {
+		createdBy := revision.CreatedBy.String()
+		res.CreatedBy = &createdBy
+	}
+	if revision.Snapshot != "" {
+		if err := json.Unmarshal([]byte(revision.Snapshot), &res.Snapshot); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// toDomainDesignStageTransition maps the GORM DesignStageTransition model to the domain DesignStageTransition entity.
+func toDomainDesignStageTransition(transition *models.DesignStageTransition) *entities.DesignStageTransition {
+	res := &entities.DesignStageTransition{
+		ID:        transition.ID.String(),
+		DesignID:  transition.DesignID.String(),
+		FromStage: transition.FromStage,
+		ToStage:   transition.ToStage,
+		Comment:   transition.Comment,
+		Revision:  transition.Revision,
+		CreatedAt: transition.CreatedAt,
+	}
+	if transition.UserID != nil {
+		userID := transition.UserID.String()
+		res.UserID = &userID
+	}
+	return res
+}
+
+// toGormDesignStageTransition maps the domain DesignStageTransition entity to the GORM DesignStageTransition model.
+func toGormDesignStageTransition(transition *entities.DesignStageTransition) (*models.DesignStageTransition, error) {
+	designID, err := uuid.Parse(transition.DesignID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.DesignStageTransition{
+		DesignID:  designID,
+		FromStage: transition.FromStage,
+		ToStage:   transition.ToStage,
+		Comment:   transition.Comment,
+		Revision:  transition.Revision,
+	}
+	if transition.UserID != nil {
+		userID, err := uuid.Parse(*transition.UserID)
+		if err != nil {
+			return nil, err
+		}
+		res.UserID = &userID
+	}
+	return res, nil
+}
+
+// toDomainDrillingReading maps the GORM DrillingReading model to the domain DrillingReading entity.
+func toDomainDrillingReading(reading *models.DrillingReading) *entities.DrillingReading {
+	return &entities.DrillingReading{
+		ID:                reading.ID.String(),
+		WellboreID:        reading.WellboreID.String(),
+		Time:              reading.Time,
+		BitDepth:          reading.BitDepth,
+		HookLoad:          reading.HookLoad,
+		SurfaceTorque:     reading.SurfaceTorque,
+		WeightOnBit:       reading.WeightOnBit,
+		RPM:               reading.RPM,
+		FlowRate:          reading.FlowRate,
+		StandpipePressure: reading.StandpipePressure,
+	}
+}
+
+// toGormDrillingReading maps the domain DrillingReading entity to the GORM DrillingReading model.
+func toGormDrillingReading(reading *entities.DrillingReading) (*models.DrillingReading, error) {
+	wellboreID, err := uuid.Parse(reading.WellboreID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DrillingReading{
+		WellboreID:        wellboreID,
+		Time:              reading.Time,
+		BitDepth:          reading.BitDepth,
+		HookLoad:          reading.HookLoad,
+		SurfaceTorque:     reading.SurfaceTorque,
+		WeightOnBit:       reading.WeightOnBit,
+		RPM:               reading.RPM,
+		FlowRate:          reading.FlowRate,
+		StandpipePressure: reading.StandpipePressure,
+	}, nil
+}
+
+// toDomainWellLog maps the GORM WellLog model to the domain WellLog entity with the loaded samples.
+func toDomainWellLog(wellLog *models.WellLog) *entities.WellLog {
+	res := &entities.WellLog{
+		ID:               wellLog.ID.String(),
+		WellboreID:       wellLog.WellboreID.String(),
+		Name:             wellLog.Name,
+		FileName:         wellLog.FileName,
+		TopDepth:         wellLog.TopDepth,
+		BottomDepth:      wellLog.BottomDepth,
+		GammaRayCurve:    wellLog.GammaRayCurve,
+		ResistivityCurve: wellLog.ResistivityCurve,
+		SonicCurve:       wellLog.SonicCurve,
+		DensityCurve:     wellLog.DensityCurve,
+		CreatedAt:        wellLog.CreatedAt,
+	}
+	if len(wellLog.Samples) > 0 {
+		res.Samples = make([]*entities.WellLogSample, len(wellLog.Samples))
+		for i, sample := range wellLog.Samples {
+			res.Samples[i] = &entities.WellLogSample{
+				MD:          sample.MD,
+				GammaRay:    sample.GammaRay,
+				Resistivity: sample.Resistivity,
+				Sonic:       sample.Sonic,
+				Density:     sample.Density,
+			}
+		}
+	}
+	return res
+}
+
+// toGormWellLog maps the domain WellLog entity to the GORM WellLog model without its samples.
+func toGormWellLog(wellLog *entities.WellLog) (*models.WellLog, error) {
+	wellboreID, err := uuid.Parse(wellLog.WellboreID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WellLog{
+		WellboreID:       wellboreID,
+		Name:             wellLog.Name,
+		FileName:         wellLog.FileName,
+		TopDepth:         wellLog.TopDepth,
+		BottomDepth:      wellLog.BottomDepth,
+		GammaRayCurve:    wellLog.GammaRayCurve,
+		ResistivityCurve: wellLog.ResistivityCurve,
+		SonicCurve:       wellLog.SonicCurve,
+		DensityCurve:     wellLog.DensityCurve,
+	}, nil
+}
+
+// toDomainJob maps the GORM Job model to the domain Job entity.
+func toDomainJob(job *models.Job) *entities.Job {
+	res := &entities.Job{
+		ID:             job.ID.String(),
+		OrganizationID: job.OrganizationID.String(),
+		CaseID:         job.CaseID.String(),
+		Type:           job.Type,
+		Status:         job.Status,
+		Error:          job.Error,
+		Attempts:       job.Attempts,
+		RunAfter:       job.RunAfter,
+		CreatedAt:      job.CreatedAt,
+		StartedAt:      job.StartedAt,
+		FinishedAt:     job.FinishedAt,
+		WorkerID:       job.WorkerID,
+	}
+	if job.Params != nil {
+		res.Params = json.RawMessage(*job.Params)
+	}
+	if job.Result != nil {
+		res.Result = json.RawMessage(*job.Result)
+	}
+	return res
+}
+
+// toGormJob maps the domain Job entity to the GORM Job model.
+func toGormJob(job *entities.Job) (*models.Job, error) {
+	organizationID, err := uuid.Parse(job.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	caseID, err := uuid.Parse(job.CaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Job{
+		OrganizationID: organizationID,
+		CaseID:         caseID,
+		Type:           job.Type,
+		Status:         job.Status,
+		Params:         jsonColumn(job.Params),
+		Result:         jsonColumn(job.Result),
+		Error:          job.Error,
+		Attempts:       job.Attempts,
+		RunAfter:       job.RunAfter,
+		StartedAt:      job.StartedAt,
+		FinishedAt:     job.FinishedAt,
+	}, nil
+}
+
+// jsonColumn returns the JSON for a nullable jsonb column, nil when there is none.
+func jsonColumn(value json.RawMessage) *string {
+	if len(value) == 0 {
+		return nil
+	}
+	res := string(value)
+	return &res
+}
+
+// toDomainCalculationResult maps the GORM CalculationResult model to the domain CalculationResult entity.
+func toDomainCalculationResult(result *models.CalculationResult) *entities.CalculationResult {
+	res := &entities.CalculationResult{
+		ID:        result.ID.String(),
+		CaseID:    result.CaseID.String(),
+		Engine:    result.Engine,
+		Type:      result.Type,
+		InputHash: result.InputHash,
+		Stale:     result.Stale,
+		CreatedAt: result.CreatedAt,
+	}
+	if result.Params != nil {
+		res.Params = json.RawMessage(*result.Params)
+	}
+	if result.Result != nil {
+		res.Result = json.RawMessage(*result.Result)
+	}
+	return res
+}
+
+// toGormCalculationResult maps the domain CalculationResult entity to the GORM CalculationResult model.
+func toGormCalculationResult(result *entities.CalculationResult) (*models.CalculationResult, error) {
+	caseID, err := uuid.Parse(result.CaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CalculationResult{
+		CaseID:    caseID,
+		Engine:    result.Engine,
+		Type:      result.Type,
+		InputHash: result.InputHash,
+		Params:    jsonColumn(result.Params),
+		Result:    jsonColumn(result.Result),
+		Stale:     result.Stale,
+	}, nil
+}
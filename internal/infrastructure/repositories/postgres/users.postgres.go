@@ -3,12 +3,15 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
 	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type usersRepository struct {
@@ -20,9 +23,28 @@ func NewUsersRepository(db *gorm.DB) *usersRepository {
 }
 
 func (r *usersRepository) Create(ctx context.Context, organizationId string, user *entities.User) error {
-    tempUser := toGormUser(user)
-    tempUser.OrganizationID = uuid.MustParse(organizationId)
-    return r.db.WithContext(ctx).Create(&tempUser).Error
+	tempUser := toGormUser(user)
+	tempUser.OrganizationID = uuid.MustParse(organizationId)
+	if err := r.db.WithContext(ctx).Create(&tempUser).Error; err != nil {
+		return err
+	}
+	user.ID = tempUser.ID.String()
+	return nil
+}
+
+func (r *usersRepository) GetByID(ctx context.Context, id string) (*entities.User, error) {
+	var user entities.User
+
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		First(&user).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	return &user, err
 }
 
 func (r *usersRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
@@ -34,24 +56,101 @@ func (r *usersRepository) GetByEmail(ctx context.Context, email string) (*entiti
         First(&user).Error
 
     if errors.Is(err, gorm.ErrRecordNotFound) {
-        return nil, types.ErrUserNotFound
+        return nil, domainErrors.ErrUserNotFound
     }
 
     return &user, err
 }
 
-// Todo() Decide on need
-// func (r *userRepository) Update(ctx context.Context, user domain.User) error {
-//     return r.db.WithContext(ctx).Save(&user).Error
-// }
+// Update updates the profile fields of the user.
+func (r *usersRepository) Update(ctx context.Context, user *entities.User) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", user.ID).
+		Updates(map[string]interface{}{
+			"name":    user.Name,
+			"surname": user.Surname,
+			"phone":   user.Phone,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// UpdatePassword replaces the password hash of the user.
+func (r *usersRepository) UpdatePassword(ctx context.Context, id string, password string) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Update("password", password)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// CreatePasswordReset creates a password reset token.
+func (r *usersRepository) CreatePasswordReset(ctx context.Context, reset *entities.PasswordReset) error {
+	userID, err := uuid.Parse(reset.UserID)
+	if err != nil {
+		return types.ErrInvalidUUID
+	}
+
+	gormReset := models.PasswordReset{
+		UserID:    userID,
+		TokenHash: reset.TokenHash,
+		ExpiresAt: reset.ExpiresAt,
+	}
+	if err := r.db.WithContext(ctx).Create(&gormReset).Error; err != nil {
+		return err
+	}
+	reset.ID = gormReset.ID.String()
+	reset.CreatedAt = gormReset.CreatedAt
+	return nil
+}
+
+// UsePasswordReset marks an unused and unexpired password reset token as used and returns it.
+// Marking and checking happen in one statement, so a token can only be used once.
+func (r *usersRepository) UsePasswordReset(ctx context.Context, tokenHash string) (*entities.PasswordReset, error) {
+	var reset models.PasswordReset
+	now := time.Now()
+
+	result := r.db.WithContext(ctx).
+		Model(&reset).
+		Clauses(clause.Returning{}).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, domainErrors.ErrInvalidPasswordResetToken
+	}
+
+	return &entities.PasswordReset{
+		ID:        reset.ID.String(),
+		UserID:    reset.UserID.String(),
+		ExpiresAt: reset.ExpiresAt,
+		UsedAt:    reset.UsedAt,
+		CreatedAt: reset.CreatedAt,
+	}, nil
+}
 
 
 // ToGormUser maps the domain User entity to the GORM User model.
 func toGormUser(user *entities.User) models.User {
-    return models.User{
-        Name:      user.Name,
-        Email:     user.Email,
-        Password:  user.Password,
-        Phone:     user.Phone,
-    }
-}
\ No newline at end of file
+	return models.User{
+		Name:     user.Name,
+		Surname:  user.Surname,
+		Email:    user.Email,
+		Password: user.Password,
+		Phone:    user.Phone,
+	}
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// wellLogSamplesBatchSize keeps the parameters of one insert below the PostgreSQL limit
+	wellLogSamplesBatchSize = 1000
+)
+
+type wellLogsRepository struct {
+	db *gorm.DB
+}
+
+func NewWellLogsRepository(db *gorm.DB) *wellLogsRepository {
+	return &wellLogsRepository{db: db}
+}
+
+// CreateWellLog creates the log and inserts its samples in batches in one transaction.
+func (r *wellLogsRepository) CreateWellLog(ctx context.Context, wellLog *entities.WellLog) error {
+	gormWellLog, err := toGormWellLog(wellLog)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(gormWellLog).Error; err != nil {
+			return err
+		}
+
+		samples := make([]*models.WellLogSample, len(wellLog.Samples))
+		for i, sample := range wellLog.Samples {
+			samples[i] = &models.WellLogSample{
+				WellLogID:   gormWellLog.ID,
+				MD:          sample.MD,
+				GammaRay:    sample.GammaRay,
+				Resistivity: sample.Resistivity,
+				Sonic:       sample.Sonic,
+				Density:     sample.Density,
+			}
+		}
+		return tx.CreateInBatches(samples, wellLogSamplesBatchSize).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	wellLog.ID = gormWellLog.ID.String()
+	return nil
+}
+
+// GetWellLogs retrieves the logs of the wellbore without their samples, newest first.
+func (r *wellLogsRepository) GetWellLogs(ctx context.Context, wellboreID string) ([]*entities.WellLog, error) {
+	var rows []*models.WellLog
+	if err := r.db.WithContext(ctx).Where("wellbore_id = ?", wellboreID).Order("created_at DESC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.WellLog, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, toDomainWellLog(row))
+	}
+	return res, nil
+}
+
+// GetWellLogByID retrieves the log of the wellbore with its samples ordered by depth. Logs of other
+// wellbores return ErrResourceNotFound.
+func (r *wellLogsRepository) GetWellLogByID(ctx context.Context, wellboreID string, id string) (*entities.WellLog, error) {
+	var row models.WellLog
+	err := r.db.WithContext(ctx).
+		Preload("Samples", func(db *gorm.DB) *gorm.DB { return db.Order("md") }).
+		Where("id = ? AND wellbore_id = ?", id, wellboreID).
+		First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainErrors.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainWellLog(&row), nil
+}
+
+// DeleteWellLog deletes the log of the wellbore, its samples are deleted by the foreign key.
+func (r *wellLogsRepository) DeleteWellLog(ctx context.Context, wellboreID string, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND wellbore_id = ?", id, wellboreID).Delete(&models.WellLog{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrResourceNotFound
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ func (r *wellboresRepository) CreateWellbore(ctx context.Context, wellID string,
 		return result.Error
 	}
 
+	wellbore.ID = gormWellbore.ID.String()
 	return nil
 }
 
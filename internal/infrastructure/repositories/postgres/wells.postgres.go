@@ -32,6 +32,7 @@ func (r *wellsRepository) CreateWell(ctx context.Context, siteID string, well *e
 		return result.Error
 	}
 
+	well.ID = gormWell.ID.String()
 	return nil
 }
 
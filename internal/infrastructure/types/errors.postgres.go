@@ -68,4 +68,4 @@ var (
 
 var (
 	ErrSectionIdNotFound = errors.New("section ID was not found")
-)
\ No newline at end of file
+)
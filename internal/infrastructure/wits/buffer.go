@@ -0,0 +1,56 @@
+package wits
+
+import (
+	"sync"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+// buffer is a bounded queue of readings. When it is full the oldest readings are dropped, so a slow database
+// never stalls the rig feed.
+type buffer struct {
+	mu       sync.Mutex
+	readings []*entities.DrillingReading
+	capacity int
+	dropped  int
+}
+
+func newBuffer(capacity int) *buffer {
+	return &buffer{capacity: capacity}
+}
+
+func (b *buffer) push(reading *entities.DrillingReading) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.readings) >= b.capacity {
+		b.readings = b.readings[1:]
+		b.dropped++
+	}
+	b.readings = append(b.readings, reading)
+}
+
+// take removes up to max of the oldest readings from the buffer.
+func (b *buffer) take(max int) []*entities.DrillingReading {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n := len(b.readings)
+	if n > max {
+		n = max
+	}
+	res := make([]*entities.DrillingReading, n)
+	copy(res, b.readings)
+	b.readings = b.readings[n:]
+	return res
+}
+
+// stats returns the number of buffered readings and of readings dropped since the last call.
+func (b *buffer) stats() (int, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	dropped := b.dropped
+	b.dropped = 0
+	return len(b.readings), dropped
+}
@@ -0,0 +1,127 @@
+package wits
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultBufferSize    = 100000
+	defaultBatchSize     = 500
+	defaultFlushInterval = time.Second
+	defaultRetryInterval = 5 * time.Second
+	defaultDateItem      = "0105"
+	defaultTimeItem      = "0106"
+)
+
+// Channels of a drilling reading that WITS items are mapped to
+const (
+	ChannelBitDepth          = "bit_depth"
+	ChannelHookLoad          = "hook_load"
+	ChannelSurfaceTorque     = "surface_torque"
+	ChannelWeightOnBit       = "weight_on_bit"
+	ChannelRPM               = "rpm"
+	ChannelFlowRate          = "flow_rate"
+	ChannelStandpipePressure = "standpipe_pressure"
+)
+
+// DefaultChannels maps the channels to the items of WITS record 1, the time based general record.
+// Rig systems differ, so every listener can override them.
+var DefaultChannels = map[string]string{
+	ChannelBitDepth:          "0108",
+	ChannelHookLoad:          "0114",
+	ChannelWeightOnBit:       "0116",
+	ChannelSurfaceTorque:     "0118",
+	ChannelRPM:               "0120",
+	ChannelStandpipePressure: "0122",
+	ChannelFlowRate:          "0130",
+}
+
+type (
+	// Config of the receiver. Readings wait in a buffer of BufferSize readings and are written in batches
+	// of BatchSize every FlushInterval. A failed write is retried after RetryInterval.
+	Config struct {
+		Listeners     []ListenerConfig `mapstructure:"listeners"`
+		BufferSize    int              `mapstructure:"bufferSize"`
+		BatchSize     int              `mapstructure:"batchSize"`
+		FlushInterval time.Duration    `mapstructure:"flushInterval"`
+		RetryInterval time.Duration    `mapstructure:"retryInterval"`
+	}
+
+	// ListenerConfig maps the feed received on Address to a wellbore. Values are in the Units system,
+	// the date (YYMMDD) and time (HHMMSS) items give the time of a reading, the receive time is used without them.
+	ListenerConfig struct {
+		Address    string            `mapstructure:"address"`
+		WellboreID string            `mapstructure:"wellboreId"`
+		Units      string            `mapstructure:"units"`
+		DateItem   string            `mapstructure:"dateItem"`
+		TimeItem   string            `mapstructure:"timeItem"`
+		Channels   map[string]string `mapstructure:"channels"`
+	}
+)
+
+// LoadConfig reads the receiver configuration file, fills in the defaults and validates it.
+func LoadConfig(path string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetDefault("bufferSize", defaultBufferSize)
+	v.SetDefault("batchSize", defaultBatchSize)
+	v.SetDefault("flushInterval", defaultFlushInterval)
+	v.SetDefault("retryInterval", defaultRetryInterval)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.Listeners) == 0 {
+		return nil, errors.New("no listeners configured")
+	}
+	for i := range cfg.Listeners {
+		if err := cfg.Listeners[i].prepare(); err != nil {
+			return nil, fmt.Errorf("listener %d: %w", i, err)
+		}
+	}
+	return &cfg, nil
+}
+
+func (c *ListenerConfig) prepare() error {
+	if c.Address == "" {
+		return errors.New("address is required")
+	}
+	if _, err := uuid.Parse(c.WellboreID); err != nil {
+		return fmt.Errorf("invalid wellbore id: %w", err)
+	}
+	if c.Units == "" {
+		c.Units = units.SystemCanonical
+	}
+	if _, err := units.Lookup(c.Units); err != nil {
+		return err
+	}
+	if c.DateItem == "" {
+		c.DateItem = defaultDateItem
+	}
+	if c.TimeItem == "" {
+		c.TimeItem = defaultTimeItem
+	}
+
+	channels := make(map[string]string, len(DefaultChannels))
+	for channel, item := range DefaultChannels {
+		channels[channel] = item
+	}
+	for channel, item := range c.Channels {
+		if _, ok := DefaultChannels[channel]; !ok {
+			return fmt.Errorf("unknown channel %q", channel)
+		}
+		channels[channel] = item
+	}
+	c.Channels = channels
+	return nil
+}
@@ -0,0 +1,236 @@
+// Package wits receives WITS Level 0 feeds over TCP and stores them as actual drilling readings.
+// Serial feeds can be bridged to TCP, e.g. with ser2net.
+package wits
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+	witsframe "github.com/munaiplan/munaiplan-backend/pkg/wits"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// flushTimeout bounds the last write on shutdown
+	flushTimeout = 10 * time.Second
+	// statsInterval is how often the buffer level and dropped readings are logged
+	statsInterval = time.Minute
+)
+
+// Receiver listens for WITS feeds and writes the readings to the drilling data store in batches.
+type Receiver struct {
+	cfg  *Config
+	repo repository.DrillingDataRepository
+	buf  *buffer
+}
+
+func NewReceiver(cfg *Config, repo repository.DrillingDataRepository) *Receiver {
+	return &Receiver{
+		cfg:  cfg,
+		repo: repo,
+		buf:  newBuffer(cfg.BufferSize),
+	}
+}
+
+// Run listens on the addresses of all listeners and writes the readings until the context is done.
+// Buffered readings are written once more before it returns.
+func (r *Receiver) Run(ctx context.Context) error {
+	listeners := make([]net.Listener, 0, len(r.cfg.Listeners))
+	for _, cfg := range r.cfg.Listeners {
+		listener, err := net.Listen("tcp", cfg.Address)
+		if err != nil {
+			for _, l := range listeners {
+				l.Close()
+			}
+			return err
+		}
+		listeners = append(listeners, listener)
+		logrus.Infof("wits: listening on %s for wellbore %s", listener.Addr(), cfg.WellboreID)
+	}
+
+	var wg sync.WaitGroup
+	for i := range listeners {
+		wg.Add(1)
+		go func(listener net.Listener, cfg ListenerConfig) {
+			defer wg.Done()
+			r.serve(ctx, listener, cfg)
+		}(listeners[i], r.cfg.Listeners[i])
+	}
+
+	<-ctx.Done()
+	for _, listener := range listeners {
+		listener.Close()
+	}
+	wg.Wait()
+	return nil
+}
+
+// RunWriter writes the buffered readings every flush interval until the context is done and then flushes
+// the buffer once more.
+func (r *Receiver) RunWriter(ctx context.Context) {
+	flush := time.NewTicker(r.cfg.FlushInterval)
+	defer flush.Stop()
+	stats := time.NewTicker(statsInterval)
+	defer stats.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			defer cancel()
+			for batch := r.buf.take(r.cfg.BatchSize); len(batch) > 0; batch = r.buf.take(r.cfg.BatchSize) {
+				if err := r.repo.SaveDrillingReadings(flushCtx, batch); err != nil {
+					logrus.Errorf("wits: failed to write readings on shutdown, %d lost: %v", len(batch), err)
+					return
+				}
+			}
+			return
+		case <-stats.C:
+			buffered, dropped := r.buf.stats()
+			if dropped > 0 {
+				logrus.Warnf("wits: buffer full, dropped %d readings, %d buffered", dropped, buffered)
+			}
+		case <-flush.C:
+			r.writeBuffered(ctx)
+		}
+	}
+}
+
+// writeBuffered writes batches until the buffer is empty. A failed batch is retried until it is written or
+// the context is done, readings that arrive meanwhile wait in the buffer.
+func (r *Receiver) writeBuffered(ctx context.Context) {
+	for batch := r.buf.take(r.cfg.BatchSize); len(batch) > 0; batch = r.buf.take(r.cfg.BatchSize) {
+		for {
+			err := r.repo.SaveDrillingReadings(ctx, batch)
+			if err == nil {
+				break
+			}
+			logrus.Errorf("wits: failed to write %d readings, retrying in %s: %v", len(batch), r.cfg.RetryInterval, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(r.cfg.RetryInterval):
+			}
+		}
+	}
+}
+
+func (r *Receiver) serve(ctx context.Context, listener net.Listener, cfg ListenerConfig) {
+	system, _ := units.Lookup(cfg.Units)
+	var connections sync.WaitGroup
+	defer connections.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			logrus.Errorf("wits: accept on %s: %v", listener.Addr(), err)
+			continue
+		}
+
+		connections.Add(1)
+		go func() {
+			defer connections.Done()
+			r.handle(ctx, conn, cfg, system)
+		}()
+	}
+}
+
+// handle reads frames from the connection until it is closed or the context is done.
+func (r *Receiver) handle(ctx context.Context, conn net.Conn, cfg ListenerConfig, system *units.System) {
+	logrus.Infof("wits: %s connected to %s", conn.RemoteAddr(), conn.LocalAddr())
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	decoder := witsframe.NewDecoder(conn)
+	for {
+		frame, err := decoder.Decode()
+		if errors.Is(err, witsframe.ErrFrameTooLong) {
+			logrus.Warnf("wits: %s sent a frame without an end", conn.RemoteAddr())
+			continue
+		}
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				logrus.Errorf("wits: reading from %s: %v", conn.RemoteAddr(), err)
+			}
+			logrus.Infof("wits: %s disconnected", conn.RemoteAddr())
+			return
+		}
+
+		if reading := toReading(frame, cfg, time.Now().UTC()); reading != nil {
+			system.ToCanonical(reading)
+			r.buf.push(reading)
+		}
+	}
+}
+
+// toReading maps the items of the frame to a reading of the wellbore, nil when the frame has no bit depth.
+func toReading(frame witsframe.Frame, cfg ListenerConfig, received time.Time) *entities.DrillingReading {
+	bitDepth, ok := frame.Float(cfg.Channels[ChannelBitDepth])
+	if !ok {
+		return nil
+	}
+
+	reading := &entities.DrillingReading{
+		WellboreID: cfg.WellboreID,
+		Time:       received,
+		BitDepth:   bitDepth,
+	}
+	if t, ok := frameTime(frame, cfg); ok {
+		reading.Time = t
+	}
+
+	optional := map[string]**float64{
+		ChannelHookLoad:          &reading.HookLoad,
+		ChannelSurfaceTorque:     &reading.SurfaceTorque,
+		ChannelWeightOnBit:       &reading.WeightOnBit,
+		ChannelRPM:               &reading.RPM,
+		ChannelFlowRate:          &reading.FlowRate,
+		ChannelStandpipePressure: &reading.StandpipePressure,
+	}
+	for channel, target := range optional {
+		if value, ok := frame.Float(cfg.Channels[channel]); ok && value != -999.25 {
+			*target = &value
+		}
+	}
+	return reading
+}
+
+// frameTime reads the date (YYMMDD) and time (HHMMSS) items as UTC.
+func frameTime(frame witsframe.Frame, cfg ListenerConfig) (time.Time, bool) {
+	date, okDate := frame[cfg.DateItem]
+	clock, okClock := frame[cfg.TimeItem]
+	if !okDate || !okClock {
+		return time.Time{}, false
+	}
+
+	res, err := time.Parse("060102150405", padItem(date, 6)+padItem(clock, 6))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return res, true
+}
+
+// padItem drops a decimal part and restores leading zeros, numeric items like 90512.0 lose them.
+func padItem(value string, length int) string {
+	value, _, _ = strings.Cut(strings.TrimSpace(value), ".")
+	if n, err := strconv.Atoi(value); err == nil {
+		value = strconv.Itoa(n)
+	}
+	for len(value) < length {
+		value = "0" + value
+	}
+	return value
+}
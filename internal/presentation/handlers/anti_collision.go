@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// initAntiCollisionRoutes initializes the routes for the anti-collision API.
+func (h *Handler) initAntiCollisionRoutes(api *gin.RouterGroup) {
+	antiCollision := api.Group("/anti-collision", h.authMiddleware.UserIdentity, h.authMiddleware.RequirePermission(entities.ScopeTrajectory, entities.PermissionRead))
+	{
+		antiCollision.POST("/scan", h.scanAntiCollision)
+	}
+}
+
+// scanAntiCollision scans a trajectory against the trajectories of the other wells on the same site or field.
+// @Summary Anti-collision Scan
+// @Tags anti-collision
+// @Description Reports center to center distances, closest approach points and separation factors against offset wells.
+// @Description Trajectories are compared in the coordinate reference system of the site from the surface locations of
+// @Description their wells, the scan fails with 400 when a well is not georeferenced. Offsets without stations are skipped
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param trajectoryId query string true "Trajectory ID"
+// @Param input body requests.AntiCollisionRequestBody true "Scan settings"
+// @Success 200 {object} responses.AntiCollisionResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/anti-collision/scan [post]
+func (h *Handler) scanAntiCollision(c *gin.Context) {
+	var inp requests.AntiCollisionRequest
+	var err error
+
+	if err = h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.TrajectoryID, err = h.validateQueryIDParam(c, values.TrajectoryIdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	result, err := h.services.AntiCollision.ScanTrajectory(c.Request.Context(), &inp)
+	if err != nil {
+		h.newAntiCollisionErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, result)
+}
+
+func (h *Handler) newAntiCollisionErrorResponse(c *gin.Context, err error) {
+	if errors.Is(err, domainErrors.ErrUnknownAntiCollisionScope) || errors.Is(err, domainErrors.ErrUnknownErrorModel) ||
+		errors.Is(err, domainErrors.ErrWellNotGeoreferenced) {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.newServiceErrorResponse(c, err)
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// initApiKeysRoutes initializes the routes for the personal API keys of the current user.
+func (h *Handler) initApiKeysRoutes(api *gin.RouterGroup) {
+	apiKeys := api.Group("/api-keys", h.authMiddleware.UserIdentity, h.authMiddleware.RejectApiKey)
+	{
+		apiKeys.GET("/", h.getApiKeys)
+		apiKeys.POST("/", h.createApiKey)
+		apiKeys.DELETE("/:id", h.deleteApiKey)
+	}
+}
+
+// getApiKeys retrieves the API keys of the current user.
+// @Summary Get API Keys
+// @Tags api-keys
+// @Description Retrieves the API keys of the current user. The keys themselves are never returned, only their prefixes
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {array} entities.ApiKey
+// @Failure 403 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/api-keys [get]
+func (h *Handler) getApiKeys(c *gin.Context) {
+	var inp requests.GetApiKeysRequest
+	var err error
+	var keys []*entities.ApiKey
+
+	if inp.UserID, err = h.validateContextIDKey(c, values.UserIdCtx); err != nil {
+		return
+	}
+	if keys, err = h.services.ApiKeys.GetApiKeys(c.Request.Context(), &inp); err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// createApiKey creates an API key of the current user.
+// @Summary Create API Key
+// @Tags api-keys
+// @Description Creates an API key for scripts. Send it as "Authorization: Bearer <key>".
+// @Description The key is only returned in this response. Read keys can only read, read_write keys can also change data,
+// @Description and keys with a company can only reach that company. A key never grants more than the roles of its user
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param input body requests.CreateApiKeyRequestBody true "API key input"
+// @Success 201 {object} responses.ApiKeyCreatedResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/api-keys [post]
+func (h *Handler) createApiKey(c *gin.Context) {
+	var inp requests.CreateApiKeyRequest
+	var err error
+	var key *responses.ApiKeyCreatedResponse
+
+	if err = c.BindJSON(&inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.UserID, err = h.validateContextIDKey(c, values.UserIdCtx); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if key, err = h.services.ApiKeys.CreateApiKey(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidApiKeyScope) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// deleteApiKey revokes an API key of the current user.
+// @Summary Delete API Key
+// @Tags api-keys
+// @Description Revokes an API key of the current user
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "API key ID"
+// @Success 200 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/api-keys/{id} [delete]
+func (h *Handler) deleteApiKey(c *gin.Context) {
+	var inp requests.DeleteApiKeyRequest
+	var err error
+
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.UserID, err = h.validateContextIDKey(c, values.UserIdCtx); err != nil {
+		return
+	}
+	if err = h.services.ApiKeys.DeleteApiKey(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, helpers.NewResponse("api key deleted"))
+}
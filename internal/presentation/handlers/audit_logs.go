@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// initAuditLogsRoutes initializes the routes for the audit log API.
+func (h *Handler) initAuditLogsRoutes(api *gin.RouterGroup) {
+	auditLogs := api.Group(
+		"/audit-logs",
+		h.authMiddleware.UserIdentity,
+		h.authMiddleware.RequirePermission(entities.ScopeOrganization, entities.PermissionManage),
+	)
+	{
+		auditLogs.GET("/", h.getAuditLogs)
+	}
+}
+
+// getAuditLogs retrieves the audit log of the organization.
+// @Summary Get Audit Logs
+// @Tags audit-logs
+// @Description Retrieves the creates, updates and deletes of the organization's data, newest first.
+// @Description Each entry holds the before and after values of the changed columns. Entity types are table names, e.g. caisings
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param entityType query string false "Entity type"
+// @Param entityId query string false "Entity ID"
+// @Param userId query string false "User ID"
+// @Param from query string false "Start of the time range, inclusive, RFC 3339"
+// @Param to query string false "End of the time range, exclusive, RFC 3339"
+// @Param limit query int false "Maximum number of entries, 100 by default, at most 1000"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {array} entities.AuditLogEntry
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/audit-logs [get]
+func (h *Handler) getAuditLogs(c *gin.Context) {
+	var inp requests.GetAuditLogsRequest
+	var err error
+	var entries []*entities.AuditLogEntry
+
+	if err = c.ShouldBindQuery(&inp.Query); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if entries, err = h.services.AuditLogs.GetAuditLogs(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidTimeRange) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
@@ -1,26 +1,44 @@
 package handlers
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
 	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
 	"github.com/munaiplan/munaiplan-backend/pkg/values"
 )
 
+const (
+	workbookFileField   = "file"
+	maxWorkbookFileSize = 20 << 20
+	xlsxContentType     = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
 // initCasesRoutes initializes the routes for the cases API.
 func (h *Handler) initCasesRoutes(api *gin.RouterGroup) {
-	cases := api.Group("/cases", h.authMiddleware.UserIdentity)
+	cases := api.Group("/cases", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeCase, entities.PermissionWrite))
 	{
 		cases.GET("/", h.getCases)
 		cases.POST("/", h.createCase)
 		cases.GET("/:id", h.getCaseByID)
+		cases.GET("/:id/report.pdf", h.getCaseReport)
+		cases.GET("/:id/charts/:chart", h.getCaseChart)
+		cases.GET("/:id/calculation-results", h.getCaseCalculationResults)
+		cases.GET("/:id/workbook.xlsx", h.exportCaseWorkbook)
+		cases.POST("/:id/workbook", h.importCaseWorkbook)
 		cases.PUT("/:id", h.updateCase)
 		cases.DELETE("/:id", h.deleteCase)
 	}
+	// Comparing only reads the cases, the service checks read access to every case
+	api.POST("/cases/compare", h.authMiddleware.UserIdentity, h.compareCases)
 }
 
 // getCases retrieves all cases.
@@ -32,6 +50,8 @@ func (h *Handler) initCasesRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param trajectoryId query string true "Trajectory ID"
 // @Success 200 {array} entities.Case
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/cases [get]
 func (h *Handler) getCases(c *gin.Context) {
@@ -41,13 +61,16 @@ func (h *Handler) getCases(c *gin.Context) {
 	if inp.TrajectoryID, err = h.validateQueryIDParam(c, values.TrajectoryIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if cases, err = h.services.Cases.GetCases(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, cases)
+	h.writeJSON(c, http.StatusOK, cases)
 }
 
 // createCase creates a new case.
@@ -61,21 +84,26 @@ func (h *Handler) getCases(c *gin.Context) {
 // @Param input body requests.CreateCaseRequest true "Case input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/cases [post]
 func (h *Handler) createCase(c *gin.Context) {
 	var inp requests.CreateCaseRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.TrajectoryID, err = h.validateQueryIDParam(c, values.TrajectoryIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Cases.CreateCase(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -93,26 +121,31 @@ func (h *Handler) createCase(c *gin.Context) {
 // @Param input body requests.UpdateCaseRequest true "Case input"
 // @Success 200 {object} entities.Case
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/cases/{id} [put]
 func (h *Handler) updateCase(c *gin.Context) {
 	var inp requests.UpdateCaseRequest
 	var err error
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	caseEntity, err := h.services.Cases.UpdateCase(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, caseEntity)
+	h.writeJSON(c, http.StatusOK, caseEntity)
 }
 
 // deleteCase deletes an existing case.
@@ -125,6 +158,8 @@ func (h *Handler) updateCase(c *gin.Context) {
 // @Param id path string true "Case ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/cases/{id} [delete]
 func (h *Handler) deleteCase(c *gin.Context) {
@@ -134,8 +169,11 @@ func (h *Handler) deleteCase(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Cases.DeleteCase(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -151,6 +189,8 @@ func (h *Handler) deleteCase(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Case ID"
 // @Success 200 {object} entities.Case
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/cases/{id} [get]
 func (h *Handler) getCaseByID(c *gin.Context) {
@@ -160,10 +200,309 @@ func (h *Handler) getCaseByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if caseEntity, err = h.services.Cases.GetCaseByID(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, caseEntity)
+}
+
+// getCaseCalculationResults retrieves the history of the stored calculation results of a case.
+// @Summary Get Case Calculation Results
+// @Tags cases
+// @Description Retrieves the stored torque and drag results of the case without their values, newest first.
+// @Description Each result has the engine, the calculation type and the hash of its inputs: the trajectory,
+// @Description strings, holes, fluids, rigs and parameters. Calculations with unchanged inputs are served from
+// @Description the stored result; a result becomes stale once any of its inputs changes
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Case ID"
+// @Success 200 {array} entities.CalculationResult
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/cases/{id}/calculation-results [get]
+func (h *Handler) getCaseCalculationResults(c *gin.Context) {
+	var inp requests.GetCalculationResultsRequest
+	var err error
+	var results []*entities.CalculationResult
+	if inp.CaseID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if results, err = h.services.TorqueAndDrag.GetCalculationResults(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// compareCases compares cases with the first of them.
+// @Summary Compare Cases
+// @Tags cases
+// @Description Compares the inputs and the torque and drag results of 2 to 10 cases with the first case.
+// @Description Hook load, torque, weight on bit and mud weight curves are resampled on a common depth grid,
+// @Description the mud weight is the static density of the first fluid of the case, not an ECD. A case that fails to calculate is returned with its error
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param input body requests.CompareCasesRequestBody true "Cases to compare, the first one is the baseline"
+// @Success 200 {object} responses.CaseComparisonResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/cases/compare [post]
+func (h *Handler) compareCases(c *gin.Context) {
+	var inp requests.CompareCasesRequest
+	var err error
+	var comparison *responses.CaseComparisonResponse
+
+	if err = h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.UserID, err = h.validateContextIDKey(c, values.UserIdCtx); err != nil {
+		return
+	}
+	if value, ok := c.Get(values.ApiKeyCtx); ok {
+		key := value.(*entities.ApiKey)
+		inp.ApiKeyScope, inp.ApiKeyCompanyID = key.Scope, key.CompanyID
+	}
+
+	if comparison, err = h.services.CaseComparison.CompareCases(c.Request.Context(), &inp); err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrPermissionDenied):
+			helpers.NewErrorResponse(c, http.StatusForbidden, err.Error())
+		default:
+			h.newServiceErrorResponse(c, err)
+		}
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, comparison)
+}
+
+// getCaseReport generates the engineering report of a case as a PDF file.
+// @Summary Get Case Report
+// @Tags cases
+// @Description Generates the well engineering report of the case: the well hierarchy, the trajectory with plan and
+// @Description section views and survey stations, the hole, drill string, fluids and rig, the mud weight window
+// @Description and the effective tension and surface torque charts. Values are in the unit system of the request.
+// @Description Cases the torque and drag model can't calculate get a note instead of the charts. Hydraulics results
+// @Description are not calculated yet, the report notes that they are not available
+// @Produce application/pdf
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Case ID"
+// @Success 200 {file} file
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/cases/{id}/report.pdf [get]
+func (h *Handler) getCaseReport(c *gin.Context) {
+	var inp requests.GetCaseReportRequest
+	var err error
+
+	if inp.CaseID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.Units, err = h.unitSystem(c); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.services.CaseReports.GetCaseReport(c.Request.Context(), &inp)
+	if err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	// The document is written before the status is set, so errors still return a JSON error
+	var body bytes.Buffer
+	if err = report.Write(&body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "case-"+inp.CaseID+"-report.pdf"))
+	c.Data(http.StatusOK, "application/pdf", body.Bytes())
+}
+
+// getCaseChart renders a chart of a case as an SVG image.
+// @Summary Get Case Chart
+// @Tags cases
+// @Description Renders a chart of the case in the unit system of the request: the torque and drag results
+// @Description effective-tension, weight-on-bit, surface-torque and min-weight-on-bit against measured depth, or the
+// @Description mud-weight-window of the pore pressure and fracture gradients against the density of the fluids
+// @Produce image/svg+xml
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Case ID"
+// @Param chart path string true "Chart" Enums(effective-tension, weight-on-bit, surface-torque, min-weight-on-bit, mud-weight-window)
+// @Param width query int false "Width in pixels, 800 by default"
+// @Param height query int false "Height in pixels, 500 by default"
+// @Success 200 {file} file
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 422 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/cases/{id}/charts/{chart} [get]
+func (h *Handler) getCaseChart(c *gin.Context) {
+	var inp requests.GetCaseChartRequest
+	var err error
+
+	if err = c.ShouldBindQuery(&inp.Query); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if inp.CaseID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.Chart, err = h.validateRequestParam(c, values.ChartQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.Units, err = h.unitSystem(c); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ch, err := h.services.Charts.GetCaseChart(c.Request.Context(), &inp)
+	if err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeChart(c, ch, inp.Query)
+}
+
+// exportCaseWorkbook exports a case as an Excel workbook.
+// @Summary Export Case Workbook
+// @Tags cases
+// @Description Exports the case as an xlsx workbook with a sheet for the case, the trajectory, the string and its
+// @Description sections, the hole and its casings, the fluid, the pore pressure and fracture gradient profiles,
+// @Description the rig and every torque and drag result. Values are in the unit system of the request and the
+// @Description headers name the units. Results the model can't calculate get a sheet with the reason
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Case ID"
+// @Success 200 {file} file
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/cases/{id}/workbook.xlsx [get]
+func (h *Handler) exportCaseWorkbook(c *gin.Context) {
+	var inp requests.ExportCaseWorkbookRequest
+	var err error
+
+	if inp.CaseID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.Units, err = h.unitSystem(c); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := h.services.CaseWorkbooks.ExportCaseWorkbook(c.Request.Context(), &inp)
+	if err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+	defer file.Close()
+
+	// The workbook is written before the status is set, so errors still return a JSON error
+	var body bytes.Buffer
+	if err = file.Write(&body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "case-"+inp.CaseID+".xlsx"))
+	c.Data(http.StatusOK, xlsxContentType, body.Bytes())
+}
+
+// importCaseWorkbook updates a case from an edited workbook.
+// @Summary Import Case Workbook
+// @Tags cases
+// @Description Updates the case from an xlsx workbook exported by the workbook endpoint. Sheets the workbook
+// @Description doesn't have and the result sheets are ignored. The Trajectory, Sections, Casings, Pore pressure
+// @Description and Fracture gradient sheets replace the rows of the case: rows without an id are added and rows
+// @Description left out are deleted. The String, Hole, Fluid and Rig sheets update the component or create it.
+// @Description Values are in the unit system named on the Case sheet or in the one of the request. All sheets are
+// @Description checked before the case is changed
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Case ID"
+// @Param file formData file true "xlsx workbook, at most 20 MB"
+// @Success 200 {object} responses.CaseWorkbookImportResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 409 {object} helpers.Response
+// @Failure 413 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/cases/{id}/workbook [post]
+func (h *Handler) importCaseWorkbook(c *gin.Context) {
+	var inp requests.ImportCaseWorkbookRequest
+	var err error
+	var res *responses.CaseWorkbookImportResponse
+
+	if inp.CaseID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.Units, err = h.unitSystem(c); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	header, err := c.FormFile(workbookFileField)
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if header.Size > maxWorkbookFileSize {
+		helpers.NewErrorResponse(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("the file is larger than %d MB", maxWorkbookFileSize>>20))
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+	inp.File = file
+
+	if res, err = h.services.CaseWorkbooks.ImportCaseWorkbook(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidWorkbookFile) || errors.Is(err, domainErrors.ErrUnknownWorkbookRow) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.newServiceErrorResponse(c, err)
+		return
+	}
 
-	c.JSON(http.StatusOK, caseEntity)
+	h.writeJSON(c, http.StatusOK, res)
 }
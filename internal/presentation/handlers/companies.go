@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
 	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
 	"github.com/munaiplan/munaiplan-backend/pkg/values"
@@ -13,7 +14,7 @@ import (
 
 // initCompaniesRoutes initializes the routes for the companies API.
 func (h *Handler) initCompaniesRoutes(api *gin.RouterGroup) {
-	companies := api.Group("/companies", h.authMiddleware.UserIdentity)
+	companies := api.Group("/companies", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeCompany, entities.PermissionManage))
 	{
 		companies.GET("/", h.getCompanies)
 		companies.GET("/all", h.getCompaniesWithComponents)
@@ -33,6 +34,7 @@ func (h *Handler) initCompaniesRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param organizationId query string true "Organization ID"
 // @Success 200 {array} entities.Company
+// @Failure 403 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/companies [get]
 func (h *Handler) getCompanies(c *gin.Context) {
@@ -88,6 +90,7 @@ func (h *Handler) getCompaniesWithComponents(c *gin.Context) {
 // @Param input body requests.CreateCompanyRequest true "Company input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/companies [post]
 func (h *Handler) createCompany(c *gin.Context) {
@@ -120,6 +123,7 @@ func (h *Handler) createCompany(c *gin.Context) {
 // @Param input body requests.UpdateCompanyRequest true "Company input"
 // @Success 200 {object} entities.Company
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/companies/{id} [put]
 func (h *Handler) updateCompany(c *gin.Context) {
@@ -156,6 +160,7 @@ func (h *Handler) updateCompany(c *gin.Context) {
 // @Param id path string true "Company ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/companies/{id} [delete]
 func (h *Handler) deleteCompany(c *gin.Context) {
@@ -187,6 +192,7 @@ func (h *Handler) deleteCompany(c *gin.Context) {
 // @Param organizationId query string true "Organization ID"
 // @Param companyName path string true "Company Name"
 // @Success 200 {object} entities.Company
+// @Failure 403 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/companies/{name} [get]
 func (h *Handler) getCompanyByID(c *gin.Context) {
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+)
+
+// initCoordinatesRoutes initializes the routes for the coordinate reference systems API.
+func (h *Handler) initCoordinatesRoutes(api *gin.RouterGroup) {
+	coordinates := api.Group("/coordinates", h.authMiddleware.UserIdentity)
+	{
+		coordinates.GET("/crs", h.getSupportedCRS)
+		coordinates.POST("/transform", h.transformCoordinates)
+	}
+}
+
+// getSupportedCRS retrieves the supported coordinate reference systems.
+// @Summary Get Supported CRS
+// @Tags coordinates
+// @Description Retrieves the geographic and projected coordinate reference systems supported by the server
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {array} responses.CRSResponse
+// @Router /api/v1/coordinates/crs [get]
+func (h *Handler) getSupportedCRS(c *gin.Context) {
+	c.JSON(http.StatusOK, h.services.Coordinates.GetSupportedCRS(c.Request.Context()))
+}
+
+// transformCoordinates converts points between coordinate reference systems.
+// @Summary Transform Coordinates
+// @Tags coordinates
+// @Description Converts points between geographic and projected systems, shifting the datum when the systems differ
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param input body requests.TransformCoordinatesRequestBody true "Points to convert"
+// @Success 200 {object} responses.TransformCoordinatesResponse
+// @Failure 400 {object} helpers.Response
+// @Router /api/v1/coordinates/transform [post]
+func (h *Handler) transformCoordinates(c *gin.Context) {
+	var inp requests.TransformCoordinatesRequest
+
+	if err := c.BindJSON(&inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+
+	result, err := h.services.Coordinates.TransformCoordinates(c.Request.Context(), &inp)
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
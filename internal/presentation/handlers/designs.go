@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
 	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
 	"github.com/munaiplan/munaiplan-backend/pkg/values"
@@ -13,13 +17,19 @@ import (
 
 // initDesignsRoutes initializes the routes for the designs API.
 func (h *Handler) initDesignsRoutes(api *gin.RouterGroup) {
-	designs := api.Group("/designs", h.authMiddleware.UserIdentity)
+	designs := api.Group("/designs", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeDesign, entities.PermissionWrite))
 	{
 		designs.GET("/", h.getDesigns)
 		designs.POST("/", h.createDesign)
 		designs.GET("/:id", h.getDesignByID)
 		designs.PUT("/:id", h.updateDesign)
 		designs.DELETE("/:id", h.deleteDesign)
+		designs.POST("/:id/freeze", h.freezeDesign)
+		designs.GET("/:id/revisions", h.getDesignRevisions)
+		designs.GET("/:id/revisions/diff", h.diffDesignRevisions)
+		designs.GET("/:id/revisions/:revision", h.getDesignRevision)
+		designs.POST("/:id/transitions", h.transitionDesignStage)
+		designs.GET("/:id/transitions", h.getDesignStageTransitions)
 	}
 }
 
@@ -32,6 +42,8 @@ func (h *Handler) initDesignsRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param wellboreId query string true "Wellbore ID"
 // @Success 200 {array} entities.Design
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/designs [get]
 func (h *Handler) getDesigns(c *gin.Context) {
@@ -41,13 +53,16 @@ func (h *Handler) getDesigns(c *gin.Context) {
 	if inp.WellboreID, err = h.validateQueryIDParam(c, values.WellboreIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if designs, err = h.services.Designs.GetDesigns(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, designs)
+	h.writeJSON(c, http.StatusOK, designs)
 }
 
 // createDesign creates a new design.
@@ -61,21 +76,26 @@ func (h *Handler) getDesigns(c *gin.Context) {
 // @Param input body requests.CreateDesignRequest true "Design input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/designs [post]
 func (h *Handler) createDesign(c *gin.Context) {
 	var inp requests.CreateDesignRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.WellboreID, err = h.validateQueryIDParam(c, values.WellboreIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Designs.CreateDesign(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -93,26 +113,31 @@ func (h *Handler) createDesign(c *gin.Context) {
 // @Param input body requests.UpdateDesignRequest true "Design input"
 // @Success 200 {object} entities.Design
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/designs/{id} [put]
 func (h *Handler) updateDesign(c *gin.Context) {
 	var inp requests.UpdateDesignRequest
 	var err error
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	design, err := h.services.Designs.UpdateDesign(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, design)
+	h.writeJSON(c, http.StatusOK, design)
 }
 
 // deleteDesign deletes an existing design.
@@ -125,6 +150,8 @@ func (h *Handler) updateDesign(c *gin.Context) {
 // @Param id path string true "Design ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/designs/{id} [delete]
 func (h *Handler) deleteDesign(c *gin.Context) {
@@ -134,8 +161,11 @@ func (h *Handler) deleteDesign(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Designs.DeleteDesign(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -151,6 +181,8 @@ func (h *Handler) deleteDesign(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Design ID"
 // @Success 200 {object} entities.Design
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/designs/{id} [get]
 func (h *Handler) getDesignByID(c *gin.Context) {
@@ -160,10 +192,251 @@ func (h *Handler) getDesignByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if design, err = h.services.Designs.GetDesignByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, design)
+}
+
+// freezeDesign freezes the current state of a design as a revision.
+// @Summary Freeze Design
+// @Tags designs
+// @Description Snapshots the design with its trajectories, cases and their components as an immutable revision
+// @Description numbered with the current revision of the design, then bumps the revision of the design
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Design ID"
+// @Param input body requests.FreezeDesignRequestBody false "Revision comment"
+// @Success 201 {object} entities.DesignRevision
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/designs/{id}/freeze [post]
+func (h *Handler) freezeDesign(c *gin.Context) {
+	var inp requests.FreezeDesignRequest
+	var err error
+	var revision *entities.DesignRevision
+
+	if c.Request.ContentLength != 0 {
+		if err = h.bindJSON(c, &inp.Body); err != nil {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+			return
+		}
+	}
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.UserID, err = h.validateContextIDKey(c, values.UserIdCtx); err != nil {
+		return
+	}
+	if revision, err = h.services.Designs.FreezeDesign(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, revision)
+}
 
-	c.JSON(http.StatusOK, design)
-}
\ No newline at end of file
+// getDesignRevisions retrieves the revisions of a design.
+// @Summary Get Design Revisions
+// @Tags designs
+// @Description Retrieves the revisions of a design without their snapshots, newest first
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Design ID"
+// @Success 200 {array} entities.DesignRevision
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/designs/{id}/revisions [get]
+func (h *Handler) getDesignRevisions(c *gin.Context) {
+	var inp requests.GetDesignRevisionsRequest
+	var err error
+	var revisions []*entities.DesignRevision
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if revisions, err = h.services.Designs.GetDesignRevisions(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// getDesignRevision retrieves a revision of a design with its snapshot.
+// @Summary Get Design Revision
+// @Tags designs
+// @Description Retrieves a revision of a design with the snapshot of the design tree
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Design ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} entities.DesignRevision
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/designs/{id}/revisions/{revision} [get]
+func (h *Handler) getDesignRevision(c *gin.Context) {
+	var inp requests.GetDesignRevisionRequest
+	var err error
+	var revision *entities.DesignRevision
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.Revision, err = strconv.Atoi(c.Param(values.RevisionQueryParam)); err != nil || inp.Revision < 1 {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, "invalid revision")
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if revision, err = h.services.Designs.GetDesignRevision(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, revision)
+}
+
+// diffDesignRevisions compares two revisions of a design.
+// @Summary Diff Design Revisions
+// @Tags designs
+// @Description Compares the snapshots of two revisions field by field. Array elements are matched by id,
+// @Description a path like trajectories[id=...].cases[id=...].holes[id=...].effective_diameter addresses a field
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Design ID"
+// @Param from query int true "Revision to compare from"
+// @Param to query int true "Revision to compare to"
+// @Success 200 {object} responses.DesignRevisionsDiffResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/designs/{id}/revisions/diff [get]
+func (h *Handler) diffDesignRevisions(c *gin.Context) {
+	var inp requests.DiffDesignRevisionsRequest
+	var err error
+	var diff *responses.DesignRevisionsDiffResponse
+	if err = c.ShouldBindQuery(&inp.Query); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if diff, err = h.services.Designs.DiffDesignRevisions(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// transitionDesignStage moves a design to another stage.
+// @Summary Transition Design Stage
+// @Tags designs
+// @Description Moves a design along prototype, planned, approved, actual and archived. Engineers move designs
+// @Description between prototype and planned, approving and every transition after it needs an organization admin.
+// @Description Approving freezes the design as a revision, approved, actual and archived designs are locked against edits
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Design ID"
+// @Param input body requests.TransitionDesignStageRequestBody true "Target stage and comment"
+// @Success 201 {object} entities.DesignStageTransition
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 409 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/designs/{id}/transitions [post]
+func (h *Handler) transitionDesignStage(c *gin.Context) {
+	var inp requests.TransitionDesignStageRequest
+	var err error
+	var transition *entities.DesignStageTransition
+
+	if err = h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.UserID, err = h.validateContextIDKey(c, values.UserIdCtx); err != nil {
+		return
+	}
+	if value, ok := c.Get(values.ApiKeyCtx); ok {
+		key := value.(*entities.ApiKey)
+		inp.ApiKeyScope, inp.ApiKeyCompanyID = key.Scope, key.CompanyID
+	}
+
+	if transition, err = h.services.Designs.TransitionDesignStage(c.Request.Context(), &inp); err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrInvalidDesignStage):
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, domainErrors.ErrPermissionDenied):
+			helpers.NewErrorResponse(c, http.StatusForbidden, err.Error())
+		default:
+			h.newServiceErrorResponse(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, transition)
+}
+
+// getDesignStageTransitions retrieves the stage history of a design.
+// @Summary Get Design Stage Transitions
+// @Tags designs
+// @Description Retrieves the stage transitions of a design with their comments, users and times, oldest first
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Design ID"
+// @Success 200 {array} entities.DesignStageTransition
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/designs/{id}/transitions [get]
+func (h *Handler) getDesignStageTransitions(c *gin.Context) {
+	var inp requests.GetDesignStageTransitionsRequest
+	var err error
+	var transitions []*entities.DesignStageTransition
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if transitions, err = h.services.Designs.GetDesignStageTransitions(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transitions)
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+const (
+	drillingDataFileField   = "file"
+	maxDrillingDataFileSize = 100 << 20
+)
+
+// initDrillingDataRoutes initializes the routes for the actual drilling data of wellbores.
+func (h *Handler) initDrillingDataRoutes(api *gin.RouterGroup) {
+	drillingData := api.Group("/wellbores/:id/drilling-data", h.authMiddleware.UserIdentity)
+	{
+		drillingData.GET("/", h.authMiddleware.Authorize(entities.ScopeWellbore, entities.PermissionWrite), h.getDrillingReadings)
+		drillingData.POST("/", h.authMiddleware.Authorize(entities.ScopeWellbore, entities.PermissionWrite), h.importDrillingData)
+		drillingData.DELETE("/", h.authMiddleware.Authorize(entities.ScopeWellbore, entities.PermissionWrite), h.deleteDrillingReadings)
+		// Calibrating only reads the readings and the case
+		drillingData.POST("/calibration", h.authMiddleware.RequirePermission(entities.ScopeWellbore, entities.PermissionRead), h.calibrateFrictionFactors)
+	}
+}
+
+// importDrillingData imports actual drilling readings from a CSV file.
+// @Summary Import Drilling Data
+// @Tags drilling-data
+// @Description Imports time and bit depth indexed readings (hook load, surface torque, weight on bit, RPM, flow rate,
+// @Description standpipe pressure) from a CSV file with a header line. The time and bit depth columns are required,
+// @Description values are in the unit system of the request. A reading at the same time replaces the stored one,
+// @Description rows that cannot be read are skipped and reported
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Wellbore ID"
+// @Param file formData file true "CSV file, at most 100 MB"
+// @Success 201 {object} responses.DrillingDataImportResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/wellbores/{id}/drilling-data [post]
+func (h *Handler) importDrillingData(c *gin.Context) {
+	var inp requests.ImportDrillingDataRequest
+	var err error
+	var result *responses.DrillingDataImportResponse
+
+	if inp.WellboreID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.Units, err = h.unitSystem(c); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	header, err := c.FormFile(drillingDataFileField)
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if header.Size > maxDrillingDataFileSize {
+		helpers.NewErrorResponse(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("the file is larger than %d MB", maxDrillingDataFileSize>>20))
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+	inp.File = file
+
+	if result, err = h.services.DrillingData.ImportDrillingData(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidDrillingDataFile) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// getDrillingReadings retrieves the actual drilling readings of a wellbore.
+// @Summary Get Drilling Readings
+// @Tags drilling-data
+// @Description Retrieves the readings of the wellbore in a time range, oldest first
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Wellbore ID"
+// @Param from query string false "Start of the range, inclusive, RFC 3339"
+// @Param to query string false "End of the range, exclusive, RFC 3339"
+// @Param limit query int false "Maximum number of readings, 1 to 10000, 1000 by default"
+// @Param offset query int false "Number of readings to skip"
+// @Success 200 {array} entities.DrillingReading
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/wellbores/{id}/drilling-data [get]
+func (h *Handler) getDrillingReadings(c *gin.Context) {
+	var inp requests.GetDrillingReadingsRequest
+	var err error
+	var readings []*entities.DrillingReading
+
+	if err = c.ShouldBindQuery(&inp.Query); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if inp.WellboreID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	if readings, err = h.services.DrillingData.GetDrillingReadings(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidTimeRange) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, readings)
+}
+
+// deleteDrillingReadings deletes the actual drilling readings of a wellbore.
+// @Summary Delete Drilling Readings
+// @Tags drilling-data
+// @Description Deletes the readings of the wellbore in a time range, all readings without one
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Wellbore ID"
+// @Param from query string false "Start of the range, inclusive, RFC 3339"
+// @Param to query string false "End of the range, exclusive, RFC 3339"
+// @Success 200 {object} responses.DeleteDrillingReadingsResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/wellbores/{id}/drilling-data [delete]
+func (h *Handler) deleteDrillingReadings(c *gin.Context) {
+	var inp requests.DeleteDrillingReadingsRequest
+	var err error
+	var result *responses.DeleteDrillingReadingsResponse
+
+	if err = c.ShouldBindQuery(&inp.Query); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if inp.WellboreID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	if result, err = h.services.DrillingData.DeleteDrillingReadings(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidTimeRange) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// calibrateFrictionFactors back-calculates friction factors from the actual drilling readings.
+// @Summary Calibrate Friction Factors
+// @Tags drilling-data
+// @Description Classifies the readings into trip in, trip out and rotating and fits, per bit depth interval, the
+// @Description friction factor whose torque and drag result for the case best matches the measured hook load and
+// @Description surface torque. The factor is applied along the whole string, so the result is an apparent friction
+// @Description factor. The case must belong to the wellbore
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Wellbore ID"
+// @Param input body requests.CalibrateFrictionFactorsRequestBody true "Case, interval length, friction factor range and time range"
+// @Success 200 {object} responses.FrictionCalibrationResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 422 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/wellbores/{id}/drilling-data/calibration [post]
+func (h *Handler) calibrateFrictionFactors(c *gin.Context) {
+	var inp requests.CalibrateFrictionFactorsRequest
+	var err error
+	var result *responses.FrictionCalibrationResponse
+
+	if err = h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.WellboreID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	if result, err = h.services.DrillingData.CalibrateFrictionFactors(c.Request.Context(), &inp); err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrInvalidTimeRange), errors.Is(err, domainErrors.ErrCaseNotInWellbore),
+			errors.Is(err, domainErrors.ErrTooManySensitivityRuns):
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, domainErrors.ErrNotEnoughDrillingData):
+			helpers.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		default:
+			h.newServiceErrorResponse(c, err)
+		}
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, result)
+}
@@ -13,7 +13,7 @@ import (
 
 // initFieldsRoutes initializes the routes for the fields API.
 func (h *Handler) initFieldsRoutes(api *gin.RouterGroup) {
-	fields := api.Group("/fields", h.authMiddleware.UserIdentity)
+	fields := api.Group("/fields", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeField, entities.PermissionManage))
 	{
 		fields.GET("/", h.getFields)
 		fields.POST("/", h.createField)
@@ -32,6 +32,8 @@ func (h *Handler) initFieldsRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param companyId query string true "Company ID"
 // @Success 200 {array} entities.Field
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fields [get]
 func (h *Handler) getFields(c *gin.Context) {
@@ -40,14 +42,17 @@ func (h *Handler) getFields(c *gin.Context) {
 	if inp.CompanyID, err = h.validateQueryIDParam(c, values.CompanyIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	fields, err := h.services.Fields.GetFields(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, fields)
+	h.writeJSON(c, http.StatusOK, fields)
 }
 
 // createField creates a new field.
@@ -61,21 +66,26 @@ func (h *Handler) getFields(c *gin.Context) {
 // @Param input body requests.CreateFieldRequest true "Field input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fields [post]
 func (h *Handler) createField(c *gin.Context) {
 	var inp requests.CreateFieldRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.CompanyID, err = h.validateQueryIDParam(c, values.CompanyIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Fields.CreateField(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -93,26 +103,31 @@ func (h *Handler) createField(c *gin.Context) {
 // @Param input body requests.UpdateFieldRequest true "Field input"
 // @Success 200 {object} entities.Field
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fields/{id} [put]
 func (h *Handler) updateField(c *gin.Context) {
 	var inp requests.UpdateFieldRequest
 	var err error
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	field, err := h.services.Fields.UpdateField(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, field)
+	h.writeJSON(c, http.StatusOK, field)
 }
 
 // deleteField deletes an existing field.
@@ -125,6 +140,8 @@ func (h *Handler) updateField(c *gin.Context) {
 // @Param id path string true "Field ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fields/{id} [delete]
 func (h *Handler) deleteField(c *gin.Context) {
@@ -134,8 +151,11 @@ func (h *Handler) deleteField(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Fields.DeleteField(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -151,6 +171,8 @@ func (h *Handler) deleteField(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Field ID"
 // @Success 200 {object} entities.Field
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fields/{id} [get]
 func (h *Handler) getFieldByID(c *gin.Context) {
@@ -160,10 +182,13 @@ func (h *Handler) getFieldByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if field, err = h.services.Fields.GetFieldByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, field)
+	h.writeJSON(c, http.StatusOK, field)
 }
@@ -13,7 +13,7 @@ import (
 
 // initFluidsRoutes initializes the routes for the fluids API.
 func (h *Handler) initFluidsRoutes(api *gin.RouterGroup) {
-	fluids := api.Group("/fluids", h.authMiddleware.UserIdentity)
+	fluids := api.Group("/fluids", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeFluid, entities.PermissionWrite))
 	{
 		fluids.GET("/", h.getFluids)
 		fluids.GET("/types", h.getFluidTypes)
@@ -33,6 +33,8 @@ func (h *Handler) initFluidsRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param caseId query string true "Case ID"
 // @Success 200 {array} entities.Fluid
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fluids [get]
 func (h *Handler) getFluids(c *gin.Context) {
@@ -43,13 +45,16 @@ func (h *Handler) getFluids(c *gin.Context) {
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if fluids, err = h.services.Fluids.GetFluids(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, fluids)
+	h.writeJSON(c, http.StatusOK, fluids)
 }
 
 // getFluidTypes retrieves all fluid types from the database.
@@ -60,6 +65,8 @@ func (h *Handler) getFluids(c *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Bearer token"
 // @Success 200 {array} entities.FluidType
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fluids/types [get]
 func (h *Handler) getFluidTypes(c *gin.Context) {
@@ -67,11 +74,11 @@ func (h *Handler) getFluidTypes(c *gin.Context) {
 	var fluidTypes []*entities.FluidType
 
 	if fluidTypes, err = h.services.Fluids.GetFluidTypes(c.Request.Context()); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, fluidTypes)
+	h.writeJSON(c, http.StatusOK, fluidTypes)
 }
 
 // createFluid creates a new fluid.
@@ -85,21 +92,26 @@ func (h *Handler) getFluidTypes(c *gin.Context) {
 // @Param input body requests.CreateFluidRequest true "Fluid input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fluids [post]
 func (h *Handler) createFluid(c *gin.Context) {
 	var inp requests.CreateFluidRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Fluids.CreateFluid(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -117,27 +129,32 @@ func (h *Handler) createFluid(c *gin.Context) {
 // @Param input body requests.UpdateFluidRequest true "Fluid input"
 // @Success 200 {object} entities.Fluid
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fluids/{id} [put]
 func (h *Handler) updateFluid(c *gin.Context) {
 	var inp requests.UpdateFluidRequest
 	var err error
 
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	fluid, err := h.services.Fluids.UpdateFluid(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, fluid)
+	h.writeJSON(c, http.StatusOK, fluid)
 }
 
 // deleteFluid deletes an existing fluid.
@@ -150,6 +167,8 @@ func (h *Handler) updateFluid(c *gin.Context) {
 // @Param id path string true "Fluid ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fluids/{id} [delete]
 func (h *Handler) deleteFluid(c *gin.Context) {
@@ -159,8 +178,11 @@ func (h *Handler) deleteFluid(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Fluids.DeleteFluid(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -176,6 +198,8 @@ func (h *Handler) deleteFluid(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Fluid ID"
 // @Success 200 {object} entities.Fluid
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fluids/{id} [get]
 func (h *Handler) getFluidByID(c *gin.Context) {
@@ -186,10 +210,13 @@ func (h *Handler) getFluidByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if fluid, err = h.services.Fluids.GetFluidByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, fluid)
+	h.writeJSON(c, http.StatusOK, fluid)
 }
@@ -13,7 +13,7 @@ import (
 
 // initFractureGradientRoutes initializes the routes for the fracture gradients API.
 func (h *Handler) initFractureGradientRoutes(api *gin.RouterGroup) {
-	fractureGradients := api.Group("/fracture-gradients", h.authMiddleware.UserIdentity)
+	fractureGradients := api.Group("/fracture-gradients", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeFractureGradient, entities.PermissionWrite))
 	{
 		fractureGradients.GET("/", h.getFractureGradients)
 		fractureGradients.POST("/", h.createFractureGradient)
@@ -32,6 +32,8 @@ func (h *Handler) initFractureGradientRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param caseId query string true "Case ID"
 // @Success 200 {array} entities.FractureGradient
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fracture_gradients [get]
 func (h *Handler) getFractureGradients(c *gin.Context) {
@@ -42,13 +44,16 @@ func (h *Handler) getFractureGradients(c *gin.Context) {
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if fractureGradients, err = h.services.FractureGradients.GetFractureGradients(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, fractureGradients)
+	h.writeJSON(c, http.StatusOK, fractureGradients)
 }
 
 // createFractureGradient creates a new fracture gradient.
@@ -62,22 +67,27 @@ func (h *Handler) getFractureGradients(c *gin.Context) {
 // @Param input body requests.CreateFractureGradientRequest true "Fracture Gradient input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fracture_gradients [post]
 func (h *Handler) createFractureGradient(c *gin.Context) {
 	var inp requests.CreateFractureGradientRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if err = h.services.FractureGradients.CreateFractureGradient(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -95,27 +105,32 @@ func (h *Handler) createFractureGradient(c *gin.Context) {
 // @Param input body requests.UpdateFractureGradientRequest true "Fracture Gradient input"
 // @Success 200 {object} entities.FractureGradient
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fracture_gradients/{id} [put]
 func (h *Handler) updateFractureGradient(c *gin.Context) {
 	var inp requests.UpdateFractureGradientRequest
 	var err error
 
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	fractureGradient, err := h.services.FractureGradients.UpdateFractureGradient(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, fractureGradient)
+	h.writeJSON(c, http.StatusOK, fractureGradient)
 }
 
 // deleteFractureGradient deletes an existing fracture gradient.
@@ -128,6 +143,8 @@ func (h *Handler) updateFractureGradient(c *gin.Context) {
 // @Param id path string true "Fracture Gradient ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fracture_gradients/{id} [delete]
 func (h *Handler) deleteFractureGradient(c *gin.Context) {
@@ -137,8 +154,11 @@ func (h *Handler) deleteFractureGradient(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.FractureGradients.DeleteFractureGradient(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -154,6 +174,8 @@ func (h *Handler) deleteFractureGradient(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Fracture Gradient ID"
 // @Success 200 {object} entities.FractureGradient
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/fracture_gradients/{id} [get]
 func (h *Handler) getFractureGradientByID(c *gin.Context) {
@@ -164,10 +186,13 @@ func (h *Handler) getFractureGradientByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if fractureGradient, err = h.services.FractureGradients.GetFractureGradientByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, fractureGradient)
+	h.writeJSON(c, http.StatusOK, fractureGradient)
 }
@@ -23,11 +23,17 @@ func (h *Handler) Init(api *gin.RouterGroup) {
 	{
 		h.initUsersRoutes(v1)
 		h.initOrganizationsRoutes(v1)
+		h.initRolesRoutes(v1)
+		h.initInvitationsRoutes(v1)
+		h.initApiKeysRoutes(v1)
+		h.initAuditLogsRoutes(v1)
 		h.initCompaniesRoutes(v1)
 		h.initFieldsRoutes(v1)
 		h.initSitesRoutes(v1)
 		h.initWellsRoutes(v1)
 		h.initWellboresRoutes(v1)
+		h.initDrillingDataRoutes(v1)
+		h.initWellLogsRoutes(v1)
 		h.initDesignsRoutes(v1)
 		h.initTrajectoriesRoutes(v1)
 		h.initCasesRoutes(v1)
@@ -37,6 +43,14 @@ func (h *Handler) Init(api *gin.RouterGroup) {
 		h.initPorePressureRoutes(v1)
 		h.initFractureGradientRoutes(v1)
 		h.initStringsRoutes(v1)
+		h.initWitsmlRoutes(v1)
 		h.initTorqueAndDragRoutes(v1)
+		h.initJobsRoutes(v1)
+		h.initAntiCollisionRoutes(v1)
+		h.initSurveyToolsRoutes(v1)
+		h.initPositionUncertaintyRoutes(v1)
+		h.initCoordinatesRoutes(v1)
+		h.initTortuosityRoutes(v1)
+		h.initUnitsRoutes(v1)
 	}
 }
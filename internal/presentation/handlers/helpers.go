@@ -6,8 +6,17 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
 	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/chart"
+)
+
+const (
+	defaultChartWidth  = 800
+	defaultChartHeight = 500
+	svgContentType     = "image/svg+xml"
 )
 
 func (h *Handler) validateQueryIDParam(c *gin.Context, key string) (string, error) {
@@ -64,3 +73,33 @@ func (h *Handler) validateUUIDParam(c *gin.Context, value string) error {
 	}
 	return nil
 }
+
+// newServiceErrorResponse reports entities that are missing or belong to another organization as not found,
+// and changes that the stage of a design does not allow as conflicts.
+func (h *Handler) newServiceErrorResponse(c *gin.Context, err error) {
+	if errors.Is(err, domainErrors.ErrResourceNotFound) || errors.Is(err, domainErrors.ErrUnknownChart) {
+		helpers.NewErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, domainErrors.ErrDesignLocked) || errors.Is(err, domainErrors.ErrInvalidStageTransition) || errors.Is(err, domainErrors.ErrDesignStageChanged) {
+		helpers.NewErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, domainErrors.ErrCaseNotReadyForTorqueAndDrag) {
+		helpers.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+}
+
+// writeChart renders the chart as an SVG image of the size of the query.
+func (h *Handler) writeChart(c *gin.Context, ch *chart.Chart, size requests.ChartSizeQuery) {
+	width, height := defaultChartWidth, defaultChartHeight
+	if size.Width != 0 {
+		width = size.Width
+	}
+	if size.Height != 0 {
+		height = size.Height
+	}
+	c.Data(http.StatusOK, svgContentType, chart.SVG(ch, float64(width), float64(height)))
+}
@@ -13,7 +13,7 @@ import (
 
 // initHolesRoutes initializes the routes for the holes API.
 func (h *Handler) initHolesRoutes(api *gin.RouterGroup) {
-	holes := api.Group("/holes", h.authMiddleware.UserIdentity)
+	holes := api.Group("/holes", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeHole, entities.PermissionWrite))
 	{
 		holes.GET("/", h.getHoles)
 		holes.POST("/", h.createHole)
@@ -32,6 +32,8 @@ func (h *Handler) initHolesRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param caseId query string true "Case ID"
 // @Success 200 {array} entities.Hole
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/holes [get]
 func (h *Handler) getHoles(c *gin.Context) {
@@ -42,13 +44,16 @@ func (h *Handler) getHoles(c *gin.Context) {
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if holes, err = h.services.Holes.GetHoles(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, holes)
+	h.writeJSON(c, http.StatusOK, holes)
 }
 
 // createHole creates a new hole.
@@ -62,21 +67,26 @@ func (h *Handler) getHoles(c *gin.Context) {
 // @Param input body requests.CreateHoleRequest true "Hole input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/holes [post]
 func (h *Handler) createHole(c *gin.Context) {
 	var inp requests.CreateHoleRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Holes.CreateHole(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -94,27 +104,32 @@ func (h *Handler) createHole(c *gin.Context) {
 // @Param input body requests.UpdateHoleRequest true "Hole input"
 // @Success 200 {object} entities.Hole
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/holes/{id} [put]
 func (h *Handler) updateHole(c *gin.Context) {
 	var inp requests.UpdateHoleRequest
 	var err error
 
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	hole, err := h.services.Holes.UpdateHole(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, hole)
+	h.writeJSON(c, http.StatusOK, hole)
 }
 
 // deleteHole deletes an existing hole.
@@ -127,6 +142,8 @@ func (h *Handler) updateHole(c *gin.Context) {
 // @Param id path string true "Hole ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/holes/{id} [delete]
 func (h *Handler) deleteHole(c *gin.Context) {
@@ -136,8 +153,11 @@ func (h *Handler) deleteHole(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Holes.DeleteHole(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -153,6 +173,8 @@ func (h *Handler) deleteHole(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Hole ID"
 // @Success 200 {object} entities.Hole
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/holes/{id} [get]
 func (h *Handler) getHoleByID(c *gin.Context) {
@@ -163,10 +185,13 @@ func (h *Handler) getHoleByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if hole, err = h.services.Holes.GetHoleByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, hole)
+	h.writeJSON(c, http.StatusOK, hole)
 }
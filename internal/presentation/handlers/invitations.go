@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// initInvitationsRoutes initializes the routes for the invitations API.
+func (h *Handler) initInvitationsRoutes(api *gin.RouterGroup) {
+	invitations := api.Group(
+		"/invitations",
+		h.authMiddleware.UserIdentity,
+		h.authMiddleware.RequirePermission(entities.ScopeOrganization, entities.PermissionManage),
+	)
+	{
+		invitations.GET("/", h.getInvitations)
+		invitations.POST("/", h.createInvitation)
+		invitations.DELETE("/:id", h.deleteInvitation)
+	}
+}
+
+// getInvitations retrieves the invitations of the organization.
+// @Summary Get Invitations
+// @Tags invitations
+// @Description Retrieves the invitations of the organization, newest first
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {array} entities.Invitation
+// @Failure 403 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/invitations [get]
+func (h *Handler) getInvitations(c *gin.Context) {
+	var inp requests.GetInvitationsRequest
+	var err error
+	var invitations []*entities.Invitation
+
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if invitations, err = h.services.Invitations.GetInvitations(c.Request.Context(), &inp); err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// createInvitation invites a person to the organization by email.
+// @Summary Create Invitation
+// @Tags invitations
+// @Description Emails a one-time sign up link that creates a user of the organization with the role. The link expires in 7 days
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param input body requests.CreateInvitationRequestBody true "Invitation input"
+// @Success 201 {object} entities.Invitation
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/invitations [post]
+func (h *Handler) createInvitation(c *gin.Context) {
+	var inp requests.CreateInvitationRequest
+	var err error
+	var invitation *entities.Invitation
+
+	if err = c.BindJSON(&inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.UserID, err = h.validateContextIDKey(c, values.UserIdCtx); err != nil {
+		return
+	}
+	if invitation, err = h.services.Invitations.CreateInvitation(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidRole) || errors.Is(err, domainErrors.ErrUserAlreadyExists) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// deleteInvitation revokes an invitation of the organization.
+// @Summary Delete Invitation
+// @Tags invitations
+// @Description Revokes an invitation of the organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/invitations/{id} [delete]
+func (h *Handler) deleteInvitation(c *gin.Context) {
+	var inp requests.DeleteInvitationRequest
+	var err error
+
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if err = h.services.Invitations.DeleteInvitation(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, helpers.NewResponse("invitation deleted"))
+}
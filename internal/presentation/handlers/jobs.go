@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// jobEventsKeepAlive is how often a comment is sent on an idle event stream, so proxies keep it open
+const jobEventsKeepAlive = 15 * time.Second
+
+// initJobsRoutes initializes the routes for asynchronous calculation jobs. The role of the user on the case
+// of a job is checked by the service, the job ID is not an ID of the hierarchy.
+func (h *Handler) initJobsRoutes(api *gin.RouterGroup) {
+	jobs := api.Group("/jobs", h.authMiddleware.UserIdentity)
+	{
+		jobs.GET("/", h.getJobs)
+		jobs.POST("/", h.createJob)
+		jobs.GET("/:id", h.getJobByID)
+		jobs.DELETE("/:id", h.cancelJob)
+	}
+
+	jobEvents := api.Group("/jobs", h.authMiddleware.EventStreamIdentity)
+	{
+		jobEvents.GET("/:id/events", h.streamJobEvents)
+	}
+}
+
+// getJobs retrieves the calculation jobs of a case.
+// @Summary Get Jobs
+// @Tags jobs
+// @Description Retrieves the calculation jobs of the case without their results, newest first
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param caseId query string true "Case ID"
+// @Success 200 {array} entities.Job
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/jobs [get]
+func (h *Handler) getJobs(c *gin.Context) {
+	var inp requests.GetJobsRequest
+	var err error
+	var jobs []*entities.Job
+
+	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
+		return
+	}
+	if err = h.jobRequester(c, &inp.JobRequester); err != nil {
+		return
+	}
+
+	if jobs, err = h.services.Jobs.GetJobs(c.Request.Context(), &inp); err != nil {
+		h.newJobErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// createJob queues a calculation of a case.
+// @Summary Create Job
+// @Tags jobs
+// @Description Queues a torque and drag calculation of the case and returns the job at once. Poll the job
+// @Description until it succeeds, fails or is cancelled; failed attempts are retried. Friction sensitivity
+// @Description jobs need the friction factor ranges
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param input body requests.CreateJobRequestBody true "Calculation"
+// @Success 202 {object} responses.JobResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/jobs [post]
+func (h *Handler) createJob(c *gin.Context) {
+	var inp requests.CreateJobRequest
+	var err error
+	var job *responses.JobResponse
+
+	if err = h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if err = h.jobRequester(c, &inp.JobRequester); err != nil {
+		return
+	}
+
+	if job, err = h.services.Jobs.CreateJob(c.Request.Context(), &inp); err != nil {
+		h.newJobErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusAccepted, job)
+}
+
+// getJobByID retrieves a calculation job with its result.
+// @Summary Get Job by ID
+// @Tags jobs
+// @Description Retrieves the job. Succeeded jobs have the result of the calculation, in the response format
+// @Description of the synchronous torque and drag endpoint of the same type; failed jobs have the error
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Job ID"
+// @Success 200 {object} responses.JobResponse
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/jobs/{id} [get]
+func (h *Handler) getJobByID(c *gin.Context) {
+	var inp requests.GetJobByIDRequest
+	var err error
+	var job *responses.JobResponse
+
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if err = h.jobRequester(c, &inp.JobRequester); err != nil {
+		return
+	}
+
+	if job, err = h.services.Jobs.GetJobByID(c.Request.Context(), &inp); err != nil {
+		h.newJobErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, job)
+}
+
+// cancelJob cancels a queued or running calculation job.
+// @Summary Cancel Job
+// @Tags jobs
+// @Description Cancels a queued job or stops a running one
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Job ID"
+// @Success 200 {object} responses.JobResponse
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 409 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/jobs/{id} [delete]
+func (h *Handler) cancelJob(c *gin.Context) {
+	var inp requests.CancelJobRequest
+	var err error
+	var job *responses.JobResponse
+
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if err = h.jobRequester(c, &inp.JobRequester); err != nil {
+		return
+	}
+
+	if job, err = h.services.Jobs.CancelJob(c.Request.Context(), &inp); err != nil {
+		h.newJobErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, job)
+}
+
+// streamJobEvents streams the events of a calculation job.
+// @Summary Stream Job Events
+// @Tags jobs
+// @Description Streams the events of the job as server-sent events: "status" with the job whenever it changes,
+// @Description "progress" with the number of finished friction sensitivity curves, "partial" with every
+// @Description finished curve, "result" with the result of a succeeded job in batches of depths and the final
+// @Description "succeeded", "failed" or "cancelled" event with the job, after which the stream is closed.
+// @Description Browsers may pass the token in the access_token query parameter and the case in caseId to get
+// @Description its active units
+// @Produce text/event-stream
+// @Param Authorization header string false "Bearer token"
+// @Param access_token query string false "Access token, if the Authorization header is not set"
+// @Param caseId query string false "Case ID, its active units are used"
+// @Param id path string true "Job ID"
+// @Success 200 {object} responses.JobEvent
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/jobs/{id}/events [get]
+func (h *Handler) streamJobEvents(c *gin.Context) {
+	var inp requests.SubscribeJobRequest
+	var err error
+
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if err = h.jobRequester(c, &inp.JobRequester); err != nil {
+		return
+	}
+	if inp.Units, err = h.unitSystem(c); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, err := h.services.Jobs.SubscribeJob(c.Request.Context(), &inp)
+	if err != nil {
+		h.newJobErrorResponse(c, err)
+		return
+	}
+
+	// The stream outlives the write timeout of the server
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	keepAlive := time.NewTicker(jobEventsKeepAlive)
+	defer keepAlive.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Name, event.Data)
+		case <-keepAlive.C:
+			_, _ = io.WriteString(w, ": keep-alive\n\n")
+		}
+		return true
+	})
+}
+
+// jobRequester fills in the organization, user and API key of the request.
+func (h *Handler) jobRequester(c *gin.Context, requester *requests.JobRequester) error {
+	var err error
+	if requester.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return err
+	}
+	if requester.UserID, err = h.validateContextIDKey(c, values.UserIdCtx); err != nil {
+		return err
+	}
+	if value, ok := c.Get(values.ApiKeyCtx); ok {
+		key := value.(*entities.ApiKey)
+		requester.ApiKeyScope, requester.ApiKeyCompanyID = key.Scope, key.CompanyID
+	}
+	return nil
+}
+
+func (h *Handler) newJobErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domainErrors.ErrPermissionDenied):
+		helpers.NewErrorResponse(c, http.StatusForbidden, err.Error())
+	case errors.Is(err, domainErrors.ErrTooManySensitivityRuns):
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domainErrors.ErrJobFinished):
+		helpers.NewErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		h.newServiceErrorResponse(c, err)
+	}
+}
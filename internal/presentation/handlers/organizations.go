@@ -13,7 +13,7 @@ import (
 
 // initOrganizationsRoutes initializes the routes for the organizations API.
 func (h *Handler) initOrganizationsRoutes(api *gin.RouterGroup) {
-	organizations := api.Group("/organizations")
+	organizations := api.Group("/organizations", h.authMiddleware.UserIdentity, h.authMiddleware.SuperAdmin)
 	{
 		organizations.GET("/", h.getOrganizations)
 		organizations.POST("/", h.createOrganization)
@@ -28,7 +28,9 @@ func (h *Handler) initOrganizationsRoutes(api *gin.RouterGroup) {
 // @Description Retrieve all organizations
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer token"
 // @Success 200 {array} entities.Organization
+// @Failure 403 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/organizations [get]
 func (h *Handler) getOrganizations(c *gin.Context) {
@@ -46,9 +48,11 @@ func (h *Handler) getOrganizations(c *gin.Context) {
 // @Description Create a new organization
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer token"
 // @Param input body requests.CreateOrganizationRequest true "Organization details"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/organizations [post]
 func (h *Handler) createOrganization(c *gin.Context) {
@@ -73,10 +77,12 @@ func (h *Handler) createOrganization(c *gin.Context) {
 // @Description Update an existing organization
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer token"
 // @Param id path string true "Organization ID"
 // @Param input body requests.UpdateOrganizationRequest true "Updated organization details"
 // @Success 200 {object} entities.Organization
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/organizations/{id} [put]
 func (h *Handler) updateOrganization(c *gin.Context) {
@@ -103,9 +109,11 @@ func (h *Handler) updateOrganization(c *gin.Context) {
 // @Description Delete an organization
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer token"
 // @Param id path string true "Organization ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/organizations/{id} [delete]
 func (h *Handler) deleteOrganization(c *gin.Context) {
@@ -128,8 +136,10 @@ func (h *Handler) deleteOrganization(c *gin.Context) {
 // @Description Retrieve an organization by its name
 // @Accept json
 // @Produce json
+// @Param Authorization header string true "Bearer token"
 // @Param name path string true "Organization name"
 // @Success 200 {object} entities.Organization
+// @Failure 403 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/organizations/{name} [get]
 func (h *Handler) getOrganizationByName(c *gin.Context) {
@@ -1,24 +1,29 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	appErrors "github.com/munaiplan/munaiplan-backend/internal/application/types/errors"
 	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
 	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
 	"github.com/munaiplan/munaiplan-backend/pkg/values"
 )
 
 // initPorePressureRoutes initializes the routes for the pore pressure API.
 func (h *Handler) initPorePressureRoutes(api *gin.RouterGroup) {
-	porePressures := api.Group("/pore-pressures", h.authMiddleware.UserIdentity)
+	porePressures := api.Group("/pore-pressures", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopePorePressure, entities.PermissionWrite))
 	{
 		porePressures.GET("/", h.getPorePressures)
 		porePressures.POST("/", h.createPorePressure)
 		porePressures.GET("/:id", h.getPorePressureByID)
 		porePressures.PUT("/:id", h.updatePorePressure)
 		porePressures.DELETE("/:id", h.deletePorePressure)
+		porePressures.POST("/eaton", h.estimatePorePressure)
 	}
 }
 
@@ -31,6 +36,8 @@ func (h *Handler) initPorePressureRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param caseId query string true "Case ID"
 // @Success 200 {array} entities.PorePressure
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/pore-pressures [get]
 func (h *Handler) getPorePressures(c *gin.Context) {
@@ -41,13 +48,16 @@ func (h *Handler) getPorePressures(c *gin.Context) {
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if porePressures, err = h.services.PorePressures.GetPorePressures(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, porePressures)
+	h.writeJSON(c, http.StatusOK, porePressures)
 }
 
 // createPorePressure creates a new pore pressure record.
@@ -60,12 +70,14 @@ func (h *Handler) getPorePressures(c *gin.Context) {
 // @Param input body requests.CreatePorePressureRequest true "Pore Pressure input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/pore-pressures [post]
 func (h *Handler) createPorePressure(c *gin.Context) {
 	var inp requests.CreatePorePressureRequest
 	var err error
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, "Invalid request body")
 		return
 	}
@@ -73,9 +85,12 @@ func (h *Handler) createPorePressure(c *gin.Context) {
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if err = h.services.PorePressures.CreatePorePressure(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -93,27 +108,32 @@ func (h *Handler) createPorePressure(c *gin.Context) {
 // @Param input body requests.UpdatePorePressureRequest true "Pore Pressure input"
 // @Success 200 {object} entities.PorePressure
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/pore-pressures/{id} [put]
 func (h *Handler) updatePorePressure(c *gin.Context) {
 	var inp requests.UpdatePorePressureRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	updatedPorePressure, err := h.services.PorePressures.UpdatePorePressure(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, updatedPorePressure)
+	h.writeJSON(c, http.StatusOK, updatedPorePressure)
 }
 
 // deletePorePressure deletes a pore pressure record.
@@ -126,6 +146,8 @@ func (h *Handler) updatePorePressure(c *gin.Context) {
 // @Param id path string true "Pore Pressure ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/pore-pressures/{id} [delete]
 func (h *Handler) deletePorePressure(c *gin.Context) {
@@ -135,8 +157,11 @@ func (h *Handler) deletePorePressure(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err := h.services.PorePressures.DeletePorePressure(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -152,6 +177,8 @@ func (h *Handler) deletePorePressure(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Pore Pressure ID"
 // @Success 200 {object} entities.PorePressure
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/pore-pressures/{id} [get]
 func (h *Handler) getPorePressureByID(c *gin.Context) {
@@ -161,12 +188,69 @@ func (h *Handler) getPorePressureByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	porePressure, err := h.services.PorePressures.GetPorePressureByID(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, porePressure)
+}
+
+// estimatePorePressure estimates the pore and fracture pressure of a case from a well log.
+// @Summary Estimate Pore Pressure
+// @Tags pore-pressures
+// @Description Runs the Eaton method on the sonic or deep resistivity curve of a log of the wellbore of the case
+// @Description against a normal compaction trend picked through two points. The log depths are converted to true
+// @Description vertical depths along the trajectory of the case, the overburden is integrated from the density
+// @Description curve or the Gardner density of the sonic curve. With save the binned result replaces the pore
+// @Description pressures and fracture gradients of the case, existing ones need replace
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param caseId query string true "Case ID"
+// @Param input body requests.EstimatePorePressureRequestBody true "Log, method, normal compaction trend and options"
+// @Success 200 {object} responses.PorePressureEstimateResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 409 {object} helpers.Response
+// @Failure 422 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/pore-pressures/eaton [post]
+func (h *Handler) estimatePorePressure(c *gin.Context) {
+	var inp requests.EstimatePorePressureRequest
+	var err error
+	var result *responses.PorePressureEstimateResponse
+
+	if err = h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	if result, err = h.services.WellLogs.EstimatePorePressure(c.Request.Context(), &inp); err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrInvalidNormalTrend):
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, appErrors.ErrAlreadyExists):
+			helpers.NewErrorResponse(c, http.StatusConflict, "the case already has pore pressures or fracture gradients, set replace to overwrite them")
+		case errors.Is(err, domainErrors.ErrWellLogCurveMissing), errors.Is(err, domainErrors.ErrNoWellLogSamples):
+			helpers.NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		default:
+			h.newServiceErrorResponse(c, err)
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, porePressure)
+	h.writeJSON(c, http.StatusOK, result)
 }
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// initPositionUncertaintyRoutes initializes the routes for the position uncertainty API.
+func (h *Handler) initPositionUncertaintyRoutes(api *gin.RouterGroup) {
+	uncertainty := api.Group("/position-uncertainty", h.authMiddleware.UserIdentity, h.authMiddleware.RequirePermission(entities.ScopeTrajectory, entities.PermissionRead))
+	{
+		uncertainty.POST("/", h.calculatePositionUncertainty)
+	}
+}
+
+// calculatePositionUncertainty calculates the uncertainty ellipses along a trajectory.
+// @Summary Position Uncertainty
+// @Tags position-uncertainty
+// @Description Propagates the error models of the survey program and returns the uncertainty ellipse at every station
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param trajectoryId query string true "Trajectory ID"
+// @Param input body requests.PositionUncertaintyRequestBody true "Calculation settings"
+// @Success 200 {object} responses.PositionUncertaintyResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/position-uncertainty [post]
+func (h *Handler) calculatePositionUncertainty(c *gin.Context) {
+	var inp requests.PositionUncertaintyRequest
+	var err error
+
+	if err = h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.TrajectoryID, err = h.validateQueryIDParam(c, values.TrajectoryIdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	result, err := h.services.PositionUncertainty.CalculatePositionUncertainty(c.Request.Context(), &inp)
+	if err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, result)
+}
@@ -13,7 +13,7 @@ import (
 
 // initRigsRoutes initializes the routes for the rigs API.
 func (h *Handler) initRigsRoutes(api *gin.RouterGroup) {
-	rigs := api.Group("/rigs", h.authMiddleware.UserIdentity)
+	rigs := api.Group("/rigs", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeRig, entities.PermissionWrite))
 	{
 		rigs.GET("/", h.getRigs)
 		rigs.POST("/", h.createRig)
@@ -32,6 +32,8 @@ func (h *Handler) initRigsRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param caseId query string true "Case ID"
 // @Success 200 {array} entities.Rig
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/rigs [get]
 func (h *Handler) getRigs(c *gin.Context) {
@@ -42,13 +44,16 @@ func (h *Handler) getRigs(c *gin.Context) {
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if rigs, err = h.services.Rigs.GetRigs(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, rigs)
+	h.writeJSON(c, http.StatusOK, rigs)
 }
 
 // createRig creates a new rig.
@@ -62,21 +67,26 @@ func (h *Handler) getRigs(c *gin.Context) {
 // @Param input body requests.CreateRigRequest true "Rig input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/rigs [post]
 func (h *Handler) createRig(c *gin.Context) {
 	var inp requests.CreateRigRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Rigs.CreateRig(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -92,6 +102,8 @@ func (h *Handler) createRig(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Rig ID"
 // @Success 200 {object} entities.Rig
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/rigs/{id} [get]
 func (h *Handler) getRigByID(c *gin.Context) {
@@ -102,12 +114,15 @@ func (h *Handler) getRigByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if rig, err = h.services.Rigs.GetRigByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, rig)
+	h.writeJSON(c, http.StatusOK, rig)
 }
 
 // updateRig updates an existing rig.
@@ -121,27 +136,32 @@ func (h *Handler) getRigByID(c *gin.Context) {
 // @Param input body requests.UpdateRigRequest true "Rig input"
 // @Success 200 {object} entities.Rig
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/rigs/{id} [put]
 func (h *Handler) updateRig(c *gin.Context) {
 	var inp requests.UpdateRigRequest
 	var err error
 
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	rig, err := h.services.Rigs.UpdateRig(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, rig)
+	h.writeJSON(c, http.StatusOK, rig)
 }
 
 // deleteRig deletes a rig by its ID.
@@ -154,6 +174,8 @@ func (h *Handler) updateRig(c *gin.Context) {
 // @Param id path string true "Rig ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/rigs/{id} [delete]
 func (h *Handler) deleteRig(c *gin.Context) {
@@ -163,8 +185,11 @@ func (h *Handler) deleteRig(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Rigs.DeleteRig(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// initRolesRoutes initializes the routes for the role assignments API.
+func (h *Handler) initRolesRoutes(api *gin.RouterGroup) {
+	roles := api.Group("/roles", h.authMiddleware.UserIdentity)
+	{
+		roles.GET("/me", h.getMyRoleAssignments)
+		roles.GET("/", h.authMiddleware.RequirePermission(entities.ScopeOrganization, entities.PermissionManage), h.getRoleAssignments)
+		roles.POST("/", h.authMiddleware.RequirePermission(entities.ScopeOrganization, entities.PermissionManage), h.createRoleAssignment)
+		roles.DELETE("/:id", h.authMiddleware.RequirePermission(entities.ScopeOrganization, entities.PermissionManage), h.deleteRoleAssignment)
+	}
+}
+
+// getMyRoleAssignments retrieves the role assignments of the current user.
+// @Summary Get My Role Assignments
+// @Tags roles
+// @Description Retrieves the role assignments of the current user in their organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {array} entities.RoleAssignment
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/roles/me [get]
+func (h *Handler) getMyRoleAssignments(c *gin.Context) {
+	var inp requests.GetRoleAssignmentsRequest
+	var err error
+	var assignments []*entities.RoleAssignment
+
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.UserID, err = h.validateContextIDKey(c, values.UserIdCtx); err != nil {
+		return
+	}
+	if assignments, err = h.services.Roles.GetRoleAssignments(c.Request.Context(), &inp); err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// getRoleAssignments retrieves the role assignments of the organization.
+// @Summary Get Role Assignments
+// @Tags roles
+// @Description Retrieves the role assignments of the organization, optionally of one user
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param userId query string false "User ID"
+// @Success 200 {array} entities.RoleAssignment
+// @Failure 403 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/roles [get]
+func (h *Handler) getRoleAssignments(c *gin.Context) {
+	var inp requests.GetRoleAssignmentsRequest
+	var err error
+	var assignments []*entities.RoleAssignment
+
+	if c.Query(values.UserIdQueryParam) != "" {
+		if inp.UserID, err = h.validateQueryIDParam(c, values.UserIdQueryParam); err != nil {
+			return
+		}
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if assignments, err = h.services.Roles.GetRoleAssignments(c.Request.Context(), &inp); err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
+
+// createRoleAssignment assigns a role to a user of the organization.
+// @Summary Create Role Assignment
+// @Tags roles
+// @Description Assigns a role (org_admin, engineer or viewer) for the whole organization or only for a company or a field
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param input body requests.CreateRoleAssignmentRequestBody true "Role assignment input"
+// @Success 201 {object} entities.RoleAssignment
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/roles [post]
+func (h *Handler) createRoleAssignment(c *gin.Context) {
+	var inp requests.CreateRoleAssignmentRequest
+	var err error
+	var assignment *entities.RoleAssignment
+
+	if err = c.BindJSON(&inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if assignment, err = h.services.Roles.CreateRoleAssignment(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidRole) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, assignment)
+}
+
+// deleteRoleAssignment deletes a role assignment of the organization.
+// @Summary Delete Role Assignment
+// @Tags roles
+// @Description Deletes a role assignment of the organization
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Role assignment ID"
+// @Success 200 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/roles/{id} [delete]
+func (h *Handler) deleteRoleAssignment(c *gin.Context) {
+	var inp requests.DeleteRoleAssignmentRequest
+	var err error
+
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if err = h.services.Roles.DeleteRoleAssignment(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, helpers.NewResponse("role assignment deleted"))
+}
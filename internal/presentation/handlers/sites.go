@@ -13,7 +13,7 @@ import (
 
 // initSitesRoutes initializes the routes for the sites API.
 func (h *Handler) initSitesRoutes(api *gin.RouterGroup) {
-	sites := api.Group("/sites", h.authMiddleware.UserIdentity)
+	sites := api.Group("/sites", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeSite, entities.PermissionWrite))
 	{
 		sites.GET("/", h.getSites)
 		sites.POST("/", h.createSite)
@@ -32,6 +32,8 @@ func (h *Handler) initSitesRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param fieldId query string true "Field ID"
 // @Success 200 {array} entities.Site
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/sites [get]
 func (h *Handler) getSites(c *gin.Context) {
@@ -40,14 +42,17 @@ func (h *Handler) getSites(c *gin.Context) {
 	if inp.FieldID, err = h.validateQueryIDParam(c, values.FieldIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	sites, err := h.services.Sites.GetSites(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, sites)
+	h.writeJSON(c, http.StatusOK, sites)
 }
 
 // createSite creates a new site.
@@ -61,21 +66,26 @@ func (h *Handler) getSites(c *gin.Context) {
 // @Param input body requests.CreateSiteRequest true "Site input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/sites [post]
 func (h *Handler) createSite(c *gin.Context) {
 	var inp requests.CreateSiteRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.FieldID, err = h.validateQueryIDParam(c, values.FieldIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Sites.CreateSite(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -93,26 +103,31 @@ func (h *Handler) createSite(c *gin.Context) {
 // @Param input body requests.UpdateSiteRequest true "Site input"
 // @Success 200 {object} entities.Site
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/sites/{id} [put]
 func (h *Handler) updateSite(c *gin.Context) {
 	var inp requests.UpdateSiteRequest
 	var err error
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	site, err := h.services.Sites.UpdateSite(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, site)
+	h.writeJSON(c, http.StatusOK, site)
 }
 
 // deleteSite deletes an existing site.
@@ -125,6 +140,8 @@ func (h *Handler) updateSite(c *gin.Context) {
 // @Param id path string true "Site ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/sites/{id} [delete]
 func (h *Handler) deleteSite(c *gin.Context) {
@@ -134,8 +151,11 @@ func (h *Handler) deleteSite(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Sites.DeleteSite(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -151,6 +171,8 @@ func (h *Handler) deleteSite(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Site ID"
 // @Success 200 {object} entities.Site
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/sites/{id} [get]
 func (h *Handler) getSiteByID(c *gin.Context) {
@@ -160,10 +182,13 @@ func (h *Handler) getSiteByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if site, err = h.services.Sites.GetSiteByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, site)
+	h.writeJSON(c, http.StatusOK, site)
 }
@@ -13,7 +13,7 @@ import (
 
 // initStringsRoutes initializes the routes for the strings API.
 func (h *Handler) initStringsRoutes(api *gin.RouterGroup) {
-	strings := api.Group("/strings", h.authMiddleware.UserIdentity)
+	strings := api.Group("/strings", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeString, entities.PermissionWrite))
 	{
 		strings.GET("/", h.getStrings)
 		strings.POST("/", h.createString)
@@ -32,6 +32,8 @@ func (h *Handler) initStringsRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param caseId query string true "Case ID"
 // @Success 200 {array} entities.String
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/strings [get]
 func (h *Handler) getStrings(c *gin.Context) {
@@ -41,13 +43,16 @@ func (h *Handler) getStrings(c *gin.Context) {
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if strings, err = h.services.Strings.GetStrings(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, strings)
+	h.writeJSON(c, http.StatusOK, strings)
 }
 
 // createString creates a new string along with its sections.
@@ -61,21 +66,26 @@ func (h *Handler) getStrings(c *gin.Context) {
 // @Param input body requests.CreateStringRequestBody true "String input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/strings [post]
 func (h *Handler) createString(c *gin.Context) {
 	var inp requests.CreateStringRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Strings.CreateString(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -93,26 +103,31 @@ func (h *Handler) createString(c *gin.Context) {
 // @Param input body requests.UpdateStringRequestBody true "String input"
 // @Success 200 {object} entities.String
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/strings/{id} [put]
 func (h *Handler) updateString(c *gin.Context) {
 	var inp requests.UpdateStringRequest
 	var err error
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	stringEntity, err := h.services.Strings.UpdateString(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, stringEntity)
+	h.writeJSON(c, http.StatusOK, stringEntity)
 }
 
 // deleteString deletes an existing string.
@@ -125,6 +140,8 @@ func (h *Handler) updateString(c *gin.Context) {
 // @Param id path string true "String ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/strings/{id} [delete]
 func (h *Handler) deleteString(c *gin.Context) {
@@ -134,8 +151,11 @@ func (h *Handler) deleteString(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Strings.DeleteString(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -151,6 +171,8 @@ func (h *Handler) deleteString(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "String ID"
 // @Success 200 {object} entities.String
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/strings/{id} [get]
 func (h *Handler) getStringByID(c *gin.Context) {
@@ -160,10 +182,13 @@ func (h *Handler) getStringByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if stringEntity, err = h.services.Strings.GetStringByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, stringEntity)
+	h.writeJSON(c, http.StatusOK, stringEntity)
 }
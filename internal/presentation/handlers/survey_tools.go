@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// initSurveyToolsRoutes initializes the routes for the survey tools API.
+func (h *Handler) initSurveyToolsRoutes(api *gin.RouterGroup) {
+	surveyTools := api.Group("/survey-tools", h.authMiddleware.UserIdentity)
+	{
+		surveyTools.GET("/", h.getSurveyTools)
+		surveyTools.POST("/", h.authMiddleware.SuperAdmin, h.createSurveyTool)
+		surveyTools.GET("/:id", h.getSurveyToolByID)
+		surveyTools.PUT("/:id", h.authMiddleware.SuperAdmin, h.updateSurveyTool)
+		surveyTools.DELETE("/:id", h.authMiddleware.SuperAdmin, h.deleteSurveyTool)
+	}
+}
+
+// getSurveyTools retrieves all survey tools.
+// @Summary Get Survey Tools
+// @Tags survey-tools
+// @Description Retrieves all survey tools with their error models
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {array} entities.SurveyTool
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/survey-tools [get]
+func (h *Handler) getSurveyTools(c *gin.Context) {
+	tools, err := h.services.SurveyTools.GetSurveyTools(c.Request.Context())
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, tools)
+}
+
+// createSurveyTool creates a new survey tool.
+// @Summary Create Survey Tool
+// @Tags survey-tools
+// @Description Creates a new survey tool with its error model
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param input body requests.CreateSurveyToolRequestBody true "Survey tool input"
+// @Success 201 {object} helpers.Response
+// @Failure 400 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/survey-tools [post]
+func (h *Handler) createSurveyTool(c *gin.Context) {
+	var inp requests.CreateSurveyToolRequest
+
+	if err := h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if err := h.services.SurveyTools.CreateSurveyTool(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidErrorTerm) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, helpers.NewResponse("survey tool created"))
+}
+
+// getSurveyToolByID retrieves a survey tool by its ID.
+// @Summary Get Survey Tool by ID
+// @Tags survey-tools
+// @Description Retrieves a survey tool by its ID
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Survey Tool ID"
+// @Success 200 {object} entities.SurveyTool
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/survey-tools/{id} [get]
+func (h *Handler) getSurveyToolByID(c *gin.Context) {
+	var inp requests.GetSurveyToolByIDRequest
+	var err error
+	var tool *entities.SurveyTool
+
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if tool, err = h.services.SurveyTools.GetSurveyToolByID(c.Request.Context(), &inp); err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, tool)
+}
+
+// updateSurveyTool updates an existing survey tool.
+// @Summary Update Survey Tool
+// @Tags survey-tools
+// @Description Updates an existing survey tool, the error terms are replaced
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Survey Tool ID"
+// @Param input body requests.CreateSurveyToolRequestBody true "Survey tool input"
+// @Success 200 {object} entities.SurveyTool
+// @Failure 400 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/survey-tools/{id} [put]
+func (h *Handler) updateSurveyTool(c *gin.Context) {
+	var inp requests.UpdateSurveyToolRequest
+	var err error
+
+	if err := h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+
+	tool, err := h.services.SurveyTools.UpdateSurveyTool(c.Request.Context(), &inp)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidErrorTerm) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, tool)
+}
+
+// deleteSurveyTool deletes a survey tool by its ID.
+// @Summary Delete Survey Tool
+// @Tags survey-tools
+// @Description Deletes a survey tool that is not used by any survey program
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Survey Tool ID"
+// @Success 200 {object} helpers.Response
+// @Failure 400 {object} helpers.Response
+// @Failure 409 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/survey-tools/{id} [delete]
+func (h *Handler) deleteSurveyTool(c *gin.Context) {
+	var inp requests.DeleteSurveyToolRequest
+	var err error
+
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if err = h.services.SurveyTools.DeleteSurveyTool(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrSurveyToolInUse) {
+			helpers.NewErrorResponse(c, http.StatusConflict, err.Error())
+			return
+		}
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, helpers.NewResponse("survey tool deleted"))
+}
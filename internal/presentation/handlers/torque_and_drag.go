@@ -1,21 +1,27 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
 	"github.com/munaiplan/munaiplan-backend/pkg/values"
 )
 
 // initTorqueAndDragRoutes initializes routes for the Torque and Drag module.
 func (h *Handler) initTorqueAndDragRoutes(api *gin.RouterGroup) {
-	torqueAndDrag := api.Group("/torque-and-drag", h.authMiddleware.UserIdentity)
+	torqueAndDrag := api.Group("/torque-and-drag", h.authMiddleware.UserIdentity, h.authMiddleware.RequirePermission(entities.ScopeCase, entities.PermissionRead))
 	{
 		torqueAndDrag.POST("/effective-tension", h.calculateEffectiveTensionFromMLModel)
 		torqueAndDrag.POST("/weight-on-bit", h.calculateWeightOnBitFromMLModel)
 		torqueAndDrag.POST("/surface-torque", h.calculateMomentFromMLModel)
 		torqueAndDrag.POST("/min-weight", h.calculateMinWeightFromMLModel)
+		torqueAndDrag.POST("/friction-sensitivity", h.calculateFrictionSensitivity)
 	}
 }
 
@@ -29,6 +35,8 @@ func (h *Handler) initTorqueAndDragRoutes(api *gin.RouterGroup) {
 // @Param input body requests.EffectiveTensionRequest true "Effective Tension Input Data"
 // @Success 200 {object} entities.EffectiveTensionResponse
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/torque-and-drag/effective-tension [post]
 func (h *Handler) calculateEffectiveTensionFromMLModel(c *gin.Context) {
@@ -37,16 +45,20 @@ func (h *Handler) calculateEffectiveTensionFromMLModel(c *gin.Context) {
 		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	organizationID, err := h.validateContextIDKey(c, values.OrganizationIdCtx)
+	if err != nil {
+		return
+	}
 
 	// Call the service to calculate effective tension
-	result, err := h.services.TorqueAndDrag.CalculateEffectiveTensionFromMLModel(c.Request.Context(), caseID)
+	result, err := h.services.TorqueAndDrag.CalculateEffectiveTensionFromMLModel(c.Request.Context(), organizationID, caseID)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
 	// Respond with the result
-	c.JSON(http.StatusOK, result)
+	h.writeJSON(c, http.StatusOK, result)
 }
 
 // calculateWeightOnBitFromMLModel handles the calculation of Weight on Bit (WOB) based on input data.
@@ -59,6 +71,8 @@ func (h *Handler) calculateEffectiveTensionFromMLModel(c *gin.Context) {
 // @Param input body requests.WeightOnBitRequest true "Weight on Bit Input Data"
 // @Success 200 {object} entities.WeightOnBitResponse
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/torque-and-drag/weight-on-bit [post]
 func (h *Handler) calculateWeightOnBitFromMLModel(c *gin.Context) {
@@ -67,16 +81,20 @@ func (h *Handler) calculateWeightOnBitFromMLModel(c *gin.Context) {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	organizationID, err := h.validateContextIDKey(c, values.OrganizationIdCtx)
+	if err != nil {
+		return
+	}
 
 	// Call the service to calculate weight on bit
-	result, err := h.services.TorqueAndDrag.CalculateWeightOnBitFromMlModel(c.Request.Context(), caseID)
+	result, err := h.services.TorqueAndDrag.CalculateWeightOnBitFromMlModel(c.Request.Context(), organizationID, caseID)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
 	// Respond with the result
-	c.JSON(http.StatusOK, result)
+	h.writeJSON(c, http.StatusOK, result)
 }
 
 // calculateMomentFromMLModel handles the calculation of moment from the ML model.
@@ -89,6 +107,8 @@ func (h *Handler) calculateWeightOnBitFromMLModel(c *gin.Context) {
 // @Param input body requests.MomentRequest true "Moment Input Data"
 // @Success 200 {object} responses.MomentFromMLModelResponse
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/torque-and-drag/moment [post]
 func (h *Handler) calculateMomentFromMLModel(c *gin.Context) {
@@ -97,16 +117,20 @@ func (h *Handler) calculateMomentFromMLModel(c *gin.Context) {
 		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	organizationID, err := h.validateContextIDKey(c, values.OrganizationIdCtx)
+	if err != nil {
+		return
+	}
 
 	// Call the service to calculate the moment from the ML model
-	result, err := h.services.TorqueAndDrag.CalculateSurfaceTorqueFromMlModel(c.Request.Context(), caseID)
+	result, err := h.services.TorqueAndDrag.CalculateSurfaceTorqueFromMlModel(c.Request.Context(), organizationID, caseID)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
 	// Respond with the result
-	c.JSON(http.StatusOK, result)
+	h.writeJSON(c, http.StatusOK, result)
 }
 
 // calculateMinWeightFromMlModel handles the calculation of minimum weight using the ML model.
@@ -119,6 +143,8 @@ func (h *Handler) calculateMomentFromMLModel(c *gin.Context) {
 // @Param caseId query string true "Case ID"
 // @Success 200 {object} entities.MinWeightFromMLModelResponse
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/torque-and-drag/min-weight [post]
 func (h *Handler) calculateMinWeightFromMLModel(c *gin.Context) {
@@ -128,14 +154,64 @@ func (h *Handler) calculateMinWeightFromMLModel(c *gin.Context) {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	organizationID, err := h.validateContextIDKey(c, values.OrganizationIdCtx)
+	if err != nil {
+		return
+	}
 
 	// Call the service to calculate minimum weight
-	result, err := h.services.TorqueAndDrag.CalculateMinWeightFromMLModel(c.Request.Context(), caseID)
+	result, err := h.services.TorqueAndDrag.CalculateMinWeightFromMLModel(c.Request.Context(), organizationID, caseID)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
 	// Respond with the result
-	c.JSON(http.StatusOK, result)
+	h.writeJSON(c, http.StatusOK, result)
+}
+
+// calculateFrictionSensitivity handles the friction factor sensitivity sweep of a case.
+// @Summary Calculate Friction Factor Sensitivity
+// @Tags torque-and-drag
+// @Description Runs torque and drag for every combination of the open hole and cased hole friction factor ranges
+// @Description and returns the trip in, trip out and rotating hook load and rotating torque curves (broomstick chart).
+// @Description Depths down to the casing shoe use the cased hole factor. At most 100 combinations per request
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param caseId query string true "Case ID"
+// @Param input body requests.FrictionSensitivityRequestBody true "Friction factor ranges"
+// @Success 200 {object} responses.FrictionSensitivityResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 422 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/torque-and-drag/friction-sensitivity [post]
+func (h *Handler) calculateFrictionSensitivity(c *gin.Context) {
+	var inp requests.FrictionSensitivityRequest
+	var err error
+
+	if err = h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	result, err := h.services.TorqueAndDrag.CalculateFrictionSensitivity(c.Request.Context(), &inp)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrTooManySensitivityRuns) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, result)
 }
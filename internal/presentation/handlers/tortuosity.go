@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// initTortuosityRoutes initializes the routes for the tortuosity API.
+func (h *Handler) initTortuosityRoutes(api *gin.RouterGroup) {
+	tortuosity := api.Group("/tortuosity", h.authMiddleware.UserIdentity)
+	{
+		tortuosity.POST("/report", h.authMiddleware.RequirePermission(entities.ScopeTrajectory, entities.PermissionRead), h.getTortuosityReport)
+		tortuosity.POST("/synthetic", h.authMiddleware.RequirePermission(entities.ScopeTrajectory, entities.PermissionWrite), h.applySyntheticTortuosity)
+	}
+}
+
+// getTortuosityReport analyses dogleg severity and tortuosity of a trajectory.
+// @Summary Tortuosity Report
+// @Tags tortuosity
+// @Description Returns DLS per 30 m and 100 ft, cumulative and micro tortuosity, DLS limit exceedances per hole section and the extra side force estimate
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param trajectoryId query string true "Trajectory ID"
+// @Param input body requests.TortuosityReportRequestBody true "Report settings"
+// @Success 200 {object} responses.TortuosityReportResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/tortuosity/report [post]
+func (h *Handler) getTortuosityReport(c *gin.Context) {
+	var inp requests.TortuosityReportRequest
+	var err error
+
+	if err = h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.TrajectoryID, err = h.validateQueryIDParam(c, values.TrajectoryIdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	result, err := h.services.Tortuosity.GetTortuosityReport(c.Request.Context(), &inp)
+	if err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, result)
+}
+
+// applySyntheticTortuosity creates a copy of a trajectory with synthetic tortuosity.
+// @Summary Apply Synthetic Tortuosity
+// @Tags tortuosity
+// @Description Saves a copy of a planned trajectory with sinusoidal or random tortuosity in the same design
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param trajectoryId query string true "Trajectory ID"
+// @Param input body requests.SyntheticTortuosityRequestBody true "Tortuosity settings"
+// @Success 201 {object} helpers.Response
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/tortuosity/synthetic [post]
+func (h *Handler) applySyntheticTortuosity(c *gin.Context) {
+	var inp requests.SyntheticTortuosityRequest
+	var err error
+
+	if err = h.bindJSON(c, &inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
+		return
+	}
+	if inp.TrajectoryID, err = h.validateQueryIDParam(c, values.TrajectoryIdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	if err = h.services.Tortuosity.ApplySyntheticTortuosity(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, helpers.NewResponse("trajectory with synthetic tortuosity created"))
+}
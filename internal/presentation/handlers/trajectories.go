@@ -13,13 +13,14 @@ import (
 
 // initTrajectoriesRoutes initializes the routes for the trajectories API.
 func (h *Handler) initTrajectoriesRoutes(api *gin.RouterGroup) {
-	trajectories := api.Group("/trajectories", h.authMiddleware.UserIdentity)
+	trajectories := api.Group("/trajectories", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeTrajectory, entities.PermissionWrite))
 	{
 		trajectories.GET("/", h.getTrajectories)
 		trajectories.POST("/", h.createTrajectory)
 		trajectories.GET("/:id", h.getTrajectoryByID)
 		trajectories.PUT("/:id", h.updateTrajectory)
 		trajectories.DELETE("/:id", h.deleteTrajectory)
+		trajectories.GET("/:id/charts/:chart", h.getTrajectoryChart)
 	}
 }
 
@@ -32,6 +33,8 @@ func (h *Handler) initTrajectoriesRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param designId query string true "Design ID"
 // @Success 200 {array} entities.Trajectory
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/trajectories [get]
 func (h *Handler) getTrajectories(c *gin.Context) {
@@ -42,13 +45,16 @@ func (h *Handler) getTrajectories(c *gin.Context) {
 	if inp.DesignID, err = h.validateQueryIDParam(c, values.DesignIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	if trajectories, err = h.services.Trajectories.GetTrajectories(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, trajectories)
+	h.writeJSON(c, http.StatusOK, trajectories)
 }
 
 // createTrajectory creates a new trajectory.
@@ -62,21 +68,26 @@ func (h *Handler) getTrajectories(c *gin.Context) {
 // @Param input body requests.CreateTrajectoryRequest true "Trajectory input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/trajectories [post]
 func (h *Handler) createTrajectory(c *gin.Context) {
 	var inp requests.CreateTrajectoryRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.DesignID, err = h.validateQueryIDParam(c, values.DesignIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Trajectories.CreateTrajectory(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -94,27 +105,32 @@ func (h *Handler) createTrajectory(c *gin.Context) {
 // @Param input body requests.UpdateTrajectoryRequest true "Trajectory input"
 // @Success 200 {object} entities.Trajectory
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/trajectories/{id} [put]
 func (h *Handler) updateTrajectory(c *gin.Context) {
 	var inp requests.UpdateTrajectoryRequest
 	var err error
 
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	trajectory, err := h.services.Trajectories.UpdateTrajectory(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, trajectory)
+	h.writeJSON(c, http.StatusOK, trajectory)
 }
 
 // deleteTrajectory deletes an existing trajectory.
@@ -127,6 +143,8 @@ func (h *Handler) updateTrajectory(c *gin.Context) {
 // @Param id path string true "Trajectory ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/trajectories/{id} [delete]
 func (h *Handler) deleteTrajectory(c *gin.Context) {
@@ -136,8 +154,11 @@ func (h *Handler) deleteTrajectory(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Trajectories.DeleteTrajectory(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -153,6 +174,8 @@ func (h *Handler) deleteTrajectory(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Trajectory ID"
 // @Success 200 {object} entities.Trajectory
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/trajectories/{id} [get]
 func (h *Handler) getTrajectoryByID(c *gin.Context) {
@@ -163,10 +186,60 @@ func (h *Handler) getTrajectoryByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if trajectory, err = h.services.Trajectories.GetTrajectoryByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, trajectory)
+}
+
+// getTrajectoryChart renders a chart of a trajectory as an SVG image.
+// @Summary Get Trajectory Chart
+// @Tags trajectories
+// @Description Renders the plan-view or the vertical-section of the trajectory in the unit system of the request
+// @Produce image/svg+xml
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Trajectory ID"
+// @Param chart path string true "Chart" Enums(plan-view, vertical-section)
+// @Param width query int false "Width in pixels, 800 by default"
+// @Param height query int false "Height in pixels, 500 by default"
+// @Success 200 {file} file
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/trajectories/{id}/charts/{chart} [get]
+func (h *Handler) getTrajectoryChart(c *gin.Context) {
+	var inp requests.GetTrajectoryChartRequest
+	var err error
+
+	if err = c.ShouldBindQuery(&inp.Query); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if inp.TrajectoryID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.Chart, err = h.validateRequestParam(c, values.ChartQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+	if inp.Units, err = h.unitSystem(c); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ch, err := h.services.Charts.GetTrajectoryChart(c.Request.Context(), &inp)
+	if err != nil {
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, trajectory)
+	h.writeChart(c, ch, inp.Query)
 }
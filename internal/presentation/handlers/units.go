@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// unitScopeQueryParams lists the parent ID query parameters from the most to the least specific.
+var unitScopeQueryParams = []struct {
+	param string
+	scope string
+}{
+	{param: values.CaseIdQueryParam, scope: entities.ScopeCase},
+	{param: values.TrajectoryIdQueryParam, scope: entities.ScopeTrajectory},
+	{param: values.DesignIdQueryParam, scope: entities.ScopeDesign},
+	{param: values.WellboreIdQueryParam, scope: entities.ScopeWellbore},
+	{param: values.WellIdQueryParam, scope: entities.ScopeWell},
+	{param: values.SiteIdQueryParam, scope: entities.ScopeSite},
+	{param: values.FieldIdQueryParam, scope: entities.ScopeField},
+}
+
+// unitScopeRouteGroups maps the route groups with an :id path parameter to the entity it identifies.
+var unitScopeRouteGroups = map[string]string{
+	"fields":             entities.ScopeField,
+	"sites":              entities.ScopeSite,
+	"wells":              entities.ScopeWell,
+	"wellbores":          entities.ScopeWellbore,
+	"designs":            entities.ScopeDesign,
+	"trajectories":       entities.ScopeTrajectory,
+	"cases":              entities.ScopeCase,
+	"holes":              entities.ScopeHole,
+	"fluids":             entities.ScopeFluid,
+	"rigs":               entities.ScopeRig,
+	"strings":            entities.ScopeString,
+	"pore-pressures":     entities.ScopePorePressure,
+	"fracture-gradients": entities.ScopeFractureGradient,
+}
+
+// initUnitsRoutes initializes the routes for the units API.
+func (h *Handler) initUnitsRoutes(api *gin.RouterGroup) {
+	unitSystems := api.Group("/units", h.authMiddleware.UserIdentity)
+	{
+		unitSystems.GET("/", h.getUnitSystems)
+	}
+}
+
+// getUnitSystems retrieves the supported unit systems.
+// @Summary Get Unit Systems
+// @Tags units
+// @Description Retrieves the unit systems accepted in the Accept-Units header and as active field or well units
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {array} responses.UnitSystemResponse
+// @Router /api/v1/units [get]
+func (h *Handler) getUnitSystems(c *gin.Context) {
+	c.JSON(http.StatusOK, h.services.Units.GetUnitSystems(c.Request.Context()))
+}
+
+// unitSystem returns the unit system of the request. The Accept-Units header wins, otherwise the active
+// unit system of the well or field the addressed entity belongs to is used.
+func (h *Handler) unitSystem(c *gin.Context) (*units.System, error) {
+	if system, ok := c.Get(values.UnitSystemCtx); ok {
+		return system.(*units.System), nil
+	}
+
+	system, err := h.resolveUnitSystem(c)
+	if err != nil {
+		return nil, err
+	}
+	c.Set(values.UnitSystemCtx, system)
+	return system, nil
+}
+
+func (h *Handler) resolveUnitSystem(c *gin.Context) (*units.System, error) {
+	if header := c.GetHeader(values.AcceptUnitsHeader); header != "" {
+		return units.Lookup(header)
+	}
+
+	scope, id := "", ""
+	if group, ok := unitScopeRouteGroups[routeGroup(c.FullPath())]; ok && c.Param(values.IdQueryParam) != "" {
+		scope, id = group, c.Param(values.IdQueryParam)
+	} else {
+		for _, param := range unitScopeQueryParams {
+			if value := c.Query(param.param); value != "" {
+				scope, id = param.scope, value
+				break
+			}
+		}
+	}
+	if scope == "" {
+		return units.Canonical, nil
+	}
+
+	system, err := h.services.Units.ResolveUnitSystem(c.Request.Context(), scope, id)
+	if err != nil {
+		// The handler reports a missing entity itself, its payload stays in canonical units
+		return units.Canonical, nil
+	}
+	return system, nil
+}
+
+// routeGroup returns the first path segment after the API version, e.g. "wells" for /api/v1/wells/:id.
+func routeGroup(fullPath string) string {
+	parts := strings.Split(strings.Trim(fullPath, "/"), "/")
+	for i, part := range parts {
+		if part == "v1" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// bindJSON binds the request body and converts its quantities to canonical units.
+func (h *Handler) bindJSON(c *gin.Context, obj any) error {
+	system, err := h.unitSystem(c)
+	if err != nil {
+		return err
+	}
+	if err := c.BindJSON(obj); err != nil {
+		return err
+	}
+
+	system.ToCanonical(obj)
+	return nil
+}
+
+// writeJSON converts the quantities of obj to the unit system of the request and writes it.
+func (h *Handler) writeJSON(c *gin.Context, code int, obj any) {
+	system, err := h.unitSystem(c)
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.Header(values.ContentUnitsHeader, system.Name)
+	c.JSON(code, system.FromCanonical(obj))
+}
@@ -17,36 +17,44 @@ func (h *Handler) initUsersRoutes(api *gin.RouterGroup) {
 	{
 		users.POST("/sign-in", h.signIn)
 		users.POST("/sign-up", h.signUp)
+		users.POST("/refresh", h.authMiddleware.RefreshTokenIdentity, h.refresh)
+		users.POST("/sign-out", h.authMiddleware.UserIdentity, h.authMiddleware.RejectApiKey, h.signOut)
+		users.POST("/sign-out-all", h.authMiddleware.UserIdentity, h.authMiddleware.RejectApiKey, h.signOutAll)
+		users.POST("/password-reset", h.requestPasswordReset)
+		users.POST("/password-reset/confirm", h.resetPassword)
+		users.GET("/me", h.authMiddleware.UserIdentity, h.getProfile)
+		users.PUT("/me", h.authMiddleware.UserIdentity, h.authMiddleware.RejectApiKey, h.updateProfile)
+		users.PUT("/me/password", h.authMiddleware.UserIdentity, h.authMiddleware.RejectApiKey, h.changePassword)
 	}
 }
 
 // signUp handles the user sign up request.
 // @Summary User SignUp
 // @Tags users-auth
-// @Description user sign up
+// @Description user sign up with an invitation token. The email, the organization and the role come from the invitation
 // @ModuleID userSignUp
 // @Accept  json
 // @Produce  json
-// @Param organizationId query string true "Organization ID"
-// @Param input body requests.UserSignUpRequest true "sign up info"
+// @Param input body requests.UserSignUpRequestBody true "sign up info"
 // @Success 201 {object} helpers.Response
 // @Failure 400,500 {object} helpers.Response
 // @Failure default {object} helpers.Response
 // @Router /api/v1/users/sign-up [post]
 func (h *Handler) signUp(c *gin.Context) {
 	var inp requests.UserSignUpRequest
-	var err error
-	if inp.OrganizationID, err = h.validateQueryIDParam(c, values.OrganizationIdQueryParam); err != nil {		
-		return
-	}
 
 	if err := c.BindJSON(&inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, "invalid input body")
 		return
 	}
 
-	err = h.services.Users.SignUp(c.Request.Context(), &inp)
+	err := h.services.Users.SignUp(c.Request.Context(), &inp)
 	if err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidInvitation) || errors.Is(err, domainErrors.ErrUserAlreadyExists) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -64,7 +72,8 @@ func (h *Handler) signUp(c *gin.Context) {
 // @Param organizationId query string true "Organization ID"
 // @Param input body requests.UserSignInRequest true "sign in info"
 // @Success 200 {object} responses.TokenResponse
-// @Failure 400,404 {object} helpers.Response
+// @Failure 400 {object} helpers.Response
+// @Failure 401 {object} helpers.Response "unknown email or wrong password"
 // @Failure 500 {object} helpers.Response
 // @Failure default {object} helpers.Response
 // @Router /api/v1/users/sign-in [post]
@@ -77,8 +86,8 @@ func (h *Handler) signIn(c *gin.Context) {
 
 	res, err := h.services.Users.SignIn(c.Request.Context(), &inp)
 	if err != nil {
-		if errors.Is(err, domainErrors.ErrUserNotFound) {
-			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		if errors.Is(err, domainErrors.ErrInvalidCredentials) {
+			helpers.NewErrorResponse(c, http.StatusUnauthorized, err.Error())
 			return
 		}
 
@@ -87,4 +96,238 @@ func (h *Handler) signIn(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, &res)
-}
\ No newline at end of file
+}
+
+// refresh handles the token refresh request.
+// @Summary User Refresh Token
+// @Tags users-auth
+// @Description exchanges a refresh token for a new token pair. Each refresh token can be used once,
+// @Description reusing one revokes the whole session
+// @ModuleID userRefresh
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer refresh token"
+// @Success 200 {object} responses.TokenResponse
+// @Failure 401 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Failure default {object} helpers.Response
+// @Router /api/v1/users/refresh [post]
+func (h *Handler) refresh(c *gin.Context) {
+	inp := requests.RefreshTokenRequest{
+		UserID:    c.GetString(values.UserIdCtx),
+		SessionID: c.GetString(values.SessionIdCtx),
+		TokenID:   c.GetString(values.RefreshTokenIdCtx),
+	}
+
+	res, err := h.services.Users.Refresh(c.Request.Context(), &inp)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidRefreshToken) || errors.Is(err, domainErrors.ErrRefreshTokenReused) {
+			helpers.NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, &res)
+}
+
+// signOut handles the user sign out request.
+// @Summary User SignOut
+// @Tags users-auth
+// @Description revokes the current session, its access and refresh tokens stop working
+// @ModuleID userSignOut
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} helpers.Response
+// @Failure 401,403 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Failure default {object} helpers.Response
+// @Router /api/v1/users/sign-out [post]
+func (h *Handler) signOut(c *gin.Context) {
+	inp := requests.SignOutRequest{
+		UserID:    c.GetString(values.UserIdCtx),
+		SessionID: c.GetString(values.SessionIdCtx),
+	}
+
+	if err := h.services.Users.SignOut(c.Request.Context(), &inp); err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, helpers.NewResponse("signed out"))
+}
+
+// signOutAll handles the request to sign out of all sessions.
+// @Summary User SignOut All Sessions
+// @Tags users-auth
+// @Description revokes all sessions of the user
+// @ModuleID userSignOutAll
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} helpers.Response
+// @Failure 401,403 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Failure default {object} helpers.Response
+// @Router /api/v1/users/sign-out-all [post]
+func (h *Handler) signOutAll(c *gin.Context) {
+	if err := h.services.Users.SignOutAll(c.Request.Context(), c.GetString(values.UserIdCtx)); err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, helpers.NewResponse("signed out of all sessions"))
+}
+
+// getProfile handles the request for the profile of the current user.
+// @Summary Get User Profile
+// @Tags users
+// @Description returns the profile of the current user
+// @ModuleID userGetProfile
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token"
+// @Success 200 {object} entities.User
+// @Failure 401,404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/users/me [get]
+func (h *Handler) getProfile(c *gin.Context) {
+	user, err := h.services.Users.GetProfile(c.Request.Context(), c.GetString(values.UserIdCtx))
+	if err != nil {
+		h.newUserErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// updateProfile handles the request to update the profile of the current user.
+// @Summary Update User Profile
+// @Tags users
+// @Description updates the name, the surname and the phone of the current user
+// @ModuleID userUpdateProfile
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token"
+// @Param input body requests.UpdateProfileRequestBody true "profile info"
+// @Success 200 {object} entities.User
+// @Failure 400,401,403,404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/users/me [put]
+func (h *Handler) updateProfile(c *gin.Context) {
+	inp := requests.UpdateProfileRequest{UserID: c.GetString(values.UserIdCtx)}
+
+	if err := c.BindJSON(&inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	user, err := h.services.Users.UpdateProfile(c.Request.Context(), &inp)
+	if err != nil {
+		h.newUserErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// changePassword handles the request to change the password of the current user.
+// @Summary Change User Password
+// @Tags users
+// @Description changes the password of the current user and signs out their other sessions
+// @ModuleID userChangePassword
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token"
+// @Param input body requests.ChangePasswordRequestBody true "passwords"
+// @Success 200 {object} helpers.Response
+// @Failure 400,401,403,404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/users/me/password [put]
+func (h *Handler) changePassword(c *gin.Context) {
+	inp := requests.ChangePasswordRequest{
+		UserID:    c.GetString(values.UserIdCtx),
+		SessionID: c.GetString(values.SessionIdCtx),
+	}
+
+	if err := c.BindJSON(&inp.Body); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	if err := h.services.Users.ChangePassword(c.Request.Context(), &inp); err != nil {
+		h.newUserErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, helpers.NewResponse("password changed"))
+}
+
+// requestPasswordReset handles the request to email a password reset link.
+// @Summary Request Password Reset
+// @Tags users-auth
+// @Description emails a one-time password reset link. Answers the same whether the email is registered or not
+// @ModuleID userRequestPasswordReset
+// @Accept  json
+// @Produce  json
+// @Param input body requests.PasswordResetRequest true "email"
+// @Success 200 {object} helpers.Response
+// @Failure 400 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/users/password-reset [post]
+func (h *Handler) requestPasswordReset(c *gin.Context) {
+	var inp requests.PasswordResetRequest
+	if err := c.BindJSON(&inp); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	if err := h.services.Users.RequestPasswordReset(c.Request.Context(), &inp); err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, helpers.NewResponse("if the email is registered, a password reset link has been sent"))
+}
+
+// resetPassword handles the request to set a new password with a password reset token.
+// @Summary Reset Password
+// @Tags users-auth
+// @Description sets a new password with a password reset token and signs out all sessions
+// @ModuleID userResetPassword
+// @Accept  json
+// @Produce  json
+// @Param input body requests.PasswordResetConfirmRequest true "token and new password"
+// @Success 200 {object} helpers.Response
+// @Failure 400 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/users/password-reset/confirm [post]
+func (h *Handler) resetPassword(c *gin.Context) {
+	var inp requests.PasswordResetConfirmRequest
+	if err := c.BindJSON(&inp); err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, "invalid input body")
+		return
+	}
+
+	if err := h.services.Users.ResetPassword(c.Request.Context(), &inp); err != nil {
+		h.newUserErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, helpers.NewResponse("password changed"))
+}
+
+// newUserErrorResponse maps the errors of the users service to HTTP responses.
+func (h *Handler) newUserErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domainErrors.ErrUserNotFound):
+		helpers.NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, domainErrors.ErrUserPasswordIncorrect), errors.Is(err, domainErrors.ErrInvalidPasswordResetToken):
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
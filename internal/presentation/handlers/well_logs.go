@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+const (
+	wellLogFileField   = "file"
+	maxWellLogFileSize = 50 << 20
+)
+
+// initWellLogsRoutes initializes the routes for the well logs of wellbores.
+func (h *Handler) initWellLogsRoutes(api *gin.RouterGroup) {
+	wellLogs := api.Group("/wellbores/:id/well-logs", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeWellbore, entities.PermissionWrite))
+	{
+		wellLogs.GET("/", h.getWellLogs)
+		wellLogs.POST("/", h.importWellLog)
+		wellLogs.GET("/:wellLogId", h.getWellLogByID)
+		wellLogs.DELETE("/:wellLogId", h.deleteWellLog)
+	}
+}
+
+// importWellLog imports a well log from a LAS file.
+// @Summary Import Well Log
+// @Tags well-logs
+// @Description Imports the depth, gamma ray, deep resistivity, sonic and density curves of a LAS 1.2 or 2.0 file.
+// @Description The file needs a sonic or a resistivity curve, the curves are converted from the units of the file
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Wellbore ID"
+// @Param file formData file true "LAS file, at most 50 MB"
+// @Success 201 {object} entities.WellLog
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/wellbores/{id}/well-logs [post]
+func (h *Handler) importWellLog(c *gin.Context) {
+	var inp requests.ImportWellLogRequest
+	var err error
+	var wellLog *entities.WellLog
+
+	if inp.WellboreID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	header, err := c.FormFile(wellLogFileField)
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if header.Size > maxWellLogFileSize {
+		helpers.NewErrorResponse(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("the file is larger than %d MB", maxWellLogFileSize>>20))
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+	inp.File = file
+	inp.FileName = header.Filename
+
+	if wellLog, err = h.services.WellLogs.ImportWellLog(c.Request.Context(), &inp); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidWellLogFile) {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusCreated, wellLog)
+}
+
+// getWellLogs retrieves the well logs of a wellbore.
+// @Summary Get Well Logs
+// @Tags well-logs
+// @Description Retrieves the logs of the wellbore without their samples, newest first
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Wellbore ID"
+// @Success 200 {array} entities.WellLog
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/wellbores/{id}/well-logs [get]
+func (h *Handler) getWellLogs(c *gin.Context) {
+	var inp requests.GetWellLogsRequest
+	var err error
+	var wellLogs []*entities.WellLog
+
+	if inp.WellboreID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	if wellLogs, err = h.services.WellLogs.GetWellLogs(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, wellLogs)
+}
+
+// getWellLogByID retrieves a well log with its samples.
+// @Summary Get Well Log By ID
+// @Tags well-logs
+// @Description Retrieves the log with its samples ordered by measured depth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Wellbore ID"
+// @Param wellLogId path string true "Well Log ID"
+// @Success 200 {object} entities.WellLog
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/wellbores/{id}/well-logs/{wellLogId} [get]
+func (h *Handler) getWellLogByID(c *gin.Context) {
+	var inp requests.GetWellLogByIDRequest
+	var err error
+	var wellLog *entities.WellLog
+
+	if inp.WellboreID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.ID, err = h.validateRequestIDParam(c, values.WellLogIdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	if wellLog, err = h.services.WellLogs.GetWellLogByID(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	h.writeJSON(c, http.StatusOK, wellLog)
+}
+
+// deleteWellLog deletes a well log.
+// @Summary Delete Well Log
+// @Tags well-logs
+// @Description Deletes the log with its samples, pore pressures estimated from it are kept
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Wellbore ID"
+// @Param wellLogId path string true "Well Log ID"
+// @Success 200 {object} helpers.Response
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/wellbores/{id}/well-logs/{wellLogId} [delete]
+func (h *Handler) deleteWellLog(c *gin.Context) {
+	var inp requests.DeleteWellLogRequest
+	var err error
+
+	if inp.WellboreID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return
+	}
+	if inp.ID, err = h.validateRequestIDParam(c, values.WellLogIdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	if err = h.services.WellLogs.DeleteWellLog(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, helpers.NewResponse("Well log deleted successfully"))
+}
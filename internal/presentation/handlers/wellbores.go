@@ -13,7 +13,7 @@ import (
 
 // initWellboresRoutes initializes the routes for the wellbores API.
 func (h *Handler) initWellboresRoutes(api *gin.RouterGroup) {
-	wellbores := api.Group("/wellbores", h.authMiddleware.UserIdentity)
+	wellbores := api.Group("/wellbores", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeWellbore, entities.PermissionWrite))
 	{
 		wellbores.GET("/", h.getWellbores)
 		wellbores.POST("/", h.createWellbore)
@@ -32,6 +32,8 @@ func (h *Handler) initWellboresRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param wellId query string true "Well ID"
 // @Success 200 {array} entities.Wellbore
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/wellbores [get]
 func (h *Handler) getWellbores(c *gin.Context) {
@@ -40,14 +42,17 @@ func (h *Handler) getWellbores(c *gin.Context) {
 	if inp.WellID, err = h.validateQueryIDParam(c, values.WellIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	wellbores, err := h.services.Wellbores.GetWellbores(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, wellbores)
+	h.writeJSON(c, http.StatusOK, wellbores)
 }
 
 // createWellbore creates a new wellbore.
@@ -61,21 +66,26 @@ func (h *Handler) getWellbores(c *gin.Context) {
 // @Param input body requests.CreateWellboreRequest true "Wellbore input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/wellbores [post]
 func (h *Handler) createWellbore(c *gin.Context) {
 	var inp requests.CreateWellboreRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.WellID, err = h.validateQueryIDParam(c, values.WellIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Wellbores.CreateWellbore(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -93,26 +103,31 @@ func (h *Handler) createWellbore(c *gin.Context) {
 // @Param input body requests.UpdateWellboreRequest true "Wellbore input"
 // @Success 200 {object} entities.Wellbore
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/wellbores/{id} [put]
 func (h *Handler) updateWellbore(c *gin.Context) {
 	var inp requests.UpdateWellboreRequest
 	var err error
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	wellbore, err := h.services.Wellbores.UpdateWellbore(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, wellbore)
+	h.writeJSON(c, http.StatusOK, wellbore)
 }
 
 // deleteWellbore deletes an existing wellbore.
@@ -125,6 +140,8 @@ func (h *Handler) updateWellbore(c *gin.Context) {
 // @Param id path string true "Wellbore ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/wellbores/{id} [delete]
 func (h *Handler) deleteWellbore(c *gin.Context) {
@@ -134,8 +151,11 @@ func (h *Handler) deleteWellbore(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Wellbores.DeleteWellbore(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -151,6 +171,8 @@ func (h *Handler) deleteWellbore(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Wellbore ID"
 // @Success 200 {object} entities.Wellbore
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/wellbores/{id} [get]
 func (h *Handler) getWellboreByID(c *gin.Context) {
@@ -160,10 +182,13 @@ func (h *Handler) getWellboreByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if wellbore, err = h.services.Wellbores.GetWellboreByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, wellbore)
+	h.writeJSON(c, http.StatusOK, wellbore)
 }
@@ -13,7 +13,7 @@ import (
 
 // initWellsRoutes initializes the routes for the wells API.
 func (h *Handler) initWellsRoutes(api *gin.RouterGroup) {
-	wells := api.Group("/wells", h.authMiddleware.UserIdentity)
+	wells := api.Group("/wells", h.authMiddleware.UserIdentity, h.authMiddleware.Authorize(entities.ScopeWell, entities.PermissionWrite))
 	{
 		wells.GET("/", h.getWells)
 		wells.POST("/", h.createWell)
@@ -32,6 +32,8 @@ func (h *Handler) initWellsRoutes(api *gin.RouterGroup) {
 // @Param Authorization header string true "Bearer token"
 // @Param siteId query string true "Site ID"
 // @Success 200 {array} entities.Well
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/wells [get]
 func (h *Handler) getWells(c *gin.Context) {
@@ -40,14 +42,17 @@ func (h *Handler) getWells(c *gin.Context) {
 	if inp.SiteID, err = h.validateQueryIDParam(c, values.SiteIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	wells, err := h.services.Wells.GetWells(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, wells)
+	h.writeJSON(c, http.StatusOK, wells)
 }
 
 // createWell creates a new well.
@@ -61,21 +66,26 @@ func (h *Handler) getWells(c *gin.Context) {
 // @Param input body requests.CreateWellRequest true "Well input"
 // @Success 201 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/wells [post]
 func (h *Handler) createWell(c *gin.Context) {
 	var inp requests.CreateWellRequest
 	var err error
 
-	if err = c.BindJSON(&inp.Body); err != nil {
+	if err = h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.SiteID, err = h.validateQueryIDParam(c, values.SiteIdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Wells.CreateWell(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -93,26 +103,31 @@ func (h *Handler) createWell(c *gin.Context) {
 // @Param input body requests.UpdateWellRequest true "Well input"
 // @Success 200 {object} entities.Well
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/wells/{id} [put]
 func (h *Handler) updateWell(c *gin.Context) {
 	var inp requests.UpdateWellRequest
 	var err error
-	if err := c.BindJSON(&inp.Body); err != nil {
+	if err := h.bindJSON(c, &inp.Body); err != nil {
 		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidInputBody.Error())
 		return
 	}
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 
 	well, err := h.services.Wells.UpdateWell(c.Request.Context(), &inp)
 	if err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, well)
+	h.writeJSON(c, http.StatusOK, well)
 }
 
 // deleteWell deletes an existing well.
@@ -125,6 +140,8 @@ func (h *Handler) updateWell(c *gin.Context) {
 // @Param id path string true "Well ID"
 // @Success 200 {object} helpers.Response
 // @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/wells/{id} [delete]
 func (h *Handler) deleteWell(c *gin.Context) {
@@ -134,8 +151,11 @@ func (h *Handler) deleteWell(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if err = h.services.Wells.DeleteWell(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -151,6 +171,8 @@ func (h *Handler) deleteWell(c *gin.Context) {
 // @Param Authorization header string true "Bearer token"
 // @Param id path string true "Well ID"
 // @Success 200 {object} entities.Well
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
 // @Failure 500 {object} helpers.Response
 // @Router /api/v1/wells/{id} [get]
 func (h *Handler) getWellByID(c *gin.Context) {
@@ -160,10 +182,13 @@ func (h *Handler) getWellByID(c *gin.Context) {
 	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
 		return
 	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
 	if well, err = h.services.Wells.GetWellByID(c.Request.Context(), &inp); err != nil {
-		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		h.newServiceErrorResponse(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, well)
+	h.writeJSON(c, http.StatusOK, well)
 }
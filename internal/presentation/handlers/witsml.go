@@ -0,0 +1,373 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	appErrors "github.com/munaiplan/munaiplan-backend/internal/application/types/errors"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+	"github.com/munaiplan/munaiplan-backend/pkg/witsml"
+)
+
+const (
+	witsmlFileField     = "file"
+	witsmlBhaRunField   = "bhaRun"
+	witsmlObjectParam   = "object"
+	witsmlObjectBhaRun  = "bhaRun"
+	maxWitsmlFileSize   = 50 << 20
+	witsmlContentType   = "application/xml"
+	witsmlFileExtension = ".xml"
+)
+
+// initWitsmlRoutes initializes the routes for the WITSML 1.4.1 import and export of wells, wellbores,
+// trajectories and drill strings.
+func (h *Handler) initWitsmlRoutes(api *gin.RouterGroup) {
+	witsmlGroup := api.Group("/witsml", h.authMiddleware.UserIdentity)
+	{
+		witsmlGroup.GET("/wells/:id", h.authMiddleware.RequirePermission(entities.ScopeWell, entities.PermissionRead), h.exportWitsmlWell)
+		witsmlGroup.GET("/wellbores/:id", h.authMiddleware.RequirePermission(entities.ScopeWellbore, entities.PermissionRead), h.exportWitsmlWellbore)
+		witsmlGroup.GET("/trajectories/:id", h.authMiddleware.RequirePermission(entities.ScopeTrajectory, entities.PermissionRead), h.exportWitsmlTrajectory)
+		witsmlGroup.GET("/strings/:id", h.authMiddleware.RequirePermission(entities.ScopeString, entities.PermissionRead), h.exportWitsmlString)
+		witsmlGroup.POST("/wells", h.authMiddleware.Authorize(entities.ScopeSite, entities.PermissionWrite), h.importWitsmlWells)
+		witsmlGroup.POST("/wellbores", h.authMiddleware.Authorize(entities.ScopeWell, entities.PermissionWrite), h.importWitsmlWellbores)
+		witsmlGroup.POST("/trajectories", h.authMiddleware.Authorize(entities.ScopeDesign, entities.PermissionWrite), h.importWitsmlTrajectories)
+		witsmlGroup.POST("/strings", h.authMiddleware.Authorize(entities.ScopeCase, entities.PermissionWrite), h.importWitsmlString)
+	}
+}
+
+// exportWitsmlWell exports a well as a WITSML file.
+// @Summary Export Well to WITSML
+// @Tags witsml
+// @Description Exports the well with its surface location as a WITSML 1.4.1 wells file, values are in SI units
+// @Produce xml
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Well ID"
+// @Success 200 {file} file
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/witsml/wells/{id} [get]
+func (h *Handler) exportWitsmlWell(c *gin.Context) {
+	var inp requests.ExportWitsmlRequest
+	var err error
+	if !h.bindWitsmlExport(c, &inp) {
+		return
+	}
+
+	var doc *witsml.Wells
+	if doc, err = h.services.Witsml.ExportWell(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+	writeWitsml(c, "well-"+inp.ID, doc)
+}
+
+// exportWitsmlWellbore exports a wellbore as a WITSML file.
+// @Summary Export Wellbore to WITSML
+// @Tags witsml
+// @Description Exports the wellbore as a WITSML 1.4.1 wellbores file, values are in SI units
+// @Produce xml
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Wellbore ID"
+// @Success 200 {file} file
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/witsml/wellbores/{id} [get]
+func (h *Handler) exportWitsmlWellbore(c *gin.Context) {
+	var inp requests.ExportWitsmlRequest
+	var err error
+	if !h.bindWitsmlExport(c, &inp) {
+		return
+	}
+
+	var doc *witsml.Wellbores
+	if doc, err = h.services.Witsml.ExportWellbore(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+	writeWitsml(c, "wellbore-"+inp.ID, doc)
+}
+
+// exportWitsmlTrajectory exports a trajectory as a WITSML file.
+// @Summary Export Trajectory to WITSML
+// @Tags witsml
+// @Description Exports the trajectory with its stations as a WITSML 1.4.1 trajectorys file, values are in SI units
+// @Description and angles in degrees. Stations have grid coordinates when the well is georeferenced
+// @Produce xml
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "Trajectory ID"
+// @Success 200 {file} file
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/witsml/trajectories/{id} [get]
+func (h *Handler) exportWitsmlTrajectory(c *gin.Context) {
+	var inp requests.ExportWitsmlRequest
+	var err error
+	if !h.bindWitsmlExport(c, &inp) {
+		return
+	}
+
+	var doc *witsml.Trajectories
+	if doc, err = h.services.Witsml.ExportTrajectory(c.Request.Context(), &inp); err != nil {
+		h.newServiceErrorResponse(c, err)
+		return
+	}
+	writeWitsml(c, "trajectory-"+inp.ID, doc)
+}
+
+// exportWitsmlString exports a drill string as a WITSML tubular or bhaRun.
+// @Summary Export String to WITSML
+// @Tags witsml
+// @Description Exports the drill string as a WITSML 1.4.1 tubulars file with the components numbered from the bit,
+// @Description or as a bhaRuns file that refers to the tubular by the string ID and holds the string depth
+// @Produce xml
+// @Param Authorization header string true "Bearer token"
+// @Param id path string true "String ID"
+// @Param object query string false "tubular (default) or bhaRun"
+// @Success 200 {file} file
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/witsml/strings/{id} [get]
+func (h *Handler) exportWitsmlString(c *gin.Context) {
+	var inp requests.ExportWitsmlRequest
+	var err error
+	if !h.bindWitsmlExport(c, &inp) {
+		return
+	}
+
+	switch c.Query(witsmlObjectParam) {
+	case "", "tubular":
+		var doc *witsml.Tubulars
+		if doc, err = h.services.Witsml.ExportTubular(c.Request.Context(), &inp); err != nil {
+			h.newServiceErrorResponse(c, err)
+			return
+		}
+		writeWitsml(c, "tubular-"+inp.ID, doc)
+	case witsmlObjectBhaRun:
+		var doc *witsml.BhaRuns
+		if doc, err = h.services.Witsml.ExportBhaRun(c.Request.Context(), &inp); err != nil {
+			h.newServiceErrorResponse(c, err)
+			return
+		}
+		writeWitsml(c, "bharun-"+inp.ID, doc)
+	default:
+		helpers.NewErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("%s must be tubular or bhaRun", witsmlObjectParam))
+	}
+}
+
+// importWitsmlWells imports the wells of a WITSML file into a site.
+// @Summary Import Wells from WITSML
+// @Tags witsml
+// @Description Creates the wells of a WITSML 1.4.1 wells file in the site. Grid coordinates are read with the EPSG
+// @Description code of their projected CRS, wells without one use the CRS of the site
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param siteId query string true "Site ID"
+// @Param file formData file true "WITSML wells file, at most 50 MB"
+// @Success 201 {object} responses.WitsmlImportResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/witsml/wells [post]
+func (h *Handler) importWitsmlWells(c *gin.Context) {
+	h.importWitsml(c, values.SiteIdQueryParam, h.services.Witsml.ImportWells)
+}
+
+// importWitsmlWellbores imports the wellbores of a WITSML file into a well.
+// @Summary Import Wellbores from WITSML
+// @Tags witsml
+// @Description Creates the wellbores of a WITSML 1.4.1 wellbores file in the well
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param wellId query string true "Well ID"
+// @Param file formData file true "WITSML wellbores file, at most 50 MB"
+// @Success 201 {object} responses.WitsmlImportResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/witsml/wellbores [post]
+func (h *Handler) importWitsmlWellbores(c *gin.Context) {
+	h.importWitsml(c, values.WellIdQueryParam, h.services.Witsml.ImportWellbores)
+}
+
+// importWitsmlTrajectories imports the trajectories of a WITSML file into a design.
+// @Summary Import Trajectories from WITSML
+// @Tags witsml
+// @Description Creates the trajectories of a WITSML 1.4.1 trajectorys file in the design. Grid coordinates of the
+// @Description stations are derived from the well location when the well is georeferenced
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param designId query string true "Design ID"
+// @Param file formData file true "WITSML trajectorys file, at most 50 MB"
+// @Success 201 {object} responses.WitsmlImportResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 409 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/witsml/trajectories [post]
+func (h *Handler) importWitsmlTrajectories(c *gin.Context) {
+	h.importWitsml(c, values.DesignIdQueryParam, h.services.Witsml.ImportTrajectories)
+}
+
+// importWitsmlString imports the drill string of a case from a WITSML tubular.
+// @Summary Import String from WITSML
+// @Tags witsml
+// @Description Creates the drill string of the case from a WITSML 1.4.1 tubulars file. The optional bhaRun file
+// @Description names the string, selects its tubular and sets the string depth, sections are placed from the bit
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer token"
+// @Param caseId query string true "Case ID"
+// @Param file formData file true "WITSML tubulars file, at most 50 MB"
+// @Param bhaRun formData file false "WITSML bhaRuns file with one bhaRun"
+// @Success 201 {object} responses.WitsmlImportResponse
+// @Failure 400 {object} helpers.Response
+// @Failure 403 {object} helpers.Response
+// @Failure 404 {object} helpers.Response
+// @Failure 409 {object} helpers.Response
+// @Failure 500 {object} helpers.Response
+// @Router /api/v1/witsml/strings [post]
+func (h *Handler) importWitsmlString(c *gin.Context) {
+	var inp requests.ImportWitsmlStringRequest
+	var err error
+	var result *responses.WitsmlImportResponse
+
+	if inp.CaseID, err = h.validateQueryIDParam(c, values.CaseIdQueryParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	tubulars, ok := openWitsmlFile(c, witsmlFileField, true)
+	if !ok {
+		return
+	}
+	defer tubulars.Close()
+	inp.Tubulars = tubulars
+
+	bhaRuns, ok := openWitsmlFile(c, witsmlBhaRunField, false)
+	if !ok {
+		return
+	}
+	if bhaRuns != nil {
+		defer bhaRuns.Close()
+		inp.BhaRuns = bhaRuns
+	}
+
+	if result, err = h.services.Witsml.ImportString(c.Request.Context(), &inp); err != nil {
+		h.newWitsmlImportErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *Handler) bindWitsmlExport(c *gin.Context, inp *requests.ExportWitsmlRequest) bool {
+	var err error
+	if inp.ID, err = h.validateRequestIDParam(c, values.IdQueryParam); err != nil {
+		return false
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return false
+	}
+	return true
+}
+
+// importWitsml reads the parent ID from the query parameter and the file from the form for the import.
+func (h *Handler) importWitsml(c *gin.Context, parentParam string, importFile func(ctx context.Context, input *requests.ImportWitsmlRequest) (*responses.WitsmlImportResponse, error)) {
+	var inp requests.ImportWitsmlRequest
+	var err error
+	var result *responses.WitsmlImportResponse
+
+	if inp.ParentID, err = h.validateQueryIDParam(c, parentParam); err != nil {
+		return
+	}
+	if inp.OrganizationID, err = h.validateContextIDKey(c, values.OrganizationIdCtx); err != nil {
+		return
+	}
+
+	file, ok := openWitsmlFile(c, witsmlFileField, true)
+	if !ok {
+		return
+	}
+	defer file.Close()
+	inp.File = file
+
+	if result, err = importFile(c.Request.Context(), &inp); err != nil {
+		h.newWitsmlImportErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *Handler) newWitsmlImportErrorResponse(c *gin.Context, err error) {
+	if errors.Is(err, domainErrors.ErrInvalidWitsmlFile) {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, appErrors.ErrAlreadyExists) {
+		helpers.NewErrorResponse(c, http.StatusConflict, "the case already has a string")
+		return
+	}
+	h.newServiceErrorResponse(c, err)
+}
+
+// openWitsmlFile opens the form file of the field, it writes the error response and returns false when the
+// file cannot be used. A missing optional file returns nil.
+func openWitsmlFile(c *gin.Context, field string, required bool) (multipart.File, bool) {
+	header, err := c.FormFile(field)
+	if errors.Is(err, http.ErrMissingFile) && !required {
+		return nil, true
+	}
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	if header.Size > maxWitsmlFileSize {
+		helpers.NewErrorResponse(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("the file is larger than %d MB", maxWitsmlFileSize>>20))
+		return nil, false
+	}
+	file, err := header.Open()
+	if err != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return file, true
+}
+
+// writeWitsml writes the document as an XML attachment. The document is encoded before the status is set,
+// so encoding errors still return a JSON error.
+func writeWitsml(c *gin.Context, name string, doc witsml.Document) {
+	var body bytes.Buffer
+	if err := witsml.Encode(&body, doc); err != nil {
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+witsmlFileExtension))
+	c.Data(http.StatusOK, witsmlContentType, body.Bytes())
+}
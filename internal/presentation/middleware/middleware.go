@@ -1,21 +1,68 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/internal/application/service"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
 	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/types"
 	"github.com/munaiplan/munaiplan-backend/pkg/values"
 )
 
+// authorizationQueryParams lists the parent ID query parameters from the most to the least specific.
+var authorizationQueryParams = []struct {
+	param string
+	scope string
+}{
+	{param: values.CaseIdQueryParam, scope: entities.ScopeCase},
+	{param: values.TrajectoryIdQueryParam, scope: entities.ScopeTrajectory},
+	{param: values.DesignIdQueryParam, scope: entities.ScopeDesign},
+	{param: values.WellboreIdQueryParam, scope: entities.ScopeWellbore},
+	{param: values.WellIdQueryParam, scope: entities.ScopeWell},
+	{param: values.SiteIdQueryParam, scope: entities.ScopeSite},
+	{param: values.FieldIdQueryParam, scope: entities.ScopeField},
+	{param: values.CompanyIdQueryParam, scope: entities.ScopeCompany},
+}
+
+// scopeQueryParams lists the ID query parameters the routes of a scope address their entity or its parent by.
+// Other ID query parameters are not checked, the handlers of the scope do not read them.
+var scopeQueryParams = map[string][]string{
+	entities.ScopeCompany:          {values.CompanyIdQueryParam},
+	entities.ScopeField:            {values.FieldIdQueryParam, values.CompanyIdQueryParam},
+	entities.ScopeSite:             {values.SiteIdQueryParam, values.FieldIdQueryParam},
+	entities.ScopeWell:             {values.WellIdQueryParam, values.SiteIdQueryParam},
+	entities.ScopeWellbore:         {values.WellboreIdQueryParam, values.WellIdQueryParam},
+	entities.ScopeDesign:           {values.DesignIdQueryParam, values.WellboreIdQueryParam},
+	entities.ScopeTrajectory:       {values.TrajectoryIdQueryParam, values.DesignIdQueryParam},
+	entities.ScopeCase:             {values.CaseIdQueryParam, values.TrajectoryIdQueryParam},
+	entities.ScopeHole:             {values.CaseIdQueryParam},
+	entities.ScopeFluid:            {values.CaseIdQueryParam},
+	entities.ScopeRig:              {values.CaseIdQueryParam},
+	entities.ScopeString:           {values.CaseIdQueryParam},
+	entities.ScopePorePressure:     {values.CaseIdQueryParam},
+	entities.ScopeFractureGradient: {values.CaseIdQueryParam},
+}
+
 type AuthMiddleware struct {
-	Jwt               helpers.Jwt
+	Jwt     helpers.Jwt
+	Users   service.Users
+	Roles   service.Roles
+	ApiKeys service.ApiKeys
 }
 
-func NewAuthMiddleware(jwt helpers.Jwt) *AuthMiddleware {
+func NewAuthMiddleware(jwt helpers.Jwt, users service.Users, roles service.Roles, apiKeys service.ApiKeys) *AuthMiddleware {
 	return &AuthMiddleware{
-		Jwt:               jwt,
+		Jwt:     jwt,
+		Users:   users,
+		Roles:   roles,
+		ApiKeys: apiKeys,
 	}
 }
 
@@ -39,6 +86,8 @@ func (m *AuthMiddleware) RefreshTokenIdentity(c *gin.Context) {
 	}
 
 	c.Set(values.UserIdCtx, userClaims.UserId)
+	c.Set(values.SessionIdCtx, userClaims.SessionId)
+	c.Set(values.RefreshTokenIdCtx, userClaims.Id)
 	c.Set(values.UserRefreshTokenCtx, header)
 }
 
@@ -55,13 +104,186 @@ func (m *AuthMiddleware) UserIdentity(c *gin.Context) {
 		return
 	}
 
+	if entities.IsApiKey(headerParts[1]) {
+		m.apiKeyIdentity(c, headerParts[1])
+		return
+	}
+
 	userClaims, err := m.Jwt.Verify(headerParts[1])
 	if err != nil {
 		helpers.NewErrorResponse(c, http.StatusUnauthorized, err.Error())
 		return
 	}
 
+	// Access tokens of signed out sessions are rejected before they expire
+	if err = m.Users.CheckSession(c.Request.Context(), userClaims.SessionId); err != nil {
+		if errors.Is(err, domainErrors.ErrSessionRevoked) {
+			helpers.NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	c.Set(values.UserIdCtx, userClaims.UserId)
 	c.Set(values.OrganizationIdCtx, userClaims.OrganizationId)
+	c.Set(values.SessionIdCtx, userClaims.SessionId)
+	// Changes made by the request are attributed to the user in the audit log
+	c.Request = c.Request.WithContext(helpers.WithActor(c.Request.Context(), helpers.Actor{
+		UserID:         userClaims.UserId,
+		OrganizationID: userClaims.OrganizationId,
+	}))
 	c.Set(values.UserRefreshTokenCtx, header)
-}
\ No newline at end of file
+}
+
+// EventStreamIdentity authenticates like UserIdentity, but also accepts the token in the access_token query
+// parameter, browsers cannot set headers on event streams.
+func (m *AuthMiddleware) EventStreamIdentity(c *gin.Context) {
+	if c.GetHeader(values.AuthorizationHeader) == "" {
+		token := c.GetString(values.QueryAccessTokenCtx)
+		if token == "" {
+			token = c.Query(values.AccessTokenQueryParam)
+		}
+		if token != "" {
+			c.Request.Header.Set(values.AuthorizationHeader, "Bearer "+token)
+		}
+	}
+	m.UserIdentity(c)
+}
+
+// StripAccessToken moves the access_token query parameter out of the URL into the context for
+// EventStreamIdentity, so the token does not end up in request logs. Must run before the logger.
+func StripAccessToken(c *gin.Context) {
+	query := c.Request.URL.Query()
+	token := query.Get(values.AccessTokenQueryParam)
+	if token == "" {
+		return
+	}
+
+	query.Del(values.AccessTokenQueryParam)
+	c.Request.URL.RawQuery = query.Encode()
+	c.Set(values.QueryAccessTokenCtx, token)
+}
+
+// apiKeyIdentity authenticates the request with a personal API key instead of a JWT.
+func (m *AuthMiddleware) apiKeyIdentity(c *gin.Context, plainKey string) {
+	key, err := m.ApiKeys.Authenticate(c.Request.Context(), plainKey)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidApiKey) {
+			helpers.NewErrorResponse(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Set(values.UserIdCtx, key.UserID)
+	c.Set(values.OrganizationIdCtx, key.OrganizationID)
+	c.Set(values.ApiKeyCtx, key)
+	c.Request = c.Request.WithContext(helpers.WithActor(c.Request.Context(), helpers.Actor{
+		UserID:         key.UserID,
+		OrganizationID: key.OrganizationID,
+		ApiKeyID:       key.ID,
+	}))
+}
+
+// RejectApiKey only lets requests authenticated with a JWT through, for actions on the account itself
+// such as managing sessions and API keys. Must run after UserIdentity.
+func (m *AuthMiddleware) RejectApiKey(c *gin.Context) {
+	if _, ok := c.Get(values.ApiKeyCtx); ok {
+		helpers.NewErrorResponse(c, http.StatusForbidden, domainErrors.ErrApiKeyNotAllowed.Error())
+	}
+}
+
+// Authorize checks the role of the user for the entity of the scope addressed by the :id path parameter,
+// or for the entity or parent addressed by the ID query parameter of the scope. Requests with more than one
+// ID query parameter are rejected, so the checked entity is the one the handler uses. GET requests need
+// the read permission, other methods need writePermission. Must run after UserIdentity.
+func (m *AuthMiddleware) Authorize(scope string, writePermission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		permission := writePermission
+		if c.Request.Method == http.MethodGet {
+			permission = entities.PermissionRead
+		}
+		m.authorize(c, scope, permission)
+	}
+}
+
+// RequirePermission checks the role of the user like Authorize, but with the same permission for all methods.
+func (m *AuthMiddleware) RequirePermission(scope string, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		m.authorize(c, scope, permission)
+	}
+}
+
+// SuperAdmin only lets platform super admins signed in with a JWT through. Must run after UserIdentity.
+func (m *AuthMiddleware) SuperAdmin(c *gin.Context) {
+	if _, ok := c.Get(values.ApiKeyCtx); ok {
+		helpers.NewErrorResponse(c, http.StatusForbidden, domainErrors.ErrApiKeyNotAllowed.Error())
+		return
+	}
+	if err := m.Roles.CheckSuperAdmin(c.Request.Context(), c.GetString(values.UserIdCtx)); err != nil {
+		newAuthorizationErrorResponse(c, err)
+	}
+}
+
+func (m *AuthMiddleware) authorize(c *gin.Context, scope string, permission string) {
+	input := requests.AuthorizeRequest{
+		UserID:         c.GetString(values.UserIdCtx),
+		OrganizationID: c.GetString(values.OrganizationIdCtx),
+		Permission:     permission,
+	}
+	if value, ok := c.Get(values.ApiKeyCtx); ok {
+		key := value.(*entities.ApiKey)
+		input.ApiKeyScope, input.ApiKeyCompanyID = key.Scope, key.CompanyID
+	}
+
+	if id := c.Param(values.IdQueryParam); id != "" && scope != entities.ScopeOrganization {
+		input.Scope, input.ID = scope, id
+	} else {
+		count := 0
+		for _, param := range authorizationQueryParams {
+			value := c.Query(param.param)
+			if value == "" {
+				continue
+			}
+			count++
+			if scopeHasQueryParam(scope, param.param) {
+				input.Scope, input.ID = param.scope, value
+			}
+		}
+		if count > 1 {
+			helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrTooManyIDQueryParameters.Error())
+			return
+		}
+	}
+	if input.ID != "" && uuid.Validate(input.ID) != nil {
+		helpers.NewErrorResponse(c, http.StatusBadRequest, types.ErrInvalidUUID.Error())
+		return
+	}
+
+	if err := m.Roles.Authorize(c.Request.Context(), &input); err != nil {
+		newAuthorizationErrorResponse(c, err)
+	}
+}
+
+// scopeHasQueryParam reports whether the routes of the scope address an entity by the query parameter.
+func scopeHasQueryParam(scope string, param string) bool {
+	for _, scopeParam := range scopeQueryParams[scope] {
+		if scopeParam == param {
+			return true
+		}
+	}
+	return false
+}
+
+func newAuthorizationErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domainErrors.ErrResourceNotFound):
+		helpers.NewErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, domainErrors.ErrPermissionDenied):
+		helpers.NewErrorResponse(c, http.StatusForbidden, err.Error())
+	default:
+		helpers.NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
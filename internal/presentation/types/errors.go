@@ -3,9 +3,10 @@ package types
 import "errors"
 
 var (
-	ErrInvalidUUID             = errors.New("invalid UUID")
-	ErrInvalidIDQueryParameter = errors.New("invalid ID query parameter")
-	ErrInvalidInputBody        = errors.New("invalid input body")
+	ErrInvalidUUID              = errors.New("invalid UUID")
+	ErrInvalidIDQueryParameter  = errors.New("invalid ID query parameter")
+	ErrInvalidInputBody         = errors.New("invalid input body")
+	ErrTooManyIDQueryParameters = errors.New("only one parent ID query parameter is allowed")
 )
 
 var (
@@ -32,4 +33,4 @@ var (
 
 var (
 	ErrInvalidWellboreIDQueryParameter = errors.New("invalid wellbore ID query parameter")
-)
\ No newline at end of file
+)
@@ -0,0 +1,293 @@
+// Package chart draws line charts of engineering curves on a canvas, like a PDF page.
+//
+// Depth charts set ReverseY so that depth grows downwards, plan views set EqualScale so that both axes have
+// the same scale. NaN values break the lines of a series.
+package chart
+
+import (
+	"math"
+	"strconv"
+)
+
+type Color struct {
+	R, G, B uint8
+}
+
+// Palette is the default order of series colors.
+var Palette = []Color{
+	{31, 119, 180}, {214, 39, 40}, {44, 160, 44}, {255, 127, 14}, {148, 103, 189},
+	{140, 86, 75}, {227, 119, 194}, {127, 127, 127}, {188, 189, 34}, {23, 190, 207},
+}
+
+var (
+	axisColor  = Color{73, 80, 87}
+	gridColor  = Color{222, 226, 230}
+	labelColor = Color{33, 37, 41}
+)
+
+type Anchor int
+
+const (
+	AnchorStart Anchor = iota
+	AnchorMiddle
+	AnchorEnd
+)
+
+type LineStyle struct {
+	Width  float64
+	Color  Color
+	Dashed bool
+}
+
+type TextStyle struct {
+	Size   float64
+	Bold   bool
+	Color  Color
+	Anchor Anchor
+}
+
+// Canvas is the surface the chart is drawn on. Positions are from the top left corner, y grows downwards
+// and text positions are on the baseline.
+type Canvas interface {
+	Line(x1, y1, x2, y2 float64, style LineStyle)
+	Polyline(points [][2]float64, style LineStyle)
+	FillRect(x, y, width, height float64, color Color)
+	Text(x, y float64, text string, style TextStyle)
+	TextWidth(text string, style TextStyle) float64
+	// Clip limits what draw paints to the rectangle.
+	Clip(x, y, width, height float64, draw func())
+}
+
+// Series is a curve of the chart. A zero color takes the next color of the palette.
+type Series struct {
+	Name   string
+	X      []float64
+	Y      []float64
+	Color  *Color
+	Dashed bool
+}
+
+type Chart struct {
+	Title      string
+	XLabel     string
+	YLabel     string
+	Series     []*Series
+	ReverseY   bool
+	EqualScale bool
+}
+
+const (
+	fontSize    = 7.0
+	titleSize   = 9.0
+	tickLength  = 3.0
+	legendEntry = 18.0
+	maxTicks    = 6
+)
+
+// Draw draws the chart into the rectangle.
+func (c *Chart) Draw(canvas Canvas, x, y, width, height float64) {
+	tickStyle := TextStyle{Size: fontSize, Color: labelColor}
+
+	top := y
+	if c.Title != "" {
+		canvas.Text(x+width/2, y+titleSize, c.Title, TextStyle{Size: titleSize, Bold: true, Color: labelColor, Anchor: AnchorMiddle})
+		top += titleSize + 6
+	}
+	if c.YLabel != "" {
+		canvas.Text(x, top+fontSize, c.YLabel, tickStyle)
+		top += fontSize + 8
+	}
+
+	legendRows := c.legendRows(canvas, width)
+	bottom := y + height - float64(len(legendRows))*(fontSize+5)
+	if c.XLabel != "" {
+		bottom -= fontSize + 4
+	}
+	bottom -= fontSize + tickLength + 4
+
+	xAxis, yAxis, ok := c.axes()
+	if !ok {
+		canvas.Text(x+width/2, (top+bottom)/2, "No data", TextStyle{Size: fontSize + 1, Color: axisColor, Anchor: AnchorMiddle})
+		return
+	}
+
+	var labelWidth float64
+	for _, tick := range yAxis.ticks() {
+		labelWidth = math.Max(labelWidth, canvas.TextWidth(yAxis.format(tick), tickStyle))
+	}
+	left := x + labelWidth + tickLength + 4
+	right := x + width - 6
+	if right-left < 10 || bottom-top < 10 {
+		return
+	}
+	if c.EqualScale {
+		equalizeScale(xAxis, yAxis, right-left, bottom-top)
+	}
+
+	toX := func(v float64) float64 { return left + (v-xAxis.min)/(xAxis.max-xAxis.min)*(right-left) }
+	toY := func(v float64) float64 {
+		ratio := (v - yAxis.min) / (yAxis.max - yAxis.min)
+		if c.ReverseY {
+			return top + ratio*(bottom-top)
+		}
+		return bottom - ratio*(bottom-top)
+	}
+
+	for _, tick := range xAxis.ticks() {
+		px := toX(tick)
+		canvas.Line(px, top, px, bottom, LineStyle{Width: 0.4, Color: gridColor})
+		canvas.Line(px, bottom, px, bottom+tickLength, LineStyle{Width: 0.6, Color: axisColor})
+		canvas.Text(px, bottom+tickLength+fontSize+1, xAxis.format(tick), TextStyle{Size: fontSize, Color: labelColor, Anchor: AnchorMiddle})
+	}
+	for _, tick := range yAxis.ticks() {
+		py := toY(tick)
+		canvas.Line(left, py, right, py, LineStyle{Width: 0.4, Color: gridColor})
+		canvas.Line(left-tickLength, py, left, py, LineStyle{Width: 0.6, Color: axisColor})
+		canvas.Text(left-tickLength-2, py+fontSize/2-1, yAxis.format(tick), TextStyle{Size: fontSize, Color: labelColor, Anchor: AnchorEnd})
+	}
+	if c.XLabel != "" {
+		canvas.Text((left+right)/2, bottom+tickLength+2*fontSize+5, c.XLabel, TextStyle{Size: fontSize, Color: labelColor, Anchor: AnchorMiddle})
+	}
+
+	canvas.Clip(left, top, right-left, bottom-top, func() {
+		for i, series := range c.Series {
+			style := LineStyle{Width: 1.1, Color: c.color(i), Dashed: series.Dashed}
+			var points [][2]float64
+			for j := 0; j < len(series.X) && j < len(series.Y); j++ {
+				if math.IsNaN(series.X[j]) || math.IsNaN(series.Y[j]) {
+					canvas.Polyline(points, style)
+					points = points[:0]
+					continue
+				}
+				points = append(points, [2]float64{toX(series.X[j]), toY(series.Y[j])})
+			}
+			canvas.Polyline(points, style)
+		}
+	})
+	frame := LineStyle{Width: 0.8, Color: axisColor}
+	canvas.Line(left, top, right, top, frame)
+	canvas.Line(left, bottom, right, bottom, frame)
+	canvas.Line(left, top, left, bottom, frame)
+	canvas.Line(right, top, right, bottom, frame)
+
+	legendY := y + height - float64(len(legendRows))*(fontSize+5) + fontSize
+	for _, row := range legendRows {
+		legendX := left
+		for _, i := range row {
+			style := LineStyle{Width: 1.5, Color: c.color(i), Dashed: c.Series[i].Dashed}
+			canvas.Line(legendX, legendY-fontSize/3, legendX+12, legendY-fontSize/3, style)
+			canvas.Text(legendX+15, legendY, c.Series[i].Name, tickStyle)
+			legendX += legendEntry + canvas.TextWidth(c.Series[i].Name, tickStyle)
+		}
+		legendY += fontSize + 5
+	}
+}
+
+func (c *Chart) color(i int) Color {
+	if c.Series[i].Color != nil {
+		return *c.Series[i].Color
+	}
+	return Palette[i%len(Palette)]
+}
+
+// legendRows breaks the named series into rows of the width.
+func (c *Chart) legendRows(canvas Canvas, width float64) [][]int {
+	var rows [][]int
+	var row []int
+	var rowWidth float64
+	for i, series := range c.Series {
+		if series.Name == "" {
+			continue
+		}
+		entry := legendEntry + canvas.TextWidth(series.Name, TextStyle{Size: fontSize})
+		if len(row) > 0 && rowWidth+entry > width {
+			rows = append(rows, row)
+			row, rowWidth = nil, 0
+		}
+		row = append(row, i)
+		rowWidth += entry
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// axis is the value range of an axis and the distance of its ticks.
+type axis struct {
+	min, max, step float64
+}
+
+// axes returns the rounded ranges of the values, false without values.
+func (c *Chart) axes() (*axis, *axis, bool) {
+	xMin, xMax, yMin, yMax := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for _, series := range c.Series {
+		for j := 0; j < len(series.X) && j < len(series.Y); j++ {
+			if math.IsNaN(series.X[j]) || math.IsNaN(series.Y[j]) {
+				continue
+			}
+			xMin, xMax = math.Min(xMin, series.X[j]), math.Max(xMax, series.X[j])
+			yMin, yMax = math.Min(yMin, series.Y[j]), math.Max(yMax, series.Y[j])
+		}
+	}
+	if math.IsInf(xMin, 1) {
+		return nil, nil, false
+	}
+	return niceAxis(xMin, xMax), niceAxis(yMin, yMax), true
+}
+
+func niceAxis(min, max float64) *axis {
+	if max-min < 1e-9 {
+		pad := math.Max(math.Abs(min)*0.1, 1)
+		min, max = min-pad, max+pad
+	}
+	step := niceStep((max - min) / maxTicks)
+	return &axis{min: math.Floor(min/step) * step, max: math.Ceil(max/step) * step, step: step}
+}
+
+// niceStep rounds the step up to 1, 2, 2.5 or 5 times a power of ten.
+func niceStep(raw float64) float64 {
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, factor := range []float64{1, 2, 2.5, 5, 10} {
+		if factor*magnitude >= raw*(1-1e-9) {
+			return factor * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+func (a *axis) ticks() []float64 {
+	var res []float64
+	for tick := math.Ceil(a.min/a.step-1e-9) * a.step; tick <= a.max+a.step*1e-9; tick += a.step {
+		res = append(res, tick)
+	}
+	return res
+}
+
+// format prints the tick with the decimals of the step.
+func (a *axis) format(v float64) string {
+	decimals := 0
+	for step := a.step; step < 1-1e-9 && decimals < 6; step *= 10 {
+		decimals++
+	}
+	if math.Abs(v) < a.step*1e-9 {
+		v = 0
+	}
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
+
+// equalizeScale widens one of the axes so that a unit has the same length on both.
+func equalizeScale(x, y *axis, width, height float64) {
+	xScale := width / (x.max - x.min)
+	yScale := height / (y.max - y.min)
+	if xScale > yScale {
+		widen(x, width/yScale)
+	} else {
+		widen(y, height/xScale)
+	}
+}
+
+func widen(a *axis, length float64) {
+	center := (a.min + a.max) / 2
+	a.min, a.max = center-length/2, center+length/2
+}
@@ -0,0 +1,58 @@
+package chart
+
+import "github.com/munaiplan/munaiplan-backend/pkg/pdf"
+
+// pdfCanvas draws on a page of a PDF document.
+type pdfCanvas struct {
+	doc  *pdf.Document
+	page *pdf.Page
+}
+
+func NewPDFCanvas(doc *pdf.Document, page *pdf.Page) Canvas {
+	return &pdfCanvas{doc: doc, page: page}
+}
+
+func (c *pdfCanvas) Line(x1, y1, x2, y2 float64, style LineStyle) {
+	c.page.Line(x1, y1, x2, y2, pdfLineStyle(style))
+}
+
+func (c *pdfCanvas) Polyline(points [][2]float64, style LineStyle) {
+	c.page.Polyline(points, pdfLineStyle(style))
+}
+
+func (c *pdfCanvas) FillRect(x, y, width, height float64, color Color) {
+	c.page.FillRect(x, y, width, height, pdf.Color(color))
+}
+
+func (c *pdfCanvas) Text(x, y float64, text string, style TextStyle) {
+	switch style.Anchor {
+	case AnchorMiddle:
+		x -= c.TextWidth(text, style) / 2
+	case AnchorEnd:
+		x -= c.TextWidth(text, style)
+	}
+	c.page.Text(x, y, text, pdfFont(style), style.Size, pdf.Color(style.Color))
+}
+
+func (c *pdfCanvas) TextWidth(text string, style TextStyle) float64 {
+	return c.doc.TextWidth(text, pdfFont(style), style.Size)
+}
+
+func (c *pdfCanvas) Clip(x, y, width, height float64, draw func()) {
+	c.page.Clip(x, y, width, height, draw)
+}
+
+func pdfLineStyle(style LineStyle) pdf.LineStyle {
+	res := pdf.LineStyle{Width: style.Width, Color: pdf.Color(style.Color)}
+	if style.Dashed {
+		res.Dash = []float64{4, 2}
+	}
+	return res
+}
+
+func pdfFont(style TextStyle) pdf.Font {
+	if style.Bold {
+		return pdf.Bold
+	}
+	return pdf.Regular
+}
@@ -0,0 +1,111 @@
+package chart
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/munaiplan/munaiplan-backend/pkg/pdf"
+)
+
+const (
+	// svgFonts are the fonts viewers use for the text, the first one has the metrics of the layout.
+	svgFonts = "'Go', 'Helvetica Neue', Arial, sans-serif"
+	// svgScale is the number of pixels per point of the layout, so the text of a chart sized for a page
+	// stays readable on a screen.
+	svgScale = 1.6
+)
+
+// svgCanvas draws on a scalable vector graphics document.
+type svgCanvas struct {
+	b     strings.Builder
+	clips int
+}
+
+// SVG draws the chart on a white SVG document of the size in pixels.
+func SVG(c *Chart, width, height float64) []byte {
+	canvas := &svgCanvas{}
+	layoutWidth, layoutHeight := width/svgScale, height/svgScale
+	fmt.Fprintf(&canvas.b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="%s">`,
+		svgNumber(width), svgNumber(height), svgNumber(layoutWidth), svgNumber(layoutHeight), svgFonts)
+	if c.Title != "" {
+		fmt.Fprintf(&canvas.b, "<title>%s</title>", html.EscapeString(c.Title))
+	}
+	fmt.Fprintf(&canvas.b, `<rect width="100%%" height="100%%" fill="#ffffff"/>`)
+	c.Draw(canvas, 0, 0, layoutWidth, layoutHeight)
+	canvas.b.WriteString("</svg>\n")
+	return []byte(canvas.b.String())
+}
+
+func (c *svgCanvas) Line(x1, y1, x2, y2 float64, style LineStyle) {
+	fmt.Fprintf(&c.b, `<line x1="%s" y1="%s" x2="%s" y2="%s"%s/>`, svgNumber(x1), svgNumber(y1), svgNumber(x2), svgNumber(y2), svgStroke(style))
+}
+
+func (c *svgCanvas) Polyline(points [][2]float64, style LineStyle) {
+	if len(points) < 2 {
+		return
+	}
+	c.b.WriteString(`<polyline fill="none" points="`)
+	for i, point := range points {
+		if i > 0 {
+			c.b.WriteByte(' ')
+		}
+		c.b.WriteString(svgNumber(point[0]) + "," + svgNumber(point[1]))
+	}
+	fmt.Fprintf(&c.b, `"%s/>`, svgStroke(style))
+}
+
+func (c *svgCanvas) FillRect(x, y, width, height float64, color Color) {
+	fmt.Fprintf(&c.b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`, svgNumber(x), svgNumber(y), svgNumber(width), svgNumber(height), color.hex())
+}
+
+func (c *svgCanvas) Text(x, y float64, text string, style TextStyle) {
+	var attributes string
+	switch style.Anchor {
+	case AnchorMiddle:
+		attributes = ` text-anchor="middle"`
+	case AnchorEnd:
+		attributes = ` text-anchor="end"`
+	}
+	if style.Bold {
+		attributes += ` font-weight="bold"`
+	}
+	fmt.Fprintf(&c.b, `<text x="%s" y="%s" font-size="%s" fill="%s"%s>%s</text>`,
+		svgNumber(x), svgNumber(y), svgNumber(style.Size), style.Color.hex(), attributes, html.EscapeString(text))
+}
+
+func (c *svgCanvas) TextWidth(text string, style TextStyle) float64 {
+	return pdf.TextWidth(text, pdfFont(style), style.Size)
+}
+
+func (c *svgCanvas) Clip(x, y, width, height float64, draw func()) {
+	c.clips++
+	id := "clip" + strconv.Itoa(c.clips)
+	fmt.Fprintf(&c.b, `<clipPath id="%s"><rect x="%s" y="%s" width="%s" height="%s"/></clipPath><g clip-path="url(#%s)">`,
+		id, svgNumber(x), svgNumber(y), svgNumber(width), svgNumber(height), id)
+	draw()
+	c.b.WriteString("</g>")
+}
+
+func (c Color) hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func svgStroke(style LineStyle) string {
+	res := fmt.Sprintf(` stroke="%s" stroke-width="%s"`, style.Color.hex(), svgNumber(style.Width))
+	if style.Dashed {
+		res += ` stroke-dasharray="4 2"`
+	}
+	return res
+}
+
+// svgNumber prints the number with at most two decimals.
+func svgNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
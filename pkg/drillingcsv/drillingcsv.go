@@ -0,0 +1,259 @@
+// Package drillingcsv reads actual drilling data exported from rig data acquisition systems as CSV.
+//
+// The first line names the columns. Columns are recognized by common mnemonics and names, case-insensitive,
+// ignoring spaces, underscores, dashes and a unit suffix in parentheses or brackets, e.g. "Hook Load (kN)".
+// The time and bit depth columns are required, unknown columns are ignored. Commas, semicolons and tabs
+// separate values, with semicolons and tabs a decimal comma is accepted.
+package drillingcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Column is a quantity read from the file.
+type Column int
+
+const (
+	ColumnTime Column = iota
+	ColumnBitDepth
+	ColumnHookLoad
+	ColumnSurfaceTorque
+	ColumnWeightOnBit
+	ColumnRPM
+	ColumnFlowRate
+	ColumnStandpipePressure
+)
+
+// maxRowErrors is the number of row errors kept in the report, the others are only counted.
+const maxRowErrors = 20
+
+// nullValues mark a missing reading, -999.25 is the LAS and WITS convention.
+var nullValues = map[string]bool{"": true, "-999.25": true, "-9999": true, "nan": true, "null": true, "n/a": true}
+
+var columnAliases = map[string]Column{
+	"time": ColumnTime, "datetime": ColumnTime, "timestamp": ColumnTime, "date": ColumnTime, "время": ColumnTime,
+	"bitdepth": ColumnBitDepth, "bitmd": ColumnBitDepth, "dbtm": ColumnBitDepth, "bitpos": ColumnBitDepth, "глубинадолота": ColumnBitDepth,
+	"hookload": ColumnHookLoad, "hkld": ColumnHookLoad, "hkla": ColumnHookLoad, "нагрузканакрюке": ColumnHookLoad, "веснакрюке": ColumnHookLoad,
+	"surfacetorque": ColumnSurfaceTorque, "torque": ColumnSurfaceTorque, "tqa": ColumnSurfaceTorque, "rotarytorque": ColumnSurfaceTorque, "момент": ColumnSurfaceTorque,
+	"weightonbit": ColumnWeightOnBit, "wob": ColumnWeightOnBit, "swob": ColumnWeightOnBit, "нагрузканадолото": ColumnWeightOnBit,
+	"rpm": ColumnRPM, "rpma": ColumnRPM, "rotaryspeed": ColumnRPM, "surfacerpm": ColumnRPM, "обороты": ColumnRPM,
+	"flowrate": ColumnFlowRate, "flow": ColumnFlowRate, "flowin": ColumnFlowRate, "mfia": ColumnFlowRate, "расход": ColumnFlowRate,
+	"standpipepressure": ColumnStandpipePressure, "spp": ColumnStandpipePressure, "sppa": ColumnStandpipePressure, "давлениенастояке": ColumnStandpipePressure,
+}
+
+// timeLayouts are tried in order for the time column, times without a zone are UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"01/02/2006 15:04:05",
+}
+
+var (
+	ErrEmptyFile      = errors.New("the file has no header line")
+	ErrMissingColumns = errors.New("the file needs time and bit depth columns")
+)
+
+// Record is one row of the file. Missing readings are nil.
+type Record struct {
+	Time              time.Time
+	BitDepth          float64
+	HookLoad          *float64
+	SurfaceTorque     *float64
+	WeightOnBit       *float64
+	RPM               *float64
+	FlowRate          *float64
+	StandpipePressure *float64
+}
+
+// Report describes the rows that could not be read. Errors holds the first of them with their line numbers.
+type Report struct {
+	Skipped int
+	Errors  []string
+}
+
+// Read reads the records of the file. Rows without a valid time or bit depth and rows with values that are
+// not numbers are skipped and reported, the file only fails when the header cannot be used.
+func Read(r io.Reader) ([]*Record, *Report, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	headerLine, _, _ := strings.Cut(text, "\n")
+	if strings.TrimSpace(headerLine) == "" {
+		return nil, nil, ErrEmptyFile
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = delimiter(headerLine)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	decimalComma := reader.Comma != ','
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := make(map[Column]int)
+	for i, name := range header {
+		if column, ok := columnAliases[normalizeName(name)]; ok {
+			if _, seen := columns[column]; !seen {
+				columns[column] = i
+			}
+		}
+	}
+	if _, ok := columns[ColumnTime]; !ok {
+		return nil, nil, ErrMissingColumns
+	}
+	if _, ok := columns[ColumnBitDepth]; !ok {
+		return nil, nil, ErrMissingColumns
+	}
+
+	var records []*Record
+	report := &Report{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, nil, err
+			}
+			report.add(parseErr.Line, parseErr.Err)
+			continue
+		}
+		if isBlank(row) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		record, err := readRecord(row, columns, decimalComma)
+		if err != nil {
+			report.add(line, err)
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, report, nil
+}
+
+func readRecord(row []string, columns map[Column]int, decimalComma bool) (*Record, error) {
+	value := func(column Column) string {
+		if i, ok := columns[column]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	var record Record
+	var err error
+	if record.Time, err = parseTime(value(ColumnTime)); err != nil {
+		return nil, err
+	}
+	bitDepth, err := parseNumber(value(ColumnBitDepth), decimalComma)
+	if err != nil {
+		return nil, fmt.Errorf("bit depth: %w", err)
+	}
+	if bitDepth == nil {
+		return nil, errors.New("bit depth is missing")
+	}
+	record.BitDepth = *bitDepth
+
+	optional := []struct {
+		column Column
+		name   string
+		target **float64
+	}{
+		{ColumnHookLoad, "hook load", &record.HookLoad},
+		{ColumnSurfaceTorque, "surface torque", &record.SurfaceTorque},
+		{ColumnWeightOnBit, "weight on bit", &record.WeightOnBit},
+		{ColumnRPM, "rpm", &record.RPM},
+		{ColumnFlowRate, "flow rate", &record.FlowRate},
+		{ColumnStandpipePressure, "standpipe pressure", &record.StandpipePressure},
+	}
+	for _, field := range optional {
+		if *field.target, err = parseNumber(value(field.column), decimalComma); err != nil {
+			return nil, fmt.Errorf("%s: %w", field.name, err)
+		}
+	}
+	return &record, nil
+}
+
+// delimiter picks the separator that occurs most often in the header line.
+func delimiter(headerLine string) rune {
+	res, count := ',', strings.Count(headerLine, ",")
+	for _, candidate := range []rune{';', '\t'} {
+		if n := strings.Count(headerLine, string(candidate)); n > count {
+			res, count = candidate, n
+		}
+	}
+	return res
+}
+
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexAny(name, "(["); i > 0 {
+		name = name[:i]
+	}
+	return strings.NewReplacer(" ", "", "_", "", "-", "", ".", "").Replace(name)
+}
+
+func parseNumber(value string, decimalComma bool) (*float64, error) {
+	if nullValues[strings.ToLower(value)] {
+		return nil, nil
+	}
+	if decimalComma {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	res, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(res) || math.IsInf(res, 0) {
+		return nil, fmt.Errorf("%q is not a number", value)
+	}
+	return &res, nil
+}
+
+// parseTime accepts the layouts of timeLayouts and Unix time in seconds.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("time is missing")
+	}
+	for _, layout := range timeLayouts {
+		if res, err := time.Parse(layout, value); err == nil {
+			return res.UTC(), nil
+		}
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		whole, fraction := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(fraction*1e9)).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("time %q has an unknown format", value)
+}
+
+func isBlank(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Report) add(line int, err error) {
+	r.Skipped++
+	if len(r.Errors) < maxRowErrors {
+		r.Errors = append(r.Errors, fmt.Sprintf("line %d: %v", line, err))
+	}
+}
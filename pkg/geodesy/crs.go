@@ -0,0 +1,170 @@
+package geodesy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// CRS is a coordinate reference system identified by its EPSG code. Geographic systems
+// use latitude and longitude in degrees, projected systems use easting and northing in meters.
+type CRS struct {
+	Code       int                 `json:"epsg_code"`
+	Name       string              `json:"name"`
+	Datum      Datum               `json:"-"`
+	Projection *TransverseMercator `json:"-"`
+}
+
+// IsProjected reports whether the system has grid coordinates.
+func (c *CRS) IsProjected() bool {
+	return c.Projection != nil
+}
+
+// GridPoint is a position in the projected system together with the grid convergence
+// (angle from true north to grid north, degrees, positive when grid north is east of true north)
+// and the point scale factor.
+type GridPoint struct {
+	Easting         float64
+	Northing        float64
+	GridConvergence float64
+	ScaleFactor     float64
+}
+
+// ErrUnknownCRS is returned for EPSG codes that are not supported.
+var ErrUnknownCRS = errors.New("unknown coordinate reference system")
+
+var geographic = map[int]*CRS{
+	4326: {Code: 4326, Name: "WGS 84", Datum: DatumWGS84},
+	4269: {Code: 4269, Name: "NAD83", Datum: DatumNAD83},
+	4284: {Code: 4284, Name: "Pulkovo 1942", Datum: DatumPulkovo1942},
+	4230: {Code: 4230, Name: "ED50", Datum: DatumED50},
+	4267: {Code: 4267, Name: "NAD27", Datum: DatumNAD27},
+}
+
+// projectedFamily describes a contiguous range of EPSG codes of UTM or Gauss-Krüger zones.
+type projectedFamily struct {
+	firstCode int
+	firstZone int
+	lastZone  int
+	name      string
+	datum     Datum
+	south     bool
+	gauss     bool
+}
+
+var projectedFamilies = []projectedFamily{
+	{firstCode: 32601, firstZone: 1, lastZone: 60, name: "WGS 84 / UTM zone %dN", datum: DatumWGS84},
+	{firstCode: 32701, firstZone: 1, lastZone: 60, name: "WGS 84 / UTM zone %dS", datum: DatumWGS84, south: true},
+	{firstCode: 28404, firstZone: 4, lastZone: 32, name: "Pulkovo 1942 / Gauss-Kruger zone %d", datum: DatumPulkovo1942, gauss: true},
+	{firstCode: 23028, firstZone: 28, lastZone: 38, name: "ED50 / UTM zone %dN", datum: DatumED50},
+	{firstCode: 26703, firstZone: 3, lastZone: 22, name: "NAD27 / UTM zone %dN", datum: DatumNAD27},
+	{firstCode: 26903, firstZone: 3, lastZone: 23, name: "NAD83 / UTM zone %dN", datum: DatumNAD83},
+}
+
+func (f projectedFamily) crs(zone int) *CRS {
+	projection := &TransverseMercator{
+		CentralMeridian: float64(6*zone - 183),
+		ScaleFactor:     0.9996,
+		FalseEasting:    500000,
+	}
+	if f.south {
+		projection.FalseNorthing = 10000000
+	}
+	if f.gauss {
+		projection.CentralMeridian = float64(6*zone - 3)
+		projection.ScaleFactor = 1
+		projection.FalseEasting = float64(zone)*1000000 + 500000
+	}
+
+	return &CRS{
+		Code:       f.firstCode + zone - f.firstZone,
+		Name:       fmt.Sprintf(f.name, zone),
+		Datum:      f.datum,
+		Projection: projection,
+	}
+}
+
+// Lookup returns the coordinate reference system for the EPSG code.
+func Lookup(code int) (*CRS, error) {
+	if crs, ok := geographic[code]; ok {
+		return crs, nil
+	}
+	for _, family := range projectedFamilies {
+		zone := code - family.firstCode + family.firstZone
+		if zone >= family.firstZone && zone <= family.lastZone {
+			return family.crs(zone), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: EPSG:%d", ErrUnknownCRS, code)
+}
+
+// Supported returns all supported coordinate reference systems ordered by family and zone.
+func Supported() []*CRS {
+	res := []*CRS{geographic[4326], geographic[4269], geographic[4284], geographic[4230], geographic[4267]}
+	for _, family := range projectedFamilies {
+		for zone := family.firstZone; zone <= family.lastZone; zone++ {
+			res = append(res, family.crs(zone))
+		}
+	}
+	return res
+}
+
+// ToGrid projects latitude and longitude given in the datum of the system to grid coordinates.
+func (c *CRS) ToGrid(lat, lon float64) (*GridPoint, error) {
+	if !c.IsProjected() {
+		return nil, fmt.Errorf("EPSG:%d is not a projected coordinate reference system", c.Code)
+	}
+	if math.Abs(lat) > 90 || math.Abs(lon) > 180 {
+		return nil, fmt.Errorf("latitude %f and longitude %f are out of range", lat, lon)
+	}
+
+	easting, northing, convergence, scale := c.Projection.forward(c.Datum.Ellipsoid, lat, lon)
+	return &GridPoint{Easting: easting, Northing: northing, GridConvergence: convergence, ScaleFactor: scale}, nil
+}
+
+// ToGeographic converts grid coordinates to latitude and longitude in the datum of the system.
+func (c *CRS) ToGeographic(easting, northing float64) (float64, float64, error) {
+	if !c.IsProjected() {
+		return 0, 0, fmt.Errorf("EPSG:%d is not a projected coordinate reference system", c.Code)
+	}
+
+	lat, lon := c.Projection.inverse(c.Datum.Ellipsoid, easting, northing)
+	return lat, lon, nil
+}
+
+// Transform converts a coordinate pair between two systems, shifting the datum when they differ.
+// For geographic systems x is longitude and y is latitude, for projected systems x is easting and y is northing.
+func Transform(from, to *CRS, x, y float64) (float64, float64, error) {
+	lat, lon := y, x
+	if from.IsProjected() {
+		var err error
+		if lat, lon, err = from.ToGeographic(x, y); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	lat, lon = transformDatum(from.Datum, to.Datum, lat, lon)
+
+	if !to.IsProjected() {
+		return lon, lat, nil
+	}
+	point, err := to.ToGrid(lat, lon)
+	if err != nil {
+		return 0, 0, err
+	}
+	return point.Easting, point.Northing, nil
+}
+
+// LocalToGrid converts north and east offsets referenced to true north into grid coordinates
+// around the origin using the grid convergence in degrees and the point scale factor.
+func LocalToGrid(origin *GridPoint, localNorth, localEast float64) (float64, float64) {
+	gamma := toRadians(origin.GridConvergence)
+	scale := origin.ScaleFactor
+	if scale == 0 {
+		scale = 1
+	}
+
+	northing := origin.Northing + scale*(localNorth*math.Cos(gamma)+localEast*math.Sin(gamma))
+	easting := origin.Easting + scale*(localEast*math.Cos(gamma)-localNorth*math.Sin(gamma))
+	return northing, easting
+}
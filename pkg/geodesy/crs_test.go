@@ -0,0 +1,100 @@
+package geodesy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestToGridReferenceValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     int
+		lat, lon float64
+		easting  float64
+		northing float64
+		scale    float64
+	}{
+		// GeographicLib reference values
+		{"equator off the central meridian", 32631, 0, 0, 166021.4431, 0, 1.00098106},
+		{"central meridian at 45°N", 32618, 45, -75, 500000, 4982950.4002, 0.9996},
+		{"central meridian on the equator, south", 32731, 0, 3, 500000, 10000000, 0.9996},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crs, err := Lookup(tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			point, err := crs.ToGrid(tt.lat, tt.lon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(point.Easting-tt.easting) > 1e-3 || math.Abs(point.Northing-tt.northing) > 1e-3 {
+				t.Errorf("got %.4f %.4f, want %.4f %.4f", point.Easting, point.Northing, tt.easting, tt.northing)
+			}
+			if math.Abs(point.ScaleFactor-tt.scale) > 1e-8 {
+				t.Errorf("got scale factor %.8f, want %.8f", point.ScaleFactor, tt.scale)
+			}
+		})
+	}
+}
+
+func TestGridRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     int
+		lat, lon float64
+	}{
+		{"WGS 84 / UTM zone 39N", 32639, 46.8, 53.1},
+		{"WGS 84 / UTM zone 23S", 32723, -22.9, -43.2},
+		{"zone edge", 32640, 60.5, 54.01},
+		{"Pulkovo 1942 / Gauss-Kruger zone 9", 28409, 47.1, 51.9},
+		{"ED50 / UTM zone 31N", 23031, 61.2, 2.3},
+		{"NAD27 / UTM zone 15N", 26715, 29.7, -95.4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crs, err := Lookup(tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			point, err := crs.ToGrid(tt.lat, tt.lon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lat, lon, err := crs.ToGeographic(point.Easting, point.Northing)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// 1e-8° is about a millimeter
+			if math.Abs(lat-tt.lat) > 1e-8 || math.Abs(lon-tt.lon) > 1e-8 {
+				t.Errorf("got %.10f %.10f back, want %.10f %.10f", lat, lon, tt.lat, tt.lon)
+			}
+		})
+	}
+}
+
+func TestTransformDatumRoundTrip(t *testing.T) {
+	pulkovo, _ := Lookup(28409)
+	wgs84, _ := Lookup(4326)
+
+	lon, lat, err := Transform(pulkovo, wgs84, 9600000, 5220000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	easting, northing, err := Transform(wgs84, pulkovo, lon, lat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(easting-9600000) > 1e-3 || math.Abs(northing-5220000) > 1e-3 {
+		t.Errorf("got %.4f %.4f back, want 9600000 5220000", easting, northing)
+	}
+}
+
+func TestLookupUnknownCode(t *testing.T) {
+	for _, code := range []int{0, 32600, 32661, 28403, 28433} {
+		if _, err := Lookup(code); err == nil {
+			t.Errorf("Lookup(%d) returned no error", code)
+		}
+	}
+}
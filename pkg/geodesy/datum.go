@@ -0,0 +1,56 @@
+package geodesy
+
+// Helmert holds the parameters of a seven-parameter transformation to WGS 84 in the position vector
+// convention. Translations are in meters, rotations in arc-seconds and the scale difference in ppm.
+type Helmert struct {
+	DX, DY, DZ float64
+	RX, RY, RZ float64
+	DS         float64
+}
+
+// Datum is a geodetic datum with its transformation to WGS 84.
+type Datum struct {
+	Name      string
+	Ellipsoid Ellipsoid
+	ToWGS84   Helmert
+}
+
+var (
+	DatumWGS84 = Datum{Name: "WGS 84", Ellipsoid: WGS84}
+	// NAD 83 is treated as coincident with WGS 84 at the meter level
+	DatumNAD83 = Datum{Name: "NAD83", Ellipsoid: GRS80}
+	// GOST R 51794-2001 parameters
+	DatumPulkovo1942 = Datum{Name: "Pulkovo 1942", Ellipsoid: Krassowsky, ToWGS84: Helmert{DX: 23.92, DY: -141.27, DZ: -80.9, RY: 0.35, RZ: 0.82, DS: -0.12}}
+	DatumED50        = Datum{Name: "ED50", Ellipsoid: Hayford, ToWGS84: Helmert{DX: -87, DY: -98, DZ: -121}}
+	// Mean values for the conterminous United States
+	DatumNAD27 = Datum{Name: "NAD27", Ellipsoid: Clarke1866, ToWGS84: Helmert{DX: -8, DY: 160, DZ: 176}}
+)
+
+const arcSecond = 4.84813681109536e-6
+
+// apply transforms cartesian coordinates with the Helmert parameters, inverse applies the reverse transformation.
+func (h Helmert) apply(x, y, z float64, inverse bool) (float64, float64, float64) {
+	sign := 1.0
+	if inverse {
+		sign = -1
+	}
+	rx, ry, rz := sign*h.RX*arcSecond, sign*h.RY*arcSecond, sign*h.RZ*arcSecond
+	s := 1 + sign*h.DS*1e-6
+
+	return sign*h.DX + s*(x-rz*y+ry*z),
+		sign*h.DY + s*(rz*x+y-rx*z),
+		sign*h.DZ + s*(-ry*x+rx*y+z)
+}
+
+// transformDatum converts geographic coordinates in degrees from one datum to another through WGS 84.
+func transformDatum(from, to Datum, lat, lon float64) (float64, float64) {
+	if from.Name == to.Name {
+		return lat, lon
+	}
+
+	x, y, z := from.Ellipsoid.toECEF(lat, lon, 0)
+	x, y, z = from.ToWGS84.apply(x, y, z, false)
+	x, y, z = to.ToWGS84.apply(x, y, z, true)
+	lat, lon, _ = to.Ellipsoid.fromECEF(x, y, z)
+	return lat, lon
+}
@@ -0,0 +1,70 @@
+package geodesy
+
+import "math"
+
+// Ellipsoid is a reference ellipsoid given by its semi-major axis in meters and inverse flattening.
+type Ellipsoid struct {
+	Name string
+	A    float64
+	InvF float64
+}
+
+var (
+	WGS84      = Ellipsoid{Name: "WGS 84", A: 6378137, InvF: 298.257223563}
+	GRS80      = Ellipsoid{Name: "GRS 1980", A: 6378137, InvF: 298.257222101}
+	Krassowsky = Ellipsoid{Name: "Krassowsky 1940", A: 6378245, InvF: 298.3}
+	Hayford    = Ellipsoid{Name: "International 1924", A: 6378388, InvF: 297}
+	Clarke1866 = Ellipsoid{Name: "Clarke 1866", A: 6378206.4, InvF: 294.9786982138982}
+)
+
+// F returns the flattening.
+func (e Ellipsoid) F() float64 {
+	return 1 / e.InvF
+}
+
+// E2 returns the first eccentricity squared.
+func (e Ellipsoid) E2() float64 {
+	f := e.F()
+	return f * (2 - f)
+}
+
+// toECEF converts geodetic coordinates in degrees and ellipsoidal height in meters to earth-centered cartesian coordinates.
+func (e Ellipsoid) toECEF(lat, lon, h float64) (float64, float64, float64) {
+	phi, lambda := toRadians(lat), toRadians(lon)
+	e2 := e.E2()
+	n := e.A / math.Sqrt(1-e2*math.Sin(phi)*math.Sin(phi))
+
+	return (n + h) * math.Cos(phi) * math.Cos(lambda),
+		(n + h) * math.Cos(phi) * math.Sin(lambda),
+		(n*(1-e2) + h) * math.Sin(phi)
+}
+
+// fromECEF converts earth-centered cartesian coordinates to geodetic latitude, longitude in degrees and height in meters.
+func (e Ellipsoid) fromECEF(x, y, z float64) (float64, float64, float64) {
+	e2 := e.E2()
+	p := math.Hypot(x, y)
+	lambda := math.Atan2(y, x)
+
+	phi := math.Atan2(z, p*(1-e2))
+	var h float64
+	for i := 0; i < 10; i++ {
+		n := e.A / math.Sqrt(1-e2*math.Sin(phi)*math.Sin(phi))
+		h = p/math.Cos(phi) - n
+		next := math.Atan2(z, p*(1-e2*n/(n+h)))
+		if math.Abs(next-phi) < 1e-14 {
+			phi = next
+			break
+		}
+		phi = next
+	}
+
+	return toDegrees(phi), toDegrees(lambda), h
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,108 @@
+package geodesy
+
+import "math"
+
+// TransverseMercator holds the parameters of a transverse Mercator projection with the latitude of origin on the equator.
+type TransverseMercator struct {
+	CentralMeridian float64
+	ScaleFactor     float64
+	FalseEasting    float64
+	FalseNorthing   float64
+}
+
+// krueger holds the series coefficients of the Krüger formulation for an ellipsoid, accurate to millimeters
+// within the usual width of UTM and Gauss-Krüger zones.
+type krueger struct {
+	a     float64 // rectifying radius
+	e     float64
+	n     float64
+	alpha [3]float64
+	beta  [3]float64
+	delta [3]float64
+}
+
+func newKrueger(ellipsoid Ellipsoid) krueger {
+	f := ellipsoid.F()
+	n := f / (2 - f)
+	n2, n3 := n*n, n*n*n
+
+	return krueger{
+		a: ellipsoid.A / (1 + n) * (1 + n2/4 + n2*n2/64),
+		e: math.Sqrt(ellipsoid.E2()),
+		n: n,
+		alpha: [3]float64{
+			n/2 - 2*n2/3 + 5*n3/16,
+			13*n2/48 - 3*n3/5,
+			61 * n3 / 240,
+		},
+		beta: [3]float64{
+			n/2 - 2*n2/3 + 37*n3/96,
+			n2/48 + n3/15,
+			17 * n3 / 480,
+		},
+		delta: [3]float64{
+			2*n - 2*n2/3 - 2*n3,
+			7*n2/3 - 8*n3/5,
+			56 * n3 / 15,
+		},
+	}
+}
+
+// forward projects geographic coordinates in degrees and returns easting, northing,
+// grid convergence in degrees and point scale factor.
+func (p TransverseMercator) forward(ellipsoid Ellipsoid, lat, lon float64) (float64, float64, float64, float64) {
+	k := newKrueger(ellipsoid)
+	phi := toRadians(lat)
+	dLambda := toRadians(lon - p.CentralMeridian)
+
+	t := math.Sinh(math.Atanh(math.Sin(phi)) - k.e*math.Atanh(k.e*math.Sin(phi)))
+	xiP := math.Atan2(t, math.Cos(dLambda))
+	etaP := math.Atanh(math.Sin(dLambda) / math.Sqrt(1+t*t))
+
+	xi, eta := xiP, etaP
+	sigma, tau := 1.0, 0.0
+	for j := 1; j <= 3; j++ {
+		a, jj := k.alpha[j-1], float64(2*j)
+		xi += a * math.Sin(jj*xiP) * math.Cosh(jj*etaP)
+		eta += a * math.Cos(jj*xiP) * math.Sinh(jj*etaP)
+		sigma += jj * a * math.Cos(jj*xiP) * math.Cosh(jj*etaP)
+		tau += jj * a * math.Sin(jj*xiP) * math.Sinh(jj*etaP)
+	}
+
+	easting := p.FalseEasting + p.ScaleFactor*k.a*eta
+	northing := p.FalseNorthing + p.ScaleFactor*k.a*xi
+
+	tanPhi := math.Tan(phi)
+	scale := p.ScaleFactor * k.a / ellipsoid.A * math.Sqrt(
+		(1+math.Pow((1-k.n)/(1+k.n)*tanPhi, 2))*(sigma*sigma+tau*tau)/(t*t+math.Cos(dLambda)*math.Cos(dLambda)),
+	)
+	convergence := math.Atan2(
+		tau*math.Sqrt(1+t*t)+sigma*t*math.Tan(dLambda),
+		sigma*math.Sqrt(1+t*t)-tau*t*math.Tan(dLambda),
+	)
+
+	return easting, northing, toDegrees(convergence), scale
+}
+
+// inverse converts projected coordinates to geographic latitude and longitude in degrees.
+func (p TransverseMercator) inverse(ellipsoid Ellipsoid, easting, northing float64) (float64, float64) {
+	k := newKrueger(ellipsoid)
+	xi := (northing - p.FalseNorthing) / (p.ScaleFactor * k.a)
+	eta := (easting - p.FalseEasting) / (p.ScaleFactor * k.a)
+
+	xiP, etaP := xi, eta
+	for j := 1; j <= 3; j++ {
+		b, jj := k.beta[j-1], float64(2*j)
+		xiP -= b * math.Sin(jj*xi) * math.Cosh(jj*eta)
+		etaP -= b * math.Cos(jj*xi) * math.Sinh(jj*eta)
+	}
+
+	chi := math.Asin(math.Sin(xiP) / math.Cosh(etaP))
+	phi := chi
+	for j := 1; j <= 3; j++ {
+		phi += k.delta[j-1] * math.Sin(float64(2*j)*chi)
+	}
+	lambda := math.Atan2(math.Sinh(etaP), math.Cos(xiP))
+
+	return toDegrees(phi), p.CentralMeridian + toDegrees(lambda)
+}
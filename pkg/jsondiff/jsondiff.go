@@ -0,0 +1,168 @@
+// Package jsondiff compares two values field by field through their JSON representation.
+package jsondiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// idKey is the key that identifies the elements of an array of objects. Elements with an id are
+// matched by it, so inserting or removing an element does not show up as a change of every element after it.
+// When the id is ignored, elements are matched by index, which compares copies of data with different ids.
+const idKey = "id"
+
+// Change is a single changed field. Path is in dot notation, array elements are addressed
+// by their id as `[id=...]` or by their index as `[0]`. Before is nil for added fields and After
+// is nil for removed ones.
+type Change struct {
+	Path   string      `json:"path"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Diff returns the changes from before to after, sorted by path. Both values are marshaled to JSON
+// first, so only exported fields with their JSON names are compared. Keys in ignore are skipped at any depth.
+func Diff(before, after interface{}, ignore ...string) ([]Change, error) {
+	a, err := normalize(before)
+	if err != nil {
+		return nil, err
+	}
+	b, err := normalize(after)
+	if err != nil {
+		return nil, err
+	}
+
+	d := differ{ignore: make(map[string]bool, len(ignore))}
+	for _, key := range ignore {
+		d.ignore[key] = true
+	}
+	d.diff("", a, b)
+
+	if d.changes == nil {
+		d.changes = []Change{}
+	}
+	sort.SliceStable(d.changes, func(i, j int) bool { return d.changes[i].Path < d.changes[j].Path })
+	return d.changes, nil
+}
+
+type differ struct {
+	ignore  map[string]bool
+	changes []Change
+}
+
+func (d *differ) diff(path string, a, b interface{}) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			d.diffObjects(path, av, bv)
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			d.diffArrays(path, av, bv)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		d.changes = append(d.changes, Change{Path: path, Before: a, After: b})
+	}
+}
+
+func (d *differ) diffObjects(path string, a, b map[string]interface{}) {
+	for key, value := range a {
+		if d.ignore[key] {
+			continue
+		}
+		d.diff(join(path, key), value, b[key])
+	}
+	for key, value := range b {
+		if _, ok := a[key]; ok || d.ignore[key] {
+			continue
+		}
+		d.diff(join(path, key), nil, value)
+	}
+}
+
+func (d *differ) diffArrays(path string, a, b []interface{}) {
+	aIDs, aOk := ids(a)
+	bIDs, bOk := ids(b)
+	if !aOk || !bOk || d.ignore[idKey] {
+		for i := 0; i < len(a) || i < len(b); i++ {
+			d.diff(fmt.Sprintf("%s[%d]", path, i), at(a, i), at(b, i))
+		}
+		return
+	}
+
+	for i, id := range aIDs {
+		var other interface{}
+		if j, ok := indexOf(bIDs, id); ok {
+			other = b[j]
+		}
+		d.diff(fmt.Sprintf("%s[%s=%s]", path, idKey, id), a[i], other)
+	}
+	for j, id := range bIDs {
+		if _, ok := indexOf(aIDs, id); !ok {
+			d.diff(fmt.Sprintf("%s[%s=%s]", path, idKey, id), nil, b[j])
+		}
+	}
+}
+
+// ids returns the ids of the elements if every element of the array is an object with a string id.
+func ids(values []interface{}) ([]string, bool) {
+	res := make([]string, len(values))
+	for i, value := range values {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		id, ok := object[idKey].(string)
+		if !ok || id == "" {
+			return nil, false
+		}
+		res[i] = id
+	}
+	return res, true
+}
+
+func indexOf(values []string, value string) (int, bool) {
+	for i, v := range values {
+		if v == value {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func at(values []interface{}, i int) interface{} {
+	if i < len(values) {
+		return values[i]
+	}
+	return nil
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// normalize converts v to the generic form encoding/json decodes into. Raw JSON is decoded as is.
+func normalize(v interface{}) (interface{}, error) {
+	data, ok := v.(json.RawMessage)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var res interface{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,205 @@
+// Package las reads well log files in the Log ASCII Standard, versions 1.2 and 2.0.
+//
+// A file has sections that start with a "~" line: ~V holds the version and the wrap mode, ~W the well
+// information, ~C the curves in the order of the data columns, ~P the parameters and ~A the data. Header
+// lines have the form "MNEM.UNIT VALUE : DESCRIPTION", lines starting with "#" are comments. In wrapped
+// files the values of a depth step continue over several lines.
+package las
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// defaultNull is the null value of files without a NULL item.
+const defaultNull = -999.25
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported las version, only 1.2 and 2.0 are read")
+	ErrNoCurves           = errors.New("the file has no curve information")
+	ErrNoData             = errors.New("the file has no data")
+)
+
+// HeaderItem is a line of the version, well, curve or parameter section.
+type HeaderItem struct {
+	Mnemonic    string
+	Unit        string
+	Value       string
+	Description string
+}
+
+// Curve is a data column of the file. Null values are NaN.
+type Curve struct {
+	HeaderItem
+	Values []float64
+}
+
+type File struct {
+	Version    string
+	Wrap       bool
+	Well       []HeaderItem
+	Parameters []HeaderItem
+	Curves     []*Curve
+}
+
+// Read reads the file. The first curve is the index, usually the measured depth.
+func Read(r io.Reader) (*File, error) {
+	file := &File{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var section byte
+	var pending []float64
+	var pendingLine int
+	null := math.NaN()
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "~") {
+			if len(line) < 2 {
+				return nil, fmt.Errorf("line %d: section without a name", lineNumber)
+			}
+			section = upper(line[1])
+			if section == 'A' {
+				if len(file.Curves) == 0 {
+					return nil, ErrNoCurves
+				}
+				null = file.null()
+			}
+			continue
+		}
+
+		switch section {
+		case 'V':
+			item := parseHeaderItem(line)
+			switch strings.ToUpper(item.Mnemonic) {
+			case "VERS":
+				file.Version = item.Value
+				if !strings.HasPrefix(file.Version, "1.2") && !strings.HasPrefix(file.Version, "2.0") {
+					return nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, file.Version)
+				}
+			case "WRAP":
+				file.Wrap = strings.EqualFold(item.Value, "YES")
+			}
+		case 'W':
+			file.Well = append(file.Well, parseHeaderItem(line))
+		case 'C':
+			file.Curves = append(file.Curves, &Curve{HeaderItem: parseHeaderItem(line)})
+		case 'P':
+			file.Parameters = append(file.Parameters, parseHeaderItem(line))
+		case 'A':
+			if len(pending) == 0 {
+				pendingLine = lineNumber
+			}
+			for _, field := range strings.Fields(line) {
+				value, err := strconv.ParseFloat(field, 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %q is not a number", lineNumber, field)
+				}
+				if value == null || math.IsNaN(value) {
+					value = math.NaN()
+				}
+				pending = append(pending, value)
+			}
+			if !file.Wrap && len(pending) != len(file.Curves) {
+				return nil, fmt.Errorf("line %d: %d values for %d curves", lineNumber, len(pending), len(file.Curves))
+			}
+			if len(pending) > len(file.Curves) {
+				return nil, fmt.Errorf("line %d: the depth step starting on line %d has more values than curves", lineNumber, pendingLine)
+			}
+			if len(pending) == len(file.Curves) {
+				for i, curve := range file.Curves {
+					curve.Values = append(curve.Values, pending[i])
+				}
+				pending = pending[:0]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(file.Curves) == 0 {
+		return nil, ErrNoCurves
+	}
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("line %d: the last depth step has %d values for %d curves", pendingLine, len(pending), len(file.Curves))
+	}
+	if len(file.Curves[0].Values) == 0 {
+		return nil, ErrNoData
+	}
+	return file, nil
+}
+
+// WellItem returns the item of the well section with the mnemonic, case-insensitive.
+func (f *File) WellItem(mnemonic string) (HeaderItem, bool) {
+	for _, item := range f.Well {
+		if strings.EqualFold(item.Mnemonic, mnemonic) {
+			return item, true
+		}
+	}
+	return HeaderItem{}, false
+}
+
+// Index returns the index curve.
+func (f *File) Index() *Curve {
+	return f.Curves[0]
+}
+
+// Curve returns the first curve with one of the mnemonics, case-insensitive, nil when the file has none.
+// The index curve is not returned.
+func (f *File) Curve(mnemonics ...string) *Curve {
+	for _, mnemonic := range mnemonics {
+		for _, curve := range f.Curves[1:] {
+			if strings.EqualFold(curve.Mnemonic, mnemonic) {
+				return curve
+			}
+		}
+	}
+	return nil
+}
+
+func (f *File) null() float64 {
+	if item, ok := f.WellItem("NULL"); ok {
+		if value, err := strconv.ParseFloat(item.Value, 64); err == nil {
+			return value
+		}
+	}
+	return defaultNull
+}
+
+// parseHeaderItem splits "MNEM.UNIT VALUE : DESCRIPTION". The unit ends at the first space after the dot
+// and the description starts after the last colon, so values like times may contain colons.
+func parseHeaderItem(line string) HeaderItem {
+	var item HeaderItem
+	mnemonic, rest, found := strings.Cut(line, ".")
+	item.Mnemonic = strings.TrimSpace(mnemonic)
+	if !found {
+		return item
+	}
+	if i := strings.LastIndex(rest, ":"); i >= 0 {
+		item.Description = strings.TrimSpace(rest[i+1:])
+		rest = rest[:i]
+	}
+	if i := strings.IndexAny(rest, " \t"); i >= 0 {
+		item.Unit, item.Value = rest[:i], strings.TrimSpace(rest[i:])
+	} else {
+		item.Unit = rest
+	}
+	return item
+}
+
+func upper(b byte) byte {
+	if b >= 'a' && b <= 'z' {
+		return b - 'a' + 'A'
+	}
+	return b
+}
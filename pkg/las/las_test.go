@@ -0,0 +1,146 @@
+package las
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+const unwrapped = `~VERSION INFORMATION
+ VERS.                 2.0 :   CWLS LOG ASCII STANDARD - VERSION 2.0
+ WRAP.                  NO :   ONE LINE PER DEPTH STEP
+~WELL INFORMATION
+ STRT.M             1670.0 :   START DEPTH
+ STOP.M             1669.75 :  STOP DEPTH
+ NULL.             -999.25 :   NULL VALUE
+ WELL.      ANY ET AL 12-34-12-34 :   WELL
+ DATE.      13/12/1986 10:30:00 :   LOG DATE
+~CURVE INFORMATION
+ DEPT.M                    :   DEPTH
+ DT  .US/M                 :   SONIC TRANSIT TIME
+ RHOB.K/M3                 :   BULK DENSITY
+# the data follows
+~A
+1670.000   123.450 2550.000
+1669.875   123.450 -999.25
+1669.750   -999.25 2550.000
+`
+
+const wrapped = `~V
+VERS. 1.2 : CWLS LOG ASCII STANDARD - VERSION 1.2
+WRAP. YES : MULTIPLE LINES PER DEPTH STEP
+~W
+NULL. -9999 : NULL VALUE
+~C
+DEPT.FT : DEPTH
+GR  .GAPI : GAMMA RAY
+ILD .OHMM : DEEP RESISTIVITY
+~A
+910.0
+  45.2 -9999
+911.0
+  50.1
+  2.8
+`
+
+func TestReadUnwrapped(t *testing.T) {
+	file, err := Read(strings.NewReader(unwrapped))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Version != "2.0" || file.Wrap {
+		t.Errorf("got version %q and wrap %v, want 2.0 unwrapped", file.Version, file.Wrap)
+	}
+	if item, ok := file.WellItem("date"); !ok || item.Value != "13/12/1986 10:30:00" || item.Description != "LOG DATE" {
+		t.Errorf("got date item %+v", item)
+	}
+	if file.Index().Mnemonic != "DEPT" || file.Index().Unit != "M" {
+		t.Errorf("got index %s.%s, want DEPT.M", file.Index().Mnemonic, file.Index().Unit)
+	}
+	if curve := file.Curve("DT"); curve == nil || curve.Unit != "US/M" {
+		t.Errorf("got sonic curve %+v", curve)
+	}
+	if file.Curve("DEPT") != nil {
+		t.Error("Curve() returned the index curve")
+	}
+
+	expectValues(t, file.Index().Values, 1670, 1669.875, 1669.75)
+	expectValues(t, file.Curve("dt").Values, 123.45, 123.45, math.NaN())
+	expectValues(t, file.Curve("GR", "RHOB").Values, 2550, math.NaN(), 2550)
+}
+
+func TestReadWrapped(t *testing.T) {
+	file, err := Read(strings.NewReader(wrapped))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Version != "1.2" || !file.Wrap {
+		t.Errorf("got version %q and wrap %v, want 1.2 wrapped", file.Version, file.Wrap)
+	}
+	expectValues(t, file.Index().Values, 910, 911)
+	expectValues(t, file.Curve("GR").Values, 45.2, 50.1)
+	expectValues(t, file.Curve("ILD").Values, math.NaN(), 2.8)
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     error
+		message string
+	}{
+		{"version 3.0", "~V\nVERS. 3.0 : VERSION\n", ErrUnsupportedVersion, ""},
+		{"no curves", "~V\nVERS. 2.0 :\n~A\n1 2\n", ErrNoCurves, ""},
+		{"no data", "~V\nVERS. 2.0 :\n~C\nDEPT.M :\n~A\n", ErrNoData, ""},
+		{"missing value", "~C\nDEPT.M :\nGR.GAPI :\n~A\n1 2\n3\n", nil, "line 6: 1 values for 2 curves"},
+		{"not a number", "~C\nDEPT.M :\n~A\nabc\n", nil, `line 4: "abc" is not a number`},
+		{"unfinished wrapped step", "~V\nWRAP. YES :\n~C\nDEPT.M :\nGR.GAPI :\n~A\n1\n", nil, "the last depth step has 1 values"},
+		{"section without a name", "~\n", nil, "line 1: section without a name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.content))
+			if err == nil {
+				t.Fatal("Read() returned no error")
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("got %v, want %v", err, tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("got %q, want it to contain %q", err, tt.message)
+			}
+		})
+	}
+}
+
+func TestParseHeaderItem(t *testing.T) {
+	tests := []struct {
+		line string
+		want HeaderItem
+	}{
+		{"STRT.M 1670.0 : START DEPTH", HeaderItem{"STRT", "M", "1670.0", "START DEPTH"}},
+		{"DATE. 13/12/1986 10:30:00 : LOG DATE", HeaderItem{"DATE", "", "13/12/1986 10:30:00", "LOG DATE"}},
+		{"RHOB.K/M3 : BULK DENSITY", HeaderItem{"RHOB", "K/M3", "", "BULK DENSITY"}},
+		{"COMP  .  ANY OIL COMPANY", HeaderItem{"COMP", "", "ANY OIL COMPANY", ""}},
+		{"NOTE", HeaderItem{Mnemonic: "NOTE"}},
+	}
+	for _, tt := range tests {
+		if got := parseHeaderItem(tt.line); got != tt.want {
+			t.Errorf("parseHeaderItem(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func expectValues(t *testing.T, got []float64, want ...float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if math.IsNaN(want[i]) != math.IsNaN(got[i]) || !math.IsNaN(want[i]) && got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			return
+		}
+	}
+}
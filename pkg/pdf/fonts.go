@@ -0,0 +1,181 @@
+package pdf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+	fontCount
+)
+
+// face is a parsed TrueType font. Its methods are safe for concurrent use, the documents keep the
+// glyphs they use apart.
+type face struct {
+	name       string
+	data       []byte
+	font       *sfnt.Font
+	unitsPerEm float64
+	ascent     float64
+	descent    float64
+	capHeight  float64
+	bbox       [4]float64
+}
+
+var faces = [fontCount]func() *face{
+	Regular: sync.OnceValue(func() *face { return parseFace("GoRegular", goregular.TTF) }),
+	Bold:    sync.OnceValue(func() *face { return parseFace("GoBold", gobold.TTF) }),
+}
+
+// parseFace panics on errors, the fonts are compiled in.
+func parseFace(name string, data []byte) *face {
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		panic(fmt.Sprintf("pdf: parse font %s: %v", name, err))
+	}
+	var buf sfnt.Buffer
+	unitsPerEm := float64(parsed.UnitsPerEm())
+	ppem := fixed.Int26_6(parsed.UnitsPerEm()) << 6
+	metrics, err := parsed.Metrics(&buf, ppem, font.HintingNone)
+	if err != nil {
+		panic(fmt.Sprintf("pdf: font metrics %s: %v", name, err))
+	}
+	bounds, err := parsed.Bounds(&buf, ppem, font.HintingNone)
+	if err != nil {
+		panic(fmt.Sprintf("pdf: font bounds %s: %v", name, err))
+	}
+
+	// Metrics are in font units, PDF measures fonts in thousandths of the em with y up
+	scale := func(v fixed.Int26_6) float64 { return float64(v) / 64 * 1000 / unitsPerEm }
+	return &face{
+		name:       name,
+		data:       data,
+		font:       parsed,
+		unitsPerEm: unitsPerEm,
+		ascent:     scale(metrics.Ascent),
+		descent:    -scale(metrics.Descent),
+		capHeight:  scale(metrics.CapHeight),
+		bbox:       [4]float64{scale(bounds.Min.X), -scale(bounds.Max.Y), scale(bounds.Max.X), -scale(bounds.Min.Y)},
+	}
+}
+
+// fontUsage maps the runes of a document to glyphs and remembers the glyphs used for the widths and the
+// text extraction map of the embedded font.
+type fontUsage struct {
+	face   *face
+	buf    sfnt.Buffer
+	glyphs map[rune]sfnt.GlyphIndex
+	widths map[sfnt.GlyphIndex]float64
+	runes  map[sfnt.GlyphIndex]rune
+}
+
+func newFontUsage(f *face) *fontUsage {
+	return &fontUsage{
+		face:   f,
+		glyphs: make(map[rune]sfnt.GlyphIndex),
+		widths: make(map[sfnt.GlyphIndex]float64),
+		runes:  make(map[sfnt.GlyphIndex]rune),
+	}
+}
+
+// glyph returns the glyph of the rune, runes the font has no glyph for are printed as a question mark.
+func (u *fontUsage) glyph(r rune) sfnt.GlyphIndex {
+	if glyph, ok := u.glyphs[r]; ok {
+		return glyph
+	}
+	glyph, err := u.face.font.GlyphIndex(&u.buf, r)
+	if (err != nil || glyph == 0) && r != '?' {
+		glyph = u.glyph('?')
+	}
+	u.glyphs[r] = glyph
+	if _, ok := u.widths[glyph]; !ok {
+		ppem := fixed.Int26_6(u.face.unitsPerEm) << 6
+		advance, err := u.face.font.GlyphAdvance(&u.buf, glyph, ppem, font.HintingNone)
+		if err == nil {
+			u.widths[glyph] = float64(advance) / 64 * 1000 / u.face.unitsPerEm
+		}
+		u.runes[glyph] = r
+	}
+	return glyph
+}
+
+// width returns the width of the text in thousandths of the font size.
+func (u *fontUsage) width(text string) float64 {
+	var res float64
+	for _, r := range text {
+		res += u.widths[u.glyph(r)]
+	}
+	return res
+}
+
+// encode returns the glyphs of the text as a hex string for Identity-H encoding.
+func (u *fontUsage) encode(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		fmt.Fprintf(&b, "%04X", uint16(u.glyph(r)))
+	}
+	return b.String()
+}
+
+// writeFont embeds the font with the widths of the used glyphs and returns the reference of the Type0 font.
+func (w *writer) writeFont(u *fontUsage) (int, error) {
+	f := u.face
+	file, err := w.writeStream(fmt.Sprintf("/Length1 %d ", len(f.data)), f.data)
+	if err != nil {
+		return 0, err
+	}
+	descriptor := w.writeObject(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%s %s %s %s] /ItalicAngle 0 /Ascent %s /Descent %s /CapHeight %s /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, number(f.bbox[0]), number(f.bbox[1]), number(f.bbox[2]), number(f.bbox[3]), number(f.ascent), number(f.descent), number(f.capHeight), file))
+
+	glyphs := make([]sfnt.GlyphIndex, 0, len(u.widths))
+	for glyph := range u.widths {
+		glyphs = append(glyphs, glyph)
+	}
+	sort.Slice(glyphs, func(i, j int) bool { return glyphs[i] < glyphs[j] })
+	var widths strings.Builder
+	for _, glyph := range glyphs {
+		fmt.Fprintf(&widths, "%d [%s] ", glyph, number(u.widths[glyph]))
+	}
+	cidFont := w.writeObject(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /DW 1000 /W [%s] /CIDToGIDMap /Identity >>",
+		f.name, descriptor, widths.String()))
+
+	toUnicode, err := w.writeStream("", toUnicodeCMap(glyphs, u.runes))
+	if err != nil {
+		return 0, err
+	}
+	return w.writeObject(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, cidFont, toUnicode)), nil
+}
+
+// toUnicodeCMap maps the glyphs back to their runes so that viewers can search and copy the text.
+func toUnicodeCMap(glyphs []sfnt.GlyphIndex, runes map[sfnt.GlyphIndex]rune) []byte {
+	var b strings.Builder
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	b.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	b.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	for start := 0; start < len(glyphs); start += 100 {
+		end := start + 100
+		if end > len(glyphs) {
+			end = len(glyphs)
+		}
+		fmt.Fprintf(&b, "%d beginbfchar\n", end-start)
+		for _, glyph := range glyphs[start:end] {
+			fmt.Fprintf(&b, "<%04X> <%s>\n", uint16(glyph), strings.Trim(textString(string(runes[glyph])), "<>")[4:])
+		}
+		b.WriteString("endbfchar\n")
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,228 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	margin       = 40.0
+	headerHeight = 24.0
+	footerHeight = 24.0
+	textSize     = 9.0
+	lineHeight   = 12.0
+	cellPadding  = 3.0
+)
+
+var (
+	textColor   = Color{33, 37, 41}
+	mutedColor  = Color{108, 117, 125}
+	accentColor = Color{31, 78, 121}
+	ruleColor   = Color{206, 212, 218}
+	headerFill  = Color{226, 232, 240}
+	stripeFill  = Color{246, 248, 250}
+)
+
+// Flow lays blocks out from the top to the bottom of the pages and starts a new page when a block does not
+// fit. Every page gets the running header and, when the flow is closed, the page numbers.
+type Flow struct {
+	doc    *Document
+	header string
+	page   *Page
+	y      float64
+}
+
+func NewFlow(doc *Document, header string) *Flow {
+	f := &Flow{doc: doc, header: header}
+	f.NewPage()
+	return f
+}
+
+// Width returns the width of the blocks.
+func (f *Flow) Width() float64 {
+	return PageWidth - 2*margin
+}
+
+func (f *Flow) NewPage() {
+	f.page = f.doc.AddPage()
+	f.y = margin + headerHeight
+	if f.header != "" {
+		f.page.Text(margin, margin, f.header, Regular, 8, mutedColor)
+		f.page.Line(margin, margin+5, PageWidth-margin, margin+5, LineStyle{Width: 0.5, Color: ruleColor})
+	}
+}
+
+// Ensure starts a new page unless the height fits below the current position.
+func (f *Flow) Ensure(height float64) {
+	if f.y+height > PageHeight-margin-footerHeight {
+		f.NewPage()
+	}
+}
+
+func (f *Flow) Space(height float64) {
+	f.y += height
+}
+
+func (f *Flow) Title(text string) {
+	f.Ensure(30)
+	f.page.Text(margin, f.y+18, text, Bold, 18, accentColor)
+	f.y += 30
+}
+
+// Heading starts a section, it moves to the next page with the first lines of the section.
+func (f *Flow) Heading(text string) {
+	f.Ensure(60)
+	f.y += 8
+	f.page.Text(margin, f.y+12, text, Bold, 12, accentColor)
+	f.page.Line(margin, f.y+16, PageWidth-margin, f.y+16, LineStyle{Width: 0.75, Color: accentColor})
+	f.y += 24
+}
+
+func (f *Flow) Subheading(text string) {
+	f.Ensure(40)
+	f.page.Text(margin, f.y+10, text, Bold, 10, textColor)
+	f.y += 16
+}
+
+// Paragraph draws the text wrapped at word boundaries.
+func (f *Flow) Paragraph(text string) {
+	for _, line := range f.wrap(text, Regular, textSize, f.Width()) {
+		f.Ensure(lineHeight)
+		f.page.Text(margin, f.y+textSize, line, Regular, textSize, textColor)
+		f.y += lineHeight
+	}
+	f.y += 4
+}
+
+// Fields draws label and value pairs in two columns of pairs.
+func (f *Flow) Fields(fields [][2]string) {
+	columnWidth := f.Width() / 2
+	labelWidth := columnWidth * 0.38
+	for i := 0; i < len(fields); i += 2 {
+		f.Ensure(lineHeight + 2)
+		for j := i; j < i+2 && j < len(fields); j++ {
+			x := margin + float64(j-i)*columnWidth
+			f.page.Text(x, f.y+textSize, f.fit(fields[j][0], Bold, textSize, labelWidth-cellPadding), Bold, textSize, mutedColor)
+			f.page.Text(x+labelWidth, f.y+textSize, f.fit(fields[j][1], Regular, textSize, columnWidth-labelWidth-cellPadding), Regular, textSize, textColor)
+		}
+		f.y += lineHeight + 2
+	}
+	f.y += 6
+}
+
+// Column of a table. Widths are relative to the other columns, numeric columns are aligned right.
+type Column struct {
+	Title   string
+	Width   float64
+	Numeric bool
+}
+
+// Table draws the rows under a header row that is repeated on every page. Cell texts that do not fit are
+// cut with an ellipsis.
+func (f *Flow) Table(columns []Column, rows [][]string) {
+	var total float64
+	for _, column := range columns {
+		total += column.Width
+	}
+	widths := make([]float64, len(columns))
+	for i, column := range columns {
+		widths[i] = column.Width / total * f.Width()
+	}
+
+	rowHeight := textSize + 2*cellPadding + 1
+	drawHeader := func() {
+		f.page.FillRect(margin, f.y, f.Width(), rowHeight, headerFill)
+		f.drawRow(columns, widths, func(i int) string { return columns[i].Title }, Bold, rowHeight)
+	}
+
+	f.Ensure(2 * rowHeight)
+	drawHeader()
+	for index, row := range rows {
+		if f.y+rowHeight > PageHeight-margin-footerHeight {
+			f.NewPage()
+			drawHeader()
+		}
+		if index%2 == 1 {
+			f.page.FillRect(margin, f.y, f.Width(), rowHeight, stripeFill)
+		}
+		f.drawRow(columns, widths, func(i int) string {
+			if i < len(row) {
+				return row[i]
+			}
+			return ""
+		}, Regular, rowHeight)
+	}
+	f.page.Line(margin, f.y, PageWidth-margin, f.y, LineStyle{Width: 0.5, Color: ruleColor})
+	f.y += 10
+}
+
+func (f *Flow) drawRow(columns []Column, widths []float64, cell func(int) string, font Font, height float64) {
+	x := margin
+	for i, column := range columns {
+		text := f.fit(cell(i), font, textSize, widths[i]-2*cellPadding)
+		textX := x + cellPadding
+		if column.Numeric {
+			textX = x + widths[i] - cellPadding - f.doc.TextWidth(text, font, textSize)
+		}
+		f.page.Text(textX, f.y+cellPadding+textSize-1, text, font, textSize, textColor)
+		x += widths[i]
+	}
+	f.y += height
+}
+
+// Block reserves the height on one page and lets draw paint it with the top left corner at x, y.
+func (f *Flow) Block(height float64, draw func(page *Page, x, y, width float64)) {
+	f.Ensure(height)
+	draw(f.page, margin, f.y, f.Width())
+	f.y += height
+}
+
+// Close draws the footers with the page numbers, call it after the last block.
+func (f *Flow) Close(footer string) {
+	pages := f.doc.Pages()
+	for i, page := range pages {
+		y := PageHeight - margin
+		page.Line(margin, y-10, PageWidth-margin, y-10, LineStyle{Width: 0.5, Color: ruleColor})
+		page.Text(margin, y, footer, Regular, 8, mutedColor)
+		number := fmt.Sprintf("Page %d of %d", i+1, len(pages))
+		page.Text(PageWidth-margin-f.doc.TextWidth(number, Regular, 8), y, number, Regular, 8, mutedColor)
+	}
+}
+
+// fit cuts the text with an ellipsis to the width.
+func (f *Flow) fit(text string, font Font, size, width float64) string {
+	if f.doc.TextWidth(text, font, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		cut := strings.TrimSpace(string(runes)) + "…"
+		if f.doc.TextWidth(cut, font, size) <= width {
+			return cut
+		}
+	}
+	return ""
+}
+
+// wrap breaks the text into lines of the width at spaces, words longer than the width are cut.
+func (f *Flow) wrap(text string, font Font, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if f.doc.TextWidth(candidate, font, size) <= width || line == "" {
+				line = candidate
+				continue
+			}
+			lines = append(lines, f.fit(line, font, size, width))
+			line = word
+		}
+		lines = append(lines, f.fit(line, font, size, width))
+	}
+	return lines
+}
@@ -0,0 +1,261 @@
+// Package pdf writes PDF 1.4 documents with text, lines and filled rectangles on A4 pages.
+//
+// The text is set in the Go fonts, which are embedded as TrueType fonts with Identity-H encoding, so any text
+// the fonts have glyphs for, Latin and Cyrillic included, is printed without fonts installed on the server.
+// Positions are in points from the top left corner of the page, text positions are on the baseline.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Size of an A4 page in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+type Color struct {
+	R, G, B uint8
+}
+
+var (
+	Black = Color{0, 0, 0}
+	White = Color{255, 255, 255}
+)
+
+// LineStyle is the width, color and dash pattern of lines. No dash pattern draws solid lines.
+type LineStyle struct {
+	Width float64
+	Color Color
+	Dash  []float64
+}
+
+type Document struct {
+	Title   string
+	Created time.Time
+	pages   []*Page
+	fonts   [fontCount]*fontUsage
+}
+
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+	fonts   [fontCount]bool
+}
+
+func New(title string) *Document {
+	return &Document{Title: title, Created: time.Now()}
+}
+
+// AddPage adds an empty page at the end of the document.
+func (d *Document) AddPage() *Page {
+	page := &Page{doc: d}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+func (d *Document) Pages() []*Page {
+	return d.pages
+}
+
+// TextWidth returns the width of the text in points, for layouts outside of a document.
+func TextWidth(text string, font Font, size float64) float64 {
+	return newFontUsage(faces[font]()).width(text) * size / 1000
+}
+
+// TextWidth returns the width of the text in points.
+func (d *Document) TextWidth(text string, font Font, size float64) float64 {
+	return d.font(font).width(text) * size / 1000
+}
+
+// Text draws the text with its baseline starting at x, y.
+func (p *Page) Text(x, y float64, text string, font Font, size float64, color Color) {
+	if text == "" {
+		return
+	}
+	p.fonts[font] = true
+	fmt.Fprintf(&p.content, "BT %s rg /F%d %s Tf 1 0 0 1 %s %s Tm <%s> Tj ET\n",
+		color.operands(), font+1, number(size), number(x), number(PageHeight-y), p.doc.font(font).encode(text))
+}
+
+func (p *Page) Line(x1, y1, x2, y2 float64, style LineStyle) {
+	p.setLineStyle(style)
+	fmt.Fprintf(&p.content, "%s %s m %s %s l S\n", number(x1), number(PageHeight-y1), number(x2), number(PageHeight-y2))
+}
+
+// Polyline draws connected line segments through the points, given as x, y pairs.
+func (p *Page) Polyline(points [][2]float64, style LineStyle) {
+	if len(points) < 2 {
+		return
+	}
+	p.setLineStyle(style)
+	for i, point := range points {
+		operator := "l"
+		if i == 0 {
+			operator = "m"
+		}
+		fmt.Fprintf(&p.content, "%s %s %s\n", number(point[0]), number(PageHeight-point[1]), operator)
+	}
+	p.content.WriteString("S\n")
+}
+
+func (p *Page) FillRect(x, y, width, height float64, color Color) {
+	fmt.Fprintf(&p.content, "%s rg %s %s %s %s re f\n", color.operands(), number(x), number(PageHeight-y-height), number(width), number(height))
+}
+
+func (p *Page) StrokeRect(x, y, width, height float64, style LineStyle) {
+	p.setLineStyle(style)
+	fmt.Fprintf(&p.content, "%s %s %s %s re S\n", number(x), number(PageHeight-y-height), number(width), number(height))
+}
+
+// Clip limits what draw paints to the rectangle.
+func (p *Page) Clip(x, y, width, height float64, draw func()) {
+	fmt.Fprintf(&p.content, "q %s %s %s %s re W n\n", number(x), number(PageHeight-y-height), number(width), number(height))
+	draw()
+	p.content.WriteString("Q\n")
+}
+
+func (p *Page) setLineStyle(style LineStyle) {
+	dash := make([]string, len(style.Dash))
+	for i, length := range style.Dash {
+		dash[i] = number(length)
+	}
+	fmt.Fprintf(&p.content, "%s RG %s w [%s] 0 d\n", style.Color.operands(), number(style.Width), strings.Join(dash, " "))
+}
+
+func (d *Document) font(font Font) *fontUsage {
+	if d.fonts[font] == nil {
+		d.fonts[font] = newFontUsage(faces[font]())
+	}
+	return d.fonts[font]
+}
+
+// Write writes the document. Fonts are embedded once for all pages that use them.
+func (d *Document) Write(w io.Writer) error {
+	out := &writer{}
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	catalog, pages, info := out.reserve(), out.reserve(), out.reserve()
+
+	var fontRefs [fontCount]int
+	for font, usage := range d.fonts {
+		if usage != nil {
+			ref, err := out.writeFont(usage)
+			if err != nil {
+				return err
+			}
+			fontRefs[font] = ref
+		}
+	}
+
+	kids := make([]string, 0, len(d.pages))
+	for _, page := range d.pages {
+		var resources strings.Builder
+		for font, used := range page.fonts {
+			if used {
+				fmt.Fprintf(&resources, "/F%d %d 0 R ", font+1, fontRefs[font])
+			}
+		}
+		content, err := out.writeStream("", page.content.Bytes())
+		if err != nil {
+			return err
+		}
+		ref := out.writeObject(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			pages, number(PageWidth), number(PageHeight), resources.String(), content))
+		kids = append(kids, fmt.Sprintf("%d 0 R", ref))
+	}
+
+	out.writeReserved(pages, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+	out.writeReserved(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pages))
+	out.writeReserved(info, fmt.Sprintf("<< /Title %s /Producer (munaiplan) /CreationDate (D:%s) >>",
+		textString(d.Title), d.Created.UTC().Format("20060102150405Z")))
+
+	xref := out.Len()
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(out.offsets)+1)
+	for _, offset := range out.offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(out.offsets)+1, catalog, info, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// writer numbers the objects and remembers their offsets for the cross-reference table.
+type writer struct {
+	bytes.Buffer
+	offsets []int
+}
+
+// reserve returns the number of an object written later with writeReserved.
+func (w *writer) reserve() int {
+	w.offsets = append(w.offsets, 0)
+	return len(w.offsets)
+}
+
+func (w *writer) writeReserved(ref int, body string) {
+	w.offsets[ref-1] = w.Len()
+	fmt.Fprintf(w, "%d 0 obj\n%s\nendobj\n", ref, body)
+}
+
+func (w *writer) writeObject(body string) int {
+	ref := w.reserve()
+	w.writeReserved(ref, body)
+	return ref
+}
+
+// writeStream writes the data compressed, entries are added to the stream dictionary.
+func (w *writer) writeStream(entries string, data []byte) (int, error) {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+
+	ref := w.reserve()
+	w.offsets[ref-1] = w.Len()
+	fmt.Fprintf(w, "%d 0 obj\n<< /Length %d /Filter /FlateDecode %s>>\nstream\n", ref, compressed.Len(), entries)
+	w.Write(compressed.Bytes())
+	w.WriteString("\nendstream\nendobj\n")
+	return ref, nil
+}
+
+func (c Color) operands() string {
+	return fmt.Sprintf("%s %s %s", number(float64(c.R)/255), number(float64(c.G)/255), number(float64(c.B)/255))
+}
+
+// number formats with at most three decimals and without trailing zeros.
+func number(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "0"
+	}
+	s := strconv.FormatFloat(v, 'f', 3, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
+
+// textString encodes the text as a UTF-16 hex string.
+func textString(text string) string {
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, unit := range utf16.Encode([]rune(text)) {
+		fmt.Fprintf(&b, "%04X", unit)
+	}
+	b.WriteString(">")
+	return b.String()
+}
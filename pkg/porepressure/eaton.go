@@ -0,0 +1,115 @@
+// Package porepressure estimates pore and fracture pressure from well logs with the Eaton method.
+//
+// Depths are true vertical depths in meters below the rig floor, pressures are in MPa, densities in g/cm³,
+// sonic slowness in µs/ft and resistivity in ohm·m. The overburden is integrated from the bulk density
+// starting at the surface, offshore water columns and air gaps are not modelled.
+package porepressure
+
+import (
+	"errors"
+	"math"
+)
+
+// gravity converts a density in g/cm³ times a depth in meters to MPa.
+const gravity = 0.00980665
+
+// Eaton exponents commonly used in the absence of a local calibration
+const (
+	DefaultSonicExponent       = 3.0
+	DefaultResistivityExponent = 1.2
+)
+
+var ErrInvalidTrend = errors.New("the normal compaction trend needs two points at different depths with positive values")
+
+// TrendPoint is a value of the normal compaction trend picked at a depth.
+type TrendPoint struct {
+	TVD   float64
+	Value float64
+}
+
+// NormalTrend is a straight line through two picked points on a semi-logarithmic plot, the logarithm of
+// the value changes linearly with depth.
+type NormalTrend struct {
+	intercept float64
+	slope     float64
+}
+
+func NewNormalTrend(top, bottom TrendPoint) (*NormalTrend, error) {
+	if top.Value <= 0 || bottom.Value <= 0 || top.TVD == bottom.TVD {
+		return nil, ErrInvalidTrend
+	}
+	slope := (math.Log(bottom.Value) - math.Log(top.Value)) / (bottom.TVD - top.TVD)
+	return &NormalTrend{intercept: math.Log(top.Value) - slope*top.TVD, slope: slope}, nil
+}
+
+// Value returns the trend value at the depth, the trend is extrapolated outside the picked points.
+func (t *NormalTrend) Value(tvd float64) float64 {
+	return math.Exp(t.intercept + t.slope*tvd)
+}
+
+// Hydrostatic returns the normal pore pressure of a fluid column of the density.
+func Hydrostatic(tvd, fluidDensity float64) float64 {
+	return fluidDensity * gravity * tvd
+}
+
+// Overburden returns the vertical stress at the depths, which must be increasing. The density above the
+// first depth is shallowDensity, missing densities (NaN) continue with the last known density.
+func Overburden(tvd, density []float64, shallowDensity float64) []float64 {
+	res := make([]float64, len(tvd))
+	if len(tvd) == 0 {
+		return res
+	}
+	last := shallowDensity
+	stress := shallowDensity * gravity * tvd[0]
+	for i := range tvd {
+		current := last
+		if !math.IsNaN(density[i]) && density[i] > 0 {
+			current = density[i]
+		}
+		if i > 0 {
+			stress += (last + current) / 2 * gravity * (tvd[i] - tvd[i-1])
+		}
+		res[i] = stress
+		last = current
+	}
+	return res
+}
+
+// Gardner returns the bulk density of the sonic slowness with the Gardner relation for sediments.
+func Gardner(slowness float64) float64 {
+	velocity := 1e6 / slowness // ft/s
+	return 0.23 * math.Pow(velocity, 0.25)
+}
+
+// EatonSonic returns the pore pressure from the observed and the normal trend slowness. Slower than normal
+// rock is undercompacted and overpressured.
+func EatonSonic(overburden, hydrostatic, observed, normal, exponent float64) float64 {
+	return eaton(overburden, hydrostatic, normal/observed, exponent)
+}
+
+// EatonResistivity returns the pore pressure from the observed and the normal trend resistivity. Less
+// resistive than normal rock holds more water and is overpressured.
+func EatonResistivity(overburden, hydrostatic, observed, normal, exponent float64) float64 {
+	return eaton(overburden, hydrostatic, observed/normal, exponent)
+}
+
+// eaton returns the pore pressure for the ratio of the observed to the normal compaction, limited to the
+// range from zero to the overburden.
+func eaton(overburden, hydrostatic, ratio, exponent float64) float64 {
+	res := overburden - (overburden-hydrostatic)*math.Pow(ratio, exponent)
+	return math.Max(0, math.Min(res, overburden))
+}
+
+// FracturePressure returns the fracture pressure with the Eaton relation: the minimum horizontal stress of
+// a laterally confined elastic rock with the Poisson's ratio.
+func FracturePressure(overburden, porePressure, poissonRatio float64) float64 {
+	return porePressure + poissonRatio/(1-poissonRatio)*(overburden-porePressure)
+}
+
+// EquivalentDensity returns the density of a fluid column that exerts the pressure at the depth.
+func EquivalentDensity(pressure, tvd float64) float64 {
+	if tvd <= 0 {
+		return 0
+	}
+	return pressure / (gravity * tvd)
+}
@@ -0,0 +1,112 @@
+package porepressure
+
+import (
+	"math"
+	"testing"
+)
+
+// psi converts psi to MPa.
+const psi = 0.00689475729
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6*math.Max(1, math.Abs(b))
+}
+
+// The Eaton cases are the textbook example at 10,000 ft with a 1.0 psi/ft overburden and a 0.465 psi/ft
+// normal pore pressure gradient.
+func TestEaton(t *testing.T) {
+	overburden, hydrostatic := 10000*psi, 4650*psi
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"sonic at the normal trend", EatonSonic(overburden, hydrostatic, 100, 100, DefaultSonicExponent), 4650 * psi},
+		{"sonic slower than the trend", EatonSonic(overburden, hydrostatic, 125, 100, DefaultSonicExponent), 7260.8 * psi},
+		{"resistivity at the normal trend", EatonResistivity(overburden, hydrostatic, 1, 1, DefaultResistivityExponent), 4650 * psi},
+		{"resistivity below the trend", EatonResistivity(overburden, hydrostatic, 0.5, 1, DefaultResistivityExponent), 7671.277 * psi},
+		{"limited to zero", EatonSonic(overburden, hydrostatic, 10, 100, DefaultSonicExponent), 0},
+		{"fracture pressure", FracturePressure(overburden, hydrostatic, 0.25), (4650 + 5350.0/3) * psi},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.want) > 1e-3*psi {
+				t.Errorf("got %.4f psi, want %.4f psi", tt.got/psi, tt.want/psi)
+			}
+		})
+	}
+}
+
+func TestNormalTrend(t *testing.T) {
+	trend, err := NewNormalTrend(TrendPoint{TVD: 1000, Value: 100}, TrendPoint{TVD: 2000, Value: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		tvd  float64
+		want float64
+	}{
+		{1000, 100},
+		{2000, 50},
+		{1500, 50 * math.Sqrt2},
+		{3000, 25},
+		{0, 200},
+	}
+	for _, tt := range tests {
+		if got := trend.Value(tt.tvd); !almostEqual(got, tt.want) {
+			t.Errorf("Value(%v) = %v, want %v", tt.tvd, got, tt.want)
+		}
+	}
+
+	invalid := [][2]TrendPoint{
+		{{TVD: 1000, Value: 100}, {TVD: 1000, Value: 50}},
+		{{TVD: 1000, Value: 0}, {TVD: 2000, Value: 50}},
+		{{TVD: 1000, Value: 100}, {TVD: 2000, Value: -1}},
+	}
+	for _, points := range invalid {
+		if _, err := NewNormalTrend(points[0], points[1]); err != ErrInvalidTrend {
+			t.Errorf("NewNormalTrend(%v) returned %v, want ErrInvalidTrend", points, err)
+		}
+	}
+}
+
+func TestOverburden(t *testing.T) {
+	tvd := []float64{500, 1000, 1500, 2000}
+	density := []float64{2.0, math.NaN(), 2.4, 2.4}
+	got := Overburden(tvd, density, 2.0)
+	// 2.0 g/cm³ down to 1000 m, then a linear increase to 2.4 g/cm³ at 1500 m
+	want := []float64{
+		2.0 * gravity * 500,
+		2.0 * gravity * 1000,
+		2.0*gravity*1000 + 2.2*gravity*500,
+		2.0*gravity*1000 + 2.2*gravity*500 + 2.4*gravity*500,
+	}
+	for i := range want {
+		if !almostEqual(got[i], want[i]) {
+			t.Errorf("Overburden()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if len(Overburden(nil, nil, 2)) != 0 {
+		t.Error("Overburden() of no depths returned stresses")
+	}
+}
+
+func TestDensities(t *testing.T) {
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"fresh water gradient", Hydrostatic(1000, 1), 9.80665},
+		{"equivalent density of the water gradient", EquivalentDensity(9.80665, 1000), 1},
+		{"equivalent density at the surface", EquivalentDensity(1, 0), 0},
+		{"Gardner at 10,000 ft/s", Gardner(100), 2.3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !almostEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
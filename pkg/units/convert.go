@@ -0,0 +1,141 @@
+package units
+
+import "reflect"
+
+const tagName = "unit"
+
+// ToCanonical converts in place every tagged field reachable from v, which must be a pointer,
+// from the units of the system to canonical units.
+func (s *System) ToCanonical(v any) {
+	if s == nil || s == Canonical {
+		return
+	}
+	walk(reflect.ValueOf(v), func(q Quantity, x float64) float64 {
+		if unit, ok := s.Units[q]; ok {
+			return unit.toCanonical(x)
+		}
+		return x
+	})
+}
+
+// FromCanonical returns a deep copy of v, of the same type, with every tagged field reachable from it
+// converted from canonical units to the units of the system. v itself is never changed, so values shared
+// with other responses or caches stay canonical.
+func (s *System) FromCanonical(v any) any {
+	if s == nil || s == Canonical || v == nil {
+		return v
+	}
+
+	original := reflect.ValueOf(v)
+	value := reflect.New(original.Type()).Elem()
+	value.Set(deepCopy(original))
+
+	walk(value, func(q Quantity, x float64) float64 {
+		if unit, ok := s.Units[q]; ok {
+			return unit.fromCanonical(x)
+		}
+		return x
+	})
+	return value.Interface()
+}
+
+// deepCopy copies v with everything its pointers, interfaces, slices and maps reach through exported fields.
+// Unexported fields are copied shallowly, walk doesn't convert them.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type().Elem())
+		res.Elem().Set(deepCopy(v.Elem()))
+		return res
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type()).Elem()
+		res.Set(deepCopy(v.Elem()))
+		return res
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return res
+	case reflect.Array:
+		res := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return res
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			res.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return res
+	case reflect.Struct:
+		res := reflect.New(v.Type()).Elem()
+		res.Set(v)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				res.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return res
+	}
+	return v
+}
+
+func walk(v reflect.Value, convert func(Quantity, float64) float64) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walk(v.Elem(), convert)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), convert)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := v.Field(i)
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			if tag, ok := t.Field(i).Tag.Lookup(tagName); ok {
+				convertField(field, Quantity(tag), convert)
+				continue
+			}
+			walk(field, convert)
+		}
+	}
+}
+
+// convertField converts float64, *float64 and []float64 fields.
+func convertField(field reflect.Value, q Quantity, convert func(Quantity, float64) float64) {
+	switch field.Kind() {
+	case reflect.Float64:
+		if field.CanSet() {
+			field.SetFloat(convert(q, field.Float()))
+		}
+	case reflect.Ptr:
+		if !field.IsNil() {
+			convertField(field.Elem(), q, convert)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < field.Len(); i++ {
+			convertField(field.Index(i), q, convert)
+		}
+	}
+}
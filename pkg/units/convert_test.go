@@ -0,0 +1,100 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+type testStation struct {
+	MD    float64  `unit:"length"`
+	TVD   *float64 `unit:"length"`
+	Temps []float64
+	Note  string
+}
+
+type testResponse struct {
+	Stations []*testStation
+	Shared   *testStation
+	Density  float64 `unit:"density"`
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9*math.Max(1, math.Abs(b))
+}
+
+func TestFromCanonicalReferenceValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		system *System
+		q      Quantity
+		value  float64
+		want   float64
+	}{
+		{"meters to feet", API, Length, 304.8, 1000},
+		{"millimeters to inches", API, Diameter, 127, 5},
+		{"g/cm³ to ppg", API, Density, 1.19826427, 10},
+		{"MPa to psi", API, Pressure, 6.89475729, 1000},
+		{"°C to °F", API, Temperature, 100, 212},
+		{"°/30m to °/100ft", API, DoglegSeverity, 3, 3.048},
+		{"MPa to bar", Mixed, Pressure, 1, 10},
+		{"l/min to l/s", Mixed, FlowRate, 60, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit := tt.system.Units[tt.q]
+			if got := unit.fromCanonical(tt.value); !almostEqual(got, tt.want) {
+				t.Errorf("fromCanonical(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+			if got := unit.toCanonical(tt.want); !almostEqual(got, tt.value) {
+				t.Errorf("toCanonical(%v) = %v, want %v", tt.want, got, tt.value)
+			}
+		})
+	}
+}
+
+func TestFromCanonicalDoesNotChangeItsArgument(t *testing.T) {
+	tvd := 304.8
+	shared := &testStation{MD: 304.8, TVD: &tvd, Temps: []float64{20}}
+	original := &testResponse{
+		Stations: []*testStation{shared, shared},
+		Shared:   shared,
+		Density:  1.19826427,
+	}
+
+	converted := API.FromCanonical(original).(*testResponse)
+
+	if converted == original || converted.Shared == shared || converted.Shared.TVD == &tvd {
+		t.Fatal("FromCanonical returned the values of its argument")
+	}
+	if shared.MD != 304.8 || tvd != 304.8 || original.Density != 1.19826427 {
+		t.Errorf("the argument was converted: md %v, tvd %v, density %v", shared.MD, tvd, original.Density)
+	}
+	for _, station := range append(converted.Stations, converted.Shared) {
+		if !almostEqual(station.MD, 1000) || !almostEqual(*station.TVD, 1000) {
+			t.Errorf("got md %v and tvd %v, want 1000 ft once", station.MD, *station.TVD)
+		}
+	}
+	if !almostEqual(converted.Density, 10) {
+		t.Errorf("got density %v, want 10 ppg", converted.Density)
+	}
+}
+
+func TestFromCanonicalCopiesValues(t *testing.T) {
+	original := testStation{MD: 304.8}
+	converted := API.FromCanonical(original).(testStation)
+	if original.MD != 304.8 || !almostEqual(converted.MD, 1000) {
+		t.Errorf("got original %v and converted %v", original.MD, converted.MD)
+	}
+}
+
+func TestToCanonicalRoundTrip(t *testing.T) {
+	for _, system := range Systems() {
+		tvd := 1234.5
+		station := &testStation{MD: 2345.6, TVD: &tvd}
+		converted := system.FromCanonical(station).(*testStation)
+		system.ToCanonical(converted)
+		if !almostEqual(converted.MD, 2345.6) || !almostEqual(*converted.TVD, 1234.5) {
+			t.Errorf("%s: got md %v and tvd %v after the round trip", system.Name, converted.MD, *converted.TVD)
+		}
+	}
+}
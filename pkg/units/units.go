@@ -0,0 +1,153 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Quantity is a physical quantity of a numeric field, it is set with the `unit` struct tag.
+type Quantity string
+
+// Quantities and their canonical internal units. Canonical units are the units the data is stored in
+// and the T&D model is trained on, so they are kept as they are even where they mix systems.
+const (
+	Length              Quantity = "length"               // m
+	Diameter            Quantity = "diameter"             // mm
+	LinearWeight        Quantity = "linear_weight"        // kg/m
+	LinearForce         Quantity = "linear_force"         // kN/m
+	LinearCapacity      Quantity = "linear_capacity"      // l/m
+	Force               Quantity = "force"                // kN
+	Torque              Quantity = "torque"               // kN·m
+	Pressure            Quantity = "pressure"             // MPa
+	Stress              Quantity = "stress"               // ksi
+	Density             Quantity = "density"              // g/cm³
+	FlowRate            Quantity = "flow_rate"            // l/min
+	Temperature         Quantity = "temperature"          // °C
+	TemperatureGradient Quantity = "temperature_gradient" // °C/100m
+	DoglegSeverity      Quantity = "dls"                  // °/30m
+	Slowness            Quantity = "slowness"             // µs/ft
+)
+
+// Unit converts values of a quantity to canonical units as value*Factor + Offset.
+type Unit struct {
+	Symbol string
+	Factor float64
+	Offset float64
+}
+
+func (u Unit) toCanonical(v float64) float64 {
+	return v*u.Factor + u.Offset
+}
+
+func (u Unit) fromCanonical(v float64) float64 {
+	return (v - u.Offset) / u.Factor
+}
+
+var (
+	meter                = Unit{Symbol: "m", Factor: 1}
+	foot                 = Unit{Symbol: "ft", Factor: 0.3048}
+	millimeter           = Unit{Symbol: "mm", Factor: 1}
+	inch                 = Unit{Symbol: "in", Factor: 25.4}
+	kilogramPerMeter     = Unit{Symbol: "kg/m", Factor: 1}
+	poundPerFoot         = Unit{Symbol: "lb/ft", Factor: 1.48816394}
+	kilonewtonPerMeter   = Unit{Symbol: "kN/m", Factor: 1}
+	poundForcePerFoot    = Unit{Symbol: "lbf/ft", Factor: 0.0145939029}
+	literPerMeter        = Unit{Symbol: "l/m", Factor: 1}
+	barrelPerFoot        = Unit{Symbol: "bbl/ft", Factor: 521.611871}
+	kilonewton           = Unit{Symbol: "kN", Factor: 1}
+	kilopoundForce       = Unit{Symbol: "klbf", Factor: 4.44822162}
+	kilonewtonMeter      = Unit{Symbol: "kN·m", Factor: 1}
+	kiloFootPoundForce   = Unit{Symbol: "kft·lbf", Factor: 1.35581795}
+	megapascal           = Unit{Symbol: "MPa", Factor: 1}
+	bar                  = Unit{Symbol: "bar", Factor: 0.1}
+	psi                  = Unit{Symbol: "psi", Factor: 0.00689475729}
+	ksi                  = Unit{Symbol: "ksi", Factor: 1}
+	megapascalStress     = Unit{Symbol: "MPa", Factor: 0.145037738}
+	gramPerCubicCm       = Unit{Symbol: "g/cm³", Factor: 1}
+	poundPerGallon       = Unit{Symbol: "ppg", Factor: 0.119826427}
+	literPerMinute       = Unit{Symbol: "l/min", Factor: 1}
+	literPerSecond       = Unit{Symbol: "l/s", Factor: 60}
+	gallonPerMinute      = Unit{Symbol: "gpm", Factor: 3.78541178}
+	celsius              = Unit{Symbol: "°C", Factor: 1}
+	fahrenheit           = Unit{Symbol: "°F", Factor: 5.0 / 9, Offset: -32 * 5.0 / 9}
+	celsiusPer100Meter   = Unit{Symbol: "°C/100m", Factor: 1}
+	fahrenheitPer100Foot = Unit{Symbol: "°F/100ft", Factor: 5.0 / 9 / 0.3048}
+	degreePer30Meter     = Unit{Symbol: "°/30m", Factor: 1}
+	degreePer100Foot     = Unit{Symbol: "°/100ft", Factor: 30 / 30.48}
+	microsecondPerFoot   = Unit{Symbol: "µs/ft", Factor: 1}
+	microsecondPerMeter  = Unit{Symbol: "µs/m", Factor: 0.3048}
+)
+
+// System is a named set of units, one per quantity.
+type System struct {
+	Name  string
+	Units map[Quantity]Unit
+}
+
+// Names of the unit systems.
+const (
+	SystemCanonical = "canonical"
+	SystemSI        = "si"
+	SystemAPI       = "api"
+	SystemMixed     = "mixed"
+)
+
+var (
+	Canonical = &System{Name: SystemCanonical, Units: map[Quantity]Unit{
+		Length: meter, Diameter: millimeter, LinearWeight: kilogramPerMeter, LinearForce: kilonewtonPerMeter,
+		LinearCapacity: literPerMeter, Force: kilonewton, Torque: kilonewtonMeter, Pressure: megapascal,
+		Stress: ksi, Density: gramPerCubicCm, FlowRate: literPerMinute, Temperature: celsius,
+		TemperatureGradient: celsiusPer100Meter, DoglegSeverity: degreePer30Meter, Slowness: microsecondPerFoot,
+	}}
+	SI = &System{Name: SystemSI, Units: map[Quantity]Unit{
+		Length: meter, Diameter: millimeter, LinearWeight: kilogramPerMeter, LinearForce: kilonewtonPerMeter,
+		LinearCapacity: literPerMeter, Force: kilonewton, Torque: kilonewtonMeter, Pressure: megapascal,
+		Stress: megapascalStress, Density: gramPerCubicCm, FlowRate: literPerMinute, Temperature: celsius,
+		TemperatureGradient: celsiusPer100Meter, DoglegSeverity: degreePer30Meter, Slowness: microsecondPerMeter,
+	}}
+	API = &System{Name: SystemAPI, Units: map[Quantity]Unit{
+		Length: foot, Diameter: inch, LinearWeight: poundPerFoot, LinearForce: poundForcePerFoot,
+		LinearCapacity: barrelPerFoot, Force: kilopoundForce, Torque: kiloFootPoundForce, Pressure: psi,
+		Stress: ksi, Density: poundPerGallon, FlowRate: gallonPerMinute, Temperature: fahrenheit,
+		TemperatureGradient: fahrenheitPer100Foot, DoglegSeverity: degreePer100Foot, Slowness: microsecondPerFoot,
+	}}
+	// Metric depths and loads with API tubular sizes
+	Mixed = &System{Name: SystemMixed, Units: map[Quantity]Unit{
+		Length: meter, Diameter: inch, LinearWeight: poundPerFoot, LinearForce: kilonewtonPerMeter,
+		LinearCapacity: literPerMeter, Force: kilonewton, Torque: kilonewtonMeter, Pressure: bar,
+		Stress: ksi, Density: gramPerCubicCm, FlowRate: literPerSecond, Temperature: celsius,
+		TemperatureGradient: celsiusPer100Meter, DoglegSeverity: degreePer30Meter, Slowness: microsecondPerFoot,
+	}}
+)
+
+// ErrUnknownSystem is returned for unit system names that are not supported.
+var ErrUnknownSystem = errors.New("unknown unit system")
+
+var aliases = map[string]*System{
+	SystemCanonical: Canonical,
+	SystemSI:        SI,
+	"metric":        SI,
+	SystemAPI:       API,
+	"field":         API,
+	"imperial":      API,
+	SystemMixed:     Mixed,
+}
+
+// Lookup returns the unit system by name or alias, case-insensitive.
+func Lookup(name string) (*System, error) {
+	if system, ok := aliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return system, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownSystem, name)
+}
+
+// Systems returns the named unit systems.
+func Systems() []*System {
+	return []*System{Canonical, SI, API, Mixed}
+}
+
+// Symbol returns the unit symbol of the quantity in the system.
+func (s *System) Symbol(q Quantity) string {
+	return s.Units[q].Symbol
+}
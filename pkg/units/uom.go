@@ -0,0 +1,64 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownUom is returned for unit of measure symbols that are not supported for the quantity.
+var ErrUnknownUom = errors.New("unknown unit of measure")
+
+// uoms maps the Energistics unit of measure symbols used in WITSML uom attributes to units, per quantity.
+// The first symbol of a quantity is the one written for canonical values.
+var uoms = map[Quantity][]Unit{
+	Length: {
+		{Symbol: "m", Factor: 1}, {Symbol: "ft", Factor: 0.3048}, {Symbol: "cm", Factor: 0.01},
+		{Symbol: "mm", Factor: 0.001}, {Symbol: "in", Factor: 0.0254}, {Symbol: "km", Factor: 1000},
+	},
+	Diameter: {
+		{Symbol: "mm", Factor: 1}, {Symbol: "in", Factor: 25.4}, {Symbol: "cm", Factor: 10},
+		{Symbol: "m", Factor: 1000},
+	},
+	LinearWeight: {
+		{Symbol: "kg/m", Factor: 1}, {Symbol: "lbm/ft", Factor: 1.48816394},
+	},
+	Stress: {
+		{Symbol: "kpsi", Factor: 1}, {Symbol: "ksi", Factor: 1}, {Symbol: "psi", Factor: 0.001},
+		{Symbol: "MPa", Factor: 0.145037738}, {Symbol: "kPa", Factor: 0.000145037738},
+		{Symbol: "bar", Factor: 0.0145037738},
+	},
+	DoglegSeverity: {
+		{Symbol: "dega/30m", Factor: 1}, {Symbol: "dega/100ft", Factor: 30 / 30.48},
+		{Symbol: "dega/m", Factor: 30}, {Symbol: "dega/ft", Factor: 30 / 0.3048},
+	},
+}
+
+// ParseUom returns the unit of the quantity with the Energistics symbol, case-sensitive as in the standard.
+func ParseUom(q Quantity, uom string) (Unit, error) {
+	uom = strings.TrimSpace(uom)
+	for _, unit := range uoms[q] {
+		if unit.Symbol == uom {
+			return unit, nil
+		}
+	}
+	return Unit{}, fmt.Errorf("%w: %q for %s", ErrUnknownUom, uom, q)
+}
+
+// CanonicalUom returns the Energistics symbol of the canonical unit of the quantity.
+func CanonicalUom(q Quantity) string {
+	if units := uoms[q]; len(units) > 0 {
+		return units[0].Symbol
+	}
+	return ""
+}
+
+// ToCanonical converts a value in the unit to the canonical unit of its quantity.
+func (u Unit) ToCanonical(v float64) float64 {
+	return u.toCanonical(v)
+}
+
+// FromCanonical converts a value in the canonical unit of its quantity to the unit.
+func (u Unit) FromCanonical(v float64) float64 {
+	return u.fromCanonical(v)
+}
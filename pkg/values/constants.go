@@ -6,4 +6,11 @@ const (
 	OrganizationIdCtx                           = "organizationId"
 	UserAccessTokenCtx                          = "accessToken"
 	UserRefreshTokenCtx                         = "refreshToken"
+	SessionIdCtx                                = "sessionId"
+	RefreshTokenIdCtx                           = "refreshTokenId"
+	ApiKeyCtx                                   = "apiKey"
+	QueryAccessTokenCtx                         = "queryAccessToken"
+	AcceptUnitsHeader                           = "Accept-Units"
+	ContentUnitsHeader                          = "Content-Units"
+	UnitSystemCtx                               = "unitSystem"
 )
\ No newline at end of file
@@ -9,7 +9,12 @@ const (
 	WellboreIdQueryParam     = "wellboreId"
 	DesignIdQueryParam       = "designId"
 	TrajectoryIdQueryParam   = "trajectoryId"
-	CaseIdQueryParam		 = "caseId"
+	CaseIdQueryParam         = "caseId"
+	UserIdQueryParam         = "userId"
 	NameQueryParam           = "name"
 	IdQueryParam             = "id"
+	RevisionQueryParam       = "revision"
+	WellLogIdQueryParam      = "wellLogId"
+	ChartQueryParam          = "chart"
+	AccessTokenQueryParam    = "access_token"
 )
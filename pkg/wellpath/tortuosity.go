@@ -0,0 +1,135 @@
+package wellpath
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Synthetic tortuosity modes.
+const (
+	TortuositySinusoidal = "sinusoidal"
+	TortuosityRandom     = "random"
+)
+
+// Tortuosity describes a synthetic tortuosity superimposed on a smooth well path. Amplitude is
+// in degrees, Period is the wavelength of the sinusoidal mode and Step is the station spacing of
+// the result. The interval [FromMD, ToMD] limits the perturbation, ToMD of zero means total depth.
+type Tortuosity struct {
+	Mode      string
+	Amplitude float64
+	Period    float64
+	Step      float64
+	FromMD    float64
+	ToMD      float64
+	Seed      int64
+}
+
+// DoglegSeverities returns the dogleg severity of the leg ending at every station in degrees per
+// courseLength. The first station has zero severity.
+func DoglegSeverities(stations []Station, courseLength float64) []float64 {
+	res := make([]float64, len(stations))
+	for i := 1; i < len(stations); i++ {
+		res[i] = DoglegSeverity(stations[i-1], stations[i], courseLength)
+	}
+	return res
+}
+
+// CumulativeTortuosity returns the running sum of dogleg angles in degrees at every station.
+func CumulativeTortuosity(stations []Station) []float64 {
+	res := make([]float64, len(stations))
+	for i := 1; i < len(stations); i++ {
+		a, b := stations[i-1], stations[i]
+		res[i] = res[i-1] + toDegrees(DoglegAngle(a.Incl, a.Azim, b.Incl, b.Azim))
+	}
+	return res
+}
+
+// MicroTortuosity returns at every station the curvature within the trailing window that does not
+// contribute to the net change of direction over the window, in degrees per courseLength. A smooth
+// arc gives zero, a path that wiggles around its mean direction gives a positive index.
+func MicroTortuosity(stations []Station, window, courseLength float64) []float64 {
+	res := make([]float64, len(stations))
+	if window <= 0 {
+		return res
+	}
+
+	for i := 1; i < len(stations); i++ {
+		fromMD := math.Max(stations[i].MD-window, stations[0].MD)
+		start, ok := Interpolate(stations, fromMD)
+		if !ok || stations[i].MD-fromMD <= 0 {
+			continue
+		}
+
+		var total float64
+		prev := start
+		for j := 1; j <= i; j++ {
+			if stations[j].MD <= fromMD {
+				continue
+			}
+			total += toDegrees(DoglegAngle(prev.Incl, prev.Azim, stations[j].Incl, stations[j].Azim))
+			prev = stations[j]
+		}
+
+		net := toDegrees(DoglegAngle(start.Incl, start.Azim, stations[i].Incl, stations[i].Azim))
+		res[i] = math.Max(total-net, 0) * courseLength / (stations[i].MD - fromMD)
+	}
+	return res
+}
+
+// ApplyTortuosity resamples the stations and perturbs their inclination and azimuth with the synthetic
+// tortuosity, then recalculates the positions with the minimum curvature method.
+func ApplyTortuosity(stations []Station, t Tortuosity) ([]Station, error) {
+	if len(stations) < 2 {
+		return nil, fmt.Errorf("at least two stations are required to apply tortuosity")
+	}
+	if t.Amplitude <= 0 {
+		return nil, fmt.Errorf("tortuosity amplitude must be positive")
+	}
+
+	step := t.Step
+	switch t.Mode {
+	case TortuositySinusoidal:
+		if t.Period <= 0 {
+			return nil, fmt.Errorf("tortuosity period must be positive")
+		}
+		if step <= 0 {
+			step = t.Period / 10
+		}
+	case TortuosityRandom:
+		if step <= 0 {
+			step = 10
+		}
+	default:
+		return nil, fmt.Errorf("unknown tortuosity mode %s", t.Mode)
+	}
+
+	toMD := t.ToMD
+	if toMD <= 0 {
+		toMD = stations[len(stations)-1].MD
+	}
+
+	res := Resample(stations, step)
+	random := rand.New(rand.NewSource(t.Seed))
+
+	for i := 1; i < len(res); i++ {
+		if res[i].MD < t.FromMD || res[i].MD > toMD {
+			continue
+		}
+
+		var dIncl, dAzim float64
+		if t.Mode == TortuositySinusoidal {
+			phase := 2 * math.Pi * (res[i].MD - t.FromMD) / t.Period
+			dIncl = t.Amplitude * math.Sin(phase)
+			dAzim = t.Amplitude * math.Sin(phase)
+		} else {
+			dIncl = t.Amplitude * (2*random.Float64() - 1)
+			dAzim = t.Amplitude * (2*random.Float64() - 1)
+		}
+
+		res[i].Incl = math.Max(0, math.Min(180, res[i].Incl+dIncl))
+		res[i].Azim = math.Mod(res[i].Azim+dAzim+360, 360)
+	}
+
+	return Compute(res), nil
+}
@@ -0,0 +1,309 @@
+package wellpath
+
+import (
+	"fmt"
+	"math"
+)
+
+// Weighting functions of an error term. They define which survey measurement
+// the error affects and how its magnitude scales along the well.
+const (
+	WeightingDepth          = "depth"           // constant measured depth error, length units
+	WeightingDepthScale     = "depth_scale"     // measured depth error proportional to MD, dimensionless
+	WeightingInclination    = "inclination"     // constant inclination error, degrees
+	WeightingInclinationSin = "inclination_sin" // inclination error scaled by sin(inc), degrees (e.g. sag)
+	WeightingAzimuth        = "azimuth"         // constant azimuth error, degrees
+	WeightingAzimuthSinInc  = "azimuth_sin_inc" // azimuth error scaled by sin(inc), degrees (e.g. axial interference)
+)
+
+// Propagation modes of an error term between survey stations.
+const (
+	PropagationRandom     = "random"     // uncorrelated from station to station
+	PropagationSystematic = "systematic" // correlated between stations of one survey tool run
+	PropagationGlobal     = "global"     // correlated between all stations of the well
+)
+
+// ErrorTerm is a single source of survey error with its one-sigma magnitude.
+type ErrorTerm struct {
+	Name        string
+	Weighting   string
+	Propagation string
+	Magnitude   float64
+}
+
+// ToolRun is an MD interval surveyed with one tool described by its error terms.
+type ToolRun struct {
+	FromMD float64
+	ToMD   float64
+	Terms  []ErrorTerm
+}
+
+// Covariance is the position covariance matrix in north, east, vertical axes.
+type Covariance [3][3]float64
+
+// Ellipse describes the horizontal uncertainty ellipse and vertical uncertainty at a confidence factor.
+type Ellipse struct {
+	SemiMajor     float64
+	SemiMinor     float64
+	MajorAzimuth  float64
+	Vertical      float64
+	SigmaNorth    float64
+	SigmaEast     float64
+	SigmaVertical float64
+}
+
+type vector [3]float64
+
+// ValidateErrorTerm checks that the weighting and propagation of the term are known.
+func ValidateErrorTerm(term ErrorTerm) error {
+	switch term.Weighting {
+	case WeightingDepth, WeightingDepthScale, WeightingInclination, WeightingInclinationSin, WeightingAzimuth, WeightingAzimuthSinInc:
+	default:
+		return fmt.Errorf("unknown weighting %s of error term %s", term.Weighting, term.Name)
+	}
+	switch term.Propagation {
+	case PropagationRandom, PropagationSystematic, PropagationGlobal:
+	default:
+		return fmt.Errorf("unknown propagation %s of error term %s", term.Propagation, term.Name)
+	}
+	return nil
+}
+
+// legDerivatives returns the derivatives of the balanced tangential displacement of the leg
+// between stations a and b with respect to MD, inclination and azimuth of station b (toB = true)
+// or station a (toB = false).
+func legDerivatives(a, b Station, toB bool) (vector, vector, vector) {
+	ta := vectorOf(Direction(a.Incl, a.Azim))
+	tb := vectorOf(Direction(b.Incl, b.Azim))
+	half := (b.MD - a.MD) / 2
+
+	s := b
+	sign := 1.0
+	if !toB {
+		s = a
+		sign = -1
+	}
+
+	i, az := toRadians(s.Incl), toRadians(s.Azim)
+	dIncl := vector{math.Cos(i) * math.Cos(az), math.Cos(i) * math.Sin(az), -math.Sin(i)}
+	dAzim := vector{-math.Sin(i) * math.Sin(az), math.Sin(i) * math.Cos(az), 0}
+
+	var dMD vector
+	for j := range dMD {
+		dMD[j] = sign * (ta[j] + tb[j]) / 2
+		dIncl[j] *= half
+		dAzim[j] *= half
+	}
+	return dMD, dIncl, dAzim
+}
+
+func vectorOf(n, e, v float64) vector {
+	return vector{n, e, v}
+}
+
+// errorVector is the position error caused by the term at a station given the derivatives
+// of the adjacent legs with respect to the survey measurements of that station.
+func errorVector(term ErrorTerm, s Station, dMD, dIncl, dAzim vector) vector {
+	var derivative vector
+	var weight float64
+
+	switch term.Weighting {
+	case WeightingDepth:
+		derivative, weight = dMD, term.Magnitude
+	case WeightingDepthScale:
+		derivative, weight = dMD, term.Magnitude*s.MD
+	case WeightingInclination:
+		derivative, weight = dIncl, toRadians(term.Magnitude)
+	case WeightingInclinationSin:
+		derivative, weight = dIncl, toRadians(term.Magnitude)*math.Sin(toRadians(s.Incl))
+	case WeightingAzimuth:
+		derivative, weight = dAzim, toRadians(term.Magnitude)
+	case WeightingAzimuthSinInc:
+		derivative, weight = dAzim, toRadians(term.Magnitude)*math.Sin(toRadians(s.Incl))
+	}
+
+	return vector{derivative[0] * weight, derivative[1] * weight, derivative[2] * weight}
+}
+
+func (c *Covariance) addOuter(v vector) {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			c[i][j] += v[i] * v[j]
+		}
+	}
+}
+
+func (c Covariance) add(o Covariance) Covariance {
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			c[i][j] += o[i][j]
+		}
+	}
+	return c
+}
+
+// runIndex returns the index of the tool run covering md, or -1.
+func runIndex(runs []ToolRun, md float64) int {
+	for i, run := range runs {
+		if md >= run.FromMD && md <= run.ToMD {
+			return i
+		}
+	}
+	return -1
+}
+
+// PositionCovariance propagates the error terms of the tool runs along the stations
+// and returns the position covariance at every station. Stations must be sorted by MD
+// and every station must be covered by a tool run.
+func PositionCovariance(stations []Station, runs []ToolRun) ([]Covariance, error) {
+	result := make([]Covariance, len(stations))
+	if len(stations) == 0 {
+		return result, nil
+	}
+
+	runOf := make([]int, len(stations))
+	for k, s := range stations {
+		if runOf[k] = runIndex(runs, s.MD); runOf[k] < 0 {
+			return nil, fmt.Errorf("no survey tool covers md %.2f", s.MD)
+		}
+	}
+	for _, run := range runs {
+		for _, term := range run.Terms {
+			if err := ValidateErrorTerm(term); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var randomCov Covariance
+	correlated := make(map[string]vector)
+
+	for k, s := range stations {
+		run := runs[runOf[k]]
+
+		var inMD, inIncl, inAzim vector // derivatives of the leg ending at station k
+		if k > 0 {
+			inMD, inIncl, inAzim = legDerivatives(stations[k-1], s, true)
+		}
+		var outMD, outIncl, outAzim vector // derivatives of the leg starting at station k
+		if k < len(stations)-1 {
+			outMD, outIncl, outAzim = legDerivatives(s, stations[k+1], false)
+		}
+
+		// Covariance at station k uses only the incoming leg for its own survey errors
+		current := randomCov
+		partial := make(map[string]vector)
+		for _, term := range run.Terms {
+			e := errorVector(term, s, inMD, inIncl, inAzim)
+			if term.Propagation == PropagationRandom {
+				current.addOuter(e)
+				continue
+			}
+			key := correlationKey(term, runOf[k])
+			partial[key] = vector{correlated[key][0] + e[0], correlated[key][1] + e[1], correlated[key][2] + e[2]}
+		}
+		for key, sum := range correlated {
+			if _, ok := partial[key]; !ok {
+				partial[key] = sum
+			}
+		}
+		for _, sum := range partial {
+			current.addOuter(sum)
+		}
+		result[k] = current
+
+		// Accumulate the full effect of the station's errors for the following stations
+		for _, term := range run.Terms {
+			full := add(
+				errorVector(term, s, inMD, inIncl, inAzim),
+				errorVector(term, s, outMD, outIncl, outAzim),
+			)
+			if term.Propagation == PropagationRandom {
+				randomCov.addOuter(full)
+				continue
+			}
+			key := correlationKey(term, runOf[k])
+			correlated[key] = add(correlated[key], full)
+		}
+	}
+
+	return result, nil
+}
+
+func add(a, b vector) vector {
+	return vector{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func correlationKey(term ErrorTerm, run int) string {
+	if term.Propagation == PropagationGlobal {
+		return term.Name
+	}
+	return fmt.Sprintf("%d:%s", run, term.Name)
+}
+
+// InterpolateCovariance linearly interpolates the covariance at md between the surrounding stations.
+func InterpolateCovariance(stations []Station, covariances []Covariance, md float64) Covariance {
+	if len(stations) == 0 {
+		return Covariance{}
+	}
+	if md <= stations[0].MD {
+		return covariances[0]
+	}
+	for k := 1; k < len(stations); k++ {
+		if md <= stations[k].MD {
+			t := (md - stations[k-1].MD) / (stations[k].MD - stations[k-1].MD)
+			var c Covariance
+			for i := 0; i < 3; i++ {
+				for j := 0; j < 3; j++ {
+					c[i][j] = covariances[k-1][i][j] + t*(covariances[k][i][j]-covariances[k-1][i][j])
+				}
+			}
+			return c
+		}
+	}
+	return covariances[len(covariances)-1]
+}
+
+// SigmaAlong returns the one-sigma uncertainty along the direction from a to b.
+func (c Covariance) SigmaAlong(a, b Station) float64 {
+	u := vector{b.North - a.North, b.East - a.East, b.TVD - a.TVD}
+	length := math.Sqrt(u[0]*u[0] + u[1]*u[1] + u[2]*u[2])
+	if length == 0 {
+		return math.Sqrt(math.Max(c[0][0], math.Max(c[1][1], c[2][2])))
+	}
+
+	var variance float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			variance += u[i] / length * c[i][j] * u[j] / length
+		}
+	}
+	return math.Sqrt(math.Max(variance, 0))
+}
+
+// Sum returns the sum of two covariance matrices.
+func (c Covariance) Sum(o Covariance) Covariance {
+	return c.add(o)
+}
+
+// Ellipse returns the horizontal uncertainty ellipse scaled by the confidence factor sigma.
+func (c Covariance) Ellipse(sigma float64) Ellipse {
+	a, b, d := c[0][0], c[0][1], c[1][1]
+	mean := (a + d) / 2
+	radius := math.Sqrt((a-d)*(a-d)/4 + b*b)
+
+	azimuth := toDegrees(0.5 * math.Atan2(2*b, a-d))
+	if azimuth < 0 {
+		azimuth += 180
+	}
+
+	return Ellipse{
+		SemiMajor:     sigma * math.Sqrt(math.Max(mean+radius, 0)),
+		SemiMinor:     sigma * math.Sqrt(math.Max(mean-radius, 0)),
+		MajorAzimuth:  azimuth,
+		Vertical:      sigma * math.Sqrt(math.Max(c[2][2], 0)),
+		SigmaNorth:    math.Sqrt(math.Max(a, 0)),
+		SigmaEast:     math.Sqrt(math.Max(d, 0)),
+		SigmaVertical: math.Sqrt(math.Max(c[2][2], 0)),
+	}
+}
@@ -0,0 +1,201 @@
+package wellpath
+
+import (
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9*math.Max(1, math.Abs(b))
+}
+
+// The error model has single terms rather than the full ISCWSA MWD term set, so the cases are wells with one
+// error term whose covariance follows in closed form: a depth scale error on a vertical well moves the
+// stations down by the scale times MD, and an azimuth error on a horizontal well moves them sideways by the
+// azimuth error times the departure.
+func TestPositionCovariance(t *testing.T) {
+	vertical := []Station{{MD: 0}, {MD: 1000}, {MD: 2000}}
+	horizontal := []Station{{MD: 0, Incl: 90}, {MD: 1000, Incl: 90}, {MD: 2000, Incl: 90}}
+	azimuth := toRadians(1)
+
+	tests := []struct {
+		name     string
+		stations []Station
+		runs     []ToolRun
+		axis     int
+		sigma    []float64
+	}{
+		{
+			name:     "systematic depth scale on a vertical well",
+			stations: vertical,
+			runs:     []ToolRun{{FromMD: 0, ToMD: 2000, Terms: []ErrorTerm{{"DSF", WeightingDepthScale, PropagationSystematic, 0.001}}}},
+			axis:     2,
+			sigma:    []float64{0, 1, 2},
+		},
+		{
+			name:     "systematic azimuth on a horizontal well",
+			stations: horizontal,
+			runs:     []ToolRun{{FromMD: 0, ToMD: 2000, Terms: []ErrorTerm{{"AZ", WeightingAzimuth, PropagationSystematic, 1}}}},
+			axis:     1,
+			sigma:    []float64{0, 1000 * azimuth, 2000 * azimuth},
+		},
+		{
+			// every station adds its own azimuth error over the legs next to it
+			name:     "random azimuth on a horizontal well",
+			stations: horizontal,
+			runs:     []ToolRun{{FromMD: 0, ToMD: 2000, Terms: []ErrorTerm{{"AZ", WeightingAzimuth, PropagationRandom, 1}}}},
+			axis:     1,
+			sigma:    []float64{0, math.Sqrt(2) * 500 * azimuth, math.Sqrt(1.5e6) * azimuth},
+		},
+		{
+			// the errors of two runs of a tool are independent
+			name:     "systematic azimuth of two runs",
+			stations: horizontal,
+			runs: []ToolRun{
+				{FromMD: 0, ToMD: 1000, Terms: []ErrorTerm{{"AZ", WeightingAzimuth, PropagationSystematic, 1}}},
+				{FromMD: 1000, ToMD: 2000, Terms: []ErrorTerm{{"AZ", WeightingAzimuth, PropagationSystematic, 1}}},
+			},
+			axis:  1,
+			sigma: []float64{0, 1000 * azimuth, math.Sqrt(2.5e6) * azimuth},
+		},
+		{
+			// a global error is the same in every run
+			name:     "global azimuth of two runs",
+			stations: horizontal,
+			runs: []ToolRun{
+				{FromMD: 0, ToMD: 1000, Terms: []ErrorTerm{{"AZ", WeightingAzimuth, PropagationGlobal, 1}}},
+				{FromMD: 1000, ToMD: 2000, Terms: []ErrorTerm{{"AZ", WeightingAzimuth, PropagationGlobal, 1}}},
+			},
+			axis:  1,
+			sigma: []float64{0, 1000 * azimuth, 2000 * azimuth},
+		},
+		{
+			name:     "azimuth scaled by sin(inc) on a vertical well",
+			stations: vertical,
+			runs:     []ToolRun{{FromMD: 0, ToMD: 2000, Terms: []ErrorTerm{{"XYM", WeightingAzimuthSinInc, PropagationSystematic, 1}}}},
+			axis:     1,
+			sigma:    []float64{0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			covariances, err := PositionCovariance(tt.stations, tt.runs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, c := range covariances {
+				if got := math.Sqrt(c[tt.axis][tt.axis]); !almostEqual(got, tt.sigma[k]) {
+					t.Errorf("station %d: got sigma %v, want %v", k, got, tt.sigma[k])
+				}
+				for i := 0; i < 3; i++ {
+					for j := 0; j < 3; j++ {
+						if (i != tt.axis || j != tt.axis) && math.Abs(c[i][j]) > 1e-9 {
+							t.Errorf("station %d: got covariance %v off the axis", k, c)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPositionCovarianceErrors(t *testing.T) {
+	stations := []Station{{MD: 0}, {MD: 1000}}
+	tests := []struct {
+		name string
+		runs []ToolRun
+	}{
+		{"station without a tool", []ToolRun{{FromMD: 0, ToMD: 500}}},
+		{"unknown weighting", []ToolRun{{FromMD: 0, ToMD: 1000, Terms: []ErrorTerm{{"X", "unknown", PropagationRandom, 1}}}}},
+		{"unknown propagation", []ToolRun{{FromMD: 0, ToMD: 1000, Terms: []ErrorTerm{{"X", WeightingDepth, "unknown", 1}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := PositionCovariance(stations, tt.runs); err == nil {
+				t.Error("PositionCovariance() returned no error")
+			}
+		})
+	}
+}
+
+func TestEllipse(t *testing.T) {
+	tests := []struct {
+		name       string
+		covariance Covariance
+		want       Ellipse
+	}{
+		{
+			name:       "aligned with north",
+			covariance: Covariance{{4, 0, 0}, {0, 1, 0}, {0, 0, 9}},
+			want:       Ellipse{SemiMajor: 4, SemiMinor: 2, MajorAzimuth: 0, Vertical: 6, SigmaNorth: 2, SigmaEast: 1, SigmaVertical: 3},
+		},
+		{
+			name:       "aligned with east",
+			covariance: Covariance{{1, 0, 0}, {0, 4, 0}, {0, 0, 0}},
+			want:       Ellipse{SemiMajor: 4, SemiMinor: 2, MajorAzimuth: 90, SigmaNorth: 1, SigmaEast: 2},
+		},
+		{
+			name:       "north-east",
+			covariance: Covariance{{2, 1, 0}, {1, 2, 0}, {0, 0, 0}},
+			want:       Ellipse{SemiMajor: 2 * math.Sqrt(3), SemiMinor: 2, MajorAzimuth: 45, SigmaNorth: math.Sqrt2, SigmaEast: math.Sqrt2},
+		},
+		{
+			name:       "north-west",
+			covariance: Covariance{{2, -1, 0}, {-1, 2, 0}, {0, 0, 0}},
+			want:       Ellipse{SemiMajor: 2 * math.Sqrt(3), SemiMinor: 2, MajorAzimuth: 135, SigmaNorth: math.Sqrt2, SigmaEast: math.Sqrt2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.covariance.Ellipse(2)
+			if !almostEqual(got.SemiMajor, tt.want.SemiMajor) || !almostEqual(got.SemiMinor, tt.want.SemiMinor) ||
+				!almostEqual(got.MajorAzimuth, tt.want.MajorAzimuth) || !almostEqual(got.Vertical, tt.want.Vertical) ||
+				!almostEqual(got.SigmaNorth, tt.want.SigmaNorth) || !almostEqual(got.SigmaEast, tt.want.SigmaEast) ||
+				!almostEqual(got.SigmaVertical, tt.want.SigmaVertical) {
+				t.Errorf("Ellipse(2) = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSigmaAlong(t *testing.T) {
+	c := Covariance{{4, 0, 0}, {0, 1, 0}, {0, 0, 9}}
+	origin := Station{}
+	tests := []struct {
+		name string
+		to   Station
+		want float64
+	}{
+		{"north", Station{North: 10}, 2},
+		{"east", Station{East: -10}, 1},
+		{"down", Station{TVD: 10}, 3},
+		{"north-east", Station{North: 10, East: 10}, math.Sqrt(2.5)},
+		{"same point", Station{}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.SigmaAlong(origin, tt.to); !almostEqual(got, tt.want) {
+				t.Errorf("SigmaAlong() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolateCovariance(t *testing.T) {
+	stations := []Station{{MD: 0}, {MD: 1000}}
+	covariances := []Covariance{{}, {{4, 0, 0}, {0, 2, 0}, {0, 0, 8}}}
+	tests := []struct {
+		md   float64
+		want float64
+	}{
+		{-10, 0},
+		{250, 1},
+		{1000, 4},
+		{2000, 4},
+	}
+	for _, tt := range tests {
+		if got := InterpolateCovariance(stations, covariances, tt.md); !almostEqual(got[0][0], tt.want) {
+			t.Errorf("InterpolateCovariance(%v) north variance = %v, want %v", tt.md, got[0][0], tt.want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package wellpath
+
+import (
+	"math"
+	"sort"
+)
+
+// Station is a single survey point. Inclination and azimuth are in degrees,
+// positions are in the same length unit as MD.
+type Station struct {
+	MD    float64
+	Incl  float64
+	Azim  float64
+	North float64
+	East  float64
+	TVD   float64
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// Direction returns the unit tangent vector (north, east, down) for the given inclination and azimuth.
+func Direction(incl, azim float64) (float64, float64, float64) {
+	i, a := toRadians(incl), toRadians(azim)
+	return math.Sin(i) * math.Cos(a), math.Sin(i) * math.Sin(a), math.Cos(i)
+}
+
+// DoglegAngle returns the angle in radians between two survey directions.
+func DoglegAngle(incl1, azim1, incl2, azim2 float64) float64 {
+	i1, i2 := toRadians(incl1), toRadians(incl2)
+	da := toRadians(azim2 - azim1)
+	cosBeta := math.Cos(i2-i1) - math.Sin(i1)*math.Sin(i2)*(1-math.Cos(da))
+	return math.Acos(math.Max(-1, math.Min(1, cosBeta)))
+}
+
+// DoglegSeverity returns the dogleg severity between two stations in degrees per courseLength.
+func DoglegSeverity(a, b Station, courseLength float64) float64 {
+	dmd := b.MD - a.MD
+	if dmd <= 0 {
+		return 0
+	}
+	return toDegrees(DoglegAngle(a.Incl, a.Azim, b.Incl, b.Azim)) * courseLength / dmd
+}
+
+// ratioFactor is the minimum curvature ratio factor for the dogleg angle beta.
+func ratioFactor(beta float64) float64 {
+	if beta < 1e-9 {
+		return 1
+	}
+	return 2 / beta * math.Tan(beta/2)
+}
+
+// Next computes the position of the station at md with the given direction from prev
+// using the minimum curvature method.
+func Next(prev Station, md, incl, azim float64) Station {
+	dmd := md - prev.MD
+	beta := DoglegAngle(prev.Incl, prev.Azim, incl, azim)
+	rf := ratioFactor(beta)
+
+	n1, e1, v1 := Direction(prev.Incl, prev.Azim)
+	n2, e2, v2 := Direction(incl, azim)
+
+	return Station{
+		MD:    md,
+		Incl:  incl,
+		Azim:  azim,
+		North: prev.North + dmd/2*(n1+n2)*rf,
+		East:  prev.East + dmd/2*(e1+e2)*rf,
+		TVD:   prev.TVD + dmd/2*(v1+v2)*rf,
+	}
+}
+
+// Compute recalculates positions of all stations from the first one with the minimum curvature method.
+// The first station keeps its position and the slice is sorted by MD.
+func Compute(stations []Station) []Station {
+	res := make([]Station, len(stations))
+	copy(res, stations)
+	SortByMD(res)
+
+	for i := 1; i < len(res); i++ {
+		res[i] = Next(res[i-1], res[i].MD, res[i].Incl, res[i].Azim)
+	}
+	return res
+}
+
+// SortByMD sorts stations by measured depth in place.
+func SortByMD(stations []Station) {
+	sort.SliceStable(stations, func(i, j int) bool {
+		return stations[i].MD < stations[j].MD
+	})
+}
+
+// Interpolate returns the station at md on the minimum curvature arc between the surrounding stations.
+// The second value is false when md lies outside the surveyed interval.
+func Interpolate(stations []Station, md float64) (Station, bool) {
+	if len(stations) == 0 || md < stations[0].MD || md > stations[len(stations)-1].MD {
+		return Station{}, false
+	}
+
+	idx := sort.Search(len(stations), func(i int) bool { return stations[i].MD >= md })
+	if stations[idx].MD == md {
+		return stations[idx], true
+	}
+
+	a, b := stations[idx-1], stations[idx]
+	beta := DoglegAngle(a.Incl, a.Azim, b.Incl, b.Azim)
+	frac := (md - a.MD) / (b.MD - a.MD)
+
+	n1, e1, v1 := Direction(a.Incl, a.Azim)
+	n2, e2, v2 := Direction(b.Incl, b.Azim)
+
+	var n, e, v float64
+	if beta < 1e-9 {
+		n, e, v = n1, e1, v1
+	} else {
+		// Spherical interpolation of the tangent vector along the arc
+		w1 := math.Sin((1-frac)*beta) / math.Sin(beta)
+		w2 := math.Sin(frac*beta) / math.Sin(beta)
+		n, e, v = w1*n1+w2*n2, w1*e1+w2*e2, w1*v1+w2*v2
+	}
+
+	incl := toDegrees(math.Acos(math.Max(-1, math.Min(1, v))))
+	azim := toDegrees(math.Atan2(e, n))
+	if azim < 0 {
+		azim += 360
+	}
+
+	return Next(a, md, incl, azim), true
+}
+
+// Resample returns stations every step along MD, always keeping the original stations.
+func Resample(stations []Station, step float64) []Station {
+	if len(stations) < 2 || step <= 0 {
+		return stations
+	}
+
+	res := []Station{stations[0]}
+	for i := 1; i < len(stations); i++ {
+		for md := res[len(res)-1].MD + step; md < stations[i].MD; md += step {
+			if s, ok := Interpolate(stations, md); ok {
+				res = append(res, s)
+			}
+		}
+		res = append(res, stations[i])
+	}
+	return res
+}
+
+// interpolateAzimuth interpolates between two azimuths along the shorter arc.
+func interpolateAzimuth(a, b, t float64) float64 {
+	diff := math.Mod(b-a+540, 360) - 180
+	return math.Mod(a+t*diff+360, 360)
+}
+
+// Distance returns the 3D distance between two stations.
+func Distance(a, b Station) float64 {
+	return math.Sqrt((a.North-b.North)*(a.North-b.North) + (a.East-b.East)*(a.East-b.East) + (a.TVD-b.TVD)*(a.TVD-b.TVD))
+}
+
+// ClosestPoint finds the point on the path closest to p. The path between stations
+// is approximated by its chords, the MD of the result is interpolated linearly.
+func ClosestPoint(stations []Station, p Station) (Station, float64) {
+	if len(stations) == 0 {
+		return Station{}, math.Inf(1)
+	}
+
+	best, bestDist := stations[0], Distance(stations[0], p)
+	for i := 1; i < len(stations); i++ {
+		a, b := stations[i-1], stations[i]
+		dn, de, dv := b.North-a.North, b.East-a.East, b.TVD-a.TVD
+		length := dn*dn + de*de + dv*dv
+
+		t := 0.0
+		if length > 0 {
+			t = ((p.North-a.North)*dn + (p.East-a.East)*de + (p.TVD-a.TVD)*dv) / length
+			t = math.Max(0, math.Min(1, t))
+		}
+
+		candidate := Station{
+			MD:    a.MD + t*(b.MD-a.MD),
+			Incl:  a.Incl + t*(b.Incl-a.Incl),
+			Azim:  interpolateAzimuth(a.Azim, b.Azim, t),
+			North: a.North + t*dn,
+			East:  a.East + t*de,
+			TVD:   a.TVD + t*dv,
+		}
+		if d := Distance(candidate, p); d < bestDist {
+			best, bestDist = candidate, d
+		}
+	}
+	return best, bestDist
+}
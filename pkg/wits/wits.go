@@ -0,0 +1,109 @@
+// Package wits reads and writes WITS Level 0 frames.
+//
+// A WITS0 frame starts with a "&&" line and ends with a "!!" line. Every line in between is a data item:
+// a four digit code, the record type and the item number, followed by the ASCII value, e.g. "0108 1523.4"
+// is record 1, item 8, the measured bit depth. Lines end with CR LF, a bare LF is accepted.
+package wits
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	frameStart = "&&"
+	frameEnd   = "!!"
+	codeLength = 4
+	// maxFrameItems protects the decoder from a stream that never ends a frame
+	maxFrameItems = 1000
+)
+
+// ErrFrameTooLong is returned for frames with more than maxFrameItems items, the decoder resynchronizes
+// on the next frame start.
+var ErrFrameTooLong = errors.New("wits frame has too many items")
+
+// Frame holds the items of a frame by their four digit code.
+type Frame map[string]string
+
+// Float returns the item as a number, false when the item is missing or is not a number.
+func (f Frame) Float(code string) (float64, bool) {
+	value, ok := f[code]
+	if !ok {
+		return 0, false
+	}
+	res, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, false
+	}
+	return res, true
+}
+
+// Decoder reads frames from a stream. Lines outside a frame and malformed item lines are skipped.
+type Decoder struct {
+	scanner *bufio.Scanner
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{scanner: bufio.NewScanner(r)}
+}
+
+// Decode returns the next complete frame. It returns io.EOF when the stream ends, an unfinished frame
+// at the end of the stream is dropped.
+func (d *Decoder) Decode() (Frame, error) {
+	var frame Frame
+	for d.scanner.Scan() {
+		line := strings.TrimSpace(d.scanner.Text())
+		switch {
+		case line == frameStart:
+			frame = Frame{}
+		case frame == nil:
+			continue
+		case line == frameEnd:
+			return frame, nil
+		case len(line) >= codeLength && isDigits(line[:codeLength]):
+			if len(frame) >= maxFrameItems {
+				return nil, ErrFrameTooLong
+			}
+			frame[line[:codeLength]] = strings.TrimSpace(line[codeLength:])
+		}
+	}
+	if err := d.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Encode writes the frame with its items ordered by code.
+func Encode(w io.Writer, frame Frame) error {
+	codes := make([]string, 0, len(frame))
+	for code := range frame {
+		if len(code) != codeLength || !isDigits(code) {
+			return fmt.Errorf("invalid wits item code %q", code)
+		}
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var b strings.Builder
+	b.WriteString(frameStart + "\r\n")
+	for _, code := range codes {
+		b.WriteString(code + frame[code] + "\r\n")
+	}
+	b.WriteString(frameEnd + "\r\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package wits
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	stream := "noise before the first frame\r\n" +
+		"&&\r\n0108 1523.4\r\n0110  1530.1 \r\n01 short\r\n0113abc\r\n!!\r\n" +
+		"0199 outside a frame\n" +
+		"&&\n0108 1524.0\n!!\n" +
+		"&&\n0108 1525.0\n"
+	decoder := NewDecoder(strings.NewReader(stream))
+
+	frame, err := decoder.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Frame{"0108": "1523.4", "0110": "1530.1", "0113": "abc"}
+	if len(frame) != len(want) {
+		t.Fatalf("got %v, want %v", frame, want)
+	}
+	for code, value := range want {
+		if frame[code] != value {
+			t.Errorf("item %s: got %q, want %q", code, frame[code], value)
+		}
+	}
+
+	if frame, err = decoder.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if len(frame) != 1 || frame["0108"] != "1524.0" {
+		t.Errorf("got %v, want the LF terminated frame", frame)
+	}
+	if _, err = decoder.Decode(); err != io.EOF {
+		t.Errorf("got %v for the unfinished frame, want io.EOF", err)
+	}
+}
+
+func TestDecodeFrameTooLong(t *testing.T) {
+	// items with the same code count once, so every item has its own code
+	var b strings.Builder
+	b.WriteString("&&\n")
+	for i := 0; i <= maxFrameItems; i++ {
+		fmt.Fprintf(&b, "%04d 1\n", i)
+	}
+	b.WriteString("!!\n&&\n0108 1\n!!\n")
+
+	decoder := NewDecoder(strings.NewReader(b.String()))
+	if _, err := decoder.Decode(); !errors.Is(err, ErrFrameTooLong) {
+		t.Fatalf("got %v, want ErrFrameTooLong", err)
+	}
+	frame, err := decoder.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frame) != 1 {
+		t.Errorf("got %d items after resynchronizing, want 1", len(frame))
+	}
+}
+
+func TestFloat(t *testing.T) {
+	frame := Frame{"0108": " 1523.4 ", "0113": "abc"}
+	tests := []struct {
+		code  string
+		value float64
+		ok    bool
+	}{
+		{"0108", 1523.4, true},
+		{"0113", 0, false},
+		{"0110", 0, false},
+	}
+	for _, tt := range tests {
+		if value, ok := frame.Float(tt.code); value != tt.value || ok != tt.ok {
+			t.Errorf("Float(%s) = %v, %v, want %v, %v", tt.code, value, ok, tt.value, tt.ok)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	var b strings.Builder
+	if err := Encode(&b, Frame{"0110": "1530.1", "0108": "1523.4"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "&&\r\n01081523.4\r\n01101530.1\r\n!!\r\n"
+	if b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+
+	frame, err := NewDecoder(strings.NewReader(b.String())).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frame["0108"] != "1523.4" || frame["0110"] != "1530.1" {
+		t.Errorf("got %v after the round trip", frame)
+	}
+
+	for _, code := range []string{"108", "01080", "01a8"} {
+		if err = Encode(io.Discard, Frame{code: "1"}); err == nil {
+			t.Errorf("Encode() accepted the code %q", code)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+// Package witsml reads and writes WITSML 1.4.1 data object files.
+//
+// Only the elements that have a counterpart in the well plan are modelled, other elements are ignored when
+// reading. Element names are matched in any namespace, so files that omit the WITSML namespace are read too.
+// Measures carry their unit in the uom attribute, Canonical converts them to the canonical unit of a quantity.
+package witsml
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+)
+
+const (
+	Namespace = "http://www.witsml.org/schemas/1series"
+	Version   = "1.4.1.1"
+	// versionPrefix is the version family the decoder accepts
+	versionPrefix = "1.4.1"
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported witsml version")
+	ErrUnknownAngleUom    = errors.New("unknown plane angle unit of measure")
+)
+
+// Measure is a value with its unit of measure.
+type Measure struct {
+	Uom   string  `xml:"uom,attr"`
+	Value float64 `xml:",chardata"`
+}
+
+// MarshalXML writes the value in plain decimal notation, which more readers accept than exponents.
+func (m Measure) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "uom"}, Value: m.Uom})
+	return e.EncodeElement(strconv.FormatFloat(m.Value, 'f', -1, 64), start)
+}
+
+// NewMeasure returns the canonical value of the quantity as a measure, nil for a nil value.
+func NewMeasure(q units.Quantity, v *float64) *Measure {
+	if v == nil {
+		return nil
+	}
+	return &Measure{Uom: units.CanonicalUom(q), Value: *v}
+}
+
+// Canonical returns the measure in the canonical unit of the quantity, nil for a missing measure.
+func (m *Measure) Canonical(q units.Quantity) (*float64, error) {
+	if m == nil {
+		return nil, nil
+	}
+	unit, err := units.ParseUom(q, m.Uom)
+	if err != nil {
+		return nil, err
+	}
+	res := unit.ToCanonical(m.Value)
+	return &res, nil
+}
+
+// NewAngle returns the angle in degrees as a measure, nil for a nil value.
+func NewAngle(degrees *float64) *Measure {
+	if degrees == nil {
+		return nil
+	}
+	return &Measure{Uom: "dega", Value: *degrees}
+}
+
+// Degrees returns the plane angle in degrees, nil for a missing measure.
+func (m *Measure) Degrees() (*float64, error) {
+	if m == nil {
+		return nil, nil
+	}
+	var res float64
+	switch strings.TrimSpace(m.Uom) {
+	case "dega":
+		res = m.Value
+	case "rad":
+		res = m.Value * 180 / math.Pi
+	case "gon":
+		res = m.Value * 0.9
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAngleUom, m.Uom)
+	}
+	return &res, nil
+}
+
+// RefName is a name that refers to another object or element by its uid.
+type RefName struct {
+	UIDRef string `xml:"uidRef,attr,omitempty"`
+	Value  string `xml:",chardata"`
+}
+
+type CommonData struct {
+	Comments string `xml:"comments,omitempty"`
+}
+
+// Location is a position of the well or of a trajectory station in the coordinate reference system of WellCRS.
+type Location struct {
+	UID       string   `xml:"uid,attr,omitempty"`
+	WellCRS   *RefName `xml:"wellCRS"`
+	Latitude  *Measure `xml:"latitude"`
+	Longitude *Measure `xml:"longitude"`
+	Easting   *Measure `xml:"easting"`
+	Northing  *Measure `xml:"northing"`
+}
+
+// WellKnownName is a name in a naming system, e.g. namingSystem="EPSG" code="32640".
+type WellKnownName struct {
+	NamingSystem string `xml:"namingSystem,attr,omitempty"`
+	Code         string `xml:"code,attr,omitempty"`
+	Value        string `xml:",chardata"`
+}
+
+type CRSName struct {
+	NameCRS WellKnownName `xml:"nameCRS"`
+}
+
+type WellCRS struct {
+	UID           string   `xml:"uid,attr,omitempty"`
+	Name          string   `xml:"name"`
+	MapProjection *CRSName `xml:"mapProjection"`
+	Geographic    *CRSName `xml:"geographic"`
+}
+
+// EPSGCode returns the EPSG code of the projected or geographic CRS, 0 when it has none.
+func (c *WellCRS) EPSGCode() int {
+	for _, crs := range []*CRSName{c.MapProjection, c.Geographic} {
+		if crs == nil {
+			continue
+		}
+		name := crs.NameCRS
+		if strings.EqualFold(name.NamingSystem, "EPSG") {
+			if code, err := strconv.Atoi(strings.TrimSpace(name.Code)); err == nil {
+				return code
+			}
+			if code, err := strconv.Atoi(strings.TrimSpace(name.Value)); err == nil {
+				return code
+			}
+		}
+		if value, ok := cutPrefixFold(strings.TrimSpace(name.Value), "EPSG:"); ok {
+			if code, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				return code
+			}
+		}
+	}
+	return 0
+}
+
+// NewEPSGCRS returns a projected CRS with the EPSG code.
+func NewEPSGCRS(uid string, code int) *WellCRS {
+	name := fmt.Sprintf("EPSG:%d", code)
+	return &WellCRS{
+		UID:           uid,
+		Name:          name,
+		MapProjection: &CRSName{NameCRS: WellKnownName{NamingSystem: "EPSG", Code: strconv.Itoa(code), Value: name}},
+	}
+}
+
+type Wells struct {
+	XMLName xml.Name `xml:"wells"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	Version string   `xml:"version,attr"`
+	Wells   []*Well  `xml:"well"`
+}
+
+type Well struct {
+	UID          string      `xml:"uid,attr,omitempty"`
+	Name         string      `xml:"name"`
+	NumGovt      string      `xml:"numGovt,omitempty"`
+	WellLocation []*Location `xml:"wellLocation"`
+	WellCRS      []*WellCRS  `xml:"wellCRS"`
+	CommonData   *CommonData `xml:"commonData"`
+}
+
+// CRS returns the CRS of the well with the uid, nil when the well has none.
+func (w *Well) CRS(uid string) *WellCRS {
+	for _, crs := range w.WellCRS {
+		if crs.UID == uid {
+			return crs
+		}
+	}
+	return nil
+}
+
+type Wellbores struct {
+	XMLName   xml.Name    `xml:"wellbores"`
+	Xmlns     string      `xml:"xmlns,attr,omitempty"`
+	Version   string      `xml:"version,attr"`
+	Wellbores []*Wellbore `xml:"wellbore"`
+}
+
+type Wellbore struct {
+	UIDWell    string      `xml:"uidWell,attr,omitempty"`
+	UID        string      `xml:"uid,attr,omitempty"`
+	NameWell   string      `xml:"nameWell"`
+	Name       string      `xml:"name"`
+	MD         *Measure    `xml:"md"`
+	CommonData *CommonData `xml:"commonData"`
+}
+
+type Trajectories struct {
+	XMLName      xml.Name      `xml:"trajectorys"`
+	Xmlns        string        `xml:"xmlns,attr,omitempty"`
+	Version      string        `xml:"version,attr"`
+	Trajectories []*Trajectory `xml:"trajectory"`
+}
+
+type Trajectory struct {
+	UIDWell      string               `xml:"uidWell,attr,omitempty"`
+	UIDWellbore  string               `xml:"uidWellbore,attr,omitempty"`
+	UID          string               `xml:"uid,attr,omitempty"`
+	NameWell     string               `xml:"nameWell"`
+	NameWellbore string               `xml:"nameWellbore"`
+	Name         string               `xml:"name"`
+	Stations     []*TrajectoryStation `xml:"trajectoryStation"`
+	CommonData   *CommonData          `xml:"commonData"`
+}
+
+// Types of trajectory stations
+const (
+	StationTieIn   = "tie in point"
+	StationUnknown = "unknown"
+)
+
+type TrajectoryStation struct {
+	UID             string      `xml:"uid,attr,omitempty"`
+	TypeTrajStation string      `xml:"typeTrajStation"`
+	MD              *Measure    `xml:"md"`
+	TVD             *Measure    `xml:"tvd"`
+	Incl            *Measure    `xml:"incl"`
+	Azi             *Measure    `xml:"azi"`
+	DispNs          *Measure    `xml:"dispNs"`
+	DispEw          *Measure    `xml:"dispEw"`
+	VertSect        *Measure    `xml:"vertSect"`
+	DLS             *Measure    `xml:"dls"`
+	Location        []*Location `xml:"location"`
+}
+
+type Tubulars struct {
+	XMLName  xml.Name   `xml:"tubulars"`
+	Xmlns    string     `xml:"xmlns,attr,omitempty"`
+	Version  string     `xml:"version,attr"`
+	Tubulars []*Tubular `xml:"tubular"`
+}
+
+type Tubular struct {
+	UIDWell          string              `xml:"uidWell,attr,omitempty"`
+	UIDWellbore      string              `xml:"uidWellbore,attr,omitempty"`
+	UID              string              `xml:"uid,attr,omitempty"`
+	NameWell         string              `xml:"nameWell"`
+	NameWellbore     string              `xml:"nameWellbore"`
+	Name             string              `xml:"name"`
+	TubularComponent []*TubularComponent `xml:"tubularComponent"`
+	CommonData       *CommonData         `xml:"commonData"`
+}
+
+// TubularComponent is a component of the assembly, sequence 1 is the component at the bit.
+type TubularComponent struct {
+	UID             string        `xml:"uid,attr,omitempty"`
+	TypeTubularComp string        `xml:"typeTubularComp"`
+	Sequence        *int          `xml:"sequence"`
+	Description     string        `xml:"description,omitempty"`
+	ID              *Measure      `xml:"id"`
+	OD              *Measure      `xml:"od"`
+	Len             *Measure      `xml:"len"`
+	LenJointAv      *Measure      `xml:"lenJointAv"`
+	WtPerLen        *Measure      `xml:"wtPerLen"`
+	Grade           string        `xml:"grade,omitempty"`
+	TensYield       *Measure      `xml:"tensYield"`
+	TypeMaterial    string        `xml:"typeMaterial,omitempty"`
+	Vendor          string        `xml:"vendor,omitempty"`
+	Stabilizer      []*Stabilizer `xml:"stabilizer"`
+}
+
+type Stabilizer struct {
+	LenBlade  *Measure `xml:"lenBlade"`
+	OdBladeMx *Measure `xml:"odBladeMx"`
+}
+
+type BhaRuns struct {
+	XMLName xml.Name  `xml:"bhaRuns"`
+	Xmlns   string    `xml:"xmlns,attr,omitempty"`
+	Version string    `xml:"version,attr"`
+	BhaRuns []*BhaRun `xml:"bhaRun"`
+}
+
+type BhaRun struct {
+	UIDWell        string            `xml:"uidWell,attr,omitempty"`
+	UIDWellbore    string            `xml:"uidWellbore,attr,omitempty"`
+	UID            string            `xml:"uid,attr,omitempty"`
+	NameWell       string            `xml:"nameWell"`
+	NameWellbore   string            `xml:"nameWellbore"`
+	Name           string            `xml:"name"`
+	Tubular        *RefName          `xml:"tubular"`
+	DrillingParams []*DrillingParams `xml:"drillingParams"`
+	CommonData     *CommonData       `xml:"commonData"`
+}
+
+type DrillingParams struct {
+	UID         string   `xml:"uid,attr,omitempty"`
+	MdHoleStart *Measure `xml:"mdHoleStart"`
+	MdHoleStop  *Measure `xml:"mdHoleStop"`
+}
+
+// Document is one of the plural container elements of a data object file.
+type Document interface {
+	version() string
+	setVersion()
+}
+
+func (d *Wells) version() string        { return d.Version }
+func (d *Wellbores) version() string    { return d.Version }
+func (d *Trajectories) version() string { return d.Version }
+func (d *Tubulars) version() string     { return d.Version }
+func (d *BhaRuns) version() string      { return d.Version }
+
+func (d *Wells) setVersion()        { d.Xmlns, d.Version = Namespace, Version }
+func (d *Wellbores) setVersion()    { d.Xmlns, d.Version = Namespace, Version }
+func (d *Trajectories) setVersion() { d.Xmlns, d.Version = Namespace, Version }
+func (d *Tubulars) setVersion()     { d.Xmlns, d.Version = Namespace, Version }
+func (d *BhaRuns) setVersion()      { d.Xmlns, d.Version = Namespace, Version }
+
+// Decode reads the document from the file. Documents of other WITSML versions are rejected, a missing
+// version attribute is accepted.
+func Decode(r io.Reader, doc Document) error {
+	if err := xml.NewDecoder(r).Decode(doc); err != nil {
+		return err
+	}
+	if v := doc.version(); v != "" && !strings.HasPrefix(v, versionPrefix) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedVersion, v)
+	}
+	return nil
+}
+
+// Encode writes the document with the XML declaration, the WITSML namespace and version.
+func Encode(w io.Writer, doc Document) error {
+	doc.setVersion()
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
@@ -0,0 +1,487 @@
+// Package workbook writes structs to the sheets of an Excel workbook and reads them back.
+//
+// The columns of a sheet are the exported fields of the struct with a json tag, in the order of the struct.
+// Fields of embedded structs are columns of the embedding struct. Strings, numbers and booleans are written,
+// pointers to them may be empty cells, other fields like times and slices are left out. The header of a field
+// with a unit tag names the unit symbol of the system, "md [ft]", and reading takes the name before the unit.
+package workbook
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/munaiplan/munaiplan-backend/pkg/units"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	jsonTag     = "json"
+	unitTag     = "unit"
+	headerWidth = 1.2
+	minWidth    = 10.0
+	maxWidth    = 60.0
+)
+
+// column is a field of the rows and the index path to it.
+type column struct {
+	key      string
+	quantity units.Quantity
+	index    []int
+}
+
+func (c *column) header(system *units.System) string {
+	if c.quantity == "" {
+		return c.key
+	}
+	if symbol := system.Symbol(c.quantity); symbol != "" {
+		return c.key + " [" + symbol + "]"
+	}
+	return c.key
+}
+
+// headerKey returns the field name of a header, without the unit.
+func headerKey(header string) string {
+	if i := strings.Index(header, " ["); i >= 0 {
+		header = header[:i]
+	}
+	return strings.TrimSpace(header)
+}
+
+// columns returns the columns of the struct type. Like in encoding/json, a field hides the fields with the
+// same name in deeper embedded structs.
+func columns(t reflect.Type) []*column {
+	var candidates []*column
+	var collect func(t reflect.Type, index []int)
+	collect = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			path := append(append([]int{}, index...), i)
+			if field.Anonymous {
+				embedded := field.Type
+				if embedded.Kind() == reflect.Ptr {
+					embedded = embedded.Elem()
+				}
+				if embedded.Kind() == reflect.Struct {
+					collect(embedded, path)
+				}
+				continue
+			}
+			key := strings.Split(field.Tag.Get(jsonTag), ",")[0]
+			if !field.IsExported() || key == "" || key == "-" || !isScalar(field.Type) {
+				continue
+			}
+			candidates = append(candidates, &column{key: key, quantity: units.Quantity(field.Tag.Get(unitTag)), index: path})
+		}
+	}
+	collect(t, nil)
+
+	depth := make(map[string]int)
+	for _, c := range candidates {
+		if d, ok := depth[c.key]; !ok || len(c.index) < d {
+			depth[c.key] = len(c.index)
+		}
+	}
+	var res []*column
+	for _, c := range candidates {
+		if depth[c.key] == len(c.index) {
+			res = append(res, c)
+			depth[c.key] = -1
+		}
+	}
+	return res
+}
+
+func isScalar(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// structType returns the struct type of rows like []T, []*T and *[]*T.
+func structType(t reflect.Type) (reflect.Type, error) {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("workbook: %s is not a struct", t)
+	}
+	return t, nil
+}
+
+// fieldValue returns the field of the struct, nil when it is in an embedded struct that is nil.
+func fieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// settableField returns the field of the struct and allocates the nil embedded structs on the way.
+func settableField(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// cellValue returns the value written to the cell of a field, nil for empty cells.
+func cellValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+// setCell parses the text of a cell into the field, an empty cell sets pointers to nil and values to zero.
+func setCell(field reflect.Value, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	target := field
+	if field.Kind() == reflect.Ptr {
+		target = reflect.New(field.Type().Elem()).Elem()
+	}
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(text)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(strings.ToLower(text))
+		if err != nil {
+			return err
+		}
+		target.SetBool(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+		if err != nil {
+			return err
+		}
+		target.SetFloat(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return err
+		}
+		if value != float64(int64(value)) {
+			return fmt.Errorf("%s is not a whole number", text)
+		}
+		target.SetInt(int64(value))
+	}
+	if field.Kind() == reflect.Ptr {
+		field.Set(target.Addr())
+	}
+	return nil
+}
+
+// HasSheet reports whether the workbook has the sheet.
+func HasSheet(f *excelize.File, sheet string) bool {
+	index, err := f.GetSheetIndex(sheet)
+	return err == nil && index >= 0
+}
+
+// newSheet adds the sheet, the first sheet replaces the default sheet of a new workbook.
+func newSheet(f *excelize.File, sheet string) error {
+	sheets := f.GetSheetList()
+	if len(sheets) == 1 && sheets[0] == "Sheet1" && sheet != "Sheet1" {
+		if rows, err := f.GetRows("Sheet1"); err == nil && len(rows) == 0 {
+			return f.SetSheetName("Sheet1", sheet)
+		}
+	}
+	_, err := f.NewSheet(sheet)
+	return err
+}
+
+func headerStyle(f *excelize.File) (int, error) {
+	return f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"E2E8F0"}},
+		Alignment: &excelize.Alignment{Vertical: "center"},
+	})
+}
+
+// WriteTable writes the rows, a slice of structs or struct pointers, to a new sheet with a header row of the
+// columns. The values are written as they are, the system only names the units in the headers.
+func WriteTable(f *excelize.File, sheet string, rows interface{}, system *units.System) error {
+	value := reflect.ValueOf(rows)
+	t, err := structType(value.Type())
+	if err != nil {
+		return err
+	}
+	cols := columns(t)
+	if err := newSheet(f, sheet); err != nil {
+		return err
+	}
+
+	widths := make([]float64, len(cols))
+	header := make([]interface{}, len(cols))
+	for i, c := range cols {
+		header[i] = c.header(system)
+		widths[i] = float64(len([]rune(header[i].(string)))) * headerWidth
+	}
+	if err := f.SetSheetRow(sheet, "A1", &header); err != nil {
+		return err
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		row := reflect.Indirect(value.Index(i))
+		if !row.IsValid() {
+			continue
+		}
+		cells := make([]interface{}, len(cols))
+		for j, c := range cols {
+			if field, ok := fieldValue(row, c.index); ok {
+				cells[j] = cellValue(field)
+			}
+			if text, ok := cells[j].(string); ok {
+				widths[j] = max(widths[j], float64(len([]rune(text))))
+			}
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := f.SetSheetRow(sheet, cell, &cells); err != nil {
+			return err
+		}
+	}
+	return formatHeader(f, sheet, 1, len(cols), widths)
+}
+
+// ReadTable reads the rows of the sheet into rows, a pointer to a slice of structs or struct pointers.
+// Columns are matched by the names of the headers, unknown columns and empty rows are skipped.
+func ReadTable(f *excelize.File, sheet string, rows interface{}) error {
+	target := reflect.ValueOf(rows)
+	if target.Kind() != reflect.Ptr || target.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("workbook: rows must be a pointer to a slice")
+	}
+	t, err := structType(target.Type())
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]*column)
+	for _, c := range columns(t) {
+		byKey[c.key] = c
+	}
+
+	cells, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return err
+	}
+	if len(cells) == 0 {
+		return fmt.Errorf("sheet %q has no header row", sheet)
+	}
+	header := make([]*column, len(cells[0]))
+	for i, text := range cells[0] {
+		header[i] = byKey[headerKey(text)]
+	}
+
+	slice := target.Elem()
+	elemType := slice.Type().Elem()
+	for r, row := range cells[1:] {
+		if isEmptyRow(row) {
+			continue
+		}
+		item := reflect.New(t).Elem()
+		for i, text := range row {
+			if i >= len(header) || header[i] == nil {
+				continue
+			}
+			if err := setCell(settableField(item, header[i].index), text); err != nil {
+				cell, _ := excelize.CoordinatesToCellName(i+1, r+2)
+				return fmt.Errorf("sheet %q, cell %s: %v", sheet, cell, err)
+			}
+		}
+		if elemType.Kind() == reflect.Ptr {
+			slice = reflect.Append(slice, item.Addr())
+		} else {
+			slice = reflect.Append(slice, item)
+		}
+	}
+	target.Elem().Set(slice)
+	return nil
+}
+
+// WriteFields writes the fields of a struct to a new sheet with a row per field, the name in the first column
+// and the value in the second.
+func WriteFields(f *excelize.File, sheet string, value interface{}, system *units.System) error {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	t, err := structType(v.Type())
+	if err != nil {
+		return err
+	}
+	if err := newSheet(f, sheet); err != nil {
+		return err
+	}
+
+	widths := []float64{minWidth, minWidth}
+	for i, c := range columns(t) {
+		cells := []interface{}{c.header(system), nil}
+		if field, ok := fieldValue(v, c.index); ok {
+			cells[1] = cellValue(field)
+		}
+		widths[0] = max(widths[0], float64(len([]rune(cells[0].(string)))))
+		if text, ok := cells[1].(string); ok {
+			widths[1] = max(widths[1], float64(len([]rune(text))))
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow(sheet, cell, &cells); err != nil {
+			return err
+		}
+	}
+	return setWidths(f, sheet, widths)
+}
+
+// ReadFields reads a sheet written by WriteFields into the struct value points to.
+func ReadFields(f *excelize.File, sheet string, value interface{}) error {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("workbook: value must be a pointer to a struct")
+	}
+	byKey := make(map[string]*column)
+	for _, c := range columns(v.Elem().Type()) {
+		byKey[c.key] = c
+	}
+
+	cells, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return err
+	}
+	for r, row := range cells {
+		if len(row) == 0 {
+			continue
+		}
+		c := byKey[headerKey(row[0])]
+		if c == nil {
+			continue
+		}
+		text := ""
+		if len(row) > 1 {
+			text = row[1]
+		}
+		if err := setCell(settableField(v.Elem(), c.index), text); err != nil {
+			return fmt.Errorf("sheet %q, cell B%d: %v", sheet, r+1, err)
+		}
+	}
+	return nil
+}
+
+// WriteColumns writes a struct of slices, like the curves of a calculation, to a new sheet with a column per
+// slice field with a json tag. Slices of floats and float pointers are written, shorter slices leave empty cells.
+func WriteColumns(f *excelize.File, sheet string, value interface{}, system *units.System) error {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	t, err := structType(v.Type())
+	if err != nil {
+		return err
+	}
+	if err := newSheet(f, sheet); err != nil {
+		return err
+	}
+
+	var header []interface{}
+	var series []reflect.Value
+	var widths []float64
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.Split(field.Tag.Get(jsonTag), ",")[0]
+		if !field.IsExported() || key == "" || key == "-" || field.Type.Kind() != reflect.Slice || !isScalar(field.Type.Elem()) {
+			continue
+		}
+		c := &column{key: key, quantity: units.Quantity(field.Tag.Get(unitTag))}
+		header = append(header, c.header(system))
+		series = append(series, v.Field(i))
+		widths = append(widths, float64(len([]rune(c.header(system))))*headerWidth)
+	}
+	if err := f.SetSheetRow(sheet, "A1", &header); err != nil {
+		return err
+	}
+
+	rows := 0
+	for _, s := range series {
+		if s.Len() > rows {
+			rows = s.Len()
+		}
+	}
+	for r := 0; r < rows; r++ {
+		cells := make([]interface{}, len(series))
+		for i, s := range series {
+			if r < s.Len() {
+				cells[i] = cellValue(s.Index(r))
+			}
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, r+2)
+		if err := f.SetSheetRow(sheet, cell, &cells); err != nil {
+			return err
+		}
+	}
+	return formatHeader(f, sheet, 1, len(header), widths)
+}
+
+// WriteNote writes a sheet with a single line of text, like the reason a result is missing.
+func WriteNote(f *excelize.File, sheet, text string) error {
+	if err := newSheet(f, sheet); err != nil {
+		return err
+	}
+	return f.SetCellValue(sheet, "A1", text)
+}
+
+// formatHeader styles and freezes the header row and sets the column widths.
+func formatHeader(f *excelize.File, sheet string, row, count int, widths []float64) error {
+	if count == 0 {
+		return nil
+	}
+	style, err := headerStyle(f)
+	if err != nil {
+		return err
+	}
+	first, _ := excelize.CoordinatesToCellName(1, row)
+	last, _ := excelize.CoordinatesToCellName(count, row)
+	if err := f.SetCellStyle(sheet, first, last, style); err != nil {
+		return err
+	}
+	topLeft, _ := excelize.CoordinatesToCellName(1, row+1)
+	if err := f.SetPanes(sheet, &excelize.Panes{Freeze: true, YSplit: row, TopLeftCell: topLeft, ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+	return setWidths(f, sheet, widths)
+}
+
+func setWidths(f *excelize.File, sheet string, widths []float64) error {
+	for i, width := range widths {
+		name, _ := excelize.ColumnNumberToName(i + 1)
+		if err := f.SetColWidth(sheet, name, name, min(max(width+2, minWidth), maxWidth)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isEmptyRow(row []string) bool {
+	for _, text := range row {
+		if strings.TrimSpace(text) != "" {
+			return false
+		}
+	}
+	return true
+}
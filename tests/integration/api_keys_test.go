@@ -0,0 +1,37 @@
+//go:build integration
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+)
+
+// TestCompanyApiKeyStaysInItsCompany checks that a key limited to a company cannot reach an entity of another
+// company, not even by passing an entity of its own company next to it.
+func TestCompanyApiKeyStaysInItsCompany(t *testing.T) {
+	other := seedHierarchy(t, tenantA.organizationID)
+	res := request(http.MethodPost, "/api/v1/api-keys/", tenantA.token, requests.CreateApiKeyRequestBody{
+		Name:      "company key",
+		Scope:     entities.ApiKeyScopeReadWrite,
+		CompanyID: &tenantA.companyID,
+	})
+	expectStatus(t, res, http.StatusCreated)
+	var key responses.ApiKeyCreatedResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &key); err != nil {
+		t.Fatal(err)
+	}
+
+	mixed := fmt.Sprintf("/api/v1/tortuosity/synthetic?caseId=%s&trajectoryId=%s", tenantA.caseID, other.trajectoryID)
+	expectStatus(t, request(http.MethodPost, mixed, key.Key, nil), http.StatusBadRequest)
+	outside := "/api/v1/tortuosity/synthetic?trajectoryId=" + other.trajectoryID
+	expectStatus(t, request(http.MethodPost, outside, key.Key, nil), http.StatusForbidden)
+	expectStatus(t, request(http.MethodGet, "/api/v1/trajectories/"+other.trajectoryID, key.Key, nil), http.StatusForbidden)
+	expectStatus(t, request(http.MethodGet, "/api/v1/trajectories/"+tenantA.trajectoryID, key.Key, nil), http.StatusOK)
+}
@@ -0,0 +1,142 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+)
+
+// hierarchy is a company of an organization down to a trajectory.
+type hierarchy struct {
+	companyID    string
+	fieldID      string
+	siteID       string
+	trajectoryID string
+}
+
+// TestMixedIDQueryParametersAreRejected checks that a request cannot be authorized by one ID query parameter
+// while the handler uses another.
+func TestMixedIDQueryParametersAreRejected(t *testing.T) {
+	paths := []string{
+		"/api/v1/tortuosity/synthetic?caseId=%[1]s&trajectoryId=%[2]s",
+		"/api/v1/witsml/wells?siteId=%[3]s&wellId=%[4]s",
+		"/api/v1/anti-collision/scan?caseId=%[1]s&trajectoryId=%[2]s",
+		"/api/v1/position-uncertainty/?caseId=%[1]s&trajectoryId=%[2]s",
+		"/api/v1/pore-pressures/eaton?caseId=%[1]s&trajectoryId=%[2]s",
+	}
+	for _, path := range paths {
+		path = fmt.Sprintf(path, tenantA.caseID, tenantA.trajectoryID, tenantA.siteID, tenantA.wellID)
+		t.Run(path, func(t *testing.T) {
+			expectStatus(t, request(http.MethodPost, path, tenantA.token, nil), http.StatusBadRequest)
+		})
+	}
+}
+
+// TestFieldViewerCannotWriteThroughAnotherParameter checks that an engineer who only views a field cannot
+// change a trajectory of the field by passing a case they may write next to it.
+func TestFieldViewerCannotWriteThroughAnotherParameter(t *testing.T) {
+	other := seedHierarchy(t, tenantA.organizationID)
+	token := seedUser(t, tenantA.organizationID,
+		models.RoleAssignment{Role: entities.RoleEngineer},
+		models.RoleAssignment{Role: entities.RoleViewer, CompanyID: uuidPtr(other.companyID), FieldID: uuidPtr(other.fieldID)},
+	)
+
+	mixed := fmt.Sprintf("/api/v1/tortuosity/synthetic?caseId=%s&trajectoryId=%s", tenantA.caseID, other.trajectoryID)
+	expectStatus(t, request(http.MethodPost, mixed, token, nil), http.StatusBadRequest)
+	viewed := "/api/v1/tortuosity/synthetic?trajectoryId=" + other.trajectoryID
+	expectStatus(t, request(http.MethodPost, viewed, token, nil), http.StatusForbidden)
+	expectStatus(t, request(http.MethodGet, "/api/v1/trajectories/"+other.trajectoryID, token, nil), http.StatusOK)
+}
+
+// seedHierarchy creates another company of the organization with a field, site, well, wellbore, design and
+// trajectory. The organization cleanup removes them.
+func seedHierarchy(t *testing.T, organizationID string) *hierarchy {
+	t.Helper()
+	suffix := uuid.NewString()[:8]
+	company := models.Company{OrganizationID: uuid.MustParse(organizationID), Name: "Company " + suffix}
+	if err := db.Create(&company).Error; err != nil {
+		t.Fatal(err)
+	}
+	field := models.Field{CompanyID: company.ID, Name: "Field " + suffix}
+	if err := db.Create(&field).Error; err != nil {
+		t.Fatal(err)
+	}
+	site := models.Site{FieldID: field.ID, Name: "Site " + suffix}
+	if err := db.Create(&site).Error; err != nil {
+		t.Fatal(err)
+	}
+	well := models.Well{SiteID: site.ID, Name: "Well " + suffix}
+	if err := db.Create(&well).Error; err != nil {
+		t.Fatal(err)
+	}
+	wellbore := models.Wellbore{WellID: well.ID, Name: "Wellbore " + suffix}
+	if err := db.Create(&wellbore).Error; err != nil {
+		t.Fatal(err)
+	}
+	design := models.Design{WellboreID: wellbore.ID, PlanName: "Plan " + suffix, Stage: entities.DesignStagePrototype, ActualDate: time.Now()}
+	if err := db.Create(&design).Error; err != nil {
+		t.Fatal(err)
+	}
+	trajectory := models.Trajectory{
+		DesignID: design.ID,
+		Name:     "Trajectory " + suffix,
+		Units: []models.TrajectoryUnit{
+			{MD: 0, Incl: 0, Azim: 0, TVD: 0},
+			{MD: 1000, Incl: 0, Azim: 0, TVD: 1000},
+		},
+	}
+	if err := db.Create(&trajectory).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return &hierarchy{
+		companyID:    company.ID.String(),
+		fieldID:      field.ID.String(),
+		siteID:       site.ID.String(),
+		trajectoryID: trajectory.ID.String(),
+	}
+}
+
+// seedUser creates a user of the organization with the role assignments and signs them in.
+func seedUser(t *testing.T, organizationID string, roles ...models.RoleAssignment) string {
+	t.Helper()
+	password, err := helpers.HashPassword(tenantPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := models.User{
+		OrganizationID: uuid.MustParse(organizationID),
+		Name:           "User",
+		Surname:        "Integration",
+		Email:          "user-" + uuid.NewString()[:8] + "@example.com",
+		Password:       password,
+	}
+	if err = db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, role := range roles {
+		role.UserID, role.OrganizationID = user.ID, user.OrganizationID
+		if err = db.Create(&role).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	token, err := signIn(user.Email)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func uuidPtr(id string) *uuid.UUID {
+	res := uuid.MustParse(id)
+	return &res
+}
@@ -0,0 +1,41 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/middleware"
+	"github.com/munaiplan/munaiplan-backend/pkg/values"
+)
+
+// TestEventStreamTokenIsNotLogged checks that a token passed in the query authenticates the event stream
+// without showing up in the request log.
+func TestEventStreamTokenIsNotLogged(t *testing.T) {
+	var log bytes.Buffer
+	stream := gin.New()
+	stream.Use(middleware.StripAccessToken, gin.LoggerWithWriter(&log))
+	stream.GET("/events", auth.EventStreamIdentity, func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(values.UserIdCtx))
+	})
+
+	res := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/events?caseId="+tenantA.caseID+"&access_token="+tenantA.token, nil)
+	stream.ServeHTTP(res, req)
+
+	expectStatus(t, res, http.StatusOK)
+	if res.Body.String() != tenantA.userID {
+		t.Errorf("got user %q, want %q", res.Body.String(), tenantA.userID)
+	}
+	if strings.Contains(log.String(), tenantA.token) {
+		t.Errorf("the access token was logged: %s", log.String())
+	}
+	if !strings.Contains(log.String(), "caseId="+tenantA.caseID) {
+		t.Errorf("the other query parameters were not logged: %s", log.String())
+	}
+}
@@ -0,0 +1,89 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+)
+
+// TestRequeueExpiredJobsKeepsLiveJobs checks that only the jobs of servers that stopped renewing their lease
+// are requeued, and that a server whose job was requeued can no longer finish it.
+func TestRequeueExpiredJobsKeepsLiveJobs(t *testing.T) {
+	ctx := context.Background()
+	jobs := repository.NewRepositories(db).Jobs
+	now := time.Now().UTC()
+
+	live := runningJob(t, "live-worker", now.Add(time.Minute))
+	stopped := runningJob(t, "stopped-worker", now.Add(-time.Minute))
+
+	if _, err := jobs.RequeueExpiredJobs(ctx, now); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := jobs.GetJobByID(ctx, live.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != entities.JobStatusRunning || job.WorkerID != "live-worker" {
+		t.Errorf("live job: got status %s on %q, want running on live-worker", job.Status, job.WorkerID)
+	}
+	if job, err = jobs.GetJobByID(ctx, stopped.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != entities.JobStatusQueued {
+		t.Errorf("stopped job: got status %s, want queued", job.Status)
+	}
+
+	job.Status, job.FinishedAt = entities.JobStatusSucceeded, &now
+	saved, err := jobs.FinishJob(ctx, job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved {
+		t.Error("a requeued job was finished by the server that lost it")
+	}
+
+	if err = jobs.RenewJobLeases(ctx, "live-worker", now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = jobs.RequeueExpiredJobs(ctx, now.Add(30*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if job, err = jobs.GetJobByID(ctx, live.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != entities.JobStatusRunning {
+		t.Errorf("renewed job: got status %s, want running", job.Status)
+	}
+}
+
+// runningJob stores a job of tenant A running on the worker with a lease until leaseUntil.
+func runningJob(t *testing.T, workerID string, leaseUntil time.Time) *models.Job {
+	t.Helper()
+	startedAt := leaseUntil.Add(-2 * time.Minute)
+	job := models.Job{
+		OrganizationID: uuid.MustParse(tenantA.organizationID),
+		CaseID:         uuid.MustParse(tenantA.caseID),
+		Type:           entities.JobTypeEffectiveTension,
+		Status:         entities.JobStatusRunning,
+		Attempts:       1,
+		RunAfter:       startedAt,
+		StartedAt:      &startedAt,
+		WorkerID:       workerID,
+		LeaseExpiresAt: &leaseUntil,
+	}
+	if err := db.Omit("Case").Create(&job).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Delete(&models.Job{}, "id = ?", job.ID)
+	})
+	return &job
+}
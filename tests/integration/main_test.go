@@ -0,0 +1,365 @@
+//go:build integration
+
+// Package integration runs the API against a real Postgres database. The tests are excluded from plain
+// `go test ./...`, run them with the database variables of the app set:
+//
+//	DB_HOST=localhost DB_USERNAME=... DB_PASSWORD=... DB_NAME=... go test -tags integration ./tests/integration/...
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/internal/application/service"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/responses"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	postgres "github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/connection"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/email"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/notify"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/handlers"
+	"github.com/munaiplan/munaiplan-backend/internal/presentation/middleware"
+	"gorm.io/gorm"
+)
+
+const tenantPassword = "password1"
+
+// tenant is an organization with an org admin and one entity of every kind below its company.
+type tenant struct {
+	token              string
+	email              string
+	userID             string
+	organizationID     string
+	companyID          string
+	fieldID            string
+	siteID             string
+	wellID             string
+	wellboreID         string
+	wellLogID          string
+	designID           string
+	trajectoryID       string
+	caseID             string
+	holeID             string
+	stringID           string
+	fluidID            string
+	fluidTypeID        string
+	rigID              string
+	porePressureID     string
+	fractureGradientID string
+	jobID              string
+}
+
+var (
+	db       *gorm.DB
+	services *service.Services
+	auth     *middleware.AuthMiddleware
+	router   *gin.Engine
+	tenantA  *tenant
+	tenantB  *tenant
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	if err := setup(); err != nil {
+		fmt.Fprintf(os.Stderr, "integration setup failed: %v\n", err)
+		return 1
+	}
+
+	var err error
+	if tenantA, err = seedTenant("a"); err == nil {
+		tenantB, err = seedTenant("b")
+	}
+	defer cleanup()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to seed tenants: %v\n", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// setup connects to the database the way the app does and builds the API router.
+func setup() error {
+	root, err := repositoryRoot()
+	if err != nil {
+		return err
+	}
+	// The setup SQL files are read relative to the repository root
+	if err = os.Chdir(root); err != nil {
+		return err
+	}
+	setDefaultEnv("USER_ACCESS_TOKEN_SECRET", "integration-access-secret")
+	setDefaultEnv("USER_REFRESH_TOKEN_SECRET", "integration-refresh-secret")
+	setDefaultEnv("ACCESS_TOKEN_LIFETIME_MINUTES", "15")
+	setDefaultEnv("REFRESH_TOKEN_LIFETIME_MINUTES", "60")
+
+	database := postgres.NewDatabase()
+	if database == nil {
+		return errors.New("failed to initialize database connection")
+	}
+	db = database.Conn
+
+	jwt, err := helpers.NewJwt()
+	if err != nil {
+		return err
+	}
+	outbox, err := os.MkdirTemp("", "munaiplan-outbox")
+	if err != nil {
+		return err
+	}
+
+	services = service.NewServices(
+		repository.NewRepositories(db),
+		jwt,
+		helpers.GetEnv("PREDICTION_SERVICE_URL", "http://localhost:8001"),
+		time.Second,
+		email.NewFileSender(outbox),
+		"http://localhost:3000",
+		notify.NewLogNotifier(),
+	)
+	auth = middleware.NewAuthMiddleware(jwt, services.Users, services.Roles, services.ApiKeys)
+
+	gin.SetMode(gin.TestMode)
+	router = gin.New()
+	handlers.NewHandler(services, auth).Init(router.Group("/api"))
+	return nil
+}
+
+func repositoryRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err = os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found")
+		}
+		dir = parent
+	}
+}
+
+func setDefaultEnv(key, value string) {
+	if os.Getenv(key) == "" {
+		os.Setenv(key, value)
+	}
+}
+
+// seedTenant creates an organization with its org admin and a full hierarchy of entities, then signs the admin in.
+func seedTenant(name string) (*tenant, error) {
+	suffix := uuid.NewString()[:8]
+	password, err := helpers.HashPassword(tenantPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	organization := models.Organization{Name: "Integration " + name, Email: fmt.Sprintf("org-%s-%s@example.com", name, suffix)}
+	if err = db.Create(&organization).Error; err != nil {
+		return nil, err
+	}
+	user := models.User{
+		OrganizationID: organization.ID,
+		Name:           "Admin",
+		Surname:        name,
+		Email:          fmt.Sprintf("admin-%s-%s@example.com", name, suffix),
+		Password:       password,
+	}
+	company := models.Company{OrganizationID: organization.ID, Name: fmt.Sprintf("Company %s %s", name, suffix)}
+	fluidType := models.FluidType{Name: "Water " + suffix}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+		role := models.RoleAssignment{UserID: user.ID, OrganizationID: organization.ID, Role: entities.RoleOrgAdmin}
+		if err := tx.Create(&role).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&company).Error; err != nil {
+			return err
+		}
+		return tx.Create(&fluidType).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	field := models.Field{CompanyID: company.ID, Name: "Field " + name}
+	if err = db.Create(&field).Error; err != nil {
+		return nil, err
+	}
+	site := models.Site{FieldID: field.ID, Name: "Site " + name}
+	if err = db.Create(&site).Error; err != nil {
+		return nil, err
+	}
+	well := models.Well{SiteID: site.ID, Name: "Well " + name}
+	if err = db.Create(&well).Error; err != nil {
+		return nil, err
+	}
+	wellbore := models.Wellbore{WellID: well.ID, Name: "Wellbore " + name}
+	if err = db.Create(&wellbore).Error; err != nil {
+		return nil, err
+	}
+	wellLog := models.WellLog{WellboreID: wellbore.ID, Name: "Log " + name, FileName: "log.las", TopDepth: 0, BottomDepth: 100}
+	if err = db.Create(&wellLog).Error; err != nil {
+		return nil, err
+	}
+	design := models.Design{WellboreID: wellbore.ID, PlanName: "Plan " + name, Stage: entities.DesignStagePrototype, ActualDate: time.Now()}
+	if err = db.Create(&design).Error; err != nil {
+		return nil, err
+	}
+	trajectory := models.Trajectory{
+		DesignID: design.ID,
+		Name:     "Trajectory " + name,
+		Units: []models.TrajectoryUnit{
+			{MD: 0, Incl: 0, Azim: 0, TVD: 0},
+			{MD: 1000, Incl: 0, Azim: 0, TVD: 1000},
+		},
+	}
+	if err = db.Create(&trajectory).Error; err != nil {
+		return nil, err
+	}
+	caseModel := models.Case{TrajectoryID: trajectory.ID, CaseName: "Case " + name, DrillDepth: 1000, PipeSize: 0.127}
+	if err = db.Create(&caseModel).Error; err != nil {
+		return nil, err
+	}
+	hole := models.Hole{CaseID: caseModel.ID, OpenHoleMDTop: 0, OpenHoleMDBase: 1000, EffectiveDiameter: 0.2159}
+	if err = db.Create(&hole).Error; err != nil {
+		return nil, err
+	}
+	stringModel := models.String{
+		CaseID:   caseModel.ID,
+		Name:     "String " + name,
+		Depth:    1000,
+		Sections: []models.Section{{Type: "Drill Pipe", BodyMD: 1000, BodyLength: 1000, BodyOD: 0.127, BodyID: 0.108}},
+	}
+	if err = db.Create(&stringModel).Error; err != nil {
+		return nil, err
+	}
+	fluid := models.Fluid{CaseID: caseModel.ID, Name: "Mud " + name, Density: 1.2, FluidBaseTypeID: fluidType.ID, BaseFluidID: fluidType.ID}
+	if err = db.Omit("Case", "FluidBaseType", "BaseFluid").Create(&fluid).Error; err != nil {
+		return nil, err
+	}
+	rig := models.Rig{CaseID: caseModel.ID, RatedWorkingPressure: 35, BopPressureRating: 35}
+	if err = db.Omit("Case").Create(&rig).Error; err != nil {
+		return nil, err
+	}
+	porePressure := models.PorePressure{CaseID: caseModel.ID, TVD: 1000, Pressure: 10, EMW: 1.02}
+	if err = db.Omit("Case").Create(&porePressure).Error; err != nil {
+		return nil, err
+	}
+	fractureGradient := models.FractureGradient{CaseID: caseModel.ID, TemperatureAtSurface: 20, TemperatureAtWellTVD: 50, TemperatureGradient: 0.03, WellTVD: 1000}
+	if err = db.Omit("Case").Create(&fractureGradient).Error; err != nil {
+		return nil, err
+	}
+	// The job waits far in the future, so no worker picks it up while the tests run
+	job := models.Job{
+		OrganizationID: organization.ID,
+		CaseID:         caseModel.ID,
+		Type:           entities.JobTypeEffectiveTension,
+		Status:         entities.JobStatusQueued,
+		RunAfter:       time.Now().AddDate(1, 0, 0),
+	}
+	if err = db.Omit("Case").Create(&job).Error; err != nil {
+		return nil, err
+	}
+
+	token, err := signIn(user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant{
+		token:              token,
+		email:              user.Email,
+		userID:             user.ID.String(),
+		organizationID:     organization.ID.String(),
+		companyID:          company.ID.String(),
+		fieldID:            field.ID.String(),
+		siteID:             site.ID.String(),
+		wellID:             well.ID.String(),
+		wellboreID:         wellbore.ID.String(),
+		wellLogID:          wellLog.ID.String(),
+		designID:           design.ID.String(),
+		trajectoryID:       trajectory.ID.String(),
+		caseID:             caseModel.ID.String(),
+		holeID:             hole.ID.String(),
+		stringID:           stringModel.ID.String(),
+		fluidID:            fluid.ID.String(),
+		fluidTypeID:        fluidType.ID.String(),
+		rigID:              rig.ID.String(),
+		porePressureID:     porePressure.ID.String(),
+		fractureGradientID: fractureGradient.ID.String(),
+		jobID:              job.ID.String(),
+	}, nil
+}
+
+func signIn(email string) (string, error) {
+	res := request(http.MethodPost, "/api/v1/users/sign-in", "", requests.UserSignInRequest{Email: email, Password: tenantPassword})
+	if res.Code != http.StatusOK {
+		return "", fmt.Errorf("sign in returned %d: %s", res.Code, res.Body.String())
+	}
+	var token responses.TokenResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &token); err != nil {
+		return "", err
+	}
+	return token.Token, nil
+}
+
+// cleanup removes the seeded organizations with their sessions and invitations, the rest of their data goes
+// with them through the cascading foreign keys.
+func cleanup() {
+	for _, t := range []*tenant{tenantA, tenantB} {
+		if t == nil {
+			continue
+		}
+		if err := db.Unscoped().Delete(&models.TokenFamily{}, "organization_id = ?", t.organizationID).Error; err != nil {
+			fmt.Fprintf(os.Stderr, "failed to remove sessions of organization %s: %v\n", t.organizationID, err)
+		}
+		if err := db.Unscoped().Delete(&models.Invitation{}, "organization_id = ?", t.organizationID).Error; err != nil {
+			fmt.Fprintf(os.Stderr, "failed to remove invitations of organization %s: %v\n", t.organizationID, err)
+		}
+		if err := db.Unscoped().Delete(&models.Organization{}, "id = ?", t.organizationID).Error; err != nil {
+			fmt.Fprintf(os.Stderr, "failed to remove organization %s: %v\n", t.organizationID, err)
+		}
+		if err := db.Unscoped().Delete(&models.FluidType{}, "id = ?", t.fluidTypeID).Error; err != nil {
+			fmt.Fprintf(os.Stderr, "failed to remove fluid type %s: %v\n", t.fluidTypeID, err)
+		}
+	}
+}
+
+// request sends a request to the API, body is encoded as JSON when it is set.
+func request(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			panic(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	return res
+}
@@ -0,0 +1,300 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	domainErrors "github.com/munaiplan/munaiplan-backend/internal/domain/types"
+)
+
+// resource is an entity with GET, PUT and DELETE endpoints by ID.
+type resource struct {
+	name   string
+	path   string
+	id     func(t *tenant) string
+	update func(t *tenant) interface{}
+}
+
+var resources = []resource{
+	{
+		name:   "field",
+		path:   "/api/v1/fields/%s",
+		id:     func(t *tenant) string { return t.fieldID },
+		update: func(t *tenant) interface{} { return requests.UpdateFieldRequestBody{Name: "Renamed field"} },
+	},
+	{
+		name:   "site",
+		path:   "/api/v1/sites/%s",
+		id:     func(t *tenant) string { return t.siteID },
+		update: func(t *tenant) interface{} { return requests.UpdateSiteRequestBody{Name: "Renamed site"} },
+	},
+	{
+		name:   "well",
+		path:   "/api/v1/wells/%s",
+		id:     func(t *tenant) string { return t.wellID },
+		update: func(t *tenant) interface{} { return requests.UpdateWellRequestBody{Name: "Renamed well"} },
+	},
+	{
+		name:   "wellbore",
+		path:   "/api/v1/wellbores/%s",
+		id:     func(t *tenant) string { return t.wellboreID },
+		update: func(t *tenant) interface{} { return requests.UpdateWellboreRequestBody{Name: "Renamed wellbore"} },
+	},
+	{
+		name: "design",
+		path: "/api/v1/designs/%s",
+		id:   func(t *tenant) string { return t.designID },
+		update: func(t *tenant) interface{} {
+			return requests.UpdateDesignRequestBody{PlanName: "Renamed plan", ActualDate: time.Now()}
+		},
+	},
+	{
+		name:   "trajectory",
+		path:   "/api/v1/trajectories/%s",
+		id:     func(t *tenant) string { return t.trajectoryID },
+		update: func(t *tenant) interface{} { return requests.UpdateTrajectoryRequestBody{Name: "Renamed trajectory"} },
+	},
+	{
+		name: "case",
+		path: "/api/v1/cases/%s",
+		id:   func(t *tenant) string { return t.caseID },
+		update: func(t *tenant) interface{} {
+			return requests.UpdateCaseRequestBody{CaseName: "Renamed case", DrillDepth: 1000}
+		},
+	},
+	{
+		name:   "hole",
+		path:   "/api/v1/holes/%s",
+		id:     func(t *tenant) string { return t.holeID },
+		update: func(t *tenant) interface{} { return requests.UpdateHoleRequestBody{OpenHoleMDBase: 1000} },
+	},
+	{
+		name: "string",
+		path: "/api/v1/strings/%s",
+		id:   func(t *tenant) string { return t.stringID },
+		update: func(t *tenant) interface{} {
+			return requests.UpdateStringRequestBody{Name: "Renamed string", Depth: 1000}
+		},
+	},
+	{
+		name: "fluid",
+		path: "/api/v1/fluids/%s",
+		id:   func(t *tenant) string { return t.fluidID },
+		update: func(t *tenant) interface{} {
+			return requests.UpdateFluidRequestBody{
+				ID:              t.fluidID,
+				Name:            "Renamed mud",
+				Density:         1.3,
+				FluidBaseTypeID: t.fluidTypeID,
+				BaseFluidID:     t.fluidTypeID,
+			}
+		},
+	},
+	{
+		name: "rig",
+		path: "/api/v1/rigs/%s",
+		id:   func(t *tenant) string { return t.rigID },
+		update: func(t *tenant) interface{} {
+			return requests.UpdateRigRequestBody{RatedWorkingPressure: 40, BopPressureRating: 40}
+		},
+	},
+	{
+		name: "pore pressure",
+		path: "/api/v1/pore-pressures/%s",
+		id:   func(t *tenant) string { return t.porePressureID },
+		update: func(t *tenant) interface{} {
+			return requests.UpdatePorePressureRequestBody{TVD: 1000, Pressure: 11, EMW: 1.1}
+		},
+	},
+	{
+		name: "fracture gradient",
+		path: "/api/v1/fracture-gradients/%s",
+		id:   func(t *tenant) string { return t.fractureGradientID },
+		update: func(t *tenant) interface{} {
+			return requests.UpdateFractureGradientRequestBody{
+				TemperatureAtSurface: 20,
+				TemperatureAtWellTVD: 60,
+				TemperatureGradient:  0.04,
+				WellTVD:              1000,
+			}
+		},
+	},
+}
+
+// TestResourcesAreIsolatedBetweenOrganizations checks that an organization can neither read, update nor delete
+// the entities of another organization, and that the entities are left intact by the attempts.
+func TestResourcesAreIsolatedBetweenOrganizations(t *testing.T) {
+	for _, pair := range [][2]*tenant{{tenantA, tenantB}, {tenantB, tenantA}} {
+		owner, other := pair[0], pair[1]
+		for _, r := range resources {
+			t.Run(fmt.Sprintf("%s of %s", r.name, owner.organizationID), func(t *testing.T) {
+				path := fmt.Sprintf(r.path, r.id(owner))
+
+				expectStatus(t, request(http.MethodGet, path, owner.token, nil), http.StatusOK)
+				expectStatus(t, request(http.MethodGet, path, other.token, nil), http.StatusNotFound)
+				expectStatus(t, request(http.MethodPut, path, other.token, r.update(owner)), http.StatusNotFound)
+				expectStatus(t, request(http.MethodDelete, path, other.token, nil), http.StatusNotFound)
+				expectStatus(t, request(http.MethodGet, path, owner.token, nil), http.StatusOK)
+			})
+		}
+	}
+}
+
+// TestNestedEndpointsAreIsolatedBetweenOrganizations checks the list endpoints by parent ID and the endpoints
+// below an entity, including torque and drag, with the IDs of another organization.
+func TestNestedEndpointsAreIsolatedBetweenOrganizations(t *testing.T) {
+	owner, other := tenantA, tenantB
+	frictionRange := requests.FrictionFactorRange{From: 0.1, To: 0.3, Step: 0.1}
+
+	endpoints := []struct {
+		method string
+		path   string
+		body   interface{}
+	}{
+		{http.MethodGet, "/api/v1/fields/?companyId=" + owner.companyID, nil},
+		{http.MethodGet, "/api/v1/sites/?fieldId=" + owner.fieldID, nil},
+		{http.MethodGet, "/api/v1/wells/?siteId=" + owner.siteID, nil},
+		{http.MethodGet, "/api/v1/wellbores/?wellId=" + owner.wellID, nil},
+		{http.MethodGet, "/api/v1/designs/?wellboreId=" + owner.wellboreID, nil},
+		{http.MethodGet, "/api/v1/trajectories/?designId=" + owner.designID, nil},
+		{http.MethodGet, "/api/v1/cases/?trajectoryId=" + owner.trajectoryID, nil},
+		{http.MethodGet, "/api/v1/holes/?caseId=" + owner.caseID, nil},
+		{http.MethodGet, "/api/v1/strings/?caseId=" + owner.caseID, nil},
+		{http.MethodGet, "/api/v1/fluids/?caseId=" + owner.caseID, nil},
+		{http.MethodGet, "/api/v1/rigs/?caseId=" + owner.caseID, nil},
+		{http.MethodGet, "/api/v1/pore-pressures/?caseId=" + owner.caseID, nil},
+		{http.MethodGet, "/api/v1/fracture-gradients/?caseId=" + owner.caseID, nil},
+		{http.MethodGet, "/api/v1/designs/" + owner.designID + "/revisions", nil},
+		{http.MethodGet, "/api/v1/designs/" + owner.designID + "/transitions", nil},
+		{http.MethodGet, "/api/v1/cases/" + owner.caseID + "/calculation-results", nil},
+		{http.MethodGet, "/api/v1/wellbores/" + owner.wellboreID + "/well-logs/", nil},
+		{http.MethodGet, "/api/v1/wellbores/" + owner.wellboreID + "/well-logs/" + owner.wellLogID, nil},
+		{http.MethodDelete, "/api/v1/wellbores/" + owner.wellboreID + "/well-logs/" + owner.wellLogID, nil},
+		{http.MethodGet, "/api/v1/wellbores/" + owner.wellboreID + "/drilling-data/", nil},
+		{http.MethodGet, "/api/v1/jobs/" + owner.jobID, nil},
+		{http.MethodDelete, "/api/v1/jobs/" + owner.jobID, nil},
+		{http.MethodPost, "/api/v1/torque-and-drag/effective-tension?caseId=" + owner.caseID, nil},
+		{http.MethodPost, "/api/v1/torque-and-drag/weight-on-bit?caseId=" + owner.caseID, nil},
+		{http.MethodPost, "/api/v1/torque-and-drag/surface-torque?caseId=" + owner.caseID, nil},
+		{http.MethodPost, "/api/v1/torque-and-drag/min-weight?caseId=" + owner.caseID, nil},
+		{
+			http.MethodPost,
+			"/api/v1/torque-and-drag/friction-sensitivity?caseId=" + owner.caseID,
+			requests.FrictionSensitivityRequestBody{OpenHole: frictionRange, CasedHole: frictionRange},
+		},
+	}
+
+	for _, e := range endpoints {
+		t.Run(e.method+" "+e.path, func(t *testing.T) {
+			expectStatus(t, request(e.method, e.path, other.token, e.body), http.StatusNotFound)
+		})
+	}
+}
+
+// TestServicesRejectOtherOrganizations calls the services behind the trajectory, string, case and torque and drag
+// handlers directly, so the ownership checks of the services are covered without the authorization middleware.
+func TestServicesRejectOtherOrganizations(t *testing.T) {
+	ctx := context.Background()
+	owner, other := tenantA, tenantB
+
+	calls := []struct {
+		name string
+		call func() error
+	}{
+		{"GetTrajectoryByID", func() error {
+			_, err := services.Trajectories.GetTrajectoryByID(ctx, &requests.GetTrajectoryByIDRequest{OrganizationID: other.organizationID, ID: owner.trajectoryID})
+			return err
+		}},
+		{"UpdateTrajectory", func() error {
+			_, err := services.Trajectories.UpdateTrajectory(ctx, &requests.UpdateTrajectoryRequest{OrganizationID: other.organizationID, ID: owner.trajectoryID})
+			return err
+		}},
+		{"DeleteTrajectory", func() error {
+			return services.Trajectories.DeleteTrajectory(ctx, &requests.DeleteTrajectoryRequest{OrganizationID: other.organizationID, ID: owner.trajectoryID})
+		}},
+		{"GetStringByID", func() error {
+			_, err := services.Strings.GetStringByID(ctx, &requests.GetStringByIDRequest{OrganizationID: other.organizationID, ID: owner.stringID})
+			return err
+		}},
+		{"UpdateString", func() error {
+			_, err := services.Strings.UpdateString(ctx, &requests.UpdateStringRequest{
+				OrganizationID: other.organizationID,
+				ID:             owner.stringID,
+				Body:           requests.UpdateStringRequestBody{Name: "Renamed string", Depth: 1000},
+			})
+			return err
+		}},
+		{"DeleteString", func() error {
+			return services.Strings.DeleteString(ctx, &requests.DeleteStringRequest{OrganizationID: other.organizationID, ID: owner.stringID})
+		}},
+		{"GetCaseByID", func() error {
+			_, err := services.Cases.GetCaseByID(ctx, &requests.GetCaseByIDRequest{OrganizationID: other.organizationID, ID: owner.caseID})
+			return err
+		}},
+		{"UpdateCase", func() error {
+			_, err := services.Cases.UpdateCase(ctx, &requests.UpdateCaseRequest{
+				OrganizationID: other.organizationID,
+				ID:             owner.caseID,
+				Body:           requests.UpdateCaseRequestBody{CaseName: "Renamed case"},
+			})
+			return err
+		}},
+		{"DeleteCase", func() error {
+			return services.Cases.DeleteCase(ctx, &requests.DeleteCaseRequest{OrganizationID: other.organizationID, ID: owner.caseID})
+		}},
+		{"CalculateEffectiveTensionFromMLModel", func() error {
+			_, err := services.TorqueAndDrag.CalculateEffectiveTensionFromMLModel(ctx, other.organizationID, owner.caseID)
+			return err
+		}},
+		{"CalculateWeightOnBitFromMlModel", func() error {
+			_, err := services.TorqueAndDrag.CalculateWeightOnBitFromMlModel(ctx, other.organizationID, owner.caseID)
+			return err
+		}},
+		{"CalculateSurfaceTorqueFromMlModel", func() error {
+			_, err := services.TorqueAndDrag.CalculateSurfaceTorqueFromMlModel(ctx, other.organizationID, owner.caseID)
+			return err
+		}},
+		{"CalculateMinWeightFromMLModel", func() error {
+			_, err := services.TorqueAndDrag.CalculateMinWeightFromMLModel(ctx, other.organizationID, owner.caseID)
+			return err
+		}},
+		{"CalculateFrictionSensitivity", func() error {
+			_, err := services.TorqueAndDrag.CalculateFrictionSensitivity(ctx, &requests.FrictionSensitivityRequest{
+				OrganizationID: other.organizationID,
+				CaseID:         owner.caseID,
+				Body: requests.FrictionSensitivityRequestBody{
+					OpenHole:  requests.FrictionFactorRange{From: 0.1, To: 0.3, Step: 0.1},
+					CasedHole: requests.FrictionFactorRange{From: 0.1, To: 0.3, Step: 0.1},
+				},
+			})
+			return err
+		}},
+		{"GetCalculationResults", func() error {
+			_, err := services.TorqueAndDrag.GetCalculationResults(ctx, &requests.GetCalculationResultsRequest{OrganizationID: other.organizationID, CaseID: owner.caseID})
+			return err
+		}},
+	}
+
+	for _, c := range calls {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.call(); !errors.Is(err, domainErrors.ErrResourceNotFound) {
+				t.Errorf("expected %v, got %v", domainErrors.ErrResourceNotFound, err)
+			}
+		})
+	}
+}
+
+func expectStatus(t *testing.T, res *httptest.ResponseRecorder, status int) {
+	t.Helper()
+	if res.Code != status {
+		t.Errorf("expected status %d, got %d: %s", status, res.Code, res.Body.String())
+	}
+}
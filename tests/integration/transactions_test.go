@@ -0,0 +1,74 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/repository"
+)
+
+// TestTransactionRollsBackRepositoryWrites checks that the writes of repositories called inside a transaction
+// are undone when it fails.
+func TestTransactionRollsBackRepositoryWrites(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewRepositories(db)
+	before, err := repos.Cases.GetCaseByID(ctx, tenantA.caseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failure := errors.New("a later sheet failed")
+	err = repos.Transactions.Transaction(ctx, func(ctx context.Context) error {
+		update := *before
+		update.CaseName = "Renamed in a failed import"
+		if _, err := repos.Cases.UpdateCase(ctx, &update); err != nil {
+			return err
+		}
+		if _, err := repos.Rigs.GetRigByID(ctx, tenantA.rigID); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("got error %v, want %v", err, failure)
+	}
+
+	after, err := repos.Cases.GetCaseByID(ctx, tenantA.caseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.CaseName != before.CaseName {
+		t.Errorf("got case name %q after the rollback, want %q", after.CaseName, before.CaseName)
+	}
+}
+
+// TestTransactionKeepsPorePressuresWithFractureGradients checks that replacing the pore pressures is undone
+// when replacing the fracture gradients fails, as saving an Eaton estimate does.
+func TestTransactionKeepsPorePressuresWithFractureGradients(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewRepositories(db)
+
+	failure := errors.New("replacing the fracture gradients failed")
+	err := repos.Transactions.Transaction(ctx, func(ctx context.Context) error {
+		replaced := []*entities.PorePressure{{TVD: 500, Pressure: 5, EMW: 1.02}}
+		if err := repos.PorePressures.ReplacePorePressures(ctx, tenantA.caseID, replaced); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("got error %v, want %v", err, failure)
+	}
+
+	porePressures, err := repos.PorePressures.GetPorePressures(ctx, tenantA.caseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(porePressures) != 1 || porePressures[0].ID != tenantA.porePressureID {
+		t.Errorf("the pore pressures of the case were replaced by a failed transaction: %+v", porePressures)
+	}
+}
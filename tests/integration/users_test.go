@@ -0,0 +1,80 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/munaiplan/munaiplan-backend/internal/application/types/requests"
+	"github.com/munaiplan/munaiplan-backend/internal/domain/entities"
+	"github.com/munaiplan/munaiplan-backend/internal/helpers"
+	"github.com/munaiplan/munaiplan-backend/internal/infrastructure/drivers/postgres/models"
+)
+
+// TestSignInIgnoresEmailCase checks that emails are matched without regard to case and surrounding spaces.
+func TestSignInIgnoresEmailCase(t *testing.T) {
+	if _, err := signIn("  " + strings.ToUpper(tenantA.email) + " "); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSignInDoesNotRevealEmails checks that an unknown email and a wrong password get the same response.
+func TestSignInDoesNotRevealEmails(t *testing.T) {
+	unknown := request(http.MethodPost, "/api/v1/users/sign-in", "",
+		requests.UserSignInRequest{Email: "unknown-" + uuid.NewString()[:8] + "@example.com", Password: tenantPassword})
+	wrongPassword := request(http.MethodPost, "/api/v1/users/sign-in", "",
+		requests.UserSignInRequest{Email: tenantA.email, Password: tenantPassword + "x"})
+
+	expectStatus(t, unknown, http.StatusUnauthorized)
+	expectStatus(t, wrongPassword, http.StatusUnauthorized)
+	if unknown.Body.String() != wrongPassword.Body.String() {
+		t.Errorf("got %s for an unknown email and %s for a wrong password", unknown.Body, wrongPassword.Body)
+	}
+}
+
+// TestSignUpAcceptsInvitationOnce checks that a sign up creates the user with the role of the invitation
+// and uses the invitation up.
+func TestSignUpAcceptsInvitationOnce(t *testing.T) {
+	token, tokenHash, err := helpers.GenerateToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	invitation := models.Invitation{
+		OrganizationID: uuid.MustParse(tenantA.organizationID),
+		Email:          "invited-" + uuid.NewString()[:8] + "@example.com",
+		Role:           entities.RoleEngineer,
+		InvitedBy:      uuid.MustParse(tenantA.userID),
+		TokenHash:      tokenHash,
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+	if err = db.Create(&invitation).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	body := requests.UserSignUpRequestBody{InviteToken: token, Password: tenantPassword, Name: "Invited", Surname: "Engineer"}
+	if res := request(http.MethodPost, "/api/v1/users/sign-up", "", body); res.Code >= http.StatusBadRequest {
+		t.Fatalf("sign up returned %d: %s", res.Code, res.Body.String())
+	}
+	if res := request(http.MethodPost, "/api/v1/users/sign-up", "", body); res.Code < http.StatusBadRequest {
+		t.Errorf("expected the second sign up with the invitation to fail, got %d", res.Code)
+	}
+
+	var user models.User
+	if err = db.Where("email = ?", invitation.Email).First(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	var roles []models.RoleAssignment
+	if err = db.Where("user_id = ?", user.ID).Find(&roles).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 1 || roles[0].Role != entities.RoleEngineer || roles[0].OrganizationID != invitation.OrganizationID {
+		t.Errorf("expected one engineer role in the organization of the invitation, got %+v", roles)
+	}
+	if _, err = signIn(strings.ToUpper(invitation.Email)); err != nil {
+		t.Error(err)
+	}
+}